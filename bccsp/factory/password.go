@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// GetPassword returns the password encrypting the keys of the file keystore,
+// read from the environment variable PasswordEnv, from the file PasswordFile
+// or prompted for on the terminal if PasswordPrompt is set, in this order of
+// precedence. It returns nil if none of them is set, the keys not being
+// encrypted.
+func (o *FileKeystoreOpts) GetPassword() ([]byte, error) {
+	prompt := ""
+	if o.PasswordPrompt {
+		prompt = fmt.Sprintf("Password of the keystore %s: ", o.KeyStorePath)
+	}
+	return ReadPassword(o.PasswordEnv, o.PasswordFile, prompt)
+}
+
+// ReadPassword returns the password held by the environment variable env,
+// or else by the file file, or else prompted for on the terminal with
+// prompt. Empty arguments are skipped, and nil is returned if all are empty.
+func ReadPassword(env, file, prompt string) ([]byte, error) {
+	var pwd []byte
+	switch {
+	case env != "":
+		value, set := os.LookupEnv(env)
+		if !set {
+			return nil, fmt.Errorf("the environment variable %s holding the password is not set", env)
+		}
+		pwd = []byte(value)
+	case file != "":
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading the password file: %s", err)
+		}
+		pwd = []byte(strings.TrimRight(string(raw), "\r\n"))
+	case prompt != "":
+		var err error
+		if pwd, err = promptPassword(prompt); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	if len(pwd) == 0 {
+		return nil, fmt.Errorf("the password must not be empty")
+	}
+	return pwd, nil
+}
+
+// promptPassword reads a password from the terminal without echoing it
+var promptPassword = func(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil, fmt.Errorf("cannot prompt for the password, the standard input is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+	pwd, err := terminal.ReadPassword(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the password: %s", err)
+	}
+	return pwd, nil
+}
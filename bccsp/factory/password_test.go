@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "password")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pwdFile := filepath.Join(dir, "pwd")
+	assert.NoError(t, ioutil.WriteFile(pwdFile, []byte("from file\n"), 0600))
+	os.Setenv("TEST_KEYSTORE_PASSWORD", "from env")
+	defer os.Unsetenv("TEST_KEYSTORE_PASSWORD")
+
+	var prompted string
+	defer func(f func(string) ([]byte, error)) { promptPassword = f }(promptPassword)
+	promptPassword = func(prompt string) ([]byte, error) {
+		prompted = prompt
+		return []byte("from prompt"), nil
+	}
+
+	pwd, err := ReadPassword("", "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, pwd, "No password should be returned when no source is set")
+
+	pwd, err = ReadPassword("TEST_KEYSTORE_PASSWORD", pwdFile, "Password: ")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from env"), pwd)
+
+	pwd, err = ReadPassword("", pwdFile, "Password: ")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from file"), pwd, "The trailing new line should be trimmed")
+
+	pwd, err = (&FileKeystoreOpts{KeyStorePath: "/ks", PasswordPrompt: true}).GetPassword()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from prompt"), pwd)
+	assert.Equal(t, "Password of the keystore /ks: ", prompted)
+
+	_, err = ReadPassword("TEST_KEYSTORE_PASSWORD_UNSET", "", "")
+	assert.Error(t, err)
+	_, err = ReadPassword("", filepath.Join(dir, "missing"), "")
+	assert.Error(t, err)
+	assert.NoError(t, ioutil.WriteFile(pwdFile, []byte("\n"), 0600))
+	_, err = ReadPassword("", pwdFile, "")
+	assert.Error(t, err, "An empty password should be rejected")
+	promptPassword = func(string) ([]byte, error) { return nil, errors.New("not a terminal") }
+	_, err = ReadPassword("", "", "Password: ")
+	assert.Error(t, err)
+}
+
+func TestSWFactoryGetEncryptedKeystore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	os.Setenv("TEST_KEYSTORE_PASSWORD", "secret")
+	defer os.Unsetenv("TEST_KEYSTORE_PASSWORD")
+
+	opts := func(env string) *FactoryOpts {
+		return &FactoryOpts{
+			SwOpts: &SwOpts{
+				SecLevel:     256,
+				HashFamily:   "SHA2",
+				FileKeystore: &FileKeystoreOpts{KeyStorePath: dir, PasswordEnv: env},
+			},
+		}
+	}
+	f := &SWFactory{}
+	csp, err := f.Get(opts("TEST_KEYSTORE_PASSWORD"))
+	assert.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	raw, err := ioutil.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "ENCRYPTED", "The private key should be stored encrypted")
+
+	csp, err = f.Get(opts("TEST_KEYSTORE_PASSWORD"))
+	assert.NoError(t, err)
+	_, err = csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+
+	csp, err = f.Get(opts(""))
+	assert.NoError(t, err)
+	_, err = csp.GetKey(k.SKI())
+	assert.Error(t, err, "The key should not be loaded without the password")
+
+	_, err = f.Get(opts("TEST_KEYSTORE_PASSWORD_UNSET"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/remote"
+)
+
+const (
+	// RemoteBasedFactoryName is the name of the factory of the BCCSP implementation
+	// delegating private key operations to a remote signing service
+	RemoteBasedFactoryName = "REMOTE"
+)
+
+// RemoteFactory is the factory of the remote signing BCCSP.
+type RemoteFactory struct{}
+
+// Name returns the name of this factory
+func (f *RemoteFactory) Name() string {
+	return RemoteBasedFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *RemoteFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.RemoteOpts == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	return remote.New(*config.RemoteOpts)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteFactoryName(t *testing.T) {
+	f := &RemoteFactory{}
+	assert.Equal(t, f.Name(), RemoteBasedFactoryName)
+}
+
+func TestRemoteFactoryGetInvalidArgs(t *testing.T) {
+	f := &RemoteFactory{}
+
+	_, err := f.Get(nil)
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{RemoteOpts: &remote.RemoteOpts{}})
+	assert.Error(t, err, "Invalid address. It must not be empty.")
+
+	_, err = GetBCCSPFromOpts(&FactoryOpts{ProviderName: "REMOTE", RemoteOpts: &remote.RemoteOpts{Address: "localhost:0"}})
+	assert.Error(t, err, "Missing TLS material should fail")
+}
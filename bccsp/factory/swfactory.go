@@ -49,7 +49,11 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	if swOpts.Ephemeral == true {
 		ks = sw.NewDummyKeyStore()
 	} else if swOpts.FileKeystore != nil {
-		fks, err := sw.NewFileBasedKeyStore(nil, swOpts.FileKeystore.KeyStorePath, false)
+		pwd, err := swOpts.FileKeystore.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get the software key store password: %s", err)
+		}
+		fks, err := sw.NewFileBasedKeyStore(pwd, swOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to initialize software key store: %s", err)
 		}
@@ -77,6 +81,13 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `mapstructure:"keystore" yaml:"KeyStore"`
+
+	// The keys are encrypted with the password held by the PasswordEnv
+	// environment variable, or else by the PasswordFile file, or else
+	// prompted for on the terminal if PasswordPrompt is set
+	PasswordEnv    string `mapstructure:"passwordenv,omitempty" json:"passwordenv,omitempty" yaml:"PasswordEnv,omitempty"`
+	PasswordFile   string `mapstructure:"passwordfile,omitempty" json:"passwordfile,omitempty" yaml:"PasswordFile,omitempty"`
+	PasswordPrompt bool   `mapstructure:"passwordprompt,omitempty" json:"passwordprompt,omitempty" yaml:"PasswordPrompt,omitempty"`
 }
 
 type DummyKeystoreOpts struct{}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// defaultTimeout bounds each request to the remote signing service
+// when RemoteOpts does not set one
+const defaultTimeout = 5 * time.Second
+
+// RemoteOpts contains options for the RemoteFactory
+type RemoteOpts struct {
+	// Default algorithms of the local operations (hashing and verification)
+	SecLevel   int    `mapstructure:"security" json:"security"`
+	HashFamily string `mapstructure:"hash" json:"hash"`
+
+	// Address of the remote signing service
+	Address string `mapstructure:"address" json:"address"`
+	// Timeout of each request to the remote signing service
+	Timeout time.Duration `mapstructure:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// TLS options. The connection to the remote signing service is
+	// always mutually authenticated
+	RootCert           string `mapstructure:"rootcert" json:"rootcert"`
+	ClientCert         string `mapstructure:"clientcert" json:"clientcert"`
+	ClientKey          string `mapstructure:"clientkey" json:"clientkey"`
+	ServerHostOverride string `mapstructure:"serverhostoverride,omitempty" json:"serverhostoverride,omitempty"`
+}
+
+func (opts *RemoteOpts) timeout() time.Duration {
+	if opts.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return opts.Timeout
+}
+
+// tlsConfig returns the client side TLS configuration of the connection
+// to the remote signing service
+func (opts *RemoteOpts) tlsConfig() (*tls.Config, error) {
+	if opts.RootCert == "" || opts.ClientCert == "" || opts.ClientKey == "" {
+		return nil, errors.New("Invalid TLS options. The root certificate, client certificate and client key must be set.")
+	}
+
+	rootPEM, err := ioutil.ReadFile(opts.RootCert)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading root certificate [%s]", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootPEM) {
+		return nil, fmt.Errorf("Failed parsing root certificate [%s]", opts.RootCert)
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading client certificate [%s]", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		ServerName:   opts.ServerHostOverride,
+	}, nil
+}
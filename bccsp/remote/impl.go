@@ -0,0 +1,232 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	pb "github.com/hyperledger/fabric/protos/bccsp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var logger = flogging.MustGetLogger("bccsp_remote")
+
+// New returns a new instance of the remote BCCSP, which delegates the
+// operations involving private keys to the remote signing service
+// at opts.Address. Hashing and verification are done locally by the
+// software-based BCCSP set at the passed security level and hash family.
+func New(opts RemoteOpts) (bccsp.BCCSP, error) {
+	if opts.Address == "" {
+		return nil, errors.New("Invalid address. It must not be empty.")
+	}
+
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(opts.Address,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithBlock(),
+		grpc.WithTimeout(opts.timeout()))
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to remote signing service at %s [%s]", opts.Address, err)
+	}
+	logger.Infof("Connected to remote signing service at %s", opts.Address)
+
+	return NewWithClient(opts, pb.NewRemoteSignerClient(conn))
+}
+
+// NewWithClient returns a new instance of the remote BCCSP that
+// reaches the remote signing service through client
+func NewWithClient(opts RemoteOpts, client pb.RemoteSignerClient) (bccsp.BCCSP, error) {
+	// Private keys never reach the local keystore
+	swCSP, err := sw.New(opts.SecLevel, opts.HashFamily, sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, fmt.Errorf("Failed initializing local SW BCCSP [%s]", err)
+	}
+
+	return &impl{BCCSP: swCSP, client: client, timeout: opts.timeout()}, nil
+}
+
+type impl struct {
+	bccsp.BCCSP
+
+	client  pb.RemoteSignerClient
+	timeout time.Duration
+}
+
+// KeyGen generates a key using opts.
+func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid Opts parameter. It must not be nil.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.KeyGen(ctx, &pb.KeyGenRequest{Algorithm: opts.Algorithm(), Ephemeral: opts.Ephemeral()})
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating remote key [%s]", err)
+	}
+
+	return csp.keyFromResponse(resp)
+}
+
+// KeyDeriv derives a key from k using opts.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+	if _, ok := k.(*remoteKey); ok {
+		return nil, errors.New("Key derivation is not supported for remote keys")
+	}
+
+	return csp.BCCSP.KeyDeriv(k, opts)
+}
+
+// KeyImport imports a key from its raw representation using opts.
+// Only public keys can be imported, since private key material must not
+// be handled locally.
+func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	k, err = csp.BCCSP.KeyImport(raw, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.Private() || k.Symmetric() {
+		return nil, errors.New("Importing private or symmetric keys is not allowed with the remote BCCSP")
+	}
+
+	return k, nil
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.GetKey(ctx, &pb.GetKeyRequest{Ski: ski})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting remote key [%x] [%s]", ski, err)
+	}
+
+	return csp.keyFromResponse(resp)
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil.")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	rk, ok := k.(*remoteKey)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported 'SignKey' provided [%T]. Only remote keys can sign", k)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.Sign(ctx, &pb.SignRequest{Ski: rk.ski, Digest: digest})
+	if err != nil {
+		return nil, fmt.Errorf("Failed remote signing [%s]", err)
+	}
+
+	return resp.Signature, nil
+}
+
+// Verify verifies signature against key k and digest
+// The verification happens locally, with the public part of remote keys.
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if rk, ok := k.(*remoteKey); ok {
+		return csp.BCCSP.Verify(rk.pub, signature, digest, opts)
+	}
+
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
+
+// Encrypt encrypts plaintext using key k.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
+	if rk, ok := k.(*remoteKey); ok {
+		return csp.BCCSP.Encrypt(rk.pub, plaintext, opts)
+	}
+
+	return csp.BCCSP.Encrypt(k, plaintext, opts)
+}
+
+// Decrypt decrypts ciphertext using key k.
+// The decryption of remote keys is delegated to the remote signing service.
+func (csp *impl) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
+	rk, ok := k.(*remoteKey)
+	if !ok {
+		return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.Decrypt(ctx, &pb.DecryptRequest{Ski: rk.ski, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("Failed remote decryption [%s]", err)
+	}
+
+	return resp.Plaintext, nil
+}
+
+// keyFromResponse returns the key described by resp. The public part
+// is imported locally so that verification does not need the service.
+func (csp *impl) keyFromResponse(resp *pb.KeyResponse) (bccsp.Key, error) {
+	lowLevelKey, err := utils.DERToPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing remote public key [%s]", err)
+	}
+
+	var opts bccsp.KeyImportOpts
+	switch lowLevelKey.(type) {
+	case *ecdsa.PublicKey:
+		opts = &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true}
+	case *rsa.PublicKey:
+		opts = &bccsp.RSAGoPublicKeyImportOpts{Temporary: true}
+	case ed25519.PublicKey:
+		opts = &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true}
+	default:
+		return nil, fmt.Errorf("Unsupported remote public key type [%T]", lowLevelKey)
+	}
+
+	pub, err := csp.BCCSP.KeyImport(lowLevelKey, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed importing remote public key [%s]", err)
+	}
+
+	if !resp.Private {
+		return pub, nil
+	}
+	if _, isRSA := lowLevelKey.(*rsa.PublicKey); isRSA {
+		// The remote signing service does not get the signer and
+		// decrypter options RSA keys need
+		return nil, errors.New("Unsupported remote key. RSA keys cannot be used through the remote signing service")
+	}
+	return &remoteKey{ski: resp.Ski, pub: pub}, nil
+}
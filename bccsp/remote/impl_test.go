@@ -0,0 +1,324 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	pb "github.com/hyperledger/fabric/protos/bccsp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{key: key, cert: cert}
+}
+
+// issue writes a key pair issued by the CA to dir/name-cert.pem and dir/name-key.pem
+func (ca *testCA) issue(t *testing.T, dir, name string, extKeyUsage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+"-cert.pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	assert.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func (ca *testCA) writeCert(t *testing.T, dir, name string) string {
+	file := filepath.Join(dir, name+"-cert.pem")
+	assert.NoError(t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	return file
+}
+
+// startServer starts a remote signing service backed by a file keystore
+// and returns client options trusted by it
+func startServer(t *testing.T, dir string) (*RemoteOpts, func()) {
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", x509.ExtKeyUsageClientAuth)
+	rootCert := ca.writeCert(t, dir, "ca")
+
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, "keystore"), false)
+	assert.NoError(t, err)
+	csp, err := sw.New(256, "SHA2", ks)
+	assert.NoError(t, err)
+
+	cert, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	pb.RegisterRemoteSignerServer(grpcServer, NewServer(csp))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go grpcServer.Serve(lis)
+
+	opts := &RemoteOpts{
+		SecLevel:   256,
+		HashFamily: "SHA2",
+		Address:    lis.Addr().String(),
+		Timeout:    time.Second,
+		RootCert:   rootCert,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	}
+	return opts, grpcServer.Stop
+}
+
+func TestRemoteSignAndVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bccsp-remote")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts, stop := startServer(t, dir)
+	defer stop()
+
+	csp, err := New(*opts)
+	assert.NoError(t, err)
+
+	for _, keyGenOpts := range []bccsp.KeyGenOpts{
+		&bccsp.ECDSAP256KeyGenOpts{Temporary: false},
+		&bccsp.ECDSAP384KeyGenOpts{Temporary: true},
+		&bccsp.ED25519KeyGenOpts{Temporary: false},
+	} {
+		k, err := csp.KeyGen(keyGenOpts)
+		assert.NoError(t, err, "Failed generating %s key", keyGenOpts.Algorithm())
+		assert.True(t, k.Private())
+		_, err = k.Bytes()
+		assert.Error(t, err, "Remote private keys must not be exported")
+
+		digest := sha256.Sum256([]byte("hello world"))
+		signature, err := csp.Sign(k, digest[:], nil)
+		assert.NoError(t, err)
+
+		valid, err := csp.Verify(k, signature, digest[:], nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		pk, err := k.PublicKey()
+		assert.NoError(t, err)
+		valid, err = csp.Verify(pk, signature, digest[:], nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		assert.Equal(t, k.SKI(), pk.SKI())
+
+		// the key can be retrieved and used again
+		k2, err := csp.GetKey(k.SKI())
+		assert.NoError(t, err)
+		assert.True(t, k2.Private())
+		signature, err = csp.Sign(k2, digest[:], nil)
+		assert.NoError(t, err)
+		valid, err = csp.Verify(pk, signature, digest[:], nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// the private keys live in the keystore of the service only
+	files, err := ioutil.ReadDir(filepath.Join(dir, "keystore"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	_, err = csp.GetKey([]byte("unknown"))
+	assert.Error(t, err)
+	_, err = csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: true})
+	assert.Error(t, err, "Symmetric keys are not supported")
+	_, err = csp.KeyGen(nil)
+	assert.Error(t, err)
+}
+
+func TestRemoteLocalOperations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bccsp-remote")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts, stop := startServer(t, dir)
+	defer stop()
+
+	csp, err := New(*opts)
+	assert.NoError(t, err)
+
+	// hashing is local
+	digest, err := csp.Hash([]byte("hello world"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	expected := sha256.Sum256([]byte("hello world"))
+	assert.Equal(t, expected[:], digest)
+
+	// public keys can be imported, private keys cannot
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	pk, err := csp.KeyImport(&key.PublicKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	_, err = csp.KeyImport(keyDER, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = csp.KeyImport(make([]byte, 32), &bccsp.AES256ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+
+	// only remote keys can sign
+	_, err = csp.Sign(pk, expected[:], nil)
+	assert.Error(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	_, err = csp.Sign(k, nil, nil)
+	assert.Error(t, err)
+	_, err = csp.KeyDeriv(k, &bccsp.ECDSAReRandKeyOpts{Temporary: true, Expansion: []byte{1}})
+	assert.Error(t, err)
+
+	// the reference service has no asymmetric decryption
+	_, err = csp.Decrypt(k, []byte("ciphertext"), nil)
+	assert.Error(t, err)
+}
+
+func TestServerRejectsRSAKeys(t *testing.T) {
+	csp, err := sw.New(256, "SHA2", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	s := NewServer(csp)
+
+	// the service does not generate RSA keys, but its keystore may hold some
+	k, err := csp.KeyGen(&bccsp.RSA1024KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	s.(*server).storeEphemeralKey(k)
+
+	// the public part can be retrieved, but the key cannot be used
+	resp, err := s.GetKey(context.Background(), &pb.GetKeyRequest{Ski: k.SKI()})
+	assert.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	_, err = s.Sign(context.Background(), &pb.SignRequest{Ski: k.SKI(), Digest: digest[:]})
+	assert.Error(t, err)
+	_, err = s.Decrypt(context.Background(), &pb.DecryptRequest{Ski: k.SKI(), Ciphertext: []byte("ciphertext")})
+	assert.Error(t, err)
+
+	// clients refuse remote RSA keys as well
+	client := &impl{BCCSP: csp}
+	_, err = client.keyFromResponse(resp)
+	assert.Error(t, err)
+	pk, err := client.keyFromResponse(&pb.KeyResponse{Ski: k.SKI(), PublicKey: resp.PublicKey})
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+}
+
+func TestServerEvictsEphemeralKeys(t *testing.T) {
+	csp, err := sw.New(256, "SHA2", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	s := NewServer(csp).(*server)
+	s.maxEphemeralKeys = 2
+
+	var skis [][]byte
+	for i := 0; i < 3; i++ {
+		resp, err := s.KeyGen(context.Background(), &pb.KeyGenRequest{Algorithm: bccsp.ECDSAP256, Ephemeral: true})
+		assert.NoError(t, err)
+		skis = append(skis, resp.Ski)
+	}
+
+	// the oldest ephemeral key is gone
+	_, err = s.GetKey(context.Background(), &pb.GetKeyRequest{Ski: skis[0]})
+	assert.Error(t, err)
+	for _, ski := range skis[1:] {
+		_, err = s.GetKey(context.Background(), &pb.GetKeyRequest{Ski: ski})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, s.ephemeralKeys, 2)
+	assert.Len(t, s.ephemeralSKIs, 2)
+}
+
+func TestRemoteConnection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bccsp-remote")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts, stop := startServer(t, dir)
+	defer stop()
+
+	_, err = New(RemoteOpts{})
+	assert.Error(t, err)
+
+	noTLS := *opts
+	noTLS.ClientKey = ""
+	_, err = New(noTLS)
+	assert.Error(t, err)
+
+	// a client certificate of another CA is rejected by the service
+	other := newTestCA(t)
+	otherDir := filepath.Join(dir, "other")
+	assert.NoError(t, os.MkdirAll(otherDir, 0755))
+	untrusted := *opts
+	untrusted.ClientCert, untrusted.ClientKey = other.issue(t, otherDir, "client", x509.ExtKeyUsageClientAuth)
+	untrusted.Timeout = 500 * time.Millisecond
+	csp, err := New(untrusted)
+	if err == nil {
+		// the handshake may only fail on the first request
+		_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	}
+	assert.Error(t, err)
+
+	// the service must be reachable
+	stop()
+	unreachable := *opts
+	unreachable.Timeout = 200 * time.Millisecond
+	_, err = New(unreachable)
+	assert.Error(t, err)
+}
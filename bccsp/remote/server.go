@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	pb "github.com/hyperledger/fabric/protos/bccsp"
+	"golang.org/x/net/context"
+)
+
+// maxEphemeralKeys bounds the number of ephemeral keys kept by the
+// service. Once reached, the oldest ephemeral key is evicted.
+const maxEphemeralKeys = 1024
+
+// NewServer returns a remote signing service performing its
+// operations with the keys of csp
+func NewServer(csp bccsp.BCCSP) pb.RemoteSignerServer {
+	return &server{csp: csp, ephemeralKeys: make(map[string]bccsp.Key), maxEphemeralKeys: maxEphemeralKeys}
+}
+
+type server struct {
+	csp bccsp.BCCSP
+
+	// ephemeral keys are not in the keystore of csp, so
+	// the service keeps them to serve later requests.
+	// ephemeralSKIs holds their SKIs from the oldest to the newest.
+	lock             sync.RWMutex
+	ephemeralKeys    map[string]bccsp.Key
+	ephemeralSKIs    []string
+	maxEphemeralKeys int
+}
+
+// KeyGen generates a key pair and returns its public part
+func (s *server) KeyGen(ctx context.Context, req *pb.KeyGenRequest) (*pb.KeyResponse, error) {
+	opts, err := keyGenOpts(req.Algorithm, req.Ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.csp.KeyGen(opts)
+	if err != nil {
+		logger.Errorf("Failed generating %s key [%s]", req.Algorithm, err)
+		return nil, err
+	}
+
+	if req.Ephemeral {
+		s.storeEphemeralKey(k)
+	}
+
+	logger.Debugf("Generated %s key [%x]", req.Algorithm, k.SKI())
+	return keyResponse(k)
+}
+
+// GetKey returns the public part of the key with the given SKI
+func (s *server) GetKey(ctx context.Context, req *pb.GetKeyRequest) (*pb.KeyResponse, error) {
+	k, err := s.getKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyResponse(k)
+}
+
+// Sign signs a digest with the private key with the given SKI
+func (s *server) Sign(ctx context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.csp.Sign(k, req.Digest, nil)
+	if err != nil {
+		logger.Errorf("Failed signing with key [%x] [%s]", req.Ski, err)
+		return nil, err
+	}
+
+	return &pb.SignResponse{Signature: signature}, nil
+}
+
+// Decrypt decrypts a ciphertext with the private key with the given SKI
+func (s *server) Decrypt(ctx context.Context, req *pb.DecryptRequest) (*pb.DecryptResponse, error) {
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := s.csp.Decrypt(k, req.Ciphertext, nil)
+	if err != nil {
+		logger.Errorf("Failed decrypting with key [%x] [%s]", req.Ski, err)
+		return nil, err
+	}
+
+	return &pb.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// storeEphemeralKey keeps k to serve later requests, evicting
+// the oldest ephemeral keys beyond maxEphemeralKeys
+func (s *server) storeEphemeralKey(k bccsp.Key) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ski := string(k.SKI())
+	if _, exists := s.ephemeralKeys[ski]; !exists {
+		s.ephemeralSKIs = append(s.ephemeralSKIs, ski)
+	}
+	s.ephemeralKeys[ski] = k
+
+	for len(s.ephemeralSKIs) > s.maxEphemeralKeys {
+		logger.Debugf("Evicting ephemeral key [%x]", s.ephemeralSKIs[0])
+		delete(s.ephemeralKeys, s.ephemeralSKIs[0])
+		s.ephemeralSKIs = s.ephemeralSKIs[1:]
+	}
+}
+
+func (s *server) getKey(ski []byte) (bccsp.Key, error) {
+	s.lock.RLock()
+	k, ok := s.ephemeralKeys[string(ski)]
+	s.lock.RUnlock()
+	if ok {
+		return k, nil
+	}
+
+	k, err := s.csp.GetKey(ski)
+	if err != nil {
+		return nil, fmt.Errorf("Key [%x] not found [%s]", ski, err)
+	}
+	return k, nil
+}
+
+func (s *server) getPrivateKey(ski []byte) (bccsp.Key, error) {
+	k, err := s.getKey(ski)
+	if err != nil {
+		return nil, err
+	}
+	if !k.Private() {
+		return nil, fmt.Errorf("Key [%x] is not a private key", ski)
+	}
+
+	// Signing and decrypting with RSA keys depend on the options of the
+	// caller (hash function, padding), which requests do not carry
+	pk, err := k.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting public key [%s]", err)
+	}
+	raw, err := pk.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling public key [%s]", err)
+	}
+	lowLevelKey, err := utils.DERToPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing public key [%s]", err)
+	}
+	if _, isRSA := lowLevelKey.(*rsa.PublicKey); isRSA {
+		return nil, fmt.Errorf("Unsupported key [%x]. RSA keys cannot be used through the remote signing service", ski)
+	}
+
+	return k, nil
+}
+
+// keyGenOpts returns the options to generate keys of the given algorithm.
+// Symmetric keys are not supported since they would have to leave the service.
+func keyGenOpts(algorithm string, ephemeral bool) (bccsp.KeyGenOpts, error) {
+	switch algorithm {
+	case bccsp.ECDSA:
+		return &bccsp.ECDSAKeyGenOpts{Temporary: ephemeral}, nil
+	case bccsp.ECDSAP256:
+		return &bccsp.ECDSAP256KeyGenOpts{Temporary: ephemeral}, nil
+	case bccsp.ECDSAP384:
+		return &bccsp.ECDSAP384KeyGenOpts{Temporary: ephemeral}, nil
+	case bccsp.ED25519:
+		return &bccsp.ED25519KeyGenOpts{Temporary: ephemeral}, nil
+	case "":
+		return nil, errors.New("Invalid algorithm. It must not be empty.")
+	default:
+		return nil, fmt.Errorf("Unsupported key generation algorithm [%s]", algorithm)
+	}
+}
+
+// keyResponse describes k to the clients of the service
+func keyResponse(k bccsp.Key) (*pb.KeyResponse, error) {
+	pk, err := k.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting public key [%s]", err)
+	}
+	raw, err := pk.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling public key [%s]", err)
+	}
+
+	return &pb.KeyResponse{Ski: k.SKI(), PublicKey: raw, Private: k.Private()}, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerifyED25519(t *testing.T) {
+	_, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signED25519(lowLevelKey, msg, nil)
+	assert.NoError(t, err)
+	assert.Len(t, sigma, ed25519.SignatureSize)
+
+	pub := lowLevelKey.Public().(ed25519.PublicKey)
+	valid, err := verifyED25519(pub, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifyED25519(pub, sigma, []byte("hello world!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = verifyED25519(pub, sigma[1:], msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signature length")
+}
+
+func TestED25519KeyLifecycle(t *testing.T) {
+	ksPath, err := ioutil.TempDir("", "bccspks-ed25519")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+
+	ks, err := NewFileBasedKeyStore(nil, ksPath, false)
+	assert.NoError(t, err)
+	csp, err := New(256, "SHA2", ks)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err, "Private key bytes must not be exported")
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	msg := []byte("hello world")
+	sigma, err := csp.Sign(k, msg, nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the key survives the keystore
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+	valid, err = csp.Verify(k2, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// public keys can be imported back from their PKIX encoding
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := csp.KeyImport(raw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+
+	_, err = csp.KeyImport(raw, &bccsp.ECDSAPKIXPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err, "An Ed25519 key must not be imported as ECDSA")
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.False(t, k.Private())
+	_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	sk, err := csp.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, sk.Private())
+	assert.Equal(t, k.SKI(), sk.SKI())
+	_, err = csp.KeyImport(nil, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+
+	// x509 certificates carrying an Ed25519 key
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ed25519"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	assert.NoError(t, err)
+	certKey, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), certKey.SKI())
+}
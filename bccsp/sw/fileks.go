@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/hex"
 	"fmt"
@@ -141,6 +142,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}, nil
 		case *rsa.PrivateKey:
 			return &rsaPrivateKey{key.(*rsa.PrivateKey)}, nil
+		case ed25519.PrivateKey:
+			return &ed25519PrivateKey{key.(ed25519.PrivateKey)}, nil
 		default:
 			return nil, errors.New("Secret key type not recognized")
 		}
@@ -156,6 +159,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPublicKey{key.(*ecdsa.PublicKey)}, nil
 		case *rsa.PublicKey:
 			return &rsaPublicKey{key.(*rsa.PublicKey)}, nil
+		case ed25519.PublicKey:
+			return &ed25519PublicKey{key.(ed25519.PublicKey)}, nil
 		default:
 			return nil, errors.New("Public key type not recognized")
 		}
@@ -207,6 +212,22 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing RSA public key [%s]", err)
 		}
 
+	case *ed25519PrivateKey:
+		kk := k.(*ed25519PrivateKey)
+
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), kk.privKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing Ed25519 private key [%s]", err)
+		}
+
+	case *ed25519PublicKey:
+		kk := k.(*ed25519PublicKey)
+
+		err = ks.storePublicKey(hex.EncodeToString(k.SKI()), kk.pubKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing Ed25519 public key [%s]", err)
+		}
+
 	case *aesPrivateKey:
 		kk := k.(*aesPrivateKey)
 
@@ -244,6 +265,8 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 			k = &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}
 		case *rsa.PrivateKey:
 			k = &rsaPrivateKey{key.(*rsa.PrivateKey)}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{key.(ed25519.PrivateKey)}
 		default:
 			continue
 		}
@@ -429,3 +452,77 @@ func (ks *fileBasedKeyStore) openKeyStore() error {
 func (ks *fileBasedKeyStore) getPathForAlias(alias, suffix string) string {
 	return filepath.Join(ks.path, alias+"_"+suffix)
 }
+
+// ReencryptFileBasedKeyStore re-encrypts with newPwd the keys of the
+// file-based key store at path, which are encrypted with oldPwd or not
+// encrypted at all. The keys are left unencrypted if newPwd is empty.
+// All the keys are decrypted before any of them is rewritten, so that a
+// wrong password leaves the key store untouched.
+// It returns the number of keys re-encrypted.
+func ReencryptFileBasedKeyStore(path string, oldPwd, newPwd []byte) (int, error) {
+	ks := &fileBasedKeyStore{path: path, pwd: oldPwd, readOnly: true}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading KeyStore at [%s]: [%s]", path, err)
+	}
+
+	reencrypted := make(map[string][]byte)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		i := strings.LastIndex(f.Name(), "_")
+		if i < 0 {
+			continue
+		}
+		alias := f.Name()[:i]
+
+		var raw []byte
+		switch f.Name()[i+1:] {
+		case "sk":
+			key, err := ks.loadPrivateKey(alias)
+			if err != nil {
+				return 0, fmt.Errorf("Failed decrypting private key [%s]: [%s]", f.Name(), err)
+			}
+			raw, err = utils.PrivateKeyToPEM(key, newPwd)
+			if err != nil {
+				return 0, fmt.Errorf("Failed encrypting private key [%s]: [%s]", f.Name(), err)
+			}
+		case "pk":
+			key, err := ks.loadPublicKey(alias)
+			if err != nil {
+				return 0, fmt.Errorf("Failed decrypting public key [%s]: [%s]", f.Name(), err)
+			}
+			raw, err = utils.PublicKeyToPEM(key, newPwd)
+			if err != nil {
+				return 0, fmt.Errorf("Failed encrypting public key [%s]: [%s]", f.Name(), err)
+			}
+		case "key":
+			key, err := ks.loadKey(alias)
+			if err != nil {
+				return 0, fmt.Errorf("Failed decrypting key [%s]: [%s]", f.Name(), err)
+			}
+			raw, err = utils.AEStoEncryptedPEM(key, newPwd)
+			if err != nil {
+				return 0, fmt.Errorf("Failed encrypting key [%s]: [%s]", f.Name(), err)
+			}
+		default:
+			continue
+		}
+		reencrypted[f.Name()] = raw
+	}
+
+	for name, raw := range reencrypted {
+		// write a temporary file first, so that a key is never left half written
+		tmp := filepath.Join(path, "."+name+".tmp")
+		if err := ioutil.WriteFile(tmp, raw, 0700); err != nil {
+			return 0, fmt.Errorf("Failed storing key [%s]: [%s]", name, err)
+		}
+		if err := os.Rename(tmp, filepath.Join(path, name)); err != nil {
+			return 0, fmt.Errorf("Failed storing key [%s]: [%s]", name, err)
+		}
+	}
+
+	return len(reencrypted), nil
+}
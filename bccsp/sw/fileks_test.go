@@ -16,10 +16,19 @@ limitations under the License.
 package sw
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInvalidStoreKey(t *testing.T) {
@@ -64,3 +73,80 @@ func TestInvalidStoreKey(t *testing.T) {
 		t.Fatal("Error should be different from nil in this case")
 	}
 }
+
+func TestReencryptFileBasedKeyStore(t *testing.T) {
+	path, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherECKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	aesRaw, err := GetRandomBytes(32)
+	assert.NoError(t, err)
+	keys := []bccsp.Key{
+		&ecdsaPrivateKey{ecKey},
+		&ecdsaPublicKey{&otherECKey.PublicKey},
+		&ed25519PrivateKey{edKey},
+		&aesPrivateKey{aesRaw, false},
+	}
+
+	ks, err := NewFileBasedKeyStore(nil, path, false)
+	assert.NoError(t, err)
+	for _, k := range keys {
+		assert.NoError(t, ks.StoreKey(k))
+	}
+
+	checkKeys := func(pwd []byte) {
+		ks, err := NewFileBasedKeyStore(pwd, path, true)
+		assert.NoError(t, err)
+		for _, k := range keys {
+			loaded, err := ks.GetKey(k.SKI())
+			assert.NoError(t, err, "Failed loading key with password %q", pwd)
+			assert.Equal(t, k, loaded)
+		}
+	}
+	checkEncrypted := func(encrypted bool) {
+		files, err := ioutil.ReadDir(path)
+		assert.NoError(t, err)
+		assert.Len(t, files, len(keys))
+		for _, f := range files {
+			raw, err := ioutil.ReadFile(filepath.Join(path, f.Name()))
+			assert.NoError(t, err)
+			assert.Equal(t, encrypted, bytes.Contains(raw, []byte("ENCRYPTED")), "Unexpected encryption of %s", f.Name())
+		}
+	}
+
+	// encrypt a plain key store
+	n, err := ReencryptFileBasedKeyStore(path, nil, []byte("pwd1"))
+	assert.NoError(t, err)
+	assert.Equal(t, len(keys), n)
+	checkEncrypted(true)
+	checkKeys([]byte("pwd1"))
+	ks, err = NewFileBasedKeyStore(nil, path, true)
+	assert.NoError(t, err)
+	_, err = ks.GetKey(keys[0].SKI())
+	assert.Error(t, err, "An encrypted key should not be loaded without password")
+
+	// a wrong password leaves the key store untouched
+	_, err = ReencryptFileBasedKeyStore(path, []byte("wrong"), []byte("pwd2"))
+	assert.Error(t, err)
+	checkKeys([]byte("pwd1"))
+
+	// rotate the password
+	_, err = ReencryptFileBasedKeyStore(path, []byte("pwd1"), []byte("pwd2"))
+	assert.NoError(t, err)
+	checkKeys([]byte("pwd2"))
+
+	// and decrypt the key store
+	_, err = ReencryptFileBasedKeyStore(path, []byte("pwd2"), nil)
+	assert.NoError(t, err)
+	checkEncrypted(false)
+	checkKeys(nil)
+
+	_, err = ReencryptFileBasedKeyStore(filepath.Join(path, "missing"), nil, nil)
+	assert.Error(t, err)
+}
@@ -28,7 +28,7 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
-var regex *regexp.Regexp = regexp.MustCompile("^([[:alnum:]]+)([.])(member|admin)$")
+var regex *regexp.Regexp = regexp.MustCompile("^([[:alnum:]]+)([.])(member|admin|client|peer|orderer)$")
 var regexErr *regexp.Regexp = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
 
 func and(args ...interface{}) (interface{}, error) {
@@ -131,7 +131,7 @@ func secondPass(args ...interface{}) (interface{}, error) {
 		switch t := principal.(type) {
 		/* if it's a string, we expect it to be formed as
 		   <MSP_ID> . <ROLE>, where MSP_ID is the MSP identifier
-		   and ROLE is either a member of an admin*/
+		   and ROLE is either a member, an admin, a client, a peer or an orderer*/
 		case string:
 			/* split the string */
 			subm := regex.FindAllStringSubmatch(t, -1)
@@ -141,10 +141,17 @@ func secondPass(args ...interface{}) (interface{}, error) {
 
 			/* get the right role */
 			var r msp.MSPRole_MSPRoleType
-			if subm[0][3] == "member" {
+			switch subm[0][3] {
+			case "member":
 				r = msp.MSPRole_MEMBER
-			} else {
+			case "admin":
 				r = msp.MSPRole_ADMIN
+			case "client":
+				r = msp.MSPRole_CLIENT
+			case "peer":
+				r = msp.MSPRole_PEER
+			case "orderer":
+				r = msp.MSPRole_ORDERER
 			}
 
 			/* build the principal we've been told */
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/cache"
 	mspprotos "github.com/hyperledger/fabric/protos/msp"
 )
 
@@ -90,13 +91,8 @@ func (bh *MSPConfigHandler) ProposeMSP(tx interface{}, mspConfig *mspprotos.MSPC
 		panic("Programming error, called BeginConfig multiply for the same tx")
 	}
 
-	// check that the type for that MSP is supported
-	if mspConfig.Type != int32(msp.FABRIC) {
-		return nil, fmt.Errorf("Setup error: unsupported msp type %d", mspConfig.Type)
-	}
-
-	// create the msp instance
-	mspInst, err := msp.NewBccspMsp()
+	// create the msp instance for the type of that MSP
+	mspInst, err := newMSP(mspConfig.Type)
 	if err != nil {
 		return nil, fmt.Errorf("Creating the MSP manager failed, err %s", err)
 	}
@@ -148,3 +144,24 @@ func (bh *MSPConfigHandler) PreCommit(tx interface{}) error {
 	err := pendingConfig.proposedMgr.Setup(mspList)
 	return err
 }
+
+// newMSP creates an MSP instance of the given type,
+// failing if the type is not supported
+func newMSP(mspType int32) (msp.MSP, error) {
+	var mspInst msp.MSP
+	var err error
+	switch msp.ProviderType(mspType) {
+	case msp.FABRIC:
+		mspInst, err = msp.NewBccspMsp()
+	case msp.IDEMIX:
+		mspInst, err = msp.NewIdemixMsp()
+	default:
+		return nil, fmt.Errorf("Setup error: unsupported msp type %d", mspType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// channel MSPs see the same identities over and over during validation
+	return cache.New(mspInst)
+}
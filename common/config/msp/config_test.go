@@ -108,3 +108,26 @@ func TestTemplates(t *testing.T) {
 	}, "Expected panic with bad msp config")
 
 }
+
+func TestIdemixMSPConfig(t *testing.T) {
+	conf, err := msp.GetVerifyingIdemixMspConfig("../../../msp/testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+
+	mspCH := NewMSPConfigHandler()
+	mspCH.BeginConfig(t)
+	mspInst, err := mspCH.ProposeMSP(t, conf)
+	assert.NoError(t, err)
+	assert.Equal(t, msp.IDEMIX, mspInst.GetType())
+	assert.NoError(t, mspCH.PreCommit(t))
+	mspCH.CommitProposals(t)
+
+	msps, err := mspCH.GetMSPs()
+	assert.NoError(t, err)
+	assert.Contains(t, msps, "MSP1")
+
+	// unknown MSP types are rejected
+	mspCH.BeginConfig(t)
+	_, err = mspCH.ProposeMSP(t, &mspprotos.MSPConfig{Type: 42, Config: conf.Config})
+	assert.Error(t, err)
+	mspCH.RollbackProposals(t)
+}
@@ -18,7 +18,6 @@ package msp
 
 import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
-	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -45,13 +44,8 @@ const (
 // TemplateGroupMSPWithAdminRolePrincipal creates an MSP ConfigValue at the given configPath with Admin policy
 // of role type ADMIN if admin==true or MEMBER otherwise
 func TemplateGroupMSPWithAdminRolePrincipal(configPath []string, mspConfig *mspprotos.MSPConfig, admin bool) *cb.ConfigGroup {
-	// check that the type for that MSP is supported
-	if mspConfig.Type != int32(msp.FABRIC) {
-		logger.Panicf("Setup error: unsupported msp type %d", mspConfig.Type)
-	}
-
-	// create the msp instance
-	mspInst, err := msp.NewBccspMsp()
+	// create the msp instance for the type of that MSP
+	mspInst, err := newMSP(mspConfig.Type)
 	if err != nil {
 		logger.Panicf("Creating the MSP manager failed, err %s", err)
 	}
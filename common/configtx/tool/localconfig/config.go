@@ -70,6 +70,11 @@ const (
 	AdminRoleAdminPrincipal = "Role.ADMIN"
 	// MemberRoleAdminPrincipal is set as AdminRole to cause the MSP role of type Member to be used as the admin principal default
 	MemberRoleAdminPrincipal = "Role.MEMBER"
+
+	// BCCSPMSPType is set as MSPType to load an X.509 based MSP, which is the default
+	BCCSPMSPType = "bccsp"
+	// IdemixMSPType is set as MSPType to load an Identity Mixer MSP
+	IdemixMSPType = "idemix"
 )
 
 // TopLevel consists of the structs used by the configtxgen tool.
@@ -103,6 +108,7 @@ type Organization struct {
 	Name           string `yaml:"Name"`
 	ID             string `yaml:"ID"`
 	MSPDir         string `yaml:"MSPDir"`
+	MSPType        string `yaml:"MSPType"`
 	AdminPrincipal string `yaml:"AdminPrincipal"`
 
 	// Note: Viper deserialization does not seem to care for
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -127,7 +128,7 @@ func New(conf *genesisconfig.Profile) Generator {
 		}
 
 		for _, org := range conf.Orderer.Organizations {
-			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+			mspConfig, err := getVerifyingMspConfig(org)
 			if err != nil {
 				logger.Panicf("1 - Error loading MSP configuration for org %s: %s", org.Name, err)
 			}
@@ -156,7 +157,7 @@ func New(conf *genesisconfig.Profile) Generator {
 			policies.TemplateImplicitMetaMajorityPolicy([]string{config.ApplicationGroupKey}, configvaluesmsp.AdminsPolicyKey),
 		}
 		for _, org := range conf.Application.Organizations {
-			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+			mspConfig, err := getVerifyingMspConfig(org)
 			if err != nil {
 				logger.Panicf("2- Error loading MSP configuration for org %s: %s", org.Name, err)
 			}
@@ -209,7 +210,7 @@ func New(conf *genesisconfig.Profile) Generator {
 			bs.consortiumsGroups = append(bs.consortiumsGroups, cg)
 
 			for _, org := range consortium.Organizations {
-				mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+				mspConfig, err := getVerifyingMspConfig(org)
 				if err != nil {
 					logger.Panicf("3 - Error loading MSP configuration for org %s: %s", org.Name, err)
 				}
@@ -226,6 +227,19 @@ func New(conf *genesisconfig.Profile) Generator {
 	return bs
 }
 
+// getVerifyingMspConfig loads the MSP configuration of the
+// given organization according to its MSP type
+func getVerifyingMspConfig(org *genesisconfig.Organization) (*mspprotos.MSPConfig, error) {
+	switch org.MSPType {
+	case "", genesisconfig.BCCSPMSPType:
+		return msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+	case genesisconfig.IdemixMSPType:
+		return msp.GetVerifyingIdemixMspConfig(org.MSPDir, org.ID)
+	default:
+		return nil, fmt.Errorf("unknown MSP type %s", org.MSPType)
+	}
+}
+
 // ChannelTemplate TODO
 func (bs *bootstrapper) ChannelTemplate() configtx.Template {
 	return configtx.NewModPolicySettingTemplate(
@@ -0,0 +1,324 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+)
+
+var expirationLogger = flogging.MustGetLogger("certexpiration")
+
+// Kinds of certificates tracked for expiration
+const (
+	EnrollmentCertificate        = "enrollment"
+	TLSCertificate               = "tls"
+	AdminCertificate             = "admin"
+	RootCACertificate            = "root CA"
+	IntermediateCACertificate    = "intermediate CA"
+	TLSRootCACertificate         = "TLS root CA"
+	TLSIntermediateCACertificate = "TLS intermediate CA"
+)
+
+// LocalSource is the source under which the certificates of the node itself are tracked
+const LocalSource = "local"
+
+// DefaultExpirationWarningThresholds are the times before expiration at which
+// a warning is logged about a certificate, unless configured otherwise
+var DefaultExpirationWarningThresholds = []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour}
+
+// CertificateExpiration describes when a certificate expires
+type CertificateExpiration struct {
+	// Source is LocalSource for the certificates of the node, or the
+	// channel whose configuration carries the certificate
+	Source string `json:"source"`
+	// Kind is one of the certificate kinds above
+	Kind string `json:"kind"`
+	// MSPID is the MSP the certificate belongs to, empty for TLS certificates
+	MSPID    string    `json:"msp_id,omitempty"`
+	Subject  string    `json:"subject"`
+	NotAfter time.Time `json:"not_after"`
+}
+
+func (ce CertificateExpiration) String() string {
+	if ce.MSPID == "" {
+		return fmt.Sprintf("%s certificate %s (%s)", ce.Kind, ce.Subject, ce.Source)
+	}
+	return fmt.Sprintf("%s certificate %s of %s (%s)", ce.Kind, ce.Subject, ce.MSPID, ce.Source)
+}
+
+// ExpirationTracker keeps track of the certificates a node relies on, logs
+// warnings when they get close to their expiration and reports their expiry dates
+type ExpirationTracker struct {
+	sync.Mutex
+	thresholds []time.Duration
+	sources    map[string]*trackedSource
+	now        func() time.Time
+	afterFunc  func(time.Duration, func()) *time.Timer
+}
+
+type trackedSource struct {
+	certs  []CertificateExpiration
+	timers []*time.Timer
+}
+
+// NewExpirationTracker creates an ExpirationTracker which warns about
+// certificates when their remaining validity drops below each of the thresholds
+func NewExpirationTracker(thresholds []time.Duration) *ExpirationTracker {
+	t := &ExpirationTracker{
+		sources:   make(map[string]*trackedSource),
+		now:       time.Now,
+		afterFunc: time.AfterFunc,
+	}
+	t.SetThresholds(thresholds)
+	return t
+}
+
+// SetThresholds changes the warning thresholds of the tracker, taking effect
+// for the certificates tracked from then on
+func (t *ExpirationTracker) SetThresholds(thresholds []time.Duration) {
+	sorted := make([]time.Duration, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold > 0 {
+			sorted = append(sorted, threshold)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	t.Lock()
+	defer t.Unlock()
+	t.thresholds = sorted
+}
+
+// Track replaces the certificates tracked for the given source. Certificates
+// which have expired or are within a warning threshold are reported right
+// away, the others as soon as they cross a threshold.
+func (t *ExpirationTracker) Track(source string, certs []CertificateExpiration) {
+	t.Lock()
+	defer t.Unlock()
+
+	if old, exists := t.sources[source]; exists {
+		for _, timer := range old.timers {
+			timer.Stop()
+		}
+	}
+
+	ts := &trackedSource{certs: make([]CertificateExpiration, len(certs))}
+	now := t.now()
+	for i, cert := range certs {
+		cert.Source = source
+		ts.certs[i] = cert
+
+		left := cert.NotAfter.Sub(now)
+		if left <= 0 {
+			expirationLogger.Errorf("The %s has expired on %s", cert, cert.NotAfter)
+			continue
+		}
+		if len(t.thresholds) > 0 && left <= t.thresholds[0] {
+			expirationLogger.Warningf("The %s expires in %s, on %s", cert, left, cert.NotAfter)
+		}
+		for _, threshold := range t.thresholds {
+			if threshold >= left {
+				continue
+			}
+			ts.timers = append(ts.timers, t.afterFunc(left-threshold, warnFunc(cert, threshold)))
+		}
+		ts.timers = append(ts.timers, t.afterFunc(left, func() {
+			expirationLogger.Errorf("The %s has expired on %s", cert, cert.NotAfter)
+		}))
+	}
+	t.sources[source] = ts
+}
+
+func warnFunc(cert CertificateExpiration, left time.Duration) func() {
+	return func() {
+		expirationLogger.Warningf("The %s expires in %s, on %s", cert, left, cert.NotAfter)
+	}
+}
+
+// Untrack stops tracking the certificates of the given source
+func (t *ExpirationTracker) Untrack(source string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if ts, exists := t.sources[source]; exists {
+		for _, timer := range ts.timers {
+			timer.Stop()
+		}
+		delete(t.sources, source)
+	}
+}
+
+// Expirations returns all tracked certificates, those expiring first coming first
+func (t *ExpirationTracker) Expirations() []CertificateExpiration {
+	t.Lock()
+	defer t.Unlock()
+
+	var res []CertificateExpiration
+	for _, ts := range t.sources {
+		res = append(res, ts.certs...)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].NotAfter.Equal(res[j].NotAfter) {
+			return res[i].Source < res[j].Source
+		}
+		return res[i].NotAfter.Before(res[j].NotAfter)
+	})
+	return res
+}
+
+var expirationTracker = NewExpirationTracker(DefaultExpirationWarningThresholds)
+
+// ExpirationTrackerInstance returns the ExpirationTracker of the process
+func ExpirationTrackerInstance() *ExpirationTracker {
+	return expirationTracker
+}
+
+// CertificateExpirationFromPEM returns the expiration of the first certificate in the PEM bytes
+func CertificateExpirationFromPEM(kind, mspID string, pemBytes []byte) (CertificateExpiration, error) {
+	cert, err := parsePEMCertificate(pemBytes)
+	if err != nil {
+		return CertificateExpiration{}, err
+	}
+	return CertificateExpiration{
+		Kind:     kind,
+		MSPID:    mspID,
+		Subject:  cert.Subject.CommonName,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
+
+// ExpiresAt returns the time at which the certificate of the given serialized
+// identity expires, or the zero time if the identity is not backed by an x509 certificate
+func ExpiresAt(identityBytes []byte) time.Time {
+	_, cert, err := identityCertificate(identityBytes)
+	if err != nil {
+		return time.Time{}
+	}
+	return cert.NotAfter
+}
+
+// LocalCertificateExpirations returns the expirations of the enrollment
+// certificate of the given serialized signing identity and of the TLS
+// certificate, if any. Identities not backed by an x509 certificate are skipped.
+func LocalCertificateExpirations(signingIdentity []byte, tlsCert []byte) ([]CertificateExpiration, error) {
+	var res []CertificateExpiration
+	if mspID, cert, err := identityCertificate(signingIdentity); err == nil {
+		res = append(res, CertificateExpiration{
+			Kind:     EnrollmentCertificate,
+			MSPID:    mspID,
+			Subject:  cert.Subject.CommonName,
+			NotAfter: cert.NotAfter,
+		})
+	}
+	if len(tlsCert) != 0 {
+		ce, err := CertificateExpirationFromPEM(TLSCertificate, "", tlsCert)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid TLS certificate: %s", err)
+		}
+		res = append(res, ce)
+	}
+	return res, nil
+}
+
+func identityCertificate(identityBytes []byte) (string, *x509.Certificate, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identityBytes, sID); err != nil {
+		return "", nil, err
+	}
+	cert, err := parsePEMCertificate(sID.IdBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	return sID.Mspid, cert, nil
+}
+
+// ChannelCertificateExpirations returns the expirations of the admin, CA and
+// TLS CA certificates of all the MSPs defined in a channel configuration
+func ChannelCertificateExpirations(config *cb.Config) ([]CertificateExpiration, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, fmt.Errorf("Channel configuration is empty")
+	}
+	var res []CertificateExpiration
+	if err := groupCertificateExpirations(config.ChannelGroup, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func groupCertificateExpirations(group *cb.ConfigGroup, res *[]CertificateExpiration) error {
+	// MSP definitions live under the "MSP" key of the organization groups
+	if value, exists := group.Values["MSP"]; exists {
+		if err := mspCertificateExpirations(value.Value, res); err != nil {
+			return err
+		}
+	}
+	// Iterate over the sub groups in order so that the result is stable
+	names := make([]string, 0, len(group.Groups))
+	for name := range group.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := groupCertificateExpirations(group.Groups[name], res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mspCertificateExpirations(value []byte, res *[]CertificateExpiration) error {
+	mspConfig := &msp.MSPConfig{}
+	if err := proto.Unmarshal(value, mspConfig); err != nil {
+		return fmt.Errorf("Error unmarshalling MSP configuration: %s", err)
+	}
+	// Only fabric MSPs (type 0) are backed by x509 certificates
+	if mspConfig.Type != 0 {
+		return nil
+	}
+	fabricConfig := &msp.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return fmt.Errorf("Error unmarshalling fabric MSP configuration: %s", err)
+	}
+
+	for _, certs := range []struct {
+		kind string
+		pems [][]byte
+	}{
+		{AdminCertificate, fabricConfig.Admins},
+		{RootCACertificate, fabricConfig.RootCerts},
+		{IntermediateCACertificate, fabricConfig.IntermediateCerts},
+		{TLSRootCACertificate, fabricConfig.TlsRootCerts},
+		{TLSIntermediateCACertificate, fabricConfig.TlsIntermediateCerts},
+	} {
+		for _, pemBytes := range certs.pems {
+			ce, err := CertificateExpirationFromPEM(certs.kind, fabricConfig.Name, pemBytes)
+			if err != nil {
+				return fmt.Errorf("Invalid %s certificate of MSP %s: %s", certs.kind, fabricConfig.Name, err)
+			}
+			*res = append(*res, ce)
+		}
+	}
+	return nil
+}
+
+func parsePEMCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("No PEM block found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
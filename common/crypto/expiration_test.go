@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCertPEM(t *testing.T, cn string, notAfter time.Time) []byte {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestExpirationTrackerTimers(t *testing.T) {
+	now := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	var scheduled []time.Duration
+	tracker := NewExpirationTracker([]time.Duration{day, 30 * day, 7 * day, 0})
+	tracker.now = func() time.Time { return now }
+	tracker.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		scheduled = append(scheduled, d)
+		return time.AfterFunc(time.Hour, f)
+	}
+
+	tracker.Track("mychannel", []CertificateExpiration{
+		{Kind: AdminCertificate, MSPID: "Org1MSP", Subject: "admin", NotAfter: now.Add(10 * day)},
+		{Kind: RootCACertificate, MSPID: "Org1MSP", Subject: "ca", NotAfter: now.Add(-day)},
+	})
+	// Within the 30 days threshold: warnings are due at 7 days, 1 day and at expiration.
+	// The expired certificate is only reported.
+	assert.Equal(t, []time.Duration{3 * day, 9 * day, 10 * day}, scheduled)
+
+	scheduled = nil
+	tracker.Track(LocalSource, []CertificateExpiration{
+		{Kind: EnrollmentCertificate, MSPID: "Org1MSP", Subject: "peer0", NotAfter: now.Add(100 * day)},
+	})
+	assert.Equal(t, []time.Duration{70 * day, 93 * day, 99 * day, 100 * day}, scheduled)
+
+	expirations := tracker.Expirations()
+	assert.Len(t, expirations, 3)
+	assert.Equal(t, "ca", expirations[0].Subject)
+	assert.Equal(t, "mychannel", expirations[0].Source)
+	assert.Equal(t, "admin", expirations[1].Subject)
+	assert.Equal(t, "peer0", expirations[2].Subject)
+	assert.Equal(t, LocalSource, expirations[2].Source)
+
+	// Tracking a source again replaces its certificates
+	tracker.Track("mychannel", nil)
+	assert.Len(t, tracker.Expirations(), 1)
+	tracker.Untrack(LocalSource)
+	assert.Len(t, tracker.Expirations(), 0)
+}
+
+func TestExpirationTrackerWarns(t *testing.T) {
+	tracker := NewExpirationTracker([]time.Duration{time.Hour})
+	tracker.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		// Fire right away, the logging must not deadlock with the tracker
+		return time.AfterFunc(0, f)
+	}
+	tracker.Track(LocalSource, []CertificateExpiration{
+		{Kind: TLSCertificate, Subject: "peer0", NotAfter: time.Now().Add(2 * time.Hour)},
+	})
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, tracker.Expirations(), 1)
+}
+
+func TestLocalCertificateExpirations(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	certPEM := newCertPEM(t, "peer0", notAfter)
+	sID, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: certPEM})
+	assert.NoError(t, err)
+
+	assert.Equal(t, notAfter, ExpiresAt(sID).UTC())
+	assert.True(t, ExpiresAt([]byte("garbage")).IsZero())
+	idemixID, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "IdemixMSP", IdBytes: []byte{1, 2, 3}})
+	assert.NoError(t, err)
+	assert.True(t, ExpiresAt(idemixID).IsZero())
+
+	tlsPEM := newCertPEM(t, "peer0.tls", notAfter.Add(time.Hour))
+	expirations, err := LocalCertificateExpirations(sID, tlsPEM)
+	assert.NoError(t, err)
+	assert.Len(t, expirations, 2)
+	assert.Equal(t, EnrollmentCertificate, expirations[0].Kind)
+	assert.Equal(t, "Org1MSP", expirations[0].MSPID)
+	assert.Equal(t, "peer0", expirations[0].Subject)
+	assert.Equal(t, TLSCertificate, expirations[1].Kind)
+	assert.Equal(t, "peer0.tls", expirations[1].Subject)
+
+	// Identities without an x509 certificate are skipped
+	expirations, err = LocalCertificateExpirations(idemixID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, expirations, 0)
+
+	_, err = LocalCertificateExpirations(sID, []byte("not a certificate"))
+	assert.Error(t, err)
+}
+
+func TestChannelCertificateExpirations(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour)
+	fabricConfig := &msp.FabricMSPConfig{
+		Name:              "Org1MSP",
+		RootCerts:         [][]byte{newCertPEM(t, "ca", notAfter)},
+		IntermediateCerts: [][]byte{newCertPEM(t, "ica", notAfter)},
+		Admins:            [][]byte{newCertPEM(t, "admin", notAfter)},
+		TlsRootCerts:      [][]byte{newCertPEM(t, "tlsca", notAfter)},
+	}
+	mspConfig := &msp.MSPConfig{Type: 0, Config: marshalOrPanic(fabricConfig)}
+	idemixConfig := &msp.MSPConfig{Type: 1, Config: []byte{1, 2, 3}}
+
+	config := &cb.Config{ChannelGroup: cb.NewConfigGroup()}
+	config.ChannelGroup.Groups["Application"] = cb.NewConfigGroup()
+	org1 := cb.NewConfigGroup()
+	org1.Values["MSP"] = &cb.ConfigValue{Value: marshalOrPanic(mspConfig)}
+	config.ChannelGroup.Groups["Application"].Groups["Org1"] = org1
+	org2 := cb.NewConfigGroup()
+	org2.Values["MSP"] = &cb.ConfigValue{Value: marshalOrPanic(idemixConfig)}
+	config.ChannelGroup.Groups["Application"].Groups["Org2"] = org2
+
+	expirations, err := ChannelCertificateExpirations(config)
+	assert.NoError(t, err)
+	assert.Len(t, expirations, 4)
+	kinds := map[string]string{}
+	for _, ce := range expirations {
+		assert.Equal(t, "Org1MSP", ce.MSPID)
+		kinds[ce.Subject] = ce.Kind
+	}
+	assert.Equal(t, map[string]string{
+		"ca":    RootCACertificate,
+		"ica":   IntermediateCACertificate,
+		"admin": AdminCertificate,
+		"tlsca": TLSRootCACertificate,
+	}, kinds)
+
+	fabricConfig.Admins = [][]byte{[]byte("not a certificate")}
+	mspConfig.Config = marshalOrPanic(fabricConfig)
+	org1.Values["MSP"].Value = marshalOrPanic(mspConfig)
+	_, err = ChannelCertificateExpirations(config)
+	assert.Error(t, err)
+
+	_, err = ChannelCertificateExpirations(&cb.Config{})
+	assert.Error(t, err)
+}
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -74,6 +74,36 @@ func TestBlockItrClose(t *testing.T) {
 	testutil.AssertNil(t, bh)
 }
 
+func TestBlockItrCloseWhileWaiting(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 2)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	// Closing an iterator that waits for a block that isn't available yet
+	// unblocks it, even though it hasn't read any block
+	itr, err := blkfileMgr.retrieveBlocks(5)
+	testutil.AssertNoError(t, err, "")
+	doneChan := make(chan bool)
+	go func() {
+		bh, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertNil(t, bh)
+		doneChan <- true
+	}()
+	time.Sleep(time.Millisecond * 100)
+	itr.Close()
+	select {
+	case <-doneChan:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Next() wasn't unblocked by Close()")
+	}
+}
+
 func testIterateAndVerify(t *testing.T, itr *blocksItr, blocks []*common.Block, doneChan chan bool) {
 	blocksIterated := 0
 	for {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
@@ -38,7 +39,7 @@ var testDir = filepath.Join(os.TempDir(), "ca-test")
 func TestNewCA(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
-	rootCA, err := ca.NewCA(caDir, testCAName, testCAName)
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 	assert.NotNil(t, rootCA, "Failed to return CA")
 	assert.NotNil(t, rootCA.Signer,
@@ -59,7 +60,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	caDir := filepath.Join(testDir, "ca")
 	certDir := filepath.Join(testDir, "certs")
 	// generate private key
-	priv, _, err := csp.GeneratePrivateKey(certDir)
+	priv, _, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// get EC public key
@@ -68,30 +69,36 @@ func TestGenerateSignCertificate(t *testing.T) {
 	assert.NotNil(t, ecPubKey, "Failed to generate signed certificate")
 
 	// create our CA
-	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name)
+	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 
-	cert, err := rootCA.SignCertificate(certDir, testName, nil, ecPubKey,
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, ecPubKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
-		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny})
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny}, 0)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 	// KeyUsage should be x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
 	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		cert.KeyUsage)
 	assert.Contains(t, cert.ExtKeyUsage, x509.ExtKeyUsageAny)
 
-	cert, err = rootCA.SignCertificate(certDir, testName, nil, ecPubKey,
-		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	cert, err = rootCA.SignCertificate(certDir, testName, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, 0)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 	assert.Equal(t, 0, len(cert.ExtKeyUsage))
 
+	// make sure the requested OUs are set in the subject
+	cert, err = rootCA.SignCertificate(certDir, testName, []string{"peer"}, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, 0)
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.Equal(t, []string{"peer"}, cert.Subject.OrganizationalUnit)
+
 	// check to make sure the signed public key was stored
 	pemFile := filepath.Join(certDir, testName+"-cert.pem")
 	assert.Equal(t, true, checkForFile(pemFile),
 		"Expected to find file "+pemFile)
 
-	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, ecPubKey,
-		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
+	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, ecPubKey,
+		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny}, 0)
 	assert.Error(t, err, "Bad name should fail")
 
 	// use an empty CA to test error path
@@ -99,13 +106,116 @@ func TestGenerateSignCertificate(t *testing.T) {
 		Name:     "badCA",
 		SignCert: &x509.Certificate{},
 	}
-	_, err = badCA.SignCertificate(certDir, testName, nil, &ecdsa.PublicKey{},
-		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
+	_, err = badCA.SignCertificate(certDir, testName, nil, nil, &ecdsa.PublicKey{},
+		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny}, 0)
 	assert.Error(t, err, "Empty CA should not be able to sign")
 	cleanup(testDir)
 
 }
 
+func TestGenerateSignCertificateED25519(t *testing.T) {
+
+	caDir := filepath.Join(testDir, "ca")
+	certDir := filepath.Join(testDir, "certs")
+	priv, _, err := csp.GeneratePrivateKey(certDir, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate private key")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+
+	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name, csp.ED25519, 0)
+	assert.NoError(t, err, "Error generating CA")
+	assert.Equal(t, csp.ED25519, rootCA.KeyAlgorithm)
+	assert.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, pubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, 0)
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.Equal(t, x509.Ed25519, cert.PublicKeyAlgorithm)
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+	cleanup(testDir)
+
+}
+
+func TestNewIntermediateCA(t *testing.T) {
+
+	caDir := filepath.Join(testDir, "ca")
+	icaDir := filepath.Join(testDir, "ica")
+	certDir := filepath.Join(testDir, "certs")
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, csp.ECDSAP384, 0)
+	assert.NoError(t, err, "Error generating CA")
+	interCA, err := rootCA.NewIntermediateCA(icaDir, testCAName, testCA2Name, csp.ECDSAP384, 24*time.Hour)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	assert.True(t, interCA.SignCert.IsCA)
+	assert.True(t, interCA.SignCert.NotAfter.Before(time.Now().Add(25*time.Hour)),
+		"The validity of the intermediate CA should be 24h")
+	assert.Equal(t, rootCA, interCA.Root())
+	assert.Equal(t, []*x509.Certificate{interCA.SignCert}, interCA.Intermediates())
+	assert.Empty(t, rootCA.Intermediates())
+	assert.True(t, checkForFile(filepath.Join(icaDir, testCA2Name+"-cert.pem")))
+
+	// certificates issued by the intermediate CA chain up to the root CA
+	priv, _, err := csp.GeneratePrivateKey(certDir, csp.ECDSAP384)
+	assert.NoError(t, err, "Failed to generate private key")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+	cert, err := interCA.SignCertificate(certDir, testName, []string{"peer", "department1"},
+		[]string{"alt.example.com"}, pubKey, x509.KeyUsageDigitalSignature,
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny}, time.Hour)
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.Equal(t, []string{"peer", "department1"}, cert.Subject.OrganizationalUnit)
+	assert.Equal(t, []string{"alt.example.com"}, cert.DNSNames)
+	assert.True(t, cert.NotAfter.Before(time.Now().Add(2*time.Hour)),
+		"The validity of the certificate should be 1h")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCA.SignCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(interCA.SignCert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err, "The certificate should chain up to the root CA")
+	cleanup(testDir)
+
+}
+
+func TestLoadCA(t *testing.T) {
+
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		caDir := filepath.Join(testDir, "ca")
+		certDir := filepath.Join(testDir, "certs")
+		rootCA, err := ca.NewCA(caDir, testCAName, testCAName, keyAlg, 0)
+		assert.NoError(t, err, "Error generating CA")
+
+		loadedCA, err := ca.LoadCA(caDir)
+		assert.NoError(t, err, "Error loading CA")
+		assert.Equal(t, testCAName, loadedCA.Name)
+		assert.Equal(t, keyAlg, loadedCA.KeyAlgorithm)
+		assert.Equal(t, rootCA.SignCert.Raw, loadedCA.SignCert.Raw)
+
+		// certificates issued by the loaded CA chain up to the original one
+		priv, _, err := csp.GeneratePrivateKey(certDir, keyAlg)
+		assert.NoError(t, err, "Failed to generate private key")
+		pubKey, err := csp.GetPublicKey(priv)
+		assert.NoError(t, err, "Failed to get public key")
+		cert, err := loadedCA.SignCertificate(certDir, testName, nil, nil, pubKey,
+			x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, 0)
+		assert.NoError(t, err, "Failed to generate signed certificate")
+		assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+		cleanup(testDir)
+	}
+
+	_, err := ca.LoadCA(filepath.Join(testDir, "missing"))
+	assert.Error(t, err, "Expected an error for a missing CA directory")
+
+	// a CA directory without certificate
+	caDir := filepath.Join(testDir, "ca")
+	_, _, err = csp.GeneratePrivateKey(caDir, csp.ECDSA)
+	assert.NoError(t, err, "Failed to generate private key")
+	_, err = ca.LoadCA(caDir)
+	assert.Error(t, err, "Expected an error for a CA directory without certificate")
+	cleanup(testDir)
+}
+
 func cleanup(dir string) {
 	os.RemoveAll(dir)
 }
@@ -16,14 +16,20 @@ limitations under the License.
 package ca
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
+	"strings"
 	"time"
 
 	"path/filepath"
@@ -36,25 +42,42 @@ type CA struct {
 	//SignKey  *ecdsa.PrivateKey
 	Signer   crypto.Signer
 	SignCert *x509.Certificate
+	// KeyAlgorithm is the algorithm of the keys of the CA
+	// and of the identities it issues
+	KeyAlgorithm string
+	// Parent is the CA which issued the certificate of an
+	// intermediate CA, nil for a root CA
+	Parent *CA
 }
 
-// NewCA creates an instance of CA and saves the signing key pair in
-// baseDir/name
-func NewCA(baseDir, org, name string) (*CA, error) {
+// NewCA creates an instance of a root CA, valid for the given period,
+// and saves the signing key pair, of the given key algorithm, in baseDir/name.
+// A zero validity stands for the default of ten years
+func NewCA(baseDir, org, name, keyAlg string, validity time.Duration) (*CA, error) {
+	return newCA(baseDir, org, name, keyAlg, validity, nil)
+}
+
+// NewIntermediateCA creates an instance of an intermediate CA whose
+// certificate is issued by ca, and saves its signing key pair in baseDir/name
+func (ca *CA) NewIntermediateCA(baseDir, org, name, keyAlg string, validity time.Duration) (*CA, error) {
+	return newCA(baseDir, org, name, keyAlg, validity, ca)
+}
+
+func newCA(baseDir, org, name, keyAlg string, validity time.Duration, parent *CA) (*CA, error) {
 
 	var response error
 	var ca *CA
 
 	err := os.MkdirAll(baseDir, 0755)
 	if err == nil {
-		priv, signer, err := csp.GeneratePrivateKey(baseDir)
+		priv, signer, err := csp.GeneratePrivateKey(baseDir, keyAlg)
 		response = err
 		if err == nil {
 			// get public signing certificate
-			ecPubKey, err := csp.GetECPublicKey(priv)
+			pubKey, err := csp.GetPublicKey(priv)
 			response = err
 			if err == nil {
-				template := x509Template()
+				template := x509Template(validity)
 				//this is a CA
 				template.IsCA = true
 				template.KeyUsage |= x509.KeyUsageDigitalSignature |
@@ -70,14 +93,22 @@ func NewCA(baseDir, org, name string) (*CA, error) {
 				template.Subject = subject
 				template.SubjectKeyId = priv.SKI()
 
-				x509Cert, err := genCertificateECDSA(baseDir, name, &template, &template,
-					ecPubKey, signer)
+				// a root CA signs its own certificate
+				parentCert, parentSigner := &template, crypto.Signer(signer)
+				if parent != nil {
+					parentCert, parentSigner = parent.SignCert, parent.Signer
+				}
+
+				x509Cert, err := genCertificate(baseDir, name, &template, parentCert,
+					pubKey, parentSigner)
 				response = err
 				if err == nil {
 					ca = &CA{
-						Name:     name,
-						Signer:   signer,
-						SignCert: x509Cert,
+						Name:         name,
+						Signer:       signer,
+						SignCert:     x509Cert,
+						KeyAlgorithm: keyAlg,
+						Parent:       parent,
 					}
 				}
 			}
@@ -86,12 +117,79 @@ func NewCA(baseDir, org, name string) (*CA, error) {
 	return ca, response
 }
 
-// SignCertificate creates a signed certificate based on a built-in template
-// and saves it in baseDir/name
-func (ca *CA) SignCertificate(baseDir, name string, sans []string, pub *ecdsa.PublicKey,
-	ku x509.KeyUsage, eku []x509.ExtKeyUsage) (*x509.Certificate, error) {
+// Root returns the root CA of the chain ca belongs to
+func (ca *CA) Root() *CA {
+	root := ca
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	return root
+}
+
+// Intermediates returns the certificates of the intermediate CAs from ca
+// up to, and excluding, the root CA
+func (ca *CA) Intermediates() []*x509.Certificate {
+	var certs []*x509.Certificate
+	for c := ca; c.Parent != nil; c = c.Parent {
+		certs = append(certs, c.SignCert)
+	}
+	return certs
+}
 
-	template := x509Template()
+// LoadCA loads the CA whose signing key pair was saved in baseDir by NewCA
+func LoadCA(baseDir string) (*CA, error) {
+
+	files, err := ioutil.ReadDir(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var cert *x509.Certificate
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), "-cert.pem") {
+			cert, err = loadCertificate(filepath.Join(baseDir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("no CA certificate found in %s", baseDir)
+	}
+
+	priv, signer, err := csp.LoadPrivateKey(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(priv.SKI(), cert.SubjectKeyId) {
+		return nil, fmt.Errorf("the private key in %s does not match the CA certificate", baseDir)
+	}
+
+	keyAlg := csp.ECDSA
+	switch pub := cert.PublicKey.(type) {
+	case ed25519.PublicKey:
+		keyAlg = csp.ED25519
+	case *ecdsa.PublicKey:
+		if pub.Curve == elliptic.P384() {
+			keyAlg = csp.ECDSAP384
+		}
+	}
+
+	return &CA{
+		Name:         cert.Subject.CommonName,
+		Signer:       signer,
+		SignCert:     cert,
+		KeyAlgorithm: keyAlg,
+	}, nil
+}
+
+// SignCertificate creates a signed certificate based on a built-in template,
+// valid for the given period, and saves it in baseDir/name. A zero validity
+// stands for the default of ten years
+func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub crypto.PublicKey,
+	ku x509.KeyUsage, eku []x509.ExtKeyUsage, validity time.Duration) (*x509.Certificate, error) {
+
+	template := x509Template(validity)
 	template.KeyUsage = ku
 	template.ExtKeyUsage = eku
 
@@ -99,10 +197,12 @@ func (ca *CA) SignCertificate(baseDir, name string, sans []string, pub *ecdsa.Pu
 	subject := subjectTemplate()
 	subject.CommonName = name
 
+	subject.OrganizationalUnit = append(subject.OrganizationalUnit, ous...)
+
 	template.Subject = subject
 	template.DNSNames = sans
 
-	cert, err := genCertificateECDSA(baseDir, name, &template, ca.SignCert,
+	cert, err := genCertificate(baseDir, name, &template, ca.SignCert,
 		pub, ca.Signer)
 
 	if err != nil {
@@ -122,26 +222,44 @@ func subjectTemplate() pkix.Name {
 }
 
 // default template for X509 certificates
-func x509Template() x509.Certificate {
+func x509Template(validity time.Duration) x509.Certificate {
 
 	//generate a serial number
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, _ := rand.Int(rand.Reader, serialNumberLimit)
 
+	if validity == 0 {
+		validity = 3650 * 24 * time.Hour //~ten years
+	}
+
 	now := time.Now()
 	//basic template to use
 	x509 := x509.Certificate{
 		SerialNumber:          serialNumber,
 		NotBefore:             now,
-		NotAfter:              now.Add(3650 * 24 * time.Hour), //~ten years
+		NotAfter:              now.Add(validity),
 		BasicConstraintsValid: true,
 	}
 	return x509
 
 }
 
-// generate a signed X509 certficate using ECDSA
-func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificate, pub *ecdsa.PublicKey,
+// load a PEM encoded X509 certificate
+func loadCertificate(fileName string) (*x509.Certificate, error) {
+
+	pemBytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", fileName)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// generate a signed X509 certficate using the key of the parent
+func genCertificate(baseDir, name string, template, parent *x509.Certificate, pub crypto.PublicKey,
 	priv interface{}) (*x509.Certificate, error) {
 
 	//create the x509 public cert
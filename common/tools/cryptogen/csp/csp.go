@@ -19,35 +19,88 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/hyperledger/fabric/bccsp"
-	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/sw"
 )
 
-// GeneratePrivateKey creates a private key and stores it in keystorePath
-func GeneratePrivateKey(keystorePath string) (bccsp.Key,
+// Key algorithms of the generated key material
+const (
+	ECDSA     = "ecdsa"
+	ECDSAP384 = "ecdsa-p384"
+	ED25519   = "ed25519"
+)
+
+// keyGenOpts returns the options to generate a key of the given algorithm,
+// which defaults to ECDSA on the P-256 curve
+func keyGenOpts(keyAlg string) (bccsp.KeyGenOpts, error) {
+	switch keyAlg {
+	case "", ECDSA:
+		return &bccsp.ECDSAP256KeyGenOpts{Temporary: false}, nil
+	case ECDSAP384:
+		return &bccsp.ECDSAP384KeyGenOpts{Temporary: false}, nil
+	case ED25519:
+		return &bccsp.ED25519KeyGenOpts{Temporary: false}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", keyAlg)
+	}
+}
+
+// keystorePassword encrypts the private keys stored in the keystores
+var keystorePassword []byte
+
+// SetKeystorePassword sets the password encrypting the private keys stored
+// by GeneratePrivateKey, and decrypting those loaded by LoadPrivateKey.
+// The keys are not encrypted if pwd is empty.
+func SetKeystorePassword(pwd []byte) {
+	keystorePassword = pwd
+}
+
+// newCSP returns a software BCCSP backed by the file keystore at keystorePath
+func newCSP(keystorePath string, pwd []byte) (bccsp.BCCSP, error) {
+	ks, err := sw.NewFileBasedKeyStore(pwd, keystorePath, false)
+	if err != nil {
+		return nil, err
+	}
+	return sw.New(256, "SHA2", ks)
+}
+
+// GeneratePrivateKey creates a private key of the given algorithm
+// and stores it in keystorePath, encrypted with the keystore password if any
+func GeneratePrivateKey(keystorePath string, keyAlg string) (bccsp.Key,
+	crypto.Signer, error) {
+	return generatePrivateKey(keystorePath, keyAlg, keystorePassword)
+}
+
+// GenerateUnencryptedPrivateKey creates a private key of the given algorithm
+// and stores it unencrypted in keystorePath, as expected of TLS private keys
+func GenerateUnencryptedPrivateKey(keystorePath string, keyAlg string) (bccsp.Key,
+	crypto.Signer, error) {
+	return generatePrivateKey(keystorePath, keyAlg, nil)
+}
+
+func generatePrivateKey(keystorePath string, keyAlg string, pwd []byte) (bccsp.Key,
 	crypto.Signer, error) {
 
 	var err error
 	var priv bccsp.Key
 	var s crypto.Signer
 
-	opts := &factory.FactoryOpts{
-		ProviderName: "SW",
-		SwOpts: &factory.SwOpts{
-			HashFamily: "SHA2",
-			SecLevel:   256,
-
-			FileKeystore: &factory.FileKeystoreOpts{
-				KeyStorePath: keystorePath,
-			},
-		},
+	keyOpts, err := keyGenOpts(keyAlg)
+	if err != nil {
+		return nil, nil, err
 	}
-	csp, err := factory.GetBCCSPFromOpts(opts)
+
+	csp, err := newCSP(keystorePath, pwd)
 	if err == nil {
 		// generate a key
-		priv, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+		priv, err = csp.KeyGen(keyOpts)
 		if err == nil {
 			// create a crypto.Signer
 			s, err = signer.New(csp, priv)
@@ -56,7 +109,55 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 	return priv, s, err
 }
 
+// LoadPrivateKey loads the private key stored in keystorePath,
+// decrypting it with the keystore password if any
+func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
+
+	csp, err := newCSP(keystorePath, keystorePassword)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// private keys are stored as <hex encoded SKI>_sk
+	files, err := ioutil.ReadDir(keystorePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), "_sk") {
+			continue
+		}
+		ski, err := hex.DecodeString(strings.TrimSuffix(file.Name(), "_sk"))
+		if err != nil {
+			continue
+		}
+		priv, err := csp.GetKey(ski)
+		if err != nil {
+			return nil, nil, err
+		}
+		s, err := signer.New(csp, priv)
+		if err != nil {
+			return nil, nil, err
+		}
+		return priv, s, nil
+	}
+	return nil, nil, fmt.Errorf("no private key found in %s", keystorePath)
+}
+
 func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
+	pubKey, err := GetPublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an ECDSA public key")
+	}
+	return ecPubKey, nil
+}
+
+// GetPublicKey returns the public key of priv as a crypto.PublicKey
+func GetPublicKey(priv bccsp.Key) (crypto.PublicKey, error) {
 
 	// get the public key
 	pubKey, err := priv.PublicKey()
@@ -69,9 +170,5 @@ func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
 		return nil, err
 	}
 	// unmarshal using pkix
-	ecPubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
-	if err != nil {
-		return nil, err
-	}
-	return ecPubKey.(*ecdsa.PublicKey), nil
+	return x509.ParsePKIXPublicKey(pubKeyBytes)
 }
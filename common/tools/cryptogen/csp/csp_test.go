@@ -17,8 +17,10 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -59,7 +61,7 @@ var testDir = filepath.Join(os.TempDir(), "csp-test")
 
 func TestGeneratePrivateKey(t *testing.T) {
 
-	priv, signer, err := csp.GeneratePrivateKey(testDir)
+	priv, signer, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate private key")
 	assert.NotNil(t, priv, "Should have returned a bccsp.Key")
 	assert.Equal(t, true, priv.Private(), "Failed to return private key")
@@ -74,7 +76,7 @@ func TestGeneratePrivateKey(t *testing.T) {
 
 func TestGetECPublicKey(t *testing.T) {
 
-	priv, _, err := csp.GeneratePrivateKey(testDir)
+	priv, _, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate private key")
 
 	ecPubKey, err := csp.GetECPublicKey(priv)
@@ -120,3 +122,78 @@ func checkForFile(file string) bool {
 	}
 	return true
 }
+
+func TestGeneratePrivateKeyED25519(t *testing.T) {
+
+	priv, signer, err := csp.GeneratePrivateKey(testDir, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate private key")
+	assert.Equal(t, true, priv.Private(), "Failed to return private key")
+	assert.NotNil(t, signer, "Should have returned a crypto.Signer")
+	pkFile := filepath.Join(testDir, hex.EncodeToString(priv.SKI())+"_sk")
+	assert.Equal(t, true, checkForFile(pkFile),
+		"Expected to find private key file")
+
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key from private key")
+	assert.IsType(t, ed25519.PublicKey{}, pubKey,
+		"Failed to return an ed25519.PublicKey")
+
+	_, err = csp.GetECPublicKey(priv)
+	assert.Error(t, err, "Expected an error for a non ECDSA key")
+
+	_, _, err = csp.GeneratePrivateKey(testDir, "rsa")
+	assert.Error(t, err, "Expected an error for an unsupported algorithm")
+
+	cleanup(testDir)
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		priv, _, err := csp.GeneratePrivateKey(testDir, keyAlg)
+		assert.NoError(t, err, "Failed to generate private key")
+
+		loaded, signer, err := csp.LoadPrivateKey(testDir)
+		assert.NoError(t, err, "Failed to load private key")
+		assert.Equal(t, priv.SKI(), loaded.SKI(), "Loaded the wrong private key")
+		assert.Equal(t, true, loaded.Private(), "Failed to return private key")
+		assert.NotNil(t, signer, "Should have returned a crypto.Signer")
+		cleanup(testDir)
+	}
+
+	os.MkdirAll(testDir, 0755)
+	_, _, err := csp.LoadPrivateKey(testDir)
+	assert.Error(t, err, "Expected an error for a keystore without keys")
+	cleanup(testDir)
+
+	_, _, err = csp.LoadPrivateKey(testDir)
+	assert.Error(t, err, "Expected an error for a missing keystore")
+}
+
+func TestEncryptedPrivateKey(t *testing.T) {
+	defer cleanup(testDir)
+	csp.SetKeystorePassword([]byte("secret"))
+	defer csp.SetKeystorePassword(nil)
+
+	priv, _, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
+	assert.NoError(t, err, "Failed to generate private key")
+	raw, err := ioutil.ReadFile(filepath.Join(testDir, hex.EncodeToString(priv.SKI())+"_sk"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "ENCRYPTED", "The private key should be encrypted")
+
+	loaded, _, err := csp.LoadPrivateKey(testDir)
+	assert.NoError(t, err, "Failed to load private key")
+	assert.Equal(t, priv.SKI(), loaded.SKI(), "Loaded the wrong private key")
+
+	csp.SetKeystorePassword([]byte("wrong"))
+	_, _, err = csp.LoadPrivateKey(testDir)
+	assert.Error(t, err, "Expected an error for a wrong password")
+	cleanup(testDir)
+
+	// TLS private keys are never encrypted
+	priv, _, err = csp.GenerateUnencryptedPrivateKey(testDir, csp.ECDSA)
+	assert.NoError(t, err, "Failed to generate private key")
+	raw, err = ioutil.ReadFile(filepath.Join(testDir, hex.EncodeToString(priv.SKI())+"_sk"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "ENCRYPTED", "The private key should not be encrypted")
+}
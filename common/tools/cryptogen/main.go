@@ -20,7 +20,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -29,7 +31,10 @@ import (
 	"bytes"
 	"io/ioutil"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/metadata"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 )
@@ -54,29 +59,38 @@ type SpecData struct {
 }
 
 type NodeTemplate struct {
-	Count    int      `yaml:"Count"`
-	Start    int      `yaml:"Start"`
-	Hostname string   `yaml:"Hostname"`
-	SANS     []string `yaml:"SANS"`
+	Count               int           `yaml:"Count"`
+	Start               int           `yaml:"Start"`
+	Hostname            string        `yaml:"Hostname"`
+	SANS                []string      `yaml:"SANS"`
+	OrganizationalUnits []string      `yaml:"OrganizationalUnits"`
+	Validity            time.Duration `yaml:"Validity"`
 }
 
 type NodeSpec struct {
-	Hostname   string   `yaml:"Hostname"`
-	CommonName string   `yaml:"CommonName"`
-	SANS       []string `yaml:"SANS"`
+	Hostname            string        `yaml:"Hostname"`
+	CommonName          string        `yaml:"CommonName"`
+	SANS                []string      `yaml:"SANS"`
+	OrganizationalUnits []string      `yaml:"OrganizationalUnits"`
+	Validity            time.Duration `yaml:"Validity"`
 }
 
 type UsersSpec struct {
-	Count int `yaml:"Count"`
+	Count               int           `yaml:"Count"`
+	OrganizationalUnits []string      `yaml:"OrganizationalUnits"`
+	Validity            time.Duration `yaml:"Validity"`
 }
 
 type OrgSpec struct {
-	Name     string       `yaml:"Name"`
-	Domain   string       `yaml:"Domain"`
-	CA       NodeSpec     `yaml:"CA"`
-	Template NodeTemplate `yaml:"Template"`
-	Specs    []NodeSpec   `yaml:"Specs"`
-	Users    UsersSpec    `yaml:"Users"`
+	Name           string       `yaml:"Name"`
+	Domain         string       `yaml:"Domain"`
+	EnableNodeOUs  bool         `yaml:"EnableNodeOUs"`
+	KeyAlgorithm   string       `yaml:"KeyAlgorithm"`
+	CA             NodeSpec     `yaml:"CA"`
+	IntermediateCA NodeSpec     `yaml:"IntermediateCA"`
+	Template       NodeTemplate `yaml:"Template"`
+	Specs          []NodeSpec   `yaml:"Specs"`
+	Users          UsersSpec    `yaml:"Users"`
 }
 
 type Config struct {
@@ -111,14 +125,48 @@ PeerOrgs:
   - Name: Org1
     Domain: org1.example.com
 
+    # ---------------------------------------------------------------------------
+    # "EnableNodeOUs"
+    # ---------------------------------------------------------------------------
+    # When true, the certificates of the users, peers, orderers and admins of
+    # this organization carry a "client", "peer", "orderer" or "admin" OU, and
+    # the MSPs are configured to tell them apart by that OU (see config.yaml)
+    # ---------------------------------------------------------------------------
+    EnableNodeOUs: false
+
+    # ---------------------------------------------------------------------------
+    # "KeyAlgorithm"
+    # ---------------------------------------------------------------------------
+    # The algorithm of the keys of the CA and of the identities of this
+    # organization: "ecdsa" (P-256, the default), "ecdsa-p384" or "ed25519".
+    # The TLS CA and the TLS material of the nodes use ECDSA on the same curve
+    # ---------------------------------------------------------------------------
+    # KeyAlgorithm: ecdsa
+
     # ---------------------------------------------------------------------------
     # "CA"
     # ---------------------------------------------------------------------------
     # Uncomment this section to enable the explicit definition of the CA for this
     # organization.  This entry is a Spec.  See "Specs" section below for details.
+    # The Validity applies to the certificates of the root CA and root TLS CA.
     # ---------------------------------------------------------------------------
     # CA:
     #    Hostname: ca # implicitly ca.org1.example.com
+    #    Validity: 87600h
+
+    # ---------------------------------------------------------------------------
+    # "IntermediateCA"
+    # ---------------------------------------------------------------------------
+    # Uncomment this section to issue the certificates of the nodes and users
+    # of this organization from intermediate CAs, themselves issued by the root
+    # CA and root TLS CA.  This entry is a Spec.  The intermediate certificates
+    # are written to the intermediatecerts and tlsintermediatecerts folders of
+    # the MSPs, and the TLS certificates of the nodes carry the intermediate
+    # TLS CA certificate after their own
+    # ---------------------------------------------------------------------------
+    # IntermediateCA:
+    #    Hostname: ica # implicitly ica.org1.example.com
+    #    Validity: 43800h
 
     # ---------------------------------------------------------------------------
     # "Specs"
@@ -141,6 +189,10 @@ PeerOrgs:
     #                 NOTE: Two implicit entries are created for you:
     #                     - {{ .CommonName }}
     #                     - {{ .Hostname }}
+    #   - OrganizationalUnits: (Optional) Extra OU values of the subject of
+    #                 the signing certificate
+    #   - Validity:   (Optional) The validity period of the signing and TLS
+    #                 certificates, e.g. 8760h. Defaults to ten years
     # ---------------------------------------------------------------------------
     # Specs:
     #   - Hostname: foo # implicitly "foo.org1.example.com"
@@ -149,6 +201,9 @@ PeerOrgs:
     #       - "bar.{{.Domain}}"
     #       - "altfoo.{{.Domain}}"
     #       - "{{.Hostname}}.org6.net"
+    #     OrganizationalUnits:
+    #       - "department1"
+    #     Validity: 8760h
     #   - Hostname: bar
     #   - Hostname: baz
 
@@ -170,14 +225,22 @@ PeerOrgs:
       # Hostname: {{.Prefix}}{{.Index}} # default
       # SANS:
       #   - "{{.Hostname}}.alt.{{.Domain}}"
+      # OrganizationalUnits:
+      #   - "department1"
+      # Validity: 8760h
 
     # ---------------------------------------------------------------------------
     # "Users"
     # ---------------------------------------------------------------------------
     # Count: The number of user accounts _in addition_ to Admin
+    # OrganizationalUnits and Validity apply to all the users, Admin included,
+    # as for Specs
     # ---------------------------------------------------------------------------
     Users:
       Count: 1
+      # OrganizationalUnits:
+      #   - "department1"
+      # Validity: 8760h
 
   # ---------------------------------------------------------------------------
   # Org2: See "Org1" for full specification
@@ -194,10 +257,24 @@ PeerOrgs:
 var (
 	app = kingpin.New("cryptogen", "Utility for generating Hyperledger Fabric key material")
 
+	passwordEnv    = app.Flag("keystore-password-env", "The environment variable holding the password encrypting the private keys").String()
+	passwordFile   = app.Flag("keystore-password-file", "The file holding the password encrypting the private keys").String()
+	passwordPrompt = app.Flag("keystore-password-prompt", "Prompt for the password encrypting the private keys").Bool()
+
 	gen        = app.Command("generate", "Generate key material")
 	outputDir  = gen.Flag("output", "The output directory in which to place artifacts").Default("crypto-config").String()
 	configFile = gen.Flag("config", "The configuration template to use").File()
 
+	ext           = app.Command("extend", "Extend existing key material with the organizations, nodes and users it lacks")
+	inputDir      = ext.Flag("input", "The directory containing the existing artifacts").Default("crypto-config").String()
+	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	reenc             = app.Command("reencrypt", "Re-encrypt the private keys of existing key material with a new password")
+	reencInputDir     = reenc.Flag("input", "The directory containing the existing artifacts, or a keystore").Default("crypto-config").String()
+	newPasswordEnv    = reenc.Flag("new-password-env", "The environment variable holding the new password, the keys being decrypted if no new password is given").String()
+	newPasswordFile   = reenc.Flag("new-password-file", "The file holding the new password").String()
+	newPasswordPrompt = reenc.Flag("new-password-prompt", "Prompt for the new password").Bool()
+
 	showtemplate = app.Command("showtemplate", "Show the default configuration template")
 
 	version = app.Command("version", "Show version information")
@@ -211,6 +288,14 @@ func main() {
 	case gen.FullCommand():
 		generate()
 
+	// "extend" command
+	case ext.FullCommand():
+		extend()
+
+	// "reencrypt" command
+	case reenc.FullCommand():
+		reencrypt()
+
 	// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -226,8 +311,12 @@ func main() {
 func getConfig() (*Config, error) {
 	var configData string
 
-	if *configFile != nil {
-		data, err := ioutil.ReadAll(*configFile)
+	file := *configFile
+	if *extConfigFile != nil {
+		file = *extConfigFile
+	}
+	if file != nil {
+		data, err := ioutil.ReadAll(file)
 		if err != nil {
 			return nil, fmt.Errorf("Error reading configuration: %s", err)
 		}
@@ -254,6 +343,13 @@ func generate() {
 		os.Exit(-1)
 	}
 
+	pwd, err := readPassword(*passwordEnv, *passwordFile, *passwordPrompt, "Keystore password", true)
+	if err != nil {
+		fmt.Printf("Error reading the keystore password: %s\n", err)
+		os.Exit(-1)
+	}
+	csp.SetKeystorePassword(pwd)
+
 	for _, orgSpec := range config.PeerOrgs {
 		err = renderOrgSpec(&orgSpec, "peer")
 		if err != nil {
@@ -273,6 +369,106 @@ func generate() {
 	}
 }
 
+func extend() {
+
+	config, err := getConfig()
+	if err != nil {
+		fmt.Printf("Error reading config: %s", err)
+		os.Exit(-1)
+	}
+
+	pwd, err := readPassword(*passwordEnv, *passwordFile, *passwordPrompt, "Keystore password", false)
+	if err != nil {
+		fmt.Printf("Error reading the keystore password: %s\n", err)
+		os.Exit(-1)
+	}
+	csp.SetKeystorePassword(pwd)
+
+	for _, orgSpec := range config.PeerOrgs {
+		err = renderOrgSpec(&orgSpec, "peer")
+		if err != nil {
+			fmt.Printf("Error processing peer configuration: %s", err)
+			os.Exit(-1)
+		}
+		extendPeerOrg(*inputDir, orgSpec)
+	}
+
+	for _, orgSpec := range config.OrdererOrgs {
+		err = renderOrgSpec(&orgSpec, "orderer")
+		if err != nil {
+			fmt.Printf("Error processing orderer configuration: %s", err)
+			os.Exit(-1)
+		}
+		extendOrdererOrg(*inputDir, orgSpec)
+	}
+}
+
+func reencrypt() {
+
+	oldPwd, err := readPassword(*passwordEnv, *passwordFile, *passwordPrompt, "Current keystore password", false)
+	if err != nil {
+		fmt.Printf("Error reading the keystore password: %s\n", err)
+		os.Exit(-1)
+	}
+	newPwd, err := readPassword(*newPasswordEnv, *newPasswordFile, *newPasswordPrompt, "New keystore password", true)
+	if err != nil {
+		fmt.Printf("Error reading the new keystore password: %s\n", err)
+		os.Exit(-1)
+	}
+
+	keystores, err := findKeystores(*reencInputDir)
+	if err != nil {
+		fmt.Printf("Error looking for keystores in %s: %s\n", *reencInputDir, err)
+		os.Exit(-1)
+	}
+	for _, keystore := range keystores {
+		count, err := sw.ReencryptFileBasedKeyStore(keystore, oldPwd, newPwd)
+		if err != nil {
+			fmt.Printf("Error re-encrypting keystore %s: %s\n", keystore, err)
+			os.Exit(-1)
+		}
+		fmt.Printf("%s: %d keys re-encrypted\n", keystore, count)
+	}
+}
+
+// readPassword reads a keystore password from the environment variable env,
+// the file file or the terminal if prompt is set, asking for it twice on the
+// terminal when confirm is set
+func readPassword(env, file string, prompt bool, label string, confirm bool) ([]byte, error) {
+	promptText := ""
+	if prompt {
+		promptText = label + ": "
+	}
+	pwd, err := factory.ReadPassword(env, file, promptText)
+	if err != nil || !prompt || !confirm || env != "" || file != "" {
+		return pwd, err
+	}
+	again, err := factory.ReadPassword("", "", "Confirm "+strings.ToLower(label[:1])+label[1:]+": ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pwd, again) {
+		return nil, fmt.Errorf("the passwords do not match")
+	}
+	return pwd, nil
+}
+
+// findKeystores returns the directories under root holding private keys
+func findKeystores(root string) ([]string, error) {
+	var keystores []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		dir := filepath.Dir(path)
+		if strings.HasSuffix(path, "_sk") && (len(keystores) == 0 || keystores[len(keystores)-1] != dir) {
+			keystores = append(keystores, dir)
+		}
+		return nil
+	})
+	return keystores, err
+}
+
 func parseTemplate(input string, data interface{}) (string, error) {
 
 	t, err := template.New("parse").Parse(input)
@@ -334,6 +530,12 @@ func renderNodeSpec(domain string, spec *NodeSpec) error {
 }
 
 func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
+	switch orgSpec.KeyAlgorithm {
+	case "", csp.ECDSA, csp.ECDSAP384, csp.ED25519:
+	default:
+		return fmt.Errorf("unsupported key algorithm %s for org %s", orgSpec.KeyAlgorithm, orgSpec.Name)
+	}
+
 	// First process all of our templated nodes
 	for i := 0; i < orgSpec.Template.Count; i++ {
 		data := HostnameData{
@@ -348,8 +550,10 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		}
 
 		spec := NodeSpec{
-			Hostname: hostname,
-			SANS:     orgSpec.Template.SANS,
+			Hostname:            hostname,
+			SANS:                orgSpec.Template.SANS,
+			OrganizationalUnits: orgSpec.Template.OrganizationalUnits,
+			Validity:            orgSpec.Template.Validity,
 		}
 		orgSpec.Specs = append(orgSpec.Specs, spec)
 	}
@@ -373,6 +577,14 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		return err
 	}
 
+	// and the intermediate CA node-spec, if any
+	if len(orgSpec.IntermediateCA.Hostname) != 0 {
+		err = renderNodeSpec(orgSpec.Domain, &orgSpec.IntermediateCA)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -383,49 +595,40 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	fmt.Println(orgName)
 	// generate CAs
 	orgDir := filepath.Join(baseDir, "peerOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
-	tlsCADir := filepath.Join(orgDir, "tlsca")
 	mspDir := filepath.Join(orgDir, "msp")
 	peersDir := filepath.Join(orgDir, "peers")
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
-	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName)
-	if err != nil {
-		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
-	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName)
-	if err != nil {
-		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
+	signCA, tlsCA := generateCAs(orgDir, orgSpec)
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
+	err := msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA)
+	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
 
 	// TODO: add ability to specify usernames
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:          fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			OrganizationalUnits: orgSpec.Users.OrganizationalUnits,
+			Validity:            orgSpec.Users.Validity,
 		}
 
 		users = append(users, user)
 	}
+	generateNodes(usersDir, users, signCA, tlsCA, msp.CLIENT, orgSpec.EnableNodeOUs)
+
 	// add an admin user
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:          fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		OrganizationalUnits: orgSpec.Users.OrganizationalUnits,
+		Validity:            orgSpec.Users.Validity,
 	}
-
-	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA)
+	generateNodes(usersDir, []NodeSpec{adminUser}, signCA, tlsCA, msp.ADMIN, orgSpec.EnableNodeOUs)
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
@@ -447,6 +650,172 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	}
 }
 
+func extendPeerOrg(baseDir string, orgSpec OrgSpec) {
+
+	orgName := orgSpec.Domain
+	orgDir := filepath.Join(baseDir, "peerOrganizations", orgName)
+	if _, err := os.Stat(orgDir); os.IsNotExist(err) {
+		generatePeerOrg(baseDir, orgSpec)
+		return
+	}
+
+	fmt.Println(orgName)
+	peersDir := filepath.Join(orgDir, "peers")
+	usersDir := filepath.Join(orgDir, "users")
+	signCA, tlsCA := loadCAs(orgDir, orgName)
+
+	peers := newNodes(peersDir, orgSpec.Specs)
+	generateNodes(peersDir, peers, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
+
+	users := []NodeSpec{}
+	for j := 1; j <= orgSpec.Users.Count; j++ {
+		user := NodeSpec{
+			CommonName:          fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			OrganizationalUnits: orgSpec.Users.OrganizationalUnits,
+			Validity:            orgSpec.Users.Validity,
+		}
+
+		users = append(users, user)
+	}
+	generateNodes(usersDir, newNodes(usersDir, users), signCA, tlsCA, msp.CLIENT, orgSpec.EnableNodeOUs)
+
+	// copy the admin cert to each of the new peer's MSP admincerts
+	adminUserName := fmt.Sprintf("%s@%s", adminBaseName, orgName)
+	for _, spec := range peers {
+		err := copyAdminCert(usersDir,
+			filepath.Join(peersDir, spec.CommonName, "msp", "admincerts"), adminUserName)
+		if err != nil {
+			fmt.Printf("Error copying admin cert for org %s peer %s:\n%v\n",
+				orgName, spec.CommonName, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func extendOrdererOrg(baseDir string, orgSpec OrgSpec) {
+
+	orgName := orgSpec.Domain
+	orgDir := filepath.Join(baseDir, "ordererOrganizations", orgName)
+	if _, err := os.Stat(orgDir); os.IsNotExist(err) {
+		generateOrdererOrg(baseDir, orgSpec)
+		return
+	}
+
+	orderersDir := filepath.Join(orgDir, "orderers")
+	usersDir := filepath.Join(orgDir, "users")
+	signCA, tlsCA := loadCAs(orgDir, orgName)
+
+	orderers := newNodes(orderersDir, orgSpec.Specs)
+	generateNodes(orderersDir, orderers, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
+
+	// copy the admin cert to each of the new orderer's MSP admincerts
+	adminUserName := fmt.Sprintf("%s@%s", adminBaseName, orgName)
+	for _, spec := range orderers {
+		err := copyAdminCert(usersDir,
+			filepath.Join(orderersDir, spec.CommonName, "msp", "admincerts"), adminUserName)
+		if err != nil {
+			fmt.Printf("Error copying admin cert for org %s orderer %s:\n%v\n",
+				orgName, spec.CommonName, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generateCAs generates the signing and TLS CAs of an organization, along
+// with their intermediate CAs if requested, and returns the CAs issuing the
+// certificates of the nodes and users
+func generateCAs(orgDir string, orgSpec OrgSpec) (*ca.CA, *ca.CA) {
+	orgName := orgSpec.Domain
+	tlsKeyAlg := tlsKeyAlgorithm(orgSpec.KeyAlgorithm)
+
+	// generate signing CA
+	signCA, err := ca.NewCA(filepath.Join(orgDir, "ca"), orgName, orgSpec.CA.CommonName,
+		orgSpec.KeyAlgorithm, orgSpec.CA.Validity)
+	if err != nil {
+		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	// generate TLS CA
+	tlsCA, err := ca.NewCA(filepath.Join(orgDir, "tlsca"), orgName, "tls"+orgSpec.CA.CommonName,
+		tlsKeyAlg, orgSpec.CA.Validity)
+	if err != nil {
+		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	if len(orgSpec.IntermediateCA.Hostname) == 0 {
+		return signCA, tlsCA
+	}
+
+	// generate intermediate signing CA
+	signCA, err = signCA.NewIntermediateCA(filepath.Join(orgDir, "ica"), orgName,
+		orgSpec.IntermediateCA.CommonName, orgSpec.KeyAlgorithm, orgSpec.IntermediateCA.Validity)
+	if err != nil {
+		fmt.Printf("Error generating intermediate signCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	// generate intermediate TLS CA
+	tlsCA, err = tlsCA.NewIntermediateCA(filepath.Join(orgDir, "tlsica"), orgName,
+		"tls"+orgSpec.IntermediateCA.CommonName, tlsKeyAlg, orgSpec.IntermediateCA.Validity)
+	if err != nil {
+		fmt.Printf("Error generating intermediate tlsCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	return signCA, tlsCA
+}
+
+// tlsKeyAlgorithm returns the algorithm of the TLS keys of an organization
+// whose identities use keyAlg: TLS keys are always ECDSA
+func tlsKeyAlgorithm(keyAlg string) string {
+	if keyAlg == csp.ECDSAP384 {
+		return csp.ECDSAP384
+	}
+	return csp.ECDSA
+}
+
+// loadCAs loads the signing and TLS CAs of an existing organization, and
+// returns the CAs issuing the certificates of its nodes and users
+func loadCAs(orgDir, orgName string) (*ca.CA, *ca.CA) {
+	signCA, err := loadCA(filepath.Join(orgDir, "ca"), filepath.Join(orgDir, "ica"))
+	if err != nil {
+		fmt.Printf("Error loading signCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	tlsCA, err := loadCA(filepath.Join(orgDir, "tlsca"), filepath.Join(orgDir, "tlsica"))
+	if err != nil {
+		fmt.Printf("Error loading tlsCA for org %s:\n%v\n", orgName, err)
+		os.Exit(1)
+	}
+	return signCA, tlsCA
+}
+
+// loadCA loads the root CA in caDir, and the intermediate CA in icaDir if any
+func loadCA(caDir, icaDir string) (*ca.CA, error) {
+	rootCA, err := ca.LoadCA(caDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(icaDir); os.IsNotExist(err) {
+		return rootCA, nil
+	}
+	interCA, err := ca.LoadCA(icaDir)
+	if err != nil {
+		return nil, err
+	}
+	interCA.Parent = rootCA
+	return interCA, nil
+}
+
+// newNodes returns the nodes which have no material in baseDir yet
+func newNodes(baseDir string, nodes []NodeSpec) []NodeSpec {
+	res := []NodeSpec{}
+	for _, node := range nodes {
+		if _, err := os.Stat(filepath.Join(baseDir, node.CommonName)); os.IsNotExist(err) {
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
 func copyAdminCert(usersDir, adminCertsDir, adminUserName string) error {
 	// delete the contents of admincerts
 	err := os.RemoveAll(adminCertsDir)
@@ -468,11 +837,12 @@ func copyAdminCert(usersDir, adminCertsDir, adminUserName string) error {
 
 }
 
-func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA) {
+func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA, nodeType int, nodeOUs bool) {
 
 	for _, node := range nodes {
 		nodeDir := filepath.Join(baseDir, node.CommonName)
-		err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA)
+		err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, node.OrganizationalUnits,
+			node.Validity, signCA, tlsCA, nodeType, nodeOUs)
 		if err != nil {
 			fmt.Printf("Error generating local MSP for %s:\n%v\n", node, err)
 			os.Exit(1)
@@ -486,42 +856,28 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 
 	// generate CAs
 	orgDir := filepath.Join(baseDir, "ordererOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
-	tlsCADir := filepath.Join(orgDir, "tlsca")
 	mspDir := filepath.Join(orgDir, "msp")
 	orderersDir := filepath.Join(orgDir, "orderers")
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
-	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName)
-	if err != nil {
-		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
-	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName)
-	if err != nil {
-		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
-		os.Exit(1)
-	}
+	signCA, tlsCA := generateCAs(orgDir, orgSpec)
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
+	err := msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA)
+	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:          fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		OrganizationalUnits: orgSpec.Users.OrganizationalUnits,
+		Validity:            orgSpec.Users.Validity,
 	}
 
 	// generate an admin for the orderer org
-	users := []NodeSpec{}
-	// add an admin user
-	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA)
+	generateNodes(usersDir, []NodeSpec{adminUser}, signCA, tlsCA, msp.ADMIN, orgSpec.EnableNodeOUs)
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
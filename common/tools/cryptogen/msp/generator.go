@@ -18,8 +18,10 @@ package msp
 import (
 	"crypto/x509"
 	"encoding/pem"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"encoding/hex"
 
@@ -27,16 +29,46 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
+	fabricmsp "github.com/hyperledger/fabric/msp"
+	"gopkg.in/yaml.v2"
 )
 
-func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
-	tlsCA *ca.CA) error {
+// Types of the identities of a local MSP
+const (
+	CLIENT = iota
+	ORDERER
+	PEER
+	ADMIN
+)
+
+// OUs telling apart the types of identities when node OUs are enabled
+const (
+	CLIENTOU  = "client"
+	ORDEREROU = "orderer"
+	PEEROU    = "peer"
+	ADMINOU   = "admin"
+)
+
+var nodeOUMap = map[int]string{
+	CLIENT:  CLIENTOU,
+	ORDERER: ORDEREROU,
+	PEER:    PEEROU,
+	ADMIN:   ADMINOU,
+}
+
+// GenerateLocalMSP generates the local MSP and TLS material of an identity in
+// baseDir. The signing certificate carries the given extra OUs, and both the
+// signing and TLS certificates are valid for the given period (zero stands
+// for the default). Intermediate signing and TLS CAs end up in the
+// intermediatecerts and tlsintermediatecerts folders.
+func GenerateLocalMSP(baseDir, name string, sans, ous []string, validity time.Duration,
+	signCA *ca.CA, tlsCA *ca.CA, nodeType int, nodeOUs bool) error {
 
 	// create folder structure
 	mspDir := filepath.Join(baseDir, "msp")
 	tlsDir := filepath.Join(baseDir, "tls")
 
-	err := createFolderStructure(mspDir, true)
+	err := createFolderStructure(mspDir, true, signCA, tlsCA)
 	if err != nil {
 		return err
 	}
@@ -53,32 +85,33 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, _, err := csp.GeneratePrivateKey(keystore)
+	priv, _, err := csp.GeneratePrivateKey(keystore, signCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
 
 	// get public key
-	ecPubKey, err := csp.GetECPublicKey(priv)
+	pubKey, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
 	// generate X509 certificate using signing CA
+	var certOUs []string
+	if nodeOUs {
+		certOUs = []string{nodeOUMap[nodeType]}
+	}
+	certOUs = append(certOUs, ous...)
 	cert, err := signCA.SignCertificate(filepath.Join(mspDir, "signcerts"),
-		name, []string{}, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		name, certOUs, []string{}, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, validity)
 	if err != nil {
 		return err
 	}
 
 	// write artifacts to MSP folders
 
-	// the signing CA certificate goes into cacerts
-	err = x509Export(filepath.Join(mspDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
-	if err != nil {
-		return err
-	}
-	// the TLS CA certificate goes into tlscacerts
-	err = x509Export(filepath.Join(mspDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+	// the root and intermediate CA certificates go into
+	// cacerts, intermediatecerts, tlscacerts and tlsintermediatecerts
+	err = exportCAs(mspDir, signCA, tlsCA)
 	if err != nil {
 		return err
 	}
@@ -95,35 +128,48 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 		return err
 	}
 
+	// the node OUs configuration goes into config.yaml
+	if nodeOUs {
+		err = exportConfig(mspDir, issuerFile(signCA))
+		if err != nil {
+			return err
+		}
+	}
+
 	/*
 		Generate the TLS artifacts in the TLS folder
 	*/
 
 	// generate private key
-	tlsPrivKey, _, err := csp.GeneratePrivateKey(tlsDir)
+	tlsPrivKey, _, err := csp.GenerateUnencryptedPrivateKey(tlsDir, tlsCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
 	// get public key
-	tlsPubKey, err := csp.GetECPublicKey(tlsPrivKey)
+	tlsPubKey, err := csp.GetPublicKey(tlsPrivKey)
 	if err != nil {
 		return err
 	}
 	// generate X509 certificate using TLS CA
-	_, err = tlsCA.SignCertificate(filepath.Join(tlsDir),
-		name, sans, tlsPubKey, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
-		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth})
+	tlsCert, err := tlsCA.SignCertificate(filepath.Join(tlsDir),
+		name, nil, sans, tlsPubKey, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, validity)
 	if err != nil {
 		return err
 	}
-	err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.SignCert)
+	err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.Root().SignCert)
 	if err != nil {
 		return err
 	}
 
-	// rename the generated TLS X509 cert
-	err = os.Rename(filepath.Join(tlsDir, x509Filename(name)),
-		filepath.Join(tlsDir, "server.crt"))
+	// the TLS certificate is followed by the intermediate
+	// TLS CA certificates, so that the whole chain is presented
+	// to the peers trusting the root TLS CA
+	err = x509Export(filepath.Join(tlsDir, "server.crt"), append([]*x509.Certificate{tlsCert}, tlsCA.Intermediates()...)...)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(tlsDir, x509Filename(name)))
 	if err != nil {
 		return err
 	}
@@ -136,18 +182,22 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	return nil
 }
 
-func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA) error {
+func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs bool) error {
 
 	// create folder structure and write artifacts to proper locations
-	err := createFolderStructure(baseDir, false)
+	err := createFolderStructure(baseDir, false, signCA, tlsCA)
 	if err == nil {
-		// the signing CA certificate goes into cacerts
-		err = x509Export(filepath.Join(baseDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
+		// the root and intermediate CA certificates go into
+		// cacerts, intermediatecerts, tlscacerts and tlsintermediatecerts
+		err = exportCAs(baseDir, signCA, tlsCA)
 		if err != nil {
 			return err
 		}
-		// the TLS CA certificate goes into tlscacerts
-		err = x509Export(filepath.Join(baseDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+	}
+
+	// the node OUs configuration goes into config.yaml
+	if nodeOUs {
+		err = exportConfig(baseDir, issuerFile(signCA))
 		if err != nil {
 			return err
 		}
@@ -165,8 +215,12 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA) error {
 	if err != nil {
 		return err
 	}
+	var ous []string
+	if nodeOUs {
+		ous = []string{ADMINOU}
+	}
 	_, err = signCA.SignCertificate(filepath.Join(baseDir, "admincerts"), signCA.Name,
-		[]string{""}, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		ous, []string{""}, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{}, 0)
 	if err != nil {
 		return err
 	}
@@ -174,7 +228,7 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA) error {
 	return nil
 }
 
-func createFolderStructure(rootDir string, local bool) error {
+func createFolderStructure(rootDir string, local bool, signCA, tlsCA *ca.CA) error {
 
 	var folders []string
 	// create admincerts, cacerts, keystore and signcerts folders
@@ -183,6 +237,13 @@ func createFolderStructure(rootDir string, local bool) error {
 		filepath.Join(rootDir, "cacerts"),
 		filepath.Join(rootDir, "tlscacerts"),
 	}
+	// and the intermediate CA folders when needed
+	if signCA.Parent != nil {
+		folders = append(folders, filepath.Join(rootDir, "intermediatecerts"))
+	}
+	if tlsCA.Parent != nil {
+		folders = append(folders, filepath.Join(rootDir, "tlsintermediatecerts"))
+	}
 	if local {
 		folders = append(folders, filepath.Join(rootDir, "keystore"),
 			filepath.Join(rootDir, "signcerts"))
@@ -198,12 +259,50 @@ func createFolderStructure(rootDir string, local bool) error {
 	return nil
 }
 
+// exportCAs writes the root and intermediate CA certificates of the
+// signing and TLS CAs to the folders of the MSP in mspDir
+func exportCAs(mspDir string, signCA, tlsCA *ca.CA) error {
+	for _, c := range []struct {
+		ca                  *ca.CA
+		rootDir, interimDir string
+	}{
+		{signCA, "cacerts", "intermediatecerts"},
+		{tlsCA, "tlscacerts", "tlsintermediatecerts"},
+	} {
+		root := c.ca.Root()
+		err := x509Export(filepath.Join(mspDir, c.rootDir, x509Filename(root.Name)), root.SignCert)
+		if err != nil {
+			return err
+		}
+		for issuer := c.ca; issuer.Parent != nil; issuer = issuer.Parent {
+			err = x509Export(filepath.Join(mspDir, c.interimDir, x509Filename(issuer.Name)), issuer.SignCert)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// issuerFile returns the path, relative to the MSP folder, of the
+// certificate of the CA issuing the identities of the MSP
+func issuerFile(signCA *ca.CA) string {
+	if signCA.Parent != nil {
+		return filepath.Join("intermediatecerts", x509Filename(signCA.Name))
+	}
+	return filepath.Join("cacerts", x509Filename(signCA.Name))
+}
+
 func x509Filename(name string) string {
 	return name + "-cert.pem"
 }
 
-func x509Export(path string, cert *x509.Certificate) error {
-	return pemExport(path, "CERTIFICATE", cert.Raw)
+func x509Export(path string, certs ...*x509.Certificate) error {
+	blocks := make([][]byte, len(certs))
+	for i, cert := range certs {
+		blocks[i] = cert.Raw
+	}
+	return pemExport(path, "CERTIFICATE", blocks...)
 }
 
 func keyExport(keystore, output string, key bccsp.Key) error {
@@ -212,7 +311,7 @@ func keyExport(keystore, output string, key bccsp.Key) error {
 	return os.Rename(filepath.Join(keystore, id+"_sk"), output)
 }
 
-func pemExport(path, pemType string, bytes []byte) error {
+func pemExport(path, pemType string, blocks ...[]byte) error {
 	//write pem out to file
 	file, err := os.Create(path)
 	if err != nil {
@@ -220,5 +319,38 @@ func pemExport(path, pemType string, bytes []byte) error {
 	}
 	defer file.Close()
 
-	return pem.Encode(file, &pem.Block{Type: pemType, Bytes: bytes})
+	for _, bytes := range blocks {
+		err = pem.Encode(file, &pem.Block{Type: pemType, Bytes: bytes})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportConfig writes the config.yaml of the MSP in mspDir, enabling the node
+// OUs under the CA whose certificate is at caFile, relative to mspDir
+func exportConfig(mspDir, caFile string) error {
+	ouID := func(ou string) *fabricmsp.OrganizationalUnitIdentifiersConfiguration {
+		return &fabricmsp.OrganizationalUnitIdentifiersConfiguration{
+			Certificate:                  caFile,
+			OrganizationalUnitIdentifier: ou,
+		}
+	}
+	config := &fabricmsp.Configuration{
+		NodeOUs: &fabricmsp.NodeOUs{
+			Enable:              true,
+			ClientOUIdentifier:  ouID(CLIENTOU),
+			PeerOUIdentifier:    ouID(PEEROU),
+			OrdererOUIdentifier: ouID(ORDEREROU),
+			AdminOUIdentifier:   ouID(ADMINOU),
+		},
+	}
+
+	configBytes, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(mspDir, "config.yaml"), configBytes, 0644)
 }
@@ -16,13 +16,22 @@ limitations under the License.
 package msp_test
 
 import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,7 +47,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 	cleanup(testDir)
 
-	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{})
+	err := msp.GenerateLocalMSP(testDir, testName, nil, nil, 0, &ca.CA{}, &ca.CA{}, msp.PEER, false)
 	assert.Error(t, err, "Empty CA should have failed")
 
 	caDir := filepath.Join(testDir, "ca")
@@ -46,13 +55,13 @@ func TestGenerateLocalMSP(t *testing.T) {
 	mspDir := filepath.Join(testDir, "msp")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 	// generate local MSP
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, nil, 0, signCA, tlsCA, msp.PEER, false)
 	assert.NoError(t, err, "Failed to generate local MSP")
 
 	// check to see that the right files were generated/saved
@@ -78,29 +87,64 @@ func TestGenerateLocalMSP(t *testing.T) {
 	assert.NoError(t, err, "Error setting up local MSP")
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, nil, 0, signCA, tlsCA, msp.PEER, false)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, nil, 0, signCA, tlsCA, msp.PEER, false)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
 
 }
 
+func TestGenerateLocalMSPED25519(t *testing.T) {
+
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+
+	// generate an Ed25519 signing CA and an ECDSA TLS CA
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, csp.ED25519, 0)
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating CA")
+	err = msp.GenerateLocalMSP(testDir, testName, nil, nil, 0, signCA, tlsCA, msp.PEER, false)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	// the Ed25519 signing identity must be usable
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err, "Error getting the signing identity")
+	assert.NoError(t, id.Validate(), "Signing identity should be valid")
+	msg := []byte("hello world")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err, "Error signing")
+	assert.NoError(t, id.Verify(msg, sig), "Signature should verify")
+	assert.Error(t, id.Verify([]byte("other"), sig), "Signature should not verify")
+}
+
 func TestGenerateVerifyingMSP(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, csp.ECDSA, 0)
 	assert.NoError(t, err, "Error generating CA")
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false)
 	assert.NoError(t, err, "Failed to generate verifying MSP")
 
 	// check to see that the right files were generated/saved
@@ -123,15 +167,169 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	assert.NoError(t, err, "Error setting up verifying MSP")
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
 }
 
+func TestGenerateMSPWithNodeOUs(t *testing.T) {
+
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+	// generate signing CA
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating CA")
+	// generate TLS CA
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating CA")
+
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	assert.NoError(t, err, "Failed to generate verifying MSP")
+	assert.Equal(t, true, checkForFile(filepath.Join(mspDir, "config.yaml")),
+		"Expected to find the MSP configuration file")
+
+	testMSPConfig, err := fabricmsp.GetVerifyingMspConfig(mspDir, testName)
+	assert.NoError(t, err, "Error parsing verifying MSP config")
+	testMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up verifying MSP")
+
+	roles := map[int]mspprotos.MSPRole_MSPRoleType{
+		msp.CLIENT:  mspprotos.MSPRole_CLIENT,
+		msp.PEER:    mspprotos.MSPRole_PEER,
+		msp.ORDERER: mspprotos.MSPRole_ORDERER,
+		msp.ADMIN:   mspprotos.MSPRole_ADMIN,
+	}
+	for nodeType, role := range roles {
+		nodeDir := filepath.Join(testDir, "nodes", fmt.Sprint(nodeType))
+		err = msp.GenerateLocalMSP(nodeDir, testName, nil, nil, 0, signCA, tlsCA, nodeType, true)
+		assert.NoError(t, err, "Failed to generate local MSP")
+
+		// the local MSP must be set up with the node OUs as well
+		localMSPConfig, err := fabricmsp.GetVerifyingMspConfig(filepath.Join(nodeDir, "msp"), testName)
+		assert.NoError(t, err, "Error parsing local MSP config")
+		localMSP, err := fabricmsp.NewBccspMsp()
+		assert.NoError(t, err, "Error creating new BCCSP MSP")
+		assert.NoError(t, localMSP.Setup(localMSPConfig), "Error setting up local MSP")
+
+		certPEM, err := ioutil.ReadFile(filepath.Join(nodeDir, "msp", "signcerts", testName+"-cert.pem"))
+		assert.NoError(t, err, "Error reading the signing certificate")
+		serialized, err := proto.Marshal(&mspprotos.SerializedIdentity{Mspid: testName, IdBytes: certPEM})
+		assert.NoError(t, err)
+		id, err := testMSP.DeserializeIdentity(serialized)
+		assert.NoError(t, err, "Error deserializing the identity")
+		assert.NoError(t, id.Validate(), "Identity of node type %d should be valid", nodeType)
+
+		// the identity must satisfy its own role and no other node role
+		for _, otherRole := range roles {
+			principal := &mspprotos.MSPPrincipal{
+				PrincipalClassification: mspprotos.MSPPrincipal_ROLE,
+				Principal:               utils.MarshalOrPanic(&mspprotos.MSPRole{MspIdentifier: testName, Role: otherRole}),
+			}
+			err = id.SatisfiesPrincipal(principal)
+			if otherRole == role {
+				assert.NoError(t, err, "Identity of node type %d should satisfy role %s", nodeType, otherRole)
+			} else {
+				assert.Error(t, err, "Identity of node type %d should not satisfy role %s", nodeType, otherRole)
+			}
+		}
+	}
+
+	// an identity without any node OU is not valid
+	nodeDir := filepath.Join(testDir, "nodes", "noou")
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, nil, 0, signCA, tlsCA, msp.PEER, false)
+	assert.NoError(t, err, "Failed to generate local MSP")
+	certPEM, err := ioutil.ReadFile(filepath.Join(nodeDir, "msp", "signcerts", testName+"-cert.pem"))
+	assert.NoError(t, err, "Error reading the signing certificate")
+	serialized, err := proto.Marshal(&mspprotos.SerializedIdentity{Mspid: testName, IdBytes: certPEM})
+	assert.NoError(t, err)
+	id, err := testMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err, "Error deserializing the identity")
+	assert.Error(t, id.Validate(), "Identity without node OU should be invalid")
+}
+
+func TestGenerateMSPWithIntermediateCA(t *testing.T) {
+
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	// generate the root and intermediate CAs
+	signRoot, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating CA")
+	tlsRoot, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, "tls"+testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating CA")
+	signCA, err := signRoot.NewIntermediateCA(filepath.Join(testDir, "ica"), testCAOrg, "i"+testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	tlsCA, err := tlsRoot.NewIntermediateCA(filepath.Join(testDir, "tlsica"), testCAOrg, "tlsi"+testCAName, csp.ECDSA, 0)
+	assert.NoError(t, err, "Error generating intermediate CA")
+
+	mspDir := filepath.Join(testDir, "msp")
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	assert.NoError(t, err, "Failed to generate verifying MSP")
+	for _, file := range []string{
+		filepath.Join("cacerts", testCAName+"-cert.pem"),
+		filepath.Join("intermediatecerts", "i"+testCAName+"-cert.pem"),
+		filepath.Join("tlscacerts", "tls"+testCAName+"-cert.pem"),
+		filepath.Join("tlsintermediatecerts", "tlsi"+testCAName+"-cert.pem"),
+	} {
+		assert.True(t, checkForFile(filepath.Join(mspDir, file)), "Expected to find file "+file)
+	}
+	testMSPConfig, err := fabricmsp.GetVerifyingMspConfig(mspDir, testName)
+	assert.NoError(t, err, "Error parsing verifying MSP config")
+	testMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	assert.NoError(t, testMSP.Setup(testMSPConfig), "Error setting up verifying MSP")
+
+	nodeDir := filepath.Join(testDir, "node")
+	err = msp.GenerateLocalMSP(nodeDir, testName, []string{"alt.example.com"}, []string{"department1"},
+		24*time.Hour, signCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+	assert.True(t, checkForFile(filepath.Join(nodeDir, "msp", "intermediatecerts", "i"+testCAName+"-cert.pem")))
+	localMSPConfig, err := fabricmsp.GetVerifyingMspConfig(filepath.Join(nodeDir, "msp"), testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	localMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	assert.NoError(t, localMSP.Setup(localMSPConfig), "Error setting up local MSP")
+
+	// the signing certificate carries the node OU followed by the extra OUs
+	certPEM, err := ioutil.ReadFile(filepath.Join(nodeDir, "msp", "signcerts", testName+"-cert.pem"))
+	assert.NoError(t, err, "Error reading the signing certificate")
+	serialized, err := proto.Marshal(&mspprotos.SerializedIdentity{Mspid: testName, IdBytes: certPEM})
+	assert.NoError(t, err)
+	id, err := testMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err, "Error deserializing the identity")
+	assert.NoError(t, id.Validate(), "Identity issued by the intermediate CA should be valid")
+	var ous []string
+	for _, ou := range id.GetOrganizationalUnits() {
+		ous = append(ous, ou.OrganizationalUnitIdentifier)
+	}
+	assert.Equal(t, []string{msp.PEEROU, "department1"}, ous)
+
+	// the TLS certificate is followed by the intermediate TLS CA certificate
+	// while ca.crt is the root TLS CA certificate
+	serverCrt, err := ioutil.ReadFile(filepath.Join(nodeDir, "tls", "server.crt"))
+	assert.NoError(t, err, "Error reading the TLS certificate")
+	var blocks []*pem.Block
+	for block, rest := pem.Decode(serverCrt); block != nil; block, rest = pem.Decode(rest) {
+		blocks = append(blocks, block)
+	}
+	assert.Len(t, blocks, 2)
+	assert.True(t, bytes.Equal(tlsCA.SignCert.Raw, blocks[1].Bytes))
+	caCrt, err := ioutil.ReadFile(filepath.Join(nodeDir, "tls", "ca.crt"))
+	assert.NoError(t, err, "Error reading the TLS CA certificate")
+	block, _ := pem.Decode(caCrt)
+	assert.True(t, bytes.Equal(tlsRoot.SignCert.Raw, block.Bytes))
+}
+
 func cleanup(dir string) {
 	os.RemoveAll(dir)
 }
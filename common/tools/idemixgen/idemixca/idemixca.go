@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+)
+
+// NewIssuerKey creates a new issuer key pair whose credentials
+// certify the attributes expected by the idemix MSP
+func NewIssuerKey() (*idemix.IssuerKey, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+
+	return idemix.NewIssuerKey(msp.IdemixAttributeNames, rng)
+}
+
+// GenerateSignerConfig creates a new signer config.
+// It generates a fresh user secret and issues a credential
+// with the given OU, role and enrollment ID using the issuer key.
+// The returned bytes are the serialized IdemixMSPSignerConfig
+func GenerateSignerConfig(ou string, role m.MSPRole_MSPRoleType, enrollmentID string, discloseOU, discloseRole bool, key *idemix.IssuerKey) ([]byte, error) {
+	if ou == "" {
+		return nil, fmt.Errorf("the OU attribute value is empty")
+	}
+	if enrollmentID == "" {
+		return nil, fmt.Errorf("the enrollment id value is empty")
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+
+	// the issuance protocol is run locally,
+	// playing both the user and the issuer
+	sk := idemix.RandModOrder(rng)
+	nonce := idemix.BigToBytes(idemix.RandModOrder(rng))
+	req, err := idemix.NewCredRequest(sk, nonce, key.Ipk, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := idemix.NewCredential(key, req, msp.IdemixAttributeValues(ou, int32(role), enrollmentID), rng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue the credential: %s", err)
+	}
+
+	credBytes, err := proto.Marshal(cred)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &m.IdemixMSPSignerConfig{
+		Cred:                         credBytes,
+		Sk:                           idemix.BigToBytes(sk),
+		OrganizationalUnitIdentifier: ou,
+		Role:                         int32(role),
+		EnrollmentId:                 enrollmentID,
+		DiscloseOu:                   discloseOU,
+		DiscloseRole:                 discloseRole,
+	}
+
+	return proto.Marshal(signer)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// idemixgen is a command line tool that generates the CA's keys and
+// generates MSP configs for signing and for verification
+// This tool can be used to setup the peers and CA to support
+// the Identity Mixer MSP
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/idemixgen/idemixca"
+	"github.com/hyperledger/fabric/common/tools/idemixgen/metadata"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	idemixDirIssuer             = "ca"
+	idemixConfigIssuerSecretKey = "IssuerSecretKey"
+)
+
+// command line flags
+var (
+	app = kingpin.New("idemixgen", "Utility for generating key material to be used with the Identity Mixer MSP in Hyperledger Fabric")
+
+	outputDir = app.Flag("output", "The output directory in which to place artifacts").Default("idemix-config").String()
+
+	genIssuerKey = app.Command("ca-keygen", "Generate CA key material")
+
+	genSignerConfig = app.Command("signerconfig", "Generate a default signer for this Idemix MSP")
+	genCredOU       = genSignerConfig.Flag("org-unit", "The Organizational Unit of the default signer").Short('u').Required().String()
+	genCredRole     = genSignerConfig.Flag("role", "The role of the default signer (member, admin, client, peer or orderer)").Short('r').Default("member").String()
+	genCredEID      = genSignerConfig.Flag("enrollment-id", "The enrollment id of the default signer").Short('e').Required().String()
+	genDiscloseOU   = genSignerConfig.Flag("disclose-ou", "Disclose the Organizational Unit in signatures of the default signer").Default("true").Bool()
+	genDiscloseRole = genSignerConfig.Flag("disclose-role", "Disclose the role in signatures of the default signer").Default("true").Bool()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	case genIssuerKey.FullCommand():
+		isk, err := idemixca.NewIssuerKey()
+		handleError(err)
+
+		ipk, err := proto.Marshal(isk.Ipk)
+		handleError(err)
+
+		// Prevent overwriting the existing key
+		path := filepath.Join(*outputDir, idemixDirIssuer)
+		checkDirectoryNotExists(path, fmt.Sprintf("Directory %s already exists", path))
+
+		path = filepath.Join(*outputDir, msp.IdemixConfigDirMsp)
+		checkDirectoryNotExists(path, fmt.Sprintf("Directory %s already exists", path))
+
+		// write private and public keys to the file
+		handleError(os.MkdirAll(filepath.Join(*outputDir, idemixDirIssuer), 0770))
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirMsp), 0770))
+		writeFile(filepath.Join(*outputDir, idemixDirIssuer, idemixConfigIssuerSecretKey), isk.Isk, 0640)
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipk, 0644)
+
+	case genSignerConfig.FullCommand():
+		role, ok := m.MSPRole_MSPRoleType_value[strings.ToUpper(*genCredRole)]
+		if !ok {
+			handleError(fmt.Errorf("unknown role %s", *genCredRole))
+		}
+
+		config, err := idemixca.GenerateSignerConfig(*genCredOU, m.MSPRole_MSPRoleType(role), *genCredEID, *genDiscloseOU, *genDiscloseRole, readIssuerKey())
+		handleError(err)
+
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser)
+		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
+
+		// Write config to file
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config, 0640)
+
+	case version.FullCommand():
+		printVersion()
+
+	}
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
+
+// writeFile writes bytes to a file and panics in case of an error
+func writeFile(path string, contents []byte, perm os.FileMode) {
+	handleError(ioutil.WriteFile(path, contents, perm))
+}
+
+// readIssuerKey reads the issuer key from the current directory
+func readIssuerKey() *idemix.IssuerKey {
+	path := filepath.Join(*outputDir, idemixDirIssuer, idemixConfigIssuerSecretKey)
+	isk, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(fmt.Errorf("failed to open issuer secret key file: %s", path))
+	}
+	path = filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey)
+	ipkBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(fmt.Errorf("failed to open issuer public key file: %s", path))
+	}
+	ipk := &idemix.IssuerPublicKey{}
+	handleError(proto.Unmarshal(ipkBytes, ipk))
+	return &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+}
+
+// checkDirectoryNotExists checks whether a directory with the given path already exists and exits if this is the case
+func checkDirectoryNotExists(path string, errorMessage string) {
+	_, err := os.Stat(path)
+	if err == nil {
+		handleError(errors.New(errorMessage))
+	}
+}
+
+func handleError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// remotesigner is a reference remote signing service for the REMOTE
+// BCCSP provider. It keeps the private keys in a file keystore and
+// only accepts mutually authenticated TLS connections.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	pb "github.com/hyperledger/fabric/protos/bccsp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("remotesigner", "Reference remote signing service for the REMOTE BCCSP provider of Hyperledger Fabric")
+
+	listenAddress = app.Flag("listen", "The address the service listens on").Default("0.0.0.0:7060").String()
+	keyStore      = app.Flag("keystore", "The directory of the file keystore holding the private keys").Required().String()
+	securityLevel = app.Flag("security", "The security level of the keys").Default("256").Int()
+	hashFamily    = app.Flag("hash", "The hash family of the keys").Default("SHA2").String()
+	tlsCert       = app.Flag("tls-cert", "The TLS certificate of the service").Required().String()
+	tlsKey        = app.Flag("tls-key", "The TLS private key of the service").Required().String()
+	clientCA      = app.Flag("client-ca", "The CA certificate the TLS certificates of the clients must chain to").Required().String()
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	ks, err := sw.NewFileBasedKeyStore(nil, *keyStore, false)
+	handleError(err)
+	csp, err := sw.New(*securityLevel, *hashFamily, ks)
+	handleError(err)
+
+	tlsConfig, err := serverTLSConfig(*tlsCert, *tlsKey, *clientCA)
+	handleError(err)
+
+	lis, err := net.Listen("tcp", *listenAddress)
+	handleError(err)
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	pb.RegisterRemoteSignerServer(server, remote.NewServer(csp))
+
+	fmt.Printf("Remote signing service listening on %s with keystore %s\n", lis.Addr(), *keyStore)
+	handleError(server.Serve(lis))
+}
+
+// serverTLSConfig returns a TLS configuration requiring the clients
+// to present a certificate issued by the CA in clientCAFile
+func serverTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading TLS certificate: %s", err)
+	}
+
+	caPEM, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading client CA certificate: %s", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed parsing client CA certificate %s", clientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+func handleError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
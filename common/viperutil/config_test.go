@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/orderer/mocks/util"
@@ -478,3 +479,30 @@ func TestEnhancedExactUnmarshalKey(t *testing.T) {
 	}
 
 }
+
+func TestEnhancedExactUnmarshalKeyDuration(t *testing.T) {
+	type durationKey struct {
+		Timeout time.Duration
+	}
+
+	yaml := "---\n" +
+		"Top:\n" +
+		"  Remote:\n" +
+		"    Timeout: 5s\n"
+
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+
+	if err := viper.ReadConfig(bytes.NewReader([]byte(yaml))); err != nil {
+		t.Fatalf("Error reading config: %s", err)
+	}
+
+	var uconf durationKey
+	if err := EnhancedExactUnmarshalKey("top.Remote", &uconf); err != nil {
+		t.Fatalf("Failed to unmarshall: %s", err)
+	}
+
+	if uconf.Timeout != 5*time.Second {
+		t.Fatalf(`Expected: "%s", Actual: "%s"`, 5*time.Second, uconf.Timeout)
+	}
+}
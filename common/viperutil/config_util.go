@@ -321,5 +321,14 @@ func EnhancedExactUnmarshalKey(baseKey string, output interface{}) error {
 	leafKeys := getKeysRecursively("", viper.Get, m)
 
 	logger.Debugf("%+v", leafKeys)
-	return mapstructure.Decode(leafKeys[baseKey], output)
+	config := &mapstructure.DecoderConfig{
+		Result:     output,
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(leafKeys[baseKey])
 }
@@ -17,8 +17,14 @@ limitations under the License.
 package core
 
 import (
+	"fmt"
+
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/cclogs"
+	"github.com/hyperledger/fabric/gossip/service"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"golang.org/x/net/context"
 )
@@ -71,3 +77,107 @@ func (*ServerAdmin) RevertLogLevels(context.Context, *empty.Empty) (*empty.Empty
 
 	return &empty.Empty{}, err
 }
+
+// GetChaincodeLogs sends the captured output of the requested chaincode and,
+// if requested, keeps sending its output until the client goes away
+func (*ServerAdmin) GetChaincodeLogs(request *pb.ChaincodeLogsRequest, stream pb.Admin_GetChaincodeLogsServer) error {
+	store := cclogs.GetStore()
+	if store == nil {
+		return fmt.Errorf("Chaincode output is not captured by this peer (see chaincode.logging.capture)")
+	}
+	if request.ChaincodeName == "" {
+		return fmt.Errorf("Chaincode name is required")
+	}
+
+	if !request.Follow {
+		lines, err := store.Lines(request.ChaincodeName)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&pb.ChaincodeLogsResponse{Lines: lines})
+	}
+
+	lines, follow, cancel, err := store.Follow(request.ChaincodeName)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	if err = stream.Send(&pb.ChaincodeLogsResponse{Lines: lines}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case line := <-follow:
+			// send the lines received meanwhile along
+			lines = []string{line}
+			for more := true; more; {
+				select {
+				case line = <-follow:
+					lines = append(lines, line)
+				default:
+					more = false
+				}
+			}
+			if err = stream.Send(&pb.ChaincodeLogsResponse{Lines: lines}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			log.Debugf("stopped following output of chaincode %s", request.ChaincodeName)
+			return nil
+		}
+	}
+}
+
+// GetGossipMembers returns the members known to the gossip component of the peer
+func (*ServerAdmin) GetGossipMembers(context.Context, *empty.Empty) (*pb.GossipMembersResponse, error) {
+	gossip := service.GetGossipService()
+	if gossip == nil {
+		return nil, fmt.Errorf("Gossip service is not initialized")
+	}
+	return gossip.MembersInfo(), nil
+}
+
+// GetGossipChannel returns the members of the requested channel, as seen
+// by the gossip component of the peer
+func (*ServerAdmin) GetGossipChannel(ctx context.Context, request *pb.GossipChannelRequest) (*pb.GossipChannelResponse, error) {
+	gossip := service.GetGossipService()
+	if gossip == nil {
+		return nil, fmt.Errorf("Gossip service is not initialized")
+	}
+	if request.Channel == "" {
+		return nil, fmt.Errorf("Channel name is required")
+	}
+	return gossip.ChannelInfo(request.Channel)
+}
+
+// GetGossipLeader returns the leader of the organization of the peer in the
+// requested channel
+func (*ServerAdmin) GetGossipLeader(ctx context.Context, request *pb.GossipChannelRequest) (*pb.GossipLeaderResponse, error) {
+	gossip := service.GetGossipService()
+	if gossip == nil {
+		return nil, fmt.Errorf("Gossip service is not initialized")
+	}
+	if request.Channel == "" {
+		return nil, fmt.Errorf("Channel name is required")
+	}
+	return gossip.LeaderInfo(request.Channel)
+}
+
+// GetCertificateExpirations returns the expiry dates of the certificates of
+// the peer and of the MSPs of the channels it has joined
+func (*ServerAdmin) GetCertificateExpirations(context.Context, *empty.Empty) (*pb.CertificateExpirationsResponse, error) {
+	resp := &pb.CertificateExpirationsResponse{}
+	for _, ce := range crypto.ExpirationTrackerInstance().Expirations() {
+		resp.Certificates = append(resp.Certificates, &pb.CertificateExpiration{
+			Source:  ce.Source,
+			Kind:    ce.Kind,
+			MspId:   ce.MSPID,
+			Subject: ce.Subject,
+			NotAfter: &timestamp.Timestamp{
+				Seconds: ce.NotAfter.Unix(),
+				Nanos:   int32(ce.NotAfter.Nanosecond()),
+			},
+		})
+	}
+	return resp, nil
+}
@@ -18,13 +18,20 @@ package core
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/cclogs"
 	"github.com/hyperledger/fabric/core/testutil"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
+	netcontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 var adminServer *ServerAdmin
@@ -68,3 +75,75 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "log level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+type mockChaincodeLogsServer struct {
+	grpc.ServerStream
+	ctx   netcontext.Context
+	lines chan []string
+}
+
+func (m *mockChaincodeLogsServer) Send(resp *pb.ChaincodeLogsResponse) error {
+	m.lines <- resp.Lines
+	return nil
+}
+
+func (m *mockChaincodeLogsServer) Context() netcontext.Context {
+	return m.ctx
+}
+
+func TestGetChaincodeLogs(t *testing.T) {
+	stream := &mockChaincodeLogsServer{ctx: context.Background(), lines: make(chan []string, 10)}
+
+	err := adminServer.GetChaincodeLogs(&pb.ChaincodeLogsRequest{}, stream)
+	assert.Error(t, err, "Expected error as output is not captured")
+
+	dir, err := ioutil.TempDir("", "cclogs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, cclogs.InitStore(dir, 1024))
+	store := cclogs.GetStore()
+	store.Append("mycc", "first")
+	store.Append("mycc", "second")
+
+	err = adminServer.GetChaincodeLogs(&pb.ChaincodeLogsRequest{}, stream)
+	assert.Error(t, err, "Expected error as chaincode name is missing")
+
+	err = adminServer.GetChaincodeLogs(&pb.ChaincodeLogsRequest{ChaincodeName: "mycc"}, stream)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, <-stream.lines)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream.ctx = ctx
+	done := make(chan error)
+	go func() {
+		done <- adminServer.GetChaincodeLogs(&pb.ChaincodeLogsRequest{ChaincodeName: "mycc", Follow: true}, stream)
+	}()
+	assert.Equal(t, []string{"first", "second"}, <-stream.lines)
+	store.Append("mycc", "third")
+	assert.Equal(t, []string{"third"}, <-stream.lines)
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestGetCertificateExpirations(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := crypto.ExpirationTrackerInstance()
+	tracker.Track("testchannel", []crypto.CertificateExpiration{
+		{Kind: crypto.AdminCertificate, MSPID: "Org1MSP", Subject: "admin", NotAfter: notAfter},
+	})
+	defer tracker.Untrack("testchannel")
+
+	resp, err := adminServer.GetCertificateExpirations(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	var found *pb.CertificateExpiration
+	for _, ce := range resp.Certificates {
+		if ce.Source == "testchannel" {
+			found = ce
+		}
+	}
+	assert.NotNil(t, found)
+	assert.Equal(t, crypto.AdminCertificate, found.Kind)
+	assert.Equal(t, "Org1MSP", found.MspId)
+	assert.Equal(t, "admin", found.Subject)
+	assert.Equal(t, notAfter.Unix(), found.NotAfter.Seconds)
+}
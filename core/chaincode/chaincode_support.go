@@ -39,6 +39,7 @@ import (
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/api"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/cclogs"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -192,6 +193,14 @@ func NewChaincodeSupport(getCCEndpoint func() (*pb.PeerEndpoint, error), userrun
 
 	theChaincodeSupport.executetimeout = execto
 
+	policies, err := ccintf.GetExecutionPolicies()
+	if err != nil {
+		chaincodeLogger.Errorf("Ignoring chaincode execution overrides: %s", err)
+		policies = nil
+	}
+	theChaincodeSupport.executionPolicies = policies
+	theChaincodeSupport.execSlots = make(map[string]chan struct{})
+
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	replacer := strings.NewReplacer(".", "_")
@@ -201,9 +210,57 @@ func NewChaincodeSupport(getCCEndpoint func() (*pb.PeerEndpoint, error), userrun
 	theChaincodeSupport.shimLogLevel = getLogLevelFromViper("shim")
 	theChaincodeSupport.logFormat = viper.GetString("chaincode.logging.format")
 
+	if viper.GetBool("chaincode.logging.capture") {
+		logsPath := config.GetPath("peer.fileSystemPath") + string(filepath.Separator) + "chaincodelogs"
+		if err = cclogs.InitStore(logsPath, int64(viper.GetInt("chaincode.logging.captureMaxSize"))); err != nil {
+			chaincodeLogger.Errorf("Chaincode output will not be captured: %s", err)
+		}
+	}
+
 	return theChaincodeSupport
 }
 
+// getExecuteTimeout returns the execute timeout of the given chaincode
+func (chaincodeSupport *ChaincodeSupport) getExecuteTimeout(ccname string) time.Duration {
+	if policy, ok := chaincodeSupport.executionPolicies[ccname]; ok && policy.ExecuteTimeout > 0 {
+		return policy.ExecuteTimeout
+	}
+	return chaincodeSupport.executetimeout
+}
+
+// getStartupTimeout returns the startup timeout of the given chaincode
+func (chaincodeSupport *ChaincodeSupport) getStartupTimeout(ccname string) time.Duration {
+	if policy, ok := chaincodeSupport.executionPolicies[ccname]; ok && policy.StartupTimeout > 0 {
+		return policy.StartupTimeout
+	}
+	return chaincodeSupport.ccStartupTimeout
+}
+
+// acquireExecSlot waits up to timeout for the given chaincode to have less
+// than its maximum number of concurrent executions. The returned function
+// must be called once the execution is over.
+func (chaincodeSupport *ChaincodeSupport) acquireExecSlot(ccname string, timeout time.Duration) (func(), error) {
+	policy, ok := chaincodeSupport.executionPolicies[ccname]
+	if !ok || policy.MaxConcurrency == 0 {
+		return func() {}, nil
+	}
+
+	chaincodeSupport.execSlotsLock.Lock()
+	slots, ok := chaincodeSupport.execSlots[ccname]
+	if !ok {
+		slots = make(chan struct{}, policy.MaxConcurrency)
+		chaincodeSupport.execSlots[ccname] = slots
+	}
+	chaincodeSupport.execSlotsLock.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("Timeout expired while waiting for one of the %d execution slots of chaincode %s", policy.MaxConcurrency, ccname)
+	}
+}
+
 // getLogLevelFromViper gets the chaincode container log levels from viper
 func getLogLevelFromViper(module string) string {
 	levelString := viper.GetString("chaincode.logging." + module)
@@ -241,6 +298,12 @@ type ChaincodeSupport struct {
 	executetimeout    time.Duration
 	userRunsCC        bool
 	peerTLS           bool
+
+	//per-chaincode overrides of the settings above, keyed by chaincode name
+	executionPolicies map[string]*ccintf.ExecutionPolicy
+	//execution slots of the chaincodes with a concurrency limit
+	execSlotsLock sync.Mutex
+	execSlots     map[string]chan struct{}
 }
 
 // DuplicateChaincodeHandlerError returned if attempt to register same chaincodeID while a stream already exists.
@@ -498,7 +561,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 		if !ok {
 			err = fmt.Errorf("registration failed for %s(networkid:%s,peerid:%s,tx:%s)", canName, chaincodeSupport.peerNetworkID, chaincodeSupport.peerID, cccid.TxID)
 		}
-	case <-time.After(chaincodeSupport.ccStartupTimeout):
+	case <-time.After(chaincodeSupport.getStartupTimeout(cccid.Name)):
 		err = fmt.Errorf("Timeout expired while starting chaincode %s(networkid:%s,peerid:%s,tx:%s)", canName, chaincodeSupport.peerNetworkID, chaincodeSupport.peerID, cccid.TxID)
 	}
 	if err != nil {
@@ -669,7 +732,7 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, cccid
 
 	if err == nil {
 		//launch will set the chaincode in Ready state
-		err = chaincodeSupport.sendReady(context, cccid, chaincodeSupport.ccStartupTimeout)
+		err = chaincodeSupport.sendReady(context, cccid, chaincodeSupport.getStartupTimeout(cccid.Name))
 		if err != nil {
 			chaincodeLogger.Errorf("sending init failed(%s)", err)
 			err = fmt.Errorf("Failed to init chaincode(%s)", err)
@@ -731,8 +794,16 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
+	// the time spent waiting for an execution slot counts against the timeout
+	start := time.Now()
+	release, err := chaincodeSupport.acquireExecSlot(cccid.Name, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	timeout -= time.Since(start)
+
 	var notfy chan *pb.ChaincodeMessage
-	var err error
 	if notfy, err = chrte.handler.sendExecuteMessage(ctxt, cccid.ChainID, msg, cccid.SignedProposal, cccid.Proposal); err != nil {
 		return nil, fmt.Errorf("Error sending %s: %s", msg.Type.String(), err)
 	}
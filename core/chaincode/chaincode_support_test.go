@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/peer"
@@ -44,6 +45,7 @@ import (
 	plgr "github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
@@ -680,3 +682,37 @@ func TestCCFramework(t *testing.T) {
 
 	ccSide.Quit()
 }
+
+func TestExecutionPolicies(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{
+		ccStartupTimeout: 5 * time.Second,
+		executetimeout:   30 * time.Second,
+		executionPolicies: map[string]*ccintf.ExecutionPolicy{
+			"slowcc":    &ccintf.ExecutionPolicy{Name: "slowcc", ExecuteTimeout: time.Minute, StartupTimeout: time.Hour},
+			"limitedcc": &ccintf.ExecutionPolicy{Name: "limitedcc", MaxConcurrency: 2},
+		},
+		execSlots: make(map[string]chan struct{}),
+	}
+
+	assert.Equal(t, time.Minute, chaincodeSupport.getExecuteTimeout("slowcc"))
+	assert.Equal(t, time.Hour, chaincodeSupport.getStartupTimeout("slowcc"))
+	assert.Equal(t, 30*time.Second, chaincodeSupport.getExecuteTimeout("limitedcc"))
+	assert.Equal(t, 5*time.Second, chaincodeSupport.getStartupTimeout("othercc"))
+
+	// no limit
+	for i := 0; i < 10; i++ {
+		_, err := chaincodeSupport.acquireExecSlot("othercc", time.Millisecond)
+		assert.NoError(t, err)
+	}
+
+	release1, err := chaincodeSupport.acquireExecSlot("limitedcc", time.Millisecond)
+	assert.NoError(t, err)
+	_, err = chaincodeSupport.acquireExecSlot("limitedcc", time.Millisecond)
+	assert.NoError(t, err)
+	_, err = chaincodeSupport.acquireExecSlot("limitedcc", 10*time.Millisecond)
+	assert.Error(t, err)
+
+	release1()
+	_, err = chaincodeSupport.acquireExecSlot("limitedcc", time.Millisecond)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// readOnlyTxSimulator is handed to a chaincode invoked by another chaincode
+// when the called chaincode must not modify the state, either because it was
+// invoked read-only or because it lives on a different channel. Reads are
+// passed on to the wrapped simulator, so they are recorded in its read set.
+// Writes fail and are remembered so the invocation as a whole can be failed
+// even if the called chaincode ignores the error.
+type readOnlyTxSimulator struct {
+	ledger.TxSimulator
+	reason string
+
+	sync.Mutex
+	rejected []string
+}
+
+func newReadOnlyTxSimulator(txsim ledger.TxSimulator, reason string) *readOnlyTxSimulator {
+	return &readOnlyTxSimulator{TxSimulator: txsim, reason: reason}
+}
+
+func (r *readOnlyTxSimulator) reject(namespace string, key string) error {
+	r.Lock()
+	defer r.Unlock()
+	r.rejected = append(r.rejected, namespace+"/"+key)
+	return fmt.Errorf("Writing key %s of chaincode %s is not allowed on %s", key, namespace, r.reason)
+}
+
+// SetState rejects the write
+func (r *readOnlyTxSimulator) SetState(namespace string, key string, value []byte) error {
+	return r.reject(namespace, key)
+}
+
+// DeleteState rejects the write
+func (r *readOnlyTxSimulator) DeleteState(namespace string, key string) error {
+	return r.reject(namespace, key)
+}
+
+// SetStateMultipleKeys rejects the writes
+func (r *readOnlyTxSimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	for key := range kvs {
+		return r.reject(namespace, key)
+	}
+	return nil
+}
+
+// ExecuteUpdate rejects the update
+func (r *readOnlyTxSimulator) ExecuteUpdate(query string) error {
+	return r.reject("", query)
+}
+
+// Done does not release the wrapped simulator, which is owned by the caller
+func (r *readOnlyTxSimulator) Done() {
+}
+
+// checkNoWrites returns an error if any write was attempted through r
+func (r *readOnlyTxSimulator) checkNoWrites() error {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.rejected) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Chaincode attempted to write %v on %s", r.rejected, r.reason)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReadTxSimulator struct {
+	ledger.TxSimulator
+	reads []string
+	done  bool
+}
+
+func (m *mockReadTxSimulator) GetState(namespace string, key string) ([]byte, error) {
+	m.reads = append(m.reads, namespace+"/"+key)
+	return []byte("value"), nil
+}
+
+func (m *mockReadTxSimulator) Done() {
+	m.done = true
+}
+
+func TestReadOnlyTxSimulator(t *testing.T) {
+	txsim := &mockReadTxSimulator{}
+	roTxsim := newReadOnlyTxSimulator(txsim, "read-only invocation")
+
+	value, err := roTxsim.GetState("cc", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, []string{"cc/a"}, txsim.reads)
+	assert.NoError(t, roTxsim.checkNoWrites())
+
+	assert.Error(t, roTxsim.SetState("cc", "a", []byte("1")))
+	assert.Error(t, roTxsim.DeleteState("cc", "b"))
+	assert.Error(t, roTxsim.SetStateMultipleKeys("cc", map[string][]byte{"c": nil}))
+	assert.NoError(t, roTxsim.SetStateMultipleKeys("cc", nil))
+
+	err = roTxsim.checkNoWrites()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cc/a cc/b cc/c")
+	assert.Contains(t, err.Error(), "read-only invocation")
+
+	// the wrapped simulator belongs to the caller
+	roTxsim.Done()
+	assert.False(t, txsim.done)
+}
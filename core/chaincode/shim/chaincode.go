@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -67,6 +68,9 @@ type ChaincodeStub struct {
 	creator   []byte
 	transient map[string][]byte
 	binding   []byte
+
+	// writes buffered between StartWriteBatch and FinishWriteBatch
+	writeBatch *writeBatch
 }
 
 // Peer address derived from command line or env var
@@ -373,6 +377,10 @@ func (stub *ChaincodeStub) GetTxID() string {
 
 // InvokeChaincode documentation can be found in interfaces.go
 func (stub *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	// the called chaincode may write to the same keys, so our writes go first
+	if err := stub.flushWriteBatch(); err != nil {
+		return Error(err.Error())
+	}
 	// Internally we handle chaincode name as a composite name
 	if channel != "" {
 		chaincodeName = chaincodeName + "/" + channel
@@ -380,6 +388,19 @@ func (stub *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte,
 	return stub.handler.handleInvokeChaincode(chaincodeName, args, stub.TxID)
 }
 
+// InvokeChaincodeReadOnly documentation can be found in interfaces.go
+func (stub *ChaincodeStub) InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response {
+	// the called chaincode may read keys we have written
+	if err := stub.flushWriteBatch(); err != nil {
+		return Error(err.Error())
+	}
+	// Internally we handle chaincode name as a composite name
+	if channel != "" {
+		chaincodeName = chaincodeName + "/" + channel
+	}
+	return stub.handler.handleInvokeChaincodeReadOnly(chaincodeName, args, stub.TxID)
+}
+
 // --------- State functions ----------
 
 // GetState documentation can be found in interfaces.go
@@ -387,19 +408,105 @@ func (stub *ChaincodeStub) GetState(key string) ([]byte, error) {
 	return stub.handler.handleGetState(key, stub.TxID)
 }
 
+// GetMultipleStates documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return [][]byte{}, nil
+	}
+	return stub.handler.handleGetStateMultiple(keys, stub.TxID)
+}
+
 // PutState documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutState(key string, value []byte) error {
 	if key == "" {
 		return fmt.Errorf("key must not be an empty string")
 	}
+	if stub.writeBatch != nil {
+		stub.writeBatch.put(key, value)
+		return nil
+	}
 	return stub.handler.handlePutState(key, value, stub.TxID)
 }
 
 // DelState documentation can be found in interfaces.go
 func (stub *ChaincodeStub) DelState(key string) error {
+	if stub.writeBatch != nil {
+		stub.writeBatch.del(key)
+		return nil
+	}
 	return stub.handler.handleDelState(key, stub.TxID)
 }
 
+// StartWriteBatch documentation can be found in interfaces.go
+func (stub *ChaincodeStub) StartWriteBatch() {
+	if stub.writeBatch == nil {
+		stub.writeBatch = newWriteBatch()
+	}
+}
+
+// FinishWriteBatch documentation can be found in interfaces.go
+func (stub *ChaincodeStub) FinishWriteBatch() error {
+	err := stub.flushWriteBatch()
+	stub.writeBatch = nil
+	return err
+}
+
+// flushWriteBatch sends the buffered writes, if any, to the peer and keeps
+// buffering
+func (stub *ChaincodeStub) flushWriteBatch() error {
+	if stub.writeBatch == nil || stub.writeBatch.isEmpty() {
+		return nil
+	}
+	payload := stub.writeBatch.toPutStateMultiple()
+	stub.writeBatch = newWriteBatch()
+	return stub.handler.handlePutStateMultiple(payload, stub.TxID)
+}
+
+// writeBatch buffers the writes of a chaincode between StartWriteBatch and
+// FinishWriteBatch, keeping only the last write of each key
+type writeBatch struct {
+	writes map[string]*pb.PutStateInfo
+	// keys deleted since the start of the batch
+	deletes map[string]bool
+}
+
+func newWriteBatch() *writeBatch {
+	return &writeBatch{writes: make(map[string]*pb.PutStateInfo), deletes: make(map[string]bool)}
+}
+
+func (b *writeBatch) put(key string, value []byte) {
+	delete(b.deletes, key)
+	b.writes[key] = &pb.PutStateInfo{Key: key, Value: value}
+}
+
+func (b *writeBatch) del(key string) {
+	delete(b.writes, key)
+	b.deletes[key] = true
+}
+
+func (b *writeBatch) isEmpty() bool {
+	return len(b.writes) == 0 && len(b.deletes) == 0
+}
+
+// toPutStateMultiple returns the buffered writes sorted by key so that the
+// message sent to the peer is deterministic
+func (b *writeBatch) toPutStateMultiple() *pb.PutStateMultiple {
+	psm := &pb.PutStateMultiple{}
+	keys := make([]string, 0, len(b.writes))
+	for key := range b.writes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		psm.Puts = append(psm.Puts, b.writes[key])
+	}
+	for key := range b.deletes {
+		psm.Deletes = append(psm.Deletes, key)
+	}
+	sort.Strings(psm.Deletes)
+	return psm
+}
+
 // CommonIterator documentation can be found in interfaces.go
 type CommonIterator struct {
 	handler    *Handler
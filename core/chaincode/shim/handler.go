@@ -242,6 +242,11 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage) {
 		res := handler.cc.Init(stub)
 		chaincodeLogger.Debugf("[%s]Init get response status: %d", shorttxid(msg.Txid), res.Status)
 
+		err = stub.FinishWriteBatch()
+		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s]Init failed to send buffered writes [%s]. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		if res.Status >= ERROR {
 			err = fmt.Errorf("%s", res.Message)
 			if nextStateMsg = errFunc(err, []byte(res.Message), stub.chaincodeEvent, "[%s]Init get error response [%s]. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
@@ -316,6 +321,11 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage) {
 
 		res := handler.cc.Invoke(stub)
 
+		err = stub.FinishWriteBatch()
+		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s]Failed to send buffered writes. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		// Endorser will handle error contained in Response.
 		resBytes, err := proto.Marshal(&res)
 		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s]Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
@@ -374,7 +384,6 @@ func (handler *Handler) afterError(e *fsm.Event) {
 	}
 }
 
-// TODO: Implement method to get and put entire state map and not one key at a time?
 // handleGetState communicates with the validator to fetch the requested state information from the ledger.
 func (handler *Handler) handleGetState(key string, txid string) ([]byte, error) {
 	// Create the channel on which to communicate the response from validating peer
@@ -412,6 +421,104 @@ func (handler *Handler) handleGetState(key string, txid string) ([]byte, error)
 	return nil, errors.New(fmt.Sprintf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR))
 }
 
+// handleGetStateMultiple communicates with the validator to fetch the values
+// of several keys from the ledger in a single round trip.
+func (handler *Handler) handleGetStateMultiple(keys []string, txid string) ([][]byte, error) {
+	payloadBytes, err := proto.Marshal(&pb.GetStateMultiple{Keys: keys})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the channel on which to communicate the response from validating peer
+	var respChan chan pb.ChaincodeMessage
+	if respChan, err = handler.createChannel(txid); err != nil {
+		return nil, err
+	}
+
+	defer handler.deleteChannel(txid)
+
+	// Send GET_STATE_MULTIPLE message to validator chaincode support
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid}
+	chaincodeLogger.Debugf("[%s]Sending %s for %d keys", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_MULTIPLE, len(keys))
+
+	var responseMsg pb.ChaincodeMessage
+
+	if responseMsg, err = handler.sendReceive(msg, respChan); err != nil {
+		return nil, fmt.Errorf("[%s]error sending GET_STATE_MULTIPLE %s", shorttxid(txid), err)
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s]GetMultipleStates received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		response := &pb.GetStateMultipleResponse{}
+		if err = proto.Unmarshal(responseMsg.Payload, response); err != nil {
+			chaincodeLogger.Errorf("[%s]unmarshall error", shorttxid(responseMsg.Txid))
+			return nil, fmt.Errorf("Error unmarshalling GetStateMultipleResponse: %s", err)
+		}
+		if len(response.Values) != len(keys) {
+			return nil, fmt.Errorf("[%s]GetMultipleStates received %d values for %d keys", shorttxid(responseMsg.Txid), len(response.Values), len(keys))
+		}
+		values := make([][]byte, len(keys))
+		for i, value := range response.Values {
+			// missing keys are sent as empty values, return nil as GetState does
+			if len(value) > 0 {
+				values[i] = value
+			}
+		}
+		return values, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s]GetMultipleStates received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	return nil, fmt.Errorf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handlePutStateMultiple communicates with the validator to write the
+// buffered puts and deletes of a transaction in a single round trip.
+func (handler *Handler) handlePutStateMultiple(writes *pb.PutStateMultiple, txid string) error {
+	payloadBytes, err := proto.Marshal(writes)
+	if err != nil {
+		return err
+	}
+
+	// Create the channel on which to communicate the response from validating peer
+	var respChan chan pb.ChaincodeMessage
+	if respChan, err = handler.createChannel(txid); err != nil {
+		return err
+	}
+
+	defer handler.deleteChannel(txid)
+
+	// Send PUT_STATE_MULTIPLE message to validator chaincode support
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid}
+	chaincodeLogger.Debugf("[%s]Sending %s with %d puts and %d deletes", shorttxid(msg.Txid), pb.ChaincodeMessage_PUT_STATE_MULTIPLE, len(writes.Puts), len(writes.Deletes))
+
+	var responseMsg pb.ChaincodeMessage
+
+	if responseMsg, err = handler.sendReceive(msg, respChan); err != nil {
+		return fmt.Errorf("[%s]error sending PUT_STATE_MULTIPLE %s", shorttxid(msg.Txid), err)
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s]Received %s. Successfully updated state", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s]Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	return fmt.Errorf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 // handlePutState communicates with the validator to put state information into the ledger.
 func (handler *Handler) handlePutState(key string, value []byte, txid string) error {
 	// Check if this is a transaction
@@ -717,6 +824,16 @@ func (handler *Handler) createResponse(status int32, payload []byte) pb.Response
 
 // handleInvokeChaincode communicates with the validator to invoke another chaincode.
 func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byte, txid string) pb.Response {
+	return handler.invokeChaincode(pb.ChaincodeMessage_INVOKE_CHAINCODE, chaincodeName, args, txid)
+}
+
+// handleInvokeChaincodeReadOnly communicates with the validator to invoke another
+// chaincode which is not allowed to write state.
+func (handler *Handler) handleInvokeChaincodeReadOnly(chaincodeName string, args [][]byte, txid string) pb.Response {
+	return handler.invokeChaincode(pb.ChaincodeMessage_INVOKE_CHAINCODE_READ_ONLY, chaincodeName, args, txid)
+}
+
+func (handler *Handler) invokeChaincode(msgType pb.ChaincodeMessage_Type, chaincodeName string, args [][]byte, txid string) pb.Response {
 	//we constructed a valid object. No need to check for error
 	payloadBytes, _ := proto.Marshal(&pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: chaincodeName}, Input: &pb.ChaincodeInput{Args: args}})
 
@@ -730,13 +847,13 @@ func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byt
 	defer handler.deleteChannel(txid)
 
 	// Send INVOKE_CHAINCODE message to validator chaincode support
-	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INVOKE_CHAINCODE, Payload: payloadBytes, Txid: txid}
-	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_INVOKE_CHAINCODE)
+	msg := &pb.ChaincodeMessage{Type: msgType, Payload: payloadBytes, Txid: txid}
+	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), msgType)
 
 	var responseMsg pb.ChaincodeMessage
 
 	if responseMsg, err = handler.sendReceive(msg, respChan); err != nil {
-		return handler.createResponse(ERROR, []byte(fmt.Sprintf("[%s]error sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_INVOKE_CHAINCODE)))
+		return handler.createResponse(ERROR, []byte(fmt.Sprintf("[%s]error sending %s", shorttxid(msg.Txid), msgType)))
 	}
 
 	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
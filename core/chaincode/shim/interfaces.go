@@ -69,16 +69,24 @@ type ChaincodeStubInterface interface {
 	// If the called chaincode is on the same channel, it simply adds the called
 	// chaincode read set and write set to the calling transaction.
 	// If the called chaincode is on a different channel,
-	// only the Response is returned to the calling chaincode; any PutState calls
-	// from the called chaincode will not have any effect on the ledger; that is,
-	// the called chaincode on a different channel will not have its read set
-	// and write set applied to the transaction. Only the calling chaincode's
+	// only the Response is returned to the calling chaincode; the called
+	// chaincode on a different channel will not have its read set applied to
+	// the transaction and any attempt to write state (PutState, DelState)
+	// fails, which in turn fails the call. Only the calling chaincode's
 	// read set and write set will be applied to the transaction. Effectively
 	// the called chaincode on a different channel is a `Query`, which does not
 	// participate in state validation checks in subsequent commit phase.
 	// If `channel` is empty, the caller's channel is assumed.
 	InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response
 
+	// InvokeChaincodeReadOnly calls the specified chaincode `Invoke` like
+	// InvokeChaincode, but declares that the called chaincode must not modify
+	// the state. The peer fails the call if the called chaincode attempts to
+	// write. If the called chaincode is on the same channel, its read set is
+	// added to the calling transaction and is validated in the commit phase.
+	// If `channel` is empty, the caller's channel is assumed.
+	InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response
+
 	// GetState returns the value of the specified `key` from the
 	// ledger. Note that GetState doesn't read data from the writeset, which
 	// has not been committed to the ledger. In other words, GetState doesn't
@@ -86,6 +94,12 @@ type ChaincodeStubInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetMultipleStates returns the values of the specified `keys` from the
+	// ledger in a single round trip to the peer. The i-th value corresponds
+	// to the i-th key and is nil if the key does not exist. As with GetState,
+	// data modified by PutState that has not been committed is not considered.
+	GetMultipleStates(keys ...string) ([][]byte, error)
+
 	// PutState puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal. PutState doesn't effect the ledger
 	// until the transaction is validated and successfully committed.
@@ -100,6 +114,19 @@ type ChaincodeStubInterface interface {
 	// the ledger when the transaction is validated and successfully committed.
 	DelState(key string) error
 
+	// StartWriteBatch makes subsequent PutState and DelState calls buffer
+	// their writes in the chaincode instead of sending each of them to the
+	// peer. The buffered writes are sent in a single round trip by
+	// FinishWriteBatch, before any InvokeChaincode call, and when Init or
+	// Invoke returns. When a key is written several times only the last
+	// write is kept. Errors for buffered writes, such as invalid keys, are
+	// only reported when the batch is sent.
+	StartWriteBatch()
+
+	// FinishWriteBatch sends the writes buffered since StartWriteBatch to the
+	// peer and stops buffering. It is a no-op if no batch was started.
+	FinishWriteBatch() error
+
 	// GetStateByRange returns a range iterator over a set of keys in the
 	// ledger. The iterator can be used to iterate over all keys
 	// between the startKey (inclusive) and endKey (exclusive).
@@ -228,8 +255,6 @@ type HistoryQueryIteratorInterface interface {
 
 // MockQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by range query.
-// TODO: Once the execute query and history query are implemented in MockStub,
-// we need to update this interface
 type MockQueryIteratorInterface interface {
 	StateQueryIteratorInterface
 }
@@ -0,0 +1,607 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+
+// mockQuery is the subset of a CouchDB Mango query understood by MockStub.
+// The selector supports implicit equality, nested fields (both as nested
+// objects and dotted names) and the $eq, $ne, $gt, $gte, $lt, $lte, $exists,
+// $in, $nin, $regex, $size, $all, $elemMatch, $and, $or, $nor and $not
+// operators. The fields, sort, limit and skip members are honoured,
+// use_index is accepted and ignored.
+type mockQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Fields   []string               `json:"fields"`
+	Sort     []interface{}          `json:"sort"`
+	Limit    *int                   `json:"limit"`
+	Skip     int                    `json:"skip"`
+	UseIndex interface{}            `json:"use_index"`
+}
+
+type mockSortField struct {
+	field string
+	desc  bool
+}
+
+// parseMockQuery parses and validates a Mango query string
+func parseMockQuery(query string) (*mockQuery, []mockSortField, error) {
+	q := &mockQuery{}
+	decoder := json.NewDecoder(strings.NewReader(query))
+	decoder.UseNumber()
+	if err := decoder.Decode(q); err != nil {
+		return nil, nil, fmt.Errorf("Invalid query [%s]: %s", query, err)
+	}
+	if q.Selector == nil {
+		return nil, nil, fmt.Errorf("Invalid query [%s]: selector is required", query)
+	}
+	if q.Skip < 0 || (q.Limit != nil && *q.Limit < 0) {
+		return nil, nil, fmt.Errorf("Invalid query [%s]: limit and skip must not be negative", query)
+	}
+	// unknown operators are reported at query time rather than silently
+	// matching nothing
+	if err := validateSelector(q.Selector); err != nil {
+		return nil, nil, fmt.Errorf("Invalid query [%s]: %s", query, err)
+	}
+
+	var sortFields []mockSortField
+	for _, s := range q.Sort {
+		switch s := s.(type) {
+		case string:
+			sortFields = append(sortFields, mockSortField{field: s})
+		case map[string]interface{}:
+			if len(s) != 1 {
+				return nil, nil, fmt.Errorf("Invalid query [%s]: sort entries must name exactly one field", query)
+			}
+			for field, dir := range s {
+				switch dir {
+				case "asc":
+					sortFields = append(sortFields, mockSortField{field: field})
+				case "desc":
+					sortFields = append(sortFields, mockSortField{field: field, desc: true})
+				default:
+					return nil, nil, fmt.Errorf("Invalid query [%s]: unknown sort direction %v", query, dir)
+				}
+			}
+		default:
+			return nil, nil, fmt.Errorf("Invalid query [%s]: unsupported sort entry %v", query, s)
+		}
+	}
+	return q, sortFields, nil
+}
+
+// executeMockQuery runs the query against the supplied keys (in key order)
+// and returns the matching entries. Values that are not JSON objects are
+// never matched, as is the case for binary values stored in CouchDB.
+func executeMockQuery(query string, keys []string, getValue func(string) []byte) ([]*queryresult.KV, error) {
+	q, sortFields, err := parseMockQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []mockQueryMatch
+	for _, key := range keys {
+		doc, ok := decodeMockDocument(getValue(key))
+		if !ok {
+			continue
+		}
+		matched, err := matchSelector(q.Selector, doc)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			matches = append(matches, mockQueryMatch{key, doc})
+		}
+	}
+
+	if len(sortFields) > 0 {
+		sort.Stable(&mockQuerySorter{matches, sortFields})
+	}
+
+	if q.Skip >= len(matches) {
+		matches = nil
+	} else {
+		matches = matches[q.Skip:]
+	}
+	if q.Limit != nil && *q.Limit < len(matches) {
+		matches = matches[:*q.Limit]
+	}
+
+	results := make([]*queryresult.KV, 0, len(matches))
+	for _, m := range matches {
+		value := getValue(m.key)
+		if len(q.Fields) > 0 {
+			projected := make(map[string]interface{})
+			for _, field := range q.Fields {
+				if v, ok := lookupField(m.doc, field); ok {
+					setField(projected, field, v)
+				}
+			}
+			if value, err = json.Marshal(projected); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, &queryresult.KV{Key: m.key, Value: value})
+	}
+	return results, nil
+}
+
+type mockQueryMatch struct {
+	key string
+	doc map[string]interface{}
+}
+
+type mockQuerySorter struct {
+	matches    []mockQueryMatch
+	sortFields []mockSortField
+}
+
+func (s *mockQuerySorter) Len() int {
+	return len(s.matches)
+}
+
+func (s *mockQuerySorter) Swap(i, j int) {
+	s.matches[i], s.matches[j] = s.matches[j], s.matches[i]
+}
+
+func (s *mockQuerySorter) Less(i, j int) bool {
+	for _, sf := range s.sortFields {
+		vi, _ := lookupField(s.matches[i].doc, sf.field)
+		vj, _ := lookupField(s.matches[j].doc, sf.field)
+		c := compareJSON(vi, vj)
+		if c == 0 {
+			continue
+		}
+		if sf.desc {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+func decodeMockDocument(value []byte) (map[string]interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	doc := make(map[string]interface{})
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// validateSelector checks every operator of selector and of its nested
+// selectors and conditions, whatever documents they would be matched against
+func validateSelector(selector map[string]interface{}) error {
+	for _, field := range sortedKeys(selector) {
+		cond := selector[field]
+		switch field {
+		case "$and", "$or", "$nor":
+			subs, ok := cond.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s requires an array argument", field)
+			}
+			for _, s := range subs {
+				sub, ok := s.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s requires an array of selectors", field)
+				}
+				if err := validateSelector(sub); err != nil {
+					return err
+				}
+			}
+		case "$not":
+			sub, ok := cond.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("$not requires an object argument")
+			}
+			if err := validateSelector(sub); err != nil {
+				return err
+			}
+		default:
+			if strings.HasPrefix(field, "$") {
+				return fmt.Errorf("unsupported combination operator %s", field)
+			}
+			if err := validateCondition(cond); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateCondition(cond interface{}) error {
+	ops, ok := cond.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if !hasOperators(ops) {
+		return validateSelector(ops)
+	}
+	for _, op := range sortedKeys(ops) {
+		arg := ops[op]
+		var err error
+		switch op {
+		case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		case "$not":
+			err = validateCondition(arg)
+		case "$elemMatch":
+			if _, ok := arg.(map[string]interface{}); !ok {
+				return fmt.Errorf("$elemMatch requires an object argument")
+			}
+			err = validateCondition(arg)
+		case "$exists":
+			if _, ok := arg.(bool); !ok {
+				err = fmt.Errorf("$exists requires a boolean argument")
+			}
+		case "$in", "$nin", "$all":
+			if _, ok := arg.([]interface{}); !ok {
+				err = fmt.Errorf("%s requires an array argument", op)
+			}
+		default:
+			// the remaining operators validate their argument against any value
+			_, err = matchOperator(op, arg, nil, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// matchSelector reports whether doc satisfies every condition of selector
+func matchSelector(selector map[string]interface{}, doc interface{}) (bool, error) {
+	for field, cond := range selector {
+		var matched bool
+		var err error
+		switch field {
+		case "$and", "$or", "$nor":
+			matched, err = matchCombination(field, cond, doc)
+		case "$not":
+			sub, ok := cond.(map[string]interface{})
+			if !ok {
+				return false, fmt.Errorf("$not requires an object argument")
+			}
+			matched, err = matchSelector(sub, doc)
+			matched = !matched
+		default:
+			if strings.HasPrefix(field, "$") {
+				return false, fmt.Errorf("unsupported combination operator %s", field)
+			}
+			value, exists := lookupField(doc, field)
+			matched, err = matchCondition(cond, value, exists)
+		}
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchCombination(op string, cond interface{}, doc interface{}) (bool, error) {
+	subs, ok := cond.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("%s requires an array argument", op)
+	}
+	anyMatched := false
+	for _, s := range subs {
+		sub, ok := s.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("%s requires an array of selectors", op)
+		}
+		matched, err := matchSelector(sub, doc)
+		if err != nil {
+			return false, err
+		}
+		if op == "$and" && !matched {
+			return false, nil
+		}
+		anyMatched = anyMatched || matched
+	}
+	switch op {
+	case "$and":
+		return true, nil
+	case "$or":
+		return anyMatched, nil
+	default:
+		return !anyMatched, nil
+	}
+}
+
+// matchCondition applies a field condition to a value. A condition is either
+// an object of operators or a plain value meaning equality; an object without
+// operators is treated as a selector on the nested object.
+func matchCondition(cond interface{}, value interface{}, exists bool) (bool, error) {
+	ops, ok := cond.(map[string]interface{})
+	if !ok {
+		return exists && compareJSON(value, cond) == 0, nil
+	}
+	if !hasOperators(ops) {
+		return matchSelector(ops, value)
+	}
+	for op, arg := range ops {
+		matched, err := matchOperator(op, arg, value, exists)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func hasOperators(m map[string]interface{}) bool {
+	for k := range m {
+		if strings.HasPrefix(k, "$") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOperator(op string, arg interface{}, value interface{}, exists bool) (bool, error) {
+	switch op {
+	case "$exists":
+		want, ok := arg.(bool)
+		if !ok {
+			return false, fmt.Errorf("$exists requires a boolean argument")
+		}
+		return exists == want, nil
+	case "$ne":
+		return !exists || compareJSON(value, arg) != 0, nil
+	case "$nin":
+		list, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("$nin requires an array argument")
+		}
+		return !exists || !containsJSON(list, value), nil
+	case "$not":
+		matched, err := matchCondition(arg, value, exists)
+		return !matched, err
+	}
+
+	if !exists {
+		// every remaining operator needs the field to be present; still
+		// validate the operator itself
+		_, err := matchOperator(op, arg, nil, true)
+		return false, err
+	}
+
+	switch op {
+	case "$eq":
+		return compareJSON(value, arg) == 0, nil
+	case "$gt":
+		return sameTypeClass(value, arg) && compareJSON(value, arg) > 0, nil
+	case "$gte":
+		return sameTypeClass(value, arg) && compareJSON(value, arg) >= 0, nil
+	case "$lt":
+		return sameTypeClass(value, arg) && compareJSON(value, arg) < 0, nil
+	case "$lte":
+		return sameTypeClass(value, arg) && compareJSON(value, arg) <= 0, nil
+	case "$in":
+		list, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("$in requires an array argument")
+		}
+		return containsJSON(list, value), nil
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return false, fmt.Errorf("$regex requires a string argument")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid $regex [%s]: %s", pattern, err)
+		}
+		s, ok := value.(string)
+		return ok && re.MatchString(s), nil
+	case "$size":
+		n, ok := arg.(json.Number)
+		if !ok {
+			return false, fmt.Errorf("$size requires a numeric argument")
+		}
+		size, err := n.Int64()
+		if err != nil {
+			return false, fmt.Errorf("$size requires an integer argument")
+		}
+		list, ok := value.([]interface{})
+		return ok && int64(len(list)) == size, nil
+	case "$all":
+		want, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("$all requires an array argument")
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, w := range want {
+			if !containsJSON(list, w) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "$elemMatch":
+		sub, ok := arg.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("$elemMatch requires an object argument")
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, elem := range list {
+			matched, err := matchCondition(sub, elem, true)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported operator %s", op)
+}
+
+// lookupField resolves a dotted field name within a document
+func lookupField(doc interface{}, field string) (interface{}, bool) {
+	current := doc
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setField sets a dotted field name within a document, creating the
+// intermediate objects as needed
+func setField(doc map[string]interface{}, field string, value interface{}) {
+	parts := strings.Split(field, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := doc[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			doc[part] = next
+		}
+		doc = next
+	}
+	doc[parts[len(parts)-1]] = value
+}
+
+func containsJSON(list []interface{}, value interface{}) bool {
+	for _, elem := range list {
+		if compareJSON(elem, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeClass returns the position of a value's type in the CouchDB
+// collation order: null, false, true, numbers, strings, arrays, objects
+func jsonTypeClass(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case json.Number, float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func sameTypeClass(a, b interface{}) bool {
+	ca, cb := jsonTypeClass(a), jsonTypeClass(b)
+	// booleans compare with each other regardless of their value
+	if ca <= 2 && cb <= 2 && ca > 0 && cb > 0 {
+		return true
+	}
+	return ca == cb
+}
+
+// compareJSON compares two decoded JSON values using CouchDB collation
+func compareJSON(a, b interface{}) int {
+	ca, cb := jsonTypeClass(a), jsonTypeClass(b)
+	if ca != cb {
+		if ca < cb {
+			return -1
+		}
+		return 1
+	}
+	switch ca {
+	case 3:
+		fa, fb := toFloat(a), toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case 4:
+		return strings.Compare(a.(string), b.(string))
+	case 5:
+		la, lb := a.([]interface{}), b.([]interface{})
+		for i := 0; i < len(la) && i < len(lb); i++ {
+			if c := compareJSON(la[i], lb[i]); c != 0 {
+				return c
+			}
+		}
+		return len(la) - len(lb)
+	case 6:
+		// objects compare field by field in key order, then by size
+		ma, _ := a.(map[string]interface{})
+		mb, _ := b.(map[string]interface{})
+		ka, kb := sortedKeys(ma), sortedKeys(mb)
+		for i := 0; i < len(ka) && i < len(kb); i++ {
+			if c := strings.Compare(ka[i], kb[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(ma[ka[i]], mb[kb[i]]); c != 0 {
+				return c
+			}
+		}
+		return len(ka) - len(kb)
+	}
+	return 0
+}
+
+func toFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case float64:
+		return v
+	}
+	return 0
+}
+
@@ -22,11 +22,13 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 )
@@ -64,6 +66,43 @@ type MockStub struct {
 
 	// mocked signedProposal
 	signedProposal *pb.SignedProposal
+
+	// TxRWSets keeps the read/write set recorded for each mock transaction,
+	// keyed by transaction id
+	TxRWSets map[string]*kvrwset.KVRWSet
+
+	// history of committed modifications, per key, oldest first
+	history map[string][]*queryresult.KeyModification
+
+	// committed version of each key, in the same form as recorded in read sets
+	versions map[string]*kvrwset.Version
+
+	// number of mock blocks committed so far, used to version keys
+	blockNum uint64
+
+	// rwset of the transaction in progress
+	txRWSet *mockTxRWSet
+
+	// when set, writes are only recorded in the rwset and reads only see
+	// committed state, see MockSimulateInvoke
+	simulating bool
+
+	// writes buffered between StartWriteBatch and FinishWriteBatch
+	writeBatch *writeBatch
+
+	// set while this stub is invoked through InvokeChaincodeReadOnly; any
+	// write is then rejected and remembered in writeAttempted
+	readOnly       bool
+	writeAttempted bool
+}
+
+// MockTxSimulation is the result of simulating a transaction with
+// MockSimulateInvoke. It can later be committed with MockCommitTransactions.
+type MockTxSimulation struct {
+	TxID        string
+	Response    pb.Response
+	RWSet       *kvrwset.KVRWSet
+	TxTimestamp *timestamp.Timestamp
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -101,10 +140,26 @@ func (stub *MockStub) MockTransactionStart(txid string) {
 	stub.TxID = txid
 	stub.setSignedProposal(&pb.SignedProposal{})
 	stub.setTxTimestamp(util.CreateUtcTimestamp())
+	stub.txRWSet = newMockTxRWSet()
 }
 
 // End a mocked transaction, clearing the UUID.
+// Writes still buffered by StartWriteBatch are applied, the writes of the
+// transaction are added to the key history and the transaction's read/write
+// set is recorded in TxRWSets.
 func (stub *MockStub) MockTransactionEnd(uuid string) {
+	if err := stub.FinishWriteBatch(); err != nil {
+		mockLogger.Error("MockStub", stub.Name, "failed to apply buffered writes:", err)
+	}
+	if stub.txRWSet != nil && stub.TxID != "" {
+		rwset := stub.txRWSet.toKVRWSet()
+		stub.TxRWSets[stub.TxID] = rwset
+		if !stub.simulating {
+			stub.commitWrites(stub.TxID, stub.TxTimestamp, rwset.Writes, &kvrwset.Version{BlockNum: stub.blockNum})
+			stub.blockNum++
+		}
+	}
+	stub.txRWSet = nil
 	stub.signedProposal = nil
 	stub.TxID = ""
 }
@@ -144,21 +199,175 @@ func (stub *MockStub) MockInvokeWithSignedProposal(uuid string, args [][]byte, s
 	return res
 }
 
+// MockSimulateInvoke invokes this chaincode the way an endorser would: reads
+// only see committed state and writes are recorded in the returned read/write
+// set without being applied. Several transactions can thus be simulated
+// against the same state and then validated against each other with
+// MockCommitTransactions.
+func (stub *MockStub) MockSimulateInvoke(uuid string, args [][]byte) *MockTxSimulation {
+	stub.args = args
+	stub.simulating = true
+	stub.MockTransactionStart(uuid)
+	sim := &MockTxSimulation{TxID: uuid, TxTimestamp: stub.TxTimestamp}
+	sim.Response = stub.cc.Invoke(stub)
+	stub.FinishWriteBatch()
+	sim.RWSet = stub.txRWSet.toKVRWSet()
+	stub.MockTransactionEnd(uuid)
+	stub.simulating = false
+	return sim
+}
+
+// MockCommitTransactions validates simulated transactions in order as a
+// single block, in the same way the committing peer does: a transaction is
+// invalidated with MVCC_READ_CONFLICT if a key it read has been changed since
+// simulation, either by a previously committed block or by a preceding valid
+// transaction of this block, and with PHANTOM_READ_CONFLICT if the result of
+// one of its range queries has changed. Transactions whose response is an
+// error are marked INVALID_OTHER_REASON. The writes of valid transactions are
+// applied to the state and the validation code of each transaction is
+// returned.
+func (stub *MockStub) MockCommitTransactions(sims ...*MockTxSimulation) []pb.TxValidationCode {
+	codes := make([]pb.TxValidationCode, len(sims))
+	// versions of the keys updated by the valid transactions of this block
+	updates := make(map[string]*kvrwset.Version)
+	for txNum, sim := range sims {
+		codes[txNum] = stub.validateSimulation(sim, updates)
+		if codes[txNum] != pb.TxValidationCode_VALID {
+			mockLogger.Debug("MockStub", stub.Name, "Transaction", sim.TxID, "invalidated with", codes[txNum])
+			continue
+		}
+		height := &kvrwset.Version{BlockNum: stub.blockNum, TxNum: uint64(txNum)}
+		for _, w := range sim.RWSet.Writes {
+			updates[w.Key] = height
+		}
+		stub.commitWrites(sim.TxID, sim.TxTimestamp, sim.RWSet.Writes, height)
+	}
+	stub.blockNum++
+	return codes
+}
+
+func (stub *MockStub) validateSimulation(sim *MockTxSimulation, updates map[string]*kvrwset.Version) pb.TxValidationCode {
+	if sim.Response.Status >= ERROR || sim.RWSet == nil {
+		return pb.TxValidationCode_INVALID_OTHER_REASON
+	}
+	for _, read := range sim.RWSet.Reads {
+		if _, ok := updates[read.Key]; ok {
+			return pb.TxValidationCode_MVCC_READ_CONFLICT
+		}
+		if !sameVersion(stub.versions[read.Key], read.Version) {
+			return pb.TxValidationCode_MVCC_READ_CONFLICT
+		}
+	}
+	for _, rqi := range sim.RWSet.RangeQueriesInfo {
+		// the range is re-executed over the committed state, which at this
+		// point already includes the writes of the preceding valid
+		// transactions of the block
+		current := stub.rangeReads(rqi.StartKey, rqi.EndKey)
+		if !sameReads(current, rqi.GetRawReads().GetKvReads()) {
+			return pb.TxValidationCode_PHANTOM_READ_CONFLICT
+		}
+	}
+	return pb.TxValidationCode_VALID
+}
+
+// commitWrites applies a write set to the state, versions and history
+func (stub *MockStub) commitWrites(txid string, ts *timestamp.Timestamp, writes []*kvrwset.KVWrite, height *kvrwset.Version) {
+	for _, w := range writes {
+		if w.IsDelete {
+			stub.deleteKey(w.Key)
+			delete(stub.versions, w.Key)
+		} else {
+			stub.putKey(w.Key, w.Value)
+			stub.versions[w.Key] = height
+		}
+		stub.history[w.Key] = append(stub.history[w.Key], &queryresult.KeyModification{
+			TxId:      txid,
+			Value:     w.Value,
+			Timestamp: ts,
+			IsDelete:  w.IsDelete,
+		})
+	}
+}
+
+// rangeReads returns the committed keys and versions covered by a range
+// query, with the same bounds as MockStateRangeQueryIterator
+func (stub *MockStub) rangeReads(startKey, endKey string) []*kvrwset.KVRead {
+	var reads []*kvrwset.KVRead
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(string)
+		if startKey == "" && endKey == "" ||
+			strings.Compare(key, startKey) >= 0 && strings.Compare(key, endKey) <= 0 {
+			reads = append(reads, &kvrwset.KVRead{Key: key, Version: stub.versions[key]})
+		}
+	}
+	return reads
+}
+
+func sameReads(a, b []*kvrwset.KVRead) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || !sameVersion(a[i].Version, b[i].Version) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameVersion(a, b *kvrwset.Version) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.BlockNum == b.BlockNum && a.TxNum == b.TxNum
+}
+
 // GetState retrieves the value for a given key from the ledger
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
+	if stub.txRWSet != nil {
+		stub.txRWSet.addRead(key, stub.versions[key])
+	}
 	mockLogger.Debug("MockStub", stub.Name, "Getting", key, value)
 	return value, nil
 }
 
+// GetMultipleStates retrieves the values for the given keys from the ledger
+func (stub *MockStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i], _ = stub.GetState(key)
+	}
+	return values, nil
+}
+
 // PutState writes the specified `value` and `key` into the ledger.
 func (stub *MockStub) PutState(key string, value []byte) error {
 	if stub.TxID == "" {
 		mockLogger.Error("Cannot PutState without a transactions - call stub.MockTransactionStart()?")
 		return errors.New("Cannot PutState without a transactions - call stub.MockTransactionStart()?")
 	}
+	if stub.readOnly {
+		return stub.rejectWrite(key)
+	}
+	if stub.writeBatch != nil {
+		stub.writeBatch.put(key, value)
+		return nil
+	}
 
 	mockLogger.Debug("MockStub", stub.Name, "Putting", key, value)
+	if stub.txRWSet != nil {
+		stub.txRWSet.addWrite(key, value, false)
+	}
+	if stub.simulating {
+		return nil
+	}
+	stub.putKey(key, value)
+	return nil
+}
+
+// putKey stores a value and inserts its key in the ordered list of keys
+func (stub *MockStub) putKey(key string, value []byte) {
 	stub.State[key] = value
 
 	// insert key into ordered list of keys
@@ -190,13 +399,30 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 		stub.Keys.PushFront(key)
 		mockLogger.Debug("MockStub", stub.Name, "Key", key, "is first element in list")
 	}
-
-	return nil
 }
 
 // DelState removes the specified `key` and its value from the ledger.
 func (stub *MockStub) DelState(key string) error {
+	if stub.readOnly {
+		return stub.rejectWrite(key)
+	}
+	if stub.writeBatch != nil {
+		stub.writeBatch.del(key)
+		return nil
+	}
 	mockLogger.Debug("MockStub", stub.Name, "Deleting", key, stub.State[key])
+	if stub.txRWSet != nil {
+		stub.txRWSet.addWrite(key, nil, true)
+	}
+	if stub.simulating {
+		return nil
+	}
+	stub.deleteKey(key)
+	return nil
+}
+
+// deleteKey removes a value and its key from the ordered list of keys
+func (stub *MockStub) deleteKey(key string) {
 	delete(stub.State, key)
 
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
@@ -204,7 +430,34 @@ func (stub *MockStub) DelState(key string) error {
 			stub.Keys.Remove(elem)
 		}
 	}
+}
+
+// StartWriteBatch buffers subsequent PutState and DelState calls until
+// FinishWriteBatch or the end of the mock transaction.
+func (stub *MockStub) StartWriteBatch() {
+	if stub.writeBatch == nil {
+		stub.writeBatch = newWriteBatch()
+	}
+}
 
+// FinishWriteBatch applies the writes buffered since StartWriteBatch.
+func (stub *MockStub) FinishWriteBatch() error {
+	batch := stub.writeBatch
+	stub.writeBatch = nil
+	if batch == nil {
+		return nil
+	}
+	writes := batch.toPutStateMultiple()
+	for _, put := range writes.Puts {
+		if err := stub.PutState(put.Key, put.Value); err != nil {
+			return err
+		}
+	}
+	for _, key := range writes.Deletes {
+		if err := stub.DelState(key); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -212,25 +465,51 @@ func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIterat
 	if err := validateSimpleKeys(startKey, endKey); err != nil {
 		return nil, err
 	}
+	stub.recordRangeQuery(startKey, endKey)
 	return NewMockStateRangeQueryIterator(stub, startKey, endKey), nil
 }
 
+// recordRangeQuery adds a range query and the keys it covers to the rwset of
+// the transaction in progress
+func (stub *MockStub) recordRangeQuery(startKey, endKey string) {
+	if stub.txRWSet == nil {
+		return
+	}
+	rqi := &kvrwset.RangeQueryInfo{StartKey: startKey, EndKey: endKey, ItrExhausted: true}
+	rqi.ReadsInfo = &kvrwset.RangeQueryInfo_RawReads{RawReads: &kvrwset.QueryReads{KvReads: stub.rangeReads(startKey, endKey)}}
+	stub.txRWSet.rangeQueriesInfo = append(stub.txRWSet.rangeQueriesInfo, rqi)
+}
+
 // GetQueryResult function can be invoked by a chaincode to perform a
 // rich query against state database.  Only supported by state database implementations
 // that support rich query.  The query string is in the syntax of the underlying
 // state database. An iterator is returned which can be used to iterate (next) over
 // the query result set
+// The mock supports a subset of the CouchDB Mango query syntax over JSON
+// values, see mockQuery. As on the peer, the results are not recorded in the
+// read set of the transaction.
 func (stub *MockStub) GetQueryResult(query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("Not Implemented")
+	keys := make([]string, 0, stub.Keys.Len())
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, elem.Value.(string))
+	}
+	results, err := executeMockQuery(query, keys, func(key string) []byte { return stub.State[key] })
+	if err != nil {
+		mockLogger.Error("MockStub", stub.Name, "GetQueryResult failed:", err)
+		return nil, err
+	}
+	return &MockQueryResultIterator{Results: results}, nil
 }
 
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
 // key values across time. GetHistoryForKey is intended to be used for read-only queries.
+// The mock returns the modifications made by the mock transactions that
+// have ended so far, oldest first.
 func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	history := stub.history[key]
+	mods := make([]*queryresult.KeyModification, len(history))
+	copy(mods, history)
+	return &MockHistoryQueryIterator{Modifications: mods}, nil
 }
 
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
@@ -244,6 +523,7 @@ func (stub *MockStub) GetStateByPartialCompositeKey(objectType string, attribute
 	if err != nil {
 		return nil, err
 	}
+	stub.recordRangeQuery(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))
 	return NewMockStateRangeQueryIterator(stub, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue)), nil
 }
 
@@ -259,17 +539,30 @@ func (stub *MockStub) SplitCompositeKey(compositeKey string) (string, []string,
 	return splitCompositeKey(compositeKey)
 }
 
+// rejectWrite remembers and reports a write made during a read-only invocation
+func (stub *MockStub) rejectWrite(key string) error {
+	stub.writeAttempted = true
+	return fmt.Errorf("Cannot write key %s: chaincode %s was invoked read-only", key, stub.Name)
+}
+
 // InvokeChaincode calls a peered chaincode.
 // E.g. stub1.InvokeChaincode("stub2Hash", funcArgs, channel)
 // Before calling this make sure to create another MockStub stub2, call stub2.MockInit(uuid, func, args)
 // and register it with stub1 by calling stub1.MockPeerChaincode("stub2Hash", stub2)
 func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	if stub.writeBatch != nil {
+		stub.FinishWriteBatch()
+		stub.StartWriteBatch()
+	}
 	// Internally we use chaincode name as a composite name
 	if channel != "" {
 		chaincodeName = chaincodeName + "/" + channel
 	}
 	// TODO "args" here should possibly be a serialized pb.ChaincodeInput
-	otherStub := stub.Invokables[chaincodeName]
+	otherStub, exists := stub.Invokables[chaincodeName]
+	if !exists {
+		return Error(fmt.Sprintf("chaincode %s is not registered with MockPeerChaincode", chaincodeName))
+	}
 	mockLogger.Debug("MockStub", stub.Name, "Invoking peer chaincode", otherStub.Name, args)
 	//	function, strings := getFuncArgs(args)
 	res := otherStub.MockInvoke(stub.TxID, args)
@@ -277,6 +570,26 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
+// InvokeChaincodeReadOnly calls a peered chaincode like InvokeChaincode but
+// fails if the called chaincode attempts to write state.
+func (stub *MockStub) InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response {
+	if channel != "" {
+		chaincodeName = chaincodeName + "/" + channel
+	}
+	otherStub, exists := stub.Invokables[chaincodeName]
+	if !exists {
+		return Error(fmt.Sprintf("chaincode %s is not registered with MockPeerChaincode", chaincodeName))
+	}
+	otherStub.readOnly = true
+	defer func() { otherStub.readOnly = false }()
+	res := stub.InvokeChaincode(chaincodeName, args, "")
+	if res.Status < ERROR && otherStub.writeAttempted {
+		res = Error(fmt.Sprintf("chaincode %s attempted to write state during a read-only invocation", chaincodeName))
+	}
+	otherStub.writeAttempted = false
+	return res
+}
+
 // Not implemented
 func (stub *MockStub) GetCreator() ([]byte, error) {
 	return nil, nil
@@ -331,10 +644,63 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	s.State = make(map[string][]byte)
 	s.Invokables = make(map[string]*MockStub)
 	s.Keys = list.New()
+	s.TxRWSets = make(map[string]*kvrwset.KVRWSet)
+	s.history = make(map[string][]*queryresult.KeyModification)
+	s.versions = make(map[string]*kvrwset.Version)
 
 	return s
 }
 
+/*****************************
+ Transaction Read/Write Set
+*****************************/
+
+// mockTxRWSet accumulates the reads and writes of a mock transaction
+type mockTxRWSet struct {
+	reads            map[string]*kvrwset.KVRead
+	writes           map[string]*kvrwset.KVWrite
+	rangeQueriesInfo []*kvrwset.RangeQueryInfo
+}
+
+func newMockTxRWSet() *mockTxRWSet {
+	return &mockTxRWSet{
+		reads:  make(map[string]*kvrwset.KVRead),
+		writes: make(map[string]*kvrwset.KVWrite),
+	}
+}
+
+func (rws *mockTxRWSet) addRead(key string, version *kvrwset.Version) {
+	rws.reads[key] = &kvrwset.KVRead{Key: key, Version: version}
+}
+
+func (rws *mockTxRWSet) addWrite(key string, value []byte, isDelete bool) {
+	rws.writes[key] = &kvrwset.KVWrite{Key: key, Value: value, IsDelete: isDelete}
+}
+
+// toKVRWSet returns the recorded reads and writes sorted by key, as the
+// peer's rwset builder does
+func (rws *mockTxRWSet) toKVRWSet() *kvrwset.KVRWSet {
+	kvrws := &kvrwset.KVRWSet{RangeQueriesInfo: rws.rangeQueriesInfo}
+	var keys []string
+	for key := range rws.reads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		kvrws.Reads = append(kvrws.Reads, rws.reads[key])
+	}
+
+	keys = nil
+	for key := range rws.writes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		kvrws.Writes = append(kvrws.Writes, rws.writes[key])
+	}
+	return kvrws
+}
+
 /*****************************
  Range Query Iterator
 *****************************/
@@ -406,9 +772,9 @@ func (iter *MockStateRangeQueryIterator) Next() (*queryresult.KV, error) {
 		// all keys, it should always return the key and value
 		if (comp1 >= 0 && comp2 <= 0) || (iter.StartKey == "" && iter.EndKey == "") {
 			key := iter.Current.Value.(string)
-			value, err := iter.Stub.GetState(key)
+			value := iter.Stub.State[key]
 			iter.Current = iter.Current.Next()
-			return &queryresult.KV{Key: key, Value: value}, err
+			return &queryresult.KV{Key: key, Value: value}, nil
 		}
 		iter.Current = iter.Current.Next()
 	}
@@ -453,6 +819,76 @@ func NewMockStateRangeQueryIterator(stub *MockStub, startKey string, endKey stri
 	return iter
 }
 
+/*****************************
+ Rich Query Iterator
+*****************************/
+
+// MockQueryResultIterator iterates over the results of a rich query
+type MockQueryResultIterator struct {
+	Closed  bool
+	Results []*queryresult.KV
+}
+
+// HasNext returns true if the query result iterator contains additional keys
+// and values.
+func (iter *MockQueryResultIterator) HasNext() bool {
+	return !iter.Closed && len(iter.Results) > 0
+}
+
+// Next returns the next key and value in the query result iterator.
+func (iter *MockQueryResultIterator) Next() (*queryresult.KV, error) {
+	if !iter.HasNext() {
+		return nil, errors.New("MockQueryResultIterator.Next() called when it does not HaveNext()")
+	}
+	kv := iter.Results[0]
+	iter.Results = iter.Results[1:]
+	return kv, nil
+}
+
+// Close closes the query result iterator.
+func (iter *MockQueryResultIterator) Close() error {
+	if iter.Closed {
+		return errors.New("MockQueryResultIterator.Close() called after Close()")
+	}
+	iter.Closed = true
+	return nil
+}
+
+/*****************************
+ History Query Iterator
+*****************************/
+
+// MockHistoryQueryIterator iterates over the modifications of a key
+type MockHistoryQueryIterator struct {
+	Closed        bool
+	Modifications []*queryresult.KeyModification
+}
+
+// HasNext returns true if the history query iterator contains additional
+// modifications.
+func (iter *MockHistoryQueryIterator) HasNext() bool {
+	return !iter.Closed && len(iter.Modifications) > 0
+}
+
+// Next returns the next modification in the history query iterator.
+func (iter *MockHistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	if !iter.HasNext() {
+		return nil, errors.New("MockHistoryQueryIterator.Next() called when it does not HaveNext()")
+	}
+	km := iter.Modifications[0]
+	iter.Modifications = iter.Modifications[1:]
+	return km, nil
+}
+
+// Close closes the history query iterator.
+func (iter *MockHistoryQueryIterator) Close() error {
+	if iter.Closed {
+		return errors.New("MockHistoryQueryIterator.Close() called after Close()")
+	}
+	iter.Closed = true
+	return nil
+}
+
 func getBytes(function string, args []string) [][]byte {
 	bytes := make([][]byte, 0, len(args)+1)
 	bytes = append(bytes, []byte(function))
@@ -23,7 +23,9 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMockStateRangeQueryIterator(t *testing.T) {
@@ -257,6 +259,7 @@ func TestMockMock(t *testing.T) {
 	stub2 := NewMockStub("othercc", &shimTestCC{})
 	stub.MockPeerChaincode("othercc/mychan", stub2)
 	stub.InvokeChaincode("othercc", nil, "mychan")
+	stub.InvokeChaincodeReadOnly("othercc", nil, "mychan")
 	stub.GetCreator()
 	stub.GetTransient()
 	stub.GetBinding()
@@ -270,3 +273,296 @@ func TestMockMock(t *testing.T) {
 	getBytes("f", []string{"a", "b"})
 	getFuncArgs([][]byte{[]byte("a")})
 }
+
+// mockFuncCC is a chaincode whose Init and Invoke run the same function
+type mockFuncCC func(stub ChaincodeStubInterface) pb.Response
+
+func (cc mockFuncCC) Init(stub ChaincodeStubInterface) pb.Response {
+	return cc(stub)
+}
+
+func (cc mockFuncCC) Invoke(stub ChaincodeStubInterface) pb.Response {
+	return cc(stub)
+}
+
+func putMarbles(t *testing.T, stub *MockStub, marbles ...*Marble) {
+	stub.MockTransactionStart("marbles")
+	for _, m := range marbles {
+		marbleJSONBytes, err := json.Marshal(m)
+		assert.NoError(t, err)
+		assert.NoError(t, stub.PutState(m.Name, marbleJSONBytes))
+	}
+	stub.MockTransactionEnd("marbles")
+}
+
+func queryKeys(t *testing.T, stub *MockStub, query string) []string {
+	iter, err := stub.GetQueryResult(query)
+	assert.NoError(t, err)
+	defer iter.Close()
+	keys := []string{}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	return keys
+}
+
+func TestMockStubGetQueryResult(t *testing.T) {
+	stub := NewMockStub("queryTest", nil)
+	putMarbles(t, stub,
+		&Marble{"marble", "marble1", "red", 5, "tom"},
+		&Marble{"marble", "marble2", "blue", 35, "tom"},
+		&Marble{"marble", "marble3", "red", 20, "jerry"},
+		&Marble{"car", "car1", "red", 100, "tom"})
+	stub.MockTransactionStart("binary")
+	stub.PutState("binary", []byte{0x00, 0x01})
+	stub.MockTransactionEnd("binary")
+
+	assert.Equal(t, []string{"marble1", "marble3"},
+		queryKeys(t, stub, `{"selector":{"docType":"marble","color":"red"}}`))
+	assert.Equal(t, []string{"marble2", "marble3"},
+		queryKeys(t, stub, `{"selector":{"docType":"marble","size":{"$gt":10}}}`))
+	assert.Equal(t, []string{"car1", "marble1", "marble2"},
+		queryKeys(t, stub, `{"selector":{"$or":[{"owner":"tom"},{"size":{"$lte":0}}]}}`))
+	assert.Equal(t, []string{"marble2"},
+		queryKeys(t, stub, `{"selector":{"owner":{"$in":["jerry","tom"]},"color":{"$ne":"red"}}}`))
+	assert.Equal(t, []string{"marble3", "marble1"},
+		queryKeys(t, stub, `{"selector":{"color":"red","docType":{"$regex":"^mar"}},"sort":[{"size":"desc"}]}`))
+	assert.Equal(t, []string{"marble2"},
+		queryKeys(t, stub, `{"selector":{"docType":"marble"},"sort":["size"],"skip":2,"limit":1}`))
+	assert.Equal(t, []string{}, queryKeys(t, stub, `{"selector":{"weight":{"$exists":true}}}`))
+
+	iter, err := stub.GetQueryResult(`{"selector":{"name":"marble1"},"fields":["owner","size"]}`)
+	assert.NoError(t, err)
+	kv, err := iter.Next()
+	assert.NoError(t, err)
+	assert.True(t, jsonBytesEqual([]byte(`{"owner":"tom","size":5}`), kv.Value))
+	assert.False(t, iter.HasNext())
+
+	_, err = stub.GetQueryResult(`{"selector":{"size":{"$near":5}}}`)
+	assert.Error(t, err)
+	_, err = stub.GetQueryResult(`not json`)
+	assert.Error(t, err)
+	_, err = stub.GetQueryResult(`{"fields":["owner"]}`)
+	assert.Error(t, err)
+}
+
+func TestMockQueryValidation(t *testing.T) {
+	// unknown operators are reported whatever the other conditions and the
+	// map iteration order
+	for i := 0; i < 20; i++ {
+		for _, query := range []string{
+			`{"selector":{"a":1,"b":{"$bogus":1}}}`,
+			`{"selector":{"a":{"$gt":1,"$bogus":2}}}`,
+			`{"selector":{"$and":[{"a":1},{"b":{"$bogus":1}}]}}`,
+			`{"selector":{"$or":[{"a":1},{"b":{"$elemMatch":{"$bogus":1}}}]}}`,
+		} {
+			_, _, err := parseMockQuery(query)
+			assert.Error(t, err, query)
+		}
+	}
+	_, _, err := parseMockQuery(`{"selector":{"a":{"$gt":1,"$lt":5},"b":{"$not":{"$in":[1,2]}}}}`)
+	assert.NoError(t, err)
+}
+
+func TestCompareJSONObjects(t *testing.T) {
+	decode := func(s string) interface{} {
+		doc, ok := decodeMockDocument([]byte(s))
+		assert.True(t, ok)
+		return doc
+	}
+	a, b := decode(`{"x":1,"y":2}`), decode(`{"x":1,"y":3}`)
+	assert.Equal(t, -1, compareJSON(a, b))
+	assert.Equal(t, 1, compareJSON(b, a))
+	assert.Equal(t, 0, compareJSON(a, decode(`{"y":2.0,"x":1}`)))
+	assert.True(t, compareJSON(a, decode(`{"x":1}`)) > 0)
+	assert.True(t, compareJSON(decode(`{"x":1}`), a) < 0)
+}
+
+func TestMockStubHistoryAndRWSet(t *testing.T) {
+	stub := NewMockStub("historyTest", nil)
+	stub.MockTransactionStart("tx1")
+	stub.PutState("a", []byte("1"))
+	stub.PutState("a", []byte("2"))
+	stub.PutState("b", []byte("1"))
+	stub.MockTransactionEnd("tx1")
+
+	stub.MockTransactionStart("tx2")
+	stub.GetState("a")
+	stub.GetStateByRange("a", "b")
+	stub.DelState("b")
+	stub.MockTransactionEnd("tx2")
+
+	iter, err := stub.GetHistoryForKey("a")
+	assert.NoError(t, err)
+	km, err := iter.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", km.TxId)
+	assert.Equal(t, []byte("2"), km.Value)
+	assert.False(t, iter.HasNext())
+	assert.NoError(t, iter.Close())
+
+	iter, err = stub.GetHistoryForKey("b")
+	assert.NoError(t, err)
+	var mods []string
+	for iter.HasNext() {
+		km, _ := iter.Next()
+		mods = append(mods, fmt.Sprintf("%s:%s:%t", km.TxId, km.Value, km.IsDelete))
+	}
+	assert.Equal(t, []string{"tx1:1:false", "tx2::true"}, mods)
+
+	rwset := stub.TxRWSets["tx1"]
+	assert.NotNil(t, rwset)
+	assert.Len(t, rwset.Reads, 0)
+	assert.Len(t, rwset.Writes, 2)
+	assert.Equal(t, "a", rwset.Writes[0].Key)
+	assert.Equal(t, []byte("2"), rwset.Writes[0].Value)
+
+	rwset = stub.TxRWSets["tx2"]
+	assert.NotNil(t, rwset)
+	assert.Len(t, rwset.Reads, 1)
+	assert.Equal(t, uint64(0), rwset.Reads[0].Version.BlockNum)
+	assert.Len(t, rwset.RangeQueriesInfo, 1)
+	assert.Len(t, rwset.RangeQueriesInfo[0].GetRawReads().KvReads, 2)
+	assert.Len(t, rwset.Writes, 1)
+	assert.True(t, rwset.Writes[0].IsDelete)
+}
+
+func TestMockStubMVCCValidation(t *testing.T) {
+	cc := mockFuncCC(func(stub ChaincodeStubInterface) pb.Response {
+		function, args := stub.GetFunctionAndParameters()
+		switch function {
+		case "move":
+			from, _ := stub.GetState(args[0])
+			to, _ := stub.GetState(args[1])
+			if len(from) == 0 {
+				return Error("nothing to move")
+			}
+			stub.PutState(args[0], from[1:])
+			stub.PutState(args[1], append(to, from[0]))
+		case "scan":
+			iter, _ := stub.GetStateByRange("a", "c")
+			iter.Close()
+			stub.PutState(args[0], []byte("scanned"))
+		}
+		return Success(nil)
+	})
+	stub := NewMockStub("mvccTest", cc)
+	stub.MockTransactionStart("init")
+	stub.PutState("a", []byte("xxx"))
+	stub.PutState("b", []byte(""))
+	stub.PutState("c", []byte(""))
+	stub.MockTransactionEnd("init")
+
+	sim1 := stub.MockSimulateInvoke("tx1", [][]byte{[]byte("move"), []byte("a"), []byte("b")})
+	sim2 := stub.MockSimulateInvoke("tx2", [][]byte{[]byte("move"), []byte("a"), []byte("c")})
+	sim3 := stub.MockSimulateInvoke("tx3", [][]byte{[]byte("scan"), []byte("d")})
+	sim4 := stub.MockSimulateInvoke("tx4", [][]byte{[]byte("move"), []byte("d"), []byte("a")})
+	assert.Equal(t, int32(ERROR), sim4.Response.Status)
+
+	// simulation must not change the state
+	assert.Equal(t, []byte("xxx"), stub.State["a"])
+	assert.Nil(t, stub.State["d"])
+
+	codes := stub.MockCommitTransactions(sim1, sim2, sim3, sim4)
+	assert.Equal(t, []pb.TxValidationCode{
+		pb.TxValidationCode_VALID,
+		pb.TxValidationCode_MVCC_READ_CONFLICT,
+		pb.TxValidationCode_PHANTOM_READ_CONFLICT,
+		pb.TxValidationCode_INVALID_OTHER_REASON,
+	}, codes)
+	assert.Equal(t, []byte("xx"), stub.State["a"])
+	assert.Equal(t, []byte("x"), stub.State["b"])
+	assert.Equal(t, []byte(""), stub.State["c"])
+	assert.Nil(t, stub.State["d"])
+
+	// re-simulating the conflicting transactions against the new state succeeds
+	sim2 = stub.MockSimulateInvoke("tx2", [][]byte{[]byte("move"), []byte("a"), []byte("c")})
+	sim3 = stub.MockSimulateInvoke("tx3", [][]byte{[]byte("scan"), []byte("d")})
+	codes = stub.MockCommitTransactions(sim2, sim3)
+	assert.Equal(t, []pb.TxValidationCode{pb.TxValidationCode_VALID, pb.TxValidationCode_PHANTOM_READ_CONFLICT}, codes)
+	assert.Equal(t, []byte("x"), stub.State["c"])
+
+	iter, _ := stub.GetHistoryForKey("a")
+	var txids []string
+	for iter.HasNext() {
+		km, _ := iter.Next()
+		txids = append(txids, km.TxId)
+	}
+	assert.Equal(t, []string{"init", "tx1", "tx2"}, txids)
+}
+
+func TestMockStubBatchStateCalls(t *testing.T) {
+	stub := NewMockStub("batchTest", nil)
+	stub.MockTransactionStart("init")
+	stub.PutState("a", []byte("1"))
+	stub.PutState("b", []byte("2"))
+	stub.MockTransactionEnd("init")
+
+	values, err := stub.GetMultipleStates("a", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2"), nil}, values)
+
+	stub.MockTransactionStart("tx1")
+	stub.StartWriteBatch()
+	stub.PutState("a", []byte("10"))
+	stub.PutState("a", []byte("11"))
+	stub.DelState("b")
+	stub.PutState("c", []byte("3"))
+	// buffered writes are not visible until the batch is sent
+	assert.Equal(t, []byte("1"), stub.State["a"])
+	assert.NoError(t, stub.FinishWriteBatch())
+	assert.Equal(t, []byte("11"), stub.State["a"])
+	assert.Nil(t, stub.State["b"])
+
+	stub.StartWriteBatch()
+	stub.PutState("d", []byte("4"))
+	stub.MockTransactionEnd("tx1")
+	assert.Equal(t, []byte("4"), stub.State["d"])
+	assert.Len(t, stub.TxRWSets["tx1"].Writes, 4)
+}
+
+func TestMockStubInvokeChaincodeReadOnly(t *testing.T) {
+	callee := NewMockStub("callee", mockFuncCC(func(stub ChaincodeStubInterface) pb.Response {
+		_, args := stub.GetFunctionAndParameters()
+		value, _ := stub.GetState("a")
+		if len(args) > 0 {
+			// the error is deliberately ignored, the call must fail anyway
+			stub.PutState("a", []byte(args[0]))
+		}
+		return Success(value)
+	}))
+	callee.MockTransactionStart("init")
+	callee.PutState("a", []byte("1"))
+	callee.MockTransactionEnd("init")
+
+	caller := NewMockStub("caller", mockFuncCC(func(stub ChaincodeStubInterface) pb.Response {
+		args := stub.GetArgs()
+		return stub.InvokeChaincodeReadOnly("callee", args, "")
+	}))
+	caller.MockPeerChaincode("callee", callee)
+
+	res := caller.MockInvoke("tx1", [][]byte{[]byte("get")})
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Equal(t, []byte("1"), res.Payload)
+
+	res = caller.MockInvoke("tx2", [][]byte{[]byte("set"), []byte("2")})
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Contains(t, res.Message, "read-only")
+	assert.Equal(t, []byte("1"), callee.State["a"])
+
+	// a regular invocation may still write
+	res = callee.MockInvoke("tx3", [][]byte{[]byte("set"), []byte("3")})
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Equal(t, []byte("3"), callee.State["a"])
+
+	// invoking a chaincode which was not registered fails
+	for _, invoke := range []func(string, [][]byte, string) pb.Response{caller.InvokeChaincode, caller.InvokeChaincodeReadOnly} {
+		res = invoke("unknown", nil, "")
+		assert.Equal(t, int32(ERROR), res.Status)
+		assert.Contains(t, res.Message, "unknown")
+		res = invoke("callee", nil, "otherchannel")
+		assert.Equal(t, int32(ERROR), res.Status)
+	}
+}
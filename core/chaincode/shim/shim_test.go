@@ -93,6 +93,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "batch" {
+		return t.batch(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	}
@@ -157,6 +159,25 @@ func (t *shimTestCC) invoke(stub ChaincodeStubInterface, args []string) pb.Respo
 }
 
 // Deletes an entity from state
+// batch swaps the values of the given keys using a single read and a single
+// write round trip
+func (t *shimTestCC) batch(stub ChaincodeStubInterface, args []string) pb.Response {
+	values, err := stub.GetMultipleStates(args...)
+	if err != nil {
+		return Error(err.Error())
+	}
+	stub.StartWriteBatch()
+	for i, key := range args {
+		value := values[len(values)-1-i]
+		if value == nil {
+			stub.DelState(key)
+		} else {
+			stub.PutState(key, value)
+		}
+	}
+	return Success(nil)
+}
+
 func (t *shimTestCC) delete(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) != 1 {
 		return Error("Incorrect number of arguments. Expecting 1")
@@ -956,3 +977,78 @@ func TestRealPeerStream(t *testing.T) {
 	_, err := userChaincodeStreamGetter("fake")
 	assert.Error(t, err)
 }
+
+//TestBatchStateCalls tests GetMultipleStates and buffered writes
+func TestBatchStateCalls(t *testing.T) {
+	streamGetter = mockChaincodeStreamGetter
+	cc := &shimTestCC{}
+	var err error
+	ccname := "shimTestCCBatch"
+	peerSide := setupcc(ccname, cc)
+	defer mockPeerCCSupport.RemoveCC(ccname)
+	//start the shim+chaincode
+	go func() {
+		err = Start(cc)
+	}()
+
+	done := setuperror()
+
+	errorFunc := func(ind int, err error) {
+		done <- err
+	}
+
+	//start the mock peer
+	go func() {
+		respSet := &mockpeer.MockResponseSet{errorFunc, nil, []*mockpeer.MockResponse{
+			&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}}}}
+		peerSide.SetResponses(respSet)
+		peerSide.SetKeepAlive(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE})
+		err = peerSide.Run()
+	}()
+
+	//wait for init
+	processDone(t, done, false)
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY, Txid: "1"})
+
+	//good batch
+	values := utils.MarshalOrPanic(&pb.GetStateMultipleResponse{Values: [][]byte{[]byte("100"), nil}})
+	respSet := &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "2"}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: values, Txid: "2"}},
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Txid: "2"}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "2"}},
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "2"}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci := &pb.ChaincodeInput{[][]byte{[]byte("batch"), []byte("A"), []byte("B")}}
+	payload := utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "2"})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//bad get
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "3"}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "3"}},
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "3"}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "3"})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//bad put, the transaction fails
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "4"}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: values, Txid: "4"}},
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Txid: "4"}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4"}},
+		&mockpeer.MockResponse{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4"}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4"})
+
+	//wait for done
+	processDone(t, done, false)
+
+	time.Sleep(1 * time.Second)
+	peerSide.Quit()
+}
@@ -92,6 +92,11 @@ func (lc *LedgerCommitter) Commit(block *common.Block) error {
 		logger.Errorf("Error publishing block %d, because: %v", block.Header.Number, err)
 	}
 
+	// send the status of the transactions of the block to the clients who submitted them
+	if err := producer.SendProducerTxStatusEvents(block); err != nil {
+		logger.Errorf("Error publishing transaction status of block %d, because: %v", block.Header.Number, err)
+	}
+
 	return nil
 }
 
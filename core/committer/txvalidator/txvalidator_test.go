@@ -63,7 +63,7 @@ func TestBlockValidation(t *testing.T) {
 	}
 
 	mockVsccValidator := &validator.MockVsccValidator{}
-	tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, mockVsccValidator}
+	tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, mockVsccValidator, make(chan struct{}, 10)}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
@@ -85,7 +85,7 @@ func TestBlockValidation(t *testing.T) {
 			CIns:     upgradeChaincodeIns,
 			RespPayl: prespPaylBytes,
 		}
-		newTxValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, newMockVsccValidator}
+		newTxValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, newMockVsccValidator, make(chan struct{}, 10)}
 
 		// generate new block
 		newBlock := testutil.ConstructBlock(t, 2, block.Header.Hash(), [][]byte{simRes}, true) // contains one tx with chaincode version v1
@@ -108,7 +108,7 @@ func TestNewTxValidator_DuplicateTransactions(t *testing.T) {
 
 	defer ledger.Close()
 
-	tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, &validator.MockVsccValidator{}}
+	tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger}, &validator.MockVsccValidator{}, make(chan struct{}, 10)}
 
 	// Create simple endorsement transaction
 	payload := &common.Payload{
@@ -18,6 +18,8 @@ package txvalidator
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -35,6 +37,7 @@ import (
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
+	"github.com/spf13/viper"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -84,6 +87,8 @@ type vsccValidatorImpl struct {
 type txValidator struct {
 	support Support
 	vscc    vsccValidator
+	// sem bounds the number of transactions validated in parallel
+	sem chan struct{}
 }
 
 // VSCCInfoLookupFailureError error to indicate inability
@@ -134,7 +139,18 @@ func NewTxValidator(support Support) Validator {
 		&vsccValidatorImpl{
 			support:     support,
 			ccprovider:  ccprovider.GetChaincodeProvider(),
-			sccprovider: sysccprovider.GetSystemChaincodeProvider()}}
+			sccprovider: sysccprovider.GetSystemChaincodeProvider()},
+		make(chan struct{}, validatorPoolSize())}
+}
+
+// validatorPoolSize returns the number of transactions of a block
+// validated in parallel, which defaults to the number of CPUs
+func validatorPoolSize() int {
+	size := viper.GetInt("peer.validatorPoolSize")
+	if size <= 0 {
+		return runtime.NumCPU()
+	}
+	return size
 }
 
 func (v *txValidator) chainExists(chain string) bool {
@@ -142,6 +158,37 @@ func (v *txValidator) chainExists(chain string) bool {
 	return true
 }
 
+// blockValidationResult is the outcome of the validation of
+// the transaction at index tIdx of a block
+type blockValidationResult struct {
+	tIdx                 int
+	validationCode       peer.TxValidationCode
+	txid                 string
+	txsChaincodeName     *sysccprovider.ChaincodeInstance
+	txsUpgradedChaincode *sysccprovider.ChaincodeInstance
+	err                  error
+}
+
+// txIDLocks serializes the validation of transactions carrying the same
+// ID, since VSCC executions are keyed by transaction ID
+type txIDLocks struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (l *txIDLocks) lock(txID string) func() {
+	l.Lock()
+	txLock, ok := l.locks[txID]
+	if !ok {
+		txLock = &sync.Mutex{}
+		l.locks[txID] = txLock
+	}
+	l.Unlock()
+
+	txLock.Lock()
+	return txLock.Unlock
+}
+
 func (v *txValidator) Validate(block *common.Block) error {
 	logger.Debug("START Block Validation")
 	defer logger.Debug("END Block Validation")
@@ -151,116 +198,57 @@ func (v *txValidator) Validate(block *common.Block) error {
 	txsChaincodeNames := make(map[int]*sysccprovider.ChaincodeInstance)
 	// upgradedChaincodes records all the chaincodes that are upgrded in a block
 	txsUpgradedChaincodes := make(map[int]*sysccprovider.ChaincodeInstance)
-	for tIdx, d := range block.Data.Data {
-		if d != nil {
-			if env, err := utils.GetEnvelopeFromBlock(d); err != nil {
-				logger.Warningf("Error getting tx from block(%s)", err)
-				txsfltr.SetFlag(tIdx, peer.TxValidationCode_INVALID_OTHER_REASON)
-			} else if env != nil {
-				// validate the transaction: here we check that the transaction
-				// is properly formed, properly signed and that the security
-				// chain binding proposal to endorsements to tx holds. We do
-				// NOT check the validity of endorsements, though. That's a
-				// job for VSCC below
-				logger.Debug("Validating transaction peer.ValidateTransaction()")
-				var payload *common.Payload
-				var err error
-				var txResult peer.TxValidationCode
-
-				if payload, txResult = validation.ValidateTransaction(env); txResult != peer.TxValidationCode_VALID {
-					logger.Errorf("Invalid transaction with index %d", tIdx)
-					txsfltr.SetFlag(tIdx, txResult)
-					continue
-				}
-
-				chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
-				if err != nil {
-					logger.Warningf("Could not unmarshal channel header, err %s, skipping", err)
-					txsfltr.SetFlag(tIdx, peer.TxValidationCode_INVALID_OTHER_REASON)
-					continue
-				}
-
-				channel := chdr.ChannelId
-				logger.Debugf("Transaction is for chain %s", channel)
-
-				if !v.chainExists(channel) {
-					logger.Errorf("Dropping transaction for non-existent chain %s", channel)
-					txsfltr.SetFlag(tIdx, peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND)
-					continue
-				}
+	// txids records the ID of the valid transactions, by index
+	txids := make([]string, len(block.Data.Data))
+
+	// Validate the transactions in parallel, at most
+	// cap(v.sem) of them at a time
+	results := make(chan *blockValidationResult)
+	locks := &txIDLocks{locks: make(map[string]*sync.Mutex)}
+	go func() {
+		for tIdx, d := range block.Data.Data {
+			v.sem <- struct{}{}
+			go func(tIdx int, d []byte) {
+				defer func() { <-v.sem }()
+				results <- v.validateTx(block, tIdx, d, locks)
+			}(tIdx, d)
+		}
+	}()
 
-				if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
-					// Check duplicate transactions
-					txID := chdr.TxId
-					if _, err := v.support.Ledger().GetTransactionByID(txID); err == nil {
-						logger.Error("Duplicate transaction found, ", txID, ", skipping")
-						txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
-						continue
-					}
-
-					// Validate tx with vscc and policy
-					logger.Debug("Validating transaction vscc tx validate")
-					err, cde := v.vscc.VSCCValidateTx(payload, d, env)
-					if err != nil {
-						txID := txID
-						logger.Errorf("VSCCValidateTx for transaction txId = %s returned error %s", txID, err)
-						switch err.(type) {
-						case *VSCCExecutionFailureError:
-							return err
-						case *VSCCInfoLookupFailureError:
-							return err
-						default:
-							txsfltr.SetFlag(tIdx, cde)
-							continue
-						}
-					}
-
-					invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
-					if err != nil {
-						logger.Errorf("Get chaincode instance from transaction txId = %s returned error %s", txID, err)
-						txsfltr.SetFlag(tIdx, peer.TxValidationCode_INVALID_OTHER_REASON)
-						continue
-					}
-					txsChaincodeNames[tIdx] = invokeCC
-					if upgradeCC != nil {
-						logger.Infof("Find chaincode upgrade transaction for chaincode %s on chain %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
-						txsUpgradedChaincodes[tIdx] = upgradeCC
-					}
-				} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
-					configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
-					if err != nil {
-						err := fmt.Errorf("Error unmarshaling config which passed initial validity checks: %s", err)
-						logger.Critical(err)
-						return err
-					}
-
-					if err := v.support.Apply(configEnvelope); err != nil {
-						err := fmt.Errorf("Error validating config which passed initial validity checks: %s", err)
-						logger.Critical(err)
-						return err
-					}
-					logger.Debugf("config transaction received for chain %s", channel)
-				} else {
-					logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
-						common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-					txsfltr.SetFlag(tIdx, peer.TxValidationCode_UNKNOWN_TX_TYPE)
-					continue
-				}
+	// Collect all the results before returning, so that no
+	// validation outlives the call
+	var err error
+	errIdx := len(block.Data.Data)
+	for i := 0; i < len(block.Data.Data); i++ {
+		res := <-results
+
+		if res.err != nil {
+			// Report the error of the first failing transaction,
+			// whatever the order in which validations complete
+			if res.tIdx < errIdx {
+				err, errIdx = res.err, res.tIdx
+			}
+			continue
+		}
 
-				if _, err := proto.Marshal(env); err != nil {
-					logger.Warningf("Cannot marshal transaction due to %s", err)
-					txsfltr.SetFlag(tIdx, peer.TxValidationCode_MARSHAL_TX_ERROR)
-					continue
-				}
-				// Succeeded to pass down here, transaction is valid
-				txsfltr.SetFlag(tIdx, peer.TxValidationCode_VALID)
-			} else {
-				logger.Warning("Nil tx from block")
-				txsfltr.SetFlag(tIdx, peer.TxValidationCode_NIL_ENVELOPE)
+		txsfltr.SetFlag(res.tIdx, res.validationCode)
+		if res.validationCode == peer.TxValidationCode_VALID {
+			txids[res.tIdx] = res.txid
+			if res.txsChaincodeName != nil {
+				txsChaincodeNames[res.tIdx] = res.txsChaincodeName
+			}
+			if res.txsUpgradedChaincode != nil {
+				txsUpgradedChaincodes[res.tIdx] = res.txsUpgradedChaincode
 			}
 		}
 	}
 
+	if err != nil {
+		return err
+	}
+
+	markTXIdDuplicates(txids, txsfltr)
+
 	txsfltr = v.invalidTXsForUpgradeCC(txsChaincodeNames, txsUpgradedChaincodes, txsfltr)
 
 	// Initialize metadata structure
@@ -271,6 +259,134 @@ func (v *txValidator) Validate(block *common.Block) error {
 	return nil
 }
 
+// markTXIdDuplicates invalidates the transactions whose ID is the one of
+// an earlier valid transaction of the same block
+func markTXIdDuplicates(txids []string, txsfltr ledgerUtil.TxValidationFlags) {
+	txidMap := make(map[string]struct{})
+	for tIdx, txid := range txids {
+		if txid == "" {
+			continue
+		}
+
+		if _, exists := txidMap[txid]; exists {
+			logger.Errorf("Duplicate transaction found, %s, at index %d, skipping", txid, tIdx)
+			txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
+			continue
+		}
+		txidMap[txid] = struct{}{}
+	}
+}
+
+// validateTx validates the transaction d at index tIdx of block
+func (v *txValidator) validateTx(block *common.Block, tIdx int, d []byte, locks *txIDLocks) *blockValidationResult {
+	if d == nil {
+		// leave the flag of missing transactions untouched
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode(0)}
+	}
+
+	env, err := utils.GetEnvelopeFromBlock(d)
+	if err != nil {
+		logger.Warningf("Error getting tx from block(%s)", err)
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_INVALID_OTHER_REASON}
+	}
+	if env == nil {
+		logger.Warning("Nil tx from block")
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_NIL_ENVELOPE}
+	}
+
+	// validate the transaction: here we check that the transaction
+	// is properly formed, properly signed and that the security
+	// chain binding proposal to endorsements to tx holds. We do
+	// NOT check the validity of endorsements, though. That's a
+	// job for VSCC below
+	logger.Debug("Validating transaction peer.ValidateTransaction()")
+	payload, txResult := validation.ValidateTransaction(env)
+	if txResult != peer.TxValidationCode_VALID {
+		logger.Errorf("Invalid transaction with index %d", tIdx)
+		return &blockValidationResult{tIdx: tIdx, validationCode: txResult}
+	}
+
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Warningf("Could not unmarshal channel header, err %s, skipping", err)
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_INVALID_OTHER_REASON}
+	}
+
+	channel := chdr.ChannelId
+	logger.Debugf("Transaction is for chain %s", channel)
+
+	if !v.chainExists(channel) {
+		logger.Errorf("Dropping transaction for non-existent chain %s", channel)
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND}
+	}
+
+	result := &blockValidationResult{tIdx: tIdx, txid: chdr.TxId}
+	if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+		// Check duplicate transactions
+		txID := chdr.TxId
+		if _, err := v.support.Ledger().GetTransactionByID(txID); err == nil {
+			logger.Error("Duplicate transaction found, ", txID, ", skipping")
+			return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_DUPLICATE_TXID}
+		}
+
+		// Validate tx with vscc and policy
+		logger.Debug("Validating transaction vscc tx validate")
+		unlock := locks.lock(txID)
+		err, cde := v.vscc.VSCCValidateTx(payload, d, env)
+		unlock()
+		if err != nil {
+			logger.Errorf("VSCCValidateTx for transaction txId = %s returned error %s", txID, err)
+			switch err.(type) {
+			case *VSCCExecutionFailureError:
+				return &blockValidationResult{tIdx: tIdx, err: err}
+			case *VSCCInfoLookupFailureError:
+				return &blockValidationResult{tIdx: tIdx, err: err}
+			default:
+				return &blockValidationResult{tIdx: tIdx, validationCode: cde}
+			}
+		}
+
+		invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
+		if err != nil {
+			logger.Errorf("Get chaincode instance from transaction txId = %s returned error %s", txID, err)
+			return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_INVALID_OTHER_REASON}
+		}
+		result.txsChaincodeName = invokeCC
+		if upgradeCC != nil {
+			logger.Infof("Find chaincode upgrade transaction for chaincode %s on chain %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+			result.txsUpgradedChaincode = upgradeCC
+		}
+	} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
+		// Config transactions are alone in their block, so applying
+		// them cannot race with the validation of other transactions
+		configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+		if err != nil {
+			err := fmt.Errorf("Error unmarshaling config which passed initial validity checks: %s", err)
+			logger.Critical(err)
+			return &blockValidationResult{tIdx: tIdx, err: err}
+		}
+
+		if err := v.support.Apply(configEnvelope); err != nil {
+			err := fmt.Errorf("Error validating config which passed initial validity checks: %s", err)
+			logger.Critical(err)
+			return &blockValidationResult{tIdx: tIdx, err: err}
+		}
+		logger.Debugf("config transaction received for chain %s", channel)
+	} else {
+		logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
+			common.HeaderType(chdr.Type), block.Header.Number, tIdx)
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_UNKNOWN_TX_TYPE}
+	}
+
+	if _, err := proto.Marshal(env); err != nil {
+		logger.Warningf("Cannot marshal transaction due to %s", err)
+		return &blockValidationResult{tIdx: tIdx, validationCode: peer.TxValidationCode_MARSHAL_TX_ERROR}
+	}
+	// Succeeded to pass down here, transaction is valid
+	result.validationCode = peer.TxValidationCode_VALID
+	return result
+}
+
 // generateCCKey generates a unique identifier for chaincode in specific chain
 func (v *txValidator) generateCCKey(ccName, chainID string) string {
 	return fmt.Sprintf("%s/%s", ccName, chainID)
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
@@ -389,6 +390,111 @@ func TestInvokeNoBlock(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestParallelValidation(t *testing.T) {
+	viper.Set("peer.validatorPoolSize", 4)
+	defer viper.Set("peer.validatorPoolSize", 0)
+
+	l, v := setupLedgerAndValidator(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	// every third transaction has a bad read-write set
+	var data [][]byte
+	for i := 0; i < 30; i++ {
+		res := createRWset(t, ccID)
+		if i%3 == 0 {
+			res = []byte("barf")
+		}
+		data = append(data, utils.MarshalOrPanic(getEnv(ccID, res, t)))
+	}
+	b := &common.Block{Data: &common.BlockData{Data: data}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+
+	txsFilter := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i := range data {
+		if i%3 == 0 {
+			assert.True(t, txsFilter.IsSetTo(i, peer.TxValidationCode_BAD_RWSET), "Transaction %d should be invalid", i)
+		} else {
+			assert.True(t, txsFilter.IsValid(i), "Transaction %d should be valid", i)
+		}
+	}
+}
+
+func TestInvokeDuplicateTxIDInBlock(t *testing.T) {
+	l, v := setupLedgerAndValidator(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	tx := utils.MarshalOrPanic(getEnv(ccID, createRWset(t, ccID), t))
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{tx, tx, tx}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+
+	// only the first occurrence is valid, whatever the order of validation
+	txsFilter := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsFilter.IsValid(0))
+	assert.True(t, txsFilter.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
+	assert.True(t, txsFilter.IsSetTo(2, peer.TxValidationCode_DUPLICATE_TXID))
+}
+
+func TestValidatorPoolSize(t *testing.T) {
+	defer viper.Set("peer.validatorPoolSize", 0)
+
+	viper.Set("peer.validatorPoolSize", 3)
+	assert.Equal(t, 3, validatorPoolSize())
+
+	viper.Set("peer.validatorPoolSize", 0)
+	assert.Equal(t, runtime.NumCPU(), validatorPoolSize())
+
+	viper.Set("peer.validatorPoolSize", -1)
+	assert.Equal(t, runtime.NumCPU(), validatorPoolSize())
+}
+
+func BenchmarkValidate(b *testing.B) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/validatorbench")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+	gb, err := ctxt.MakeGenesisBlock("TestLedger")
+	assert.NoError(b, err)
+	theLedger, err := ledgermgmt.CreateLedger(gb)
+	assert.NoError(b, err)
+	defer theLedger.Close()
+
+	t := &testing.T{}
+	ccID := "mycc"
+	putCCInfo(theLedger, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	var data [][]byte
+	for i := 0; i < 50; i++ {
+		data = append(data, utils.MarshalOrPanic(getEnv(ccID, createRWset(t, ccID), t)))
+	}
+
+	for _, poolSize := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("PoolSize%d", poolSize), func(b *testing.B) {
+			viper.Set("peer.validatorPoolSize", poolSize)
+			defer viper.Set("peer.validatorPoolSize", 0)
+			v := NewTxValidator(&mockSupport{l: theLedger})
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				block := &common.Block{Data: &common.BlockData{Data: data}}
+				if err := v.Validate(block); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // mockLedger structure used to test ledger
 // failure, therefore leveraging mocking
 // library as need to simulate ledger which not
@@ -270,6 +270,24 @@ func TestSetChaincodesPath(t *testing.T) {
 
 var ccinfocachetestpath = "/tmp/ccinfocachetest"
 
+type installListener struct {
+	installed []string
+}
+
+func (l *installListener) ChaincodeInstalled(ccname string, ccversion string) {
+	l.installed = append(l.installed, ccname+":"+ccversion)
+}
+
+func TestInstallListeners(t *testing.T) {
+	l1, l2 := &installListener{}, &installListener{}
+	RegisterInstallListener(l1)
+	RegisterInstallListener(l2)
+
+	NotifyInstalled("mycc", "1.0")
+	assert.Equal(t, []string{"mycc:1.0"}, l1.installed)
+	assert.Equal(t, []string{"mycc:1.0"}, l2.installed)
+}
+
 func TestMain(m *testing.M) {
 	os.RemoveAll(ccinfocachetestpath)
 
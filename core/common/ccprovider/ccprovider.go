@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 
@@ -258,6 +259,35 @@ func GetCCPackage(buf []byte) (CCPackage, error) {
 	return cccdspack, nil
 }
 
+// InstallListener is notified of the chaincodes installed on the peer
+type InstallListener interface {
+	// ChaincodeInstalled is called once the given chaincode is installed
+	ChaincodeInstalled(ccname string, ccversion string)
+}
+
+var installListeners struct {
+	sync.RWMutex
+	listeners []InstallListener
+}
+
+// RegisterInstallListener registers a listener notified of the chaincodes
+// installed on the peer
+func RegisterInstallListener(listener InstallListener) {
+	installListeners.Lock()
+	defer installListeners.Unlock()
+	installListeners.listeners = append(installListeners.listeners, listener)
+}
+
+// NotifyInstalled notifies the registered listeners that the given
+// chaincode has been installed
+func NotifyInstalled(ccname string, ccversion string) {
+	installListeners.RLock()
+	defer installListeners.RUnlock()
+	for _, listener := range installListeners.listeners {
+		listener.ChaincodeInstalled(ccname, ccversion)
+	}
+}
+
 // GetInstalledChaincodes returns a map whose key is the chaincode id and
 // value is the ChaincodeDeploymentSpec struct for that chaincodes that have
 // been installed (but not necessarily instantiated) on the peer by searching
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccintf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// ExecutionPolicy overrides the peer wide chaincode execution settings for
+// a single chaincode. A zero value field means the peer wide setting applies.
+type ExecutionPolicy struct {
+	// Name of the chaincode the policy applies to
+	Name string
+	// ExecuteTimeout overrides chaincode.executetimeout
+	ExecuteTimeout time.Duration
+	// StartupTimeout overrides chaincode.startuptimeout
+	StartupTimeout time.Duration
+	// Memory overrides vm.docker.hostConfig.Memory
+	Memory int64
+	// CPUShares overrides vm.docker.hostConfig.CpuShares
+	CPUShares int64
+	// CPUQuota overrides vm.docker.hostConfig.CpuQuota
+	CPUQuota int64
+	// CPUPeriod overrides vm.docker.hostConfig.CpuPeriod
+	CPUPeriod int64
+	// MaxConcurrency limits the number of transactions the chaincode
+	// executes at the same time
+	MaxConcurrency int
+}
+
+// GetExecutionPolicies loads the per-chaincode execution policies listed
+// under chaincode.overrides, keyed by chaincode name
+func GetExecutionPolicies() (map[string]*ExecutionPolicy, error) {
+	var policies []*ExecutionPolicy
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &policies,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = decoder.Decode(viper.Get("chaincode.overrides")); err != nil {
+		return nil, fmt.Errorf("Invalid chaincode.overrides: %s", err)
+	}
+
+	res := make(map[string]*ExecutionPolicy, len(policies))
+	for _, policy := range policies {
+		if policy == nil || policy.Name == "" {
+			return nil, fmt.Errorf("Invalid chaincode.overrides: missing chaincode name")
+		}
+		if policy.ExecuteTimeout < 0 || policy.StartupTimeout < 0 || policy.MaxConcurrency < 0 {
+			return nil, fmt.Errorf("Invalid chaincode.overrides for %s: negative value", policy.Name)
+		}
+		if _, exists := res[policy.Name]; exists {
+			return nil, fmt.Errorf("Invalid chaincode.overrides: %s listed more than once", policy.Name)
+		}
+		res[policy.Name] = policy
+	}
+	return res, nil
+}
+
+// GetExecutionPolicy returns the execution policy of the given chaincode,
+// or nil if the peer wide settings apply to it
+func GetExecutionPolicy(ccname string) (*ExecutionPolicy, error) {
+	policies, err := GetExecutionPolicies()
+	if err != nil {
+		return nil, err
+	}
+	return policies[ccname], nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccintf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestConfig(t *testing.T, config string) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(bytes.NewBufferString(config))
+	assert.NoError(t, err)
+}
+
+func TestGetExecutionPolicies(t *testing.T) {
+	defer viper.Reset()
+
+	loadTestConfig(t, `
+chaincode:
+    executetimeout: 30s
+    overrides:
+        - name: myCC
+          executeTimeout: 60s
+          startupTimeout: 2m
+          memory: 268435456
+          cpuShares: 512
+          maxConcurrency: 4
+        - name: other
+          cpuQuota: 50000
+          cpuPeriod: 100000
+`)
+	policies, err := GetExecutionPolicies()
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, &ExecutionPolicy{
+		Name:           "myCC",
+		ExecuteTimeout: 60 * time.Second,
+		StartupTimeout: 2 * time.Minute,
+		Memory:         268435456,
+		CPUShares:      512,
+		MaxConcurrency: 4,
+	}, policies["myCC"])
+	assert.Equal(t, &ExecutionPolicy{Name: "other", CPUQuota: 50000, CPUPeriod: 100000}, policies["other"])
+
+	policy, err := GetExecutionPolicy("other")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50000), policy.CPUQuota)
+	policy, err = GetExecutionPolicy("mycc")
+	assert.NoError(t, err)
+	assert.Nil(t, policy, "chaincode names are case sensitive")
+}
+
+func TestGetExecutionPoliciesNone(t *testing.T) {
+	defer viper.Reset()
+
+	loadTestConfig(t, `
+chaincode:
+    overrides:
+`)
+	policies, err := GetExecutionPolicies()
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+}
+
+func TestGetExecutionPoliciesInvalid(t *testing.T) {
+	defer viper.Reset()
+
+	for _, config := range []string{
+		`
+chaincode:
+    overrides:
+        - executeTimeout: 60s
+`, `
+chaincode:
+    overrides:
+        - name: mycc
+          executeTimeout: forever
+`, `
+chaincode:
+    overrides:
+        - name: mycc
+          maxConcurrency: -1
+`, `
+chaincode:
+    overrides:
+        - name: mycc
+        - name: mycc
+`} {
+		loadTestConfig(t, config)
+		_, err := GetExecutionPolicies()
+		assert.Error(t, err, config)
+		_, err = GetExecutionPolicy("mycc")
+		assert.Error(t, err, config)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cclogs keeps the output captured from the chaincode containers so
+// that it can be retrieved through the peer after the containers are gone.
+package cclogs
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+var (
+	logger = flogging.MustGetLogger("cclogs")
+
+	storeLock sync.RWMutex
+	store     *Store
+)
+
+// followBufferSize is the number of lines a follower may lag behind before
+// lines are dropped for it
+const followBufferSize = 1000
+
+// InitStore sets up the store the output of the chaincodes is captured into
+func InitStore(dir string, maxSize int64) error {
+	s, err := NewStore(dir, maxSize)
+	if err != nil {
+		return err
+	}
+	storeLock.Lock()
+	defer storeLock.Unlock()
+	store = s
+	return nil
+}
+
+// GetStore returns the store the output of the chaincodes is captured into,
+// or nil if the output is not captured
+func GetStore() *Store {
+	storeLock.RLock()
+	defer storeLock.RUnlock()
+	return store
+}
+
+// LoggerName returns the name of the logger the output of the given
+// chaincode is forwarded to
+func LoggerName(ccname string) string {
+	return "chaincode." + ccname
+}
+
+// Store keeps the most recent output of every chaincode in a bounded on-disk
+// ring buffer. The buffer of a chaincode is made of two segment files: when
+// the current segment reaches half of the size limit, it replaces the
+// previous segment and a new current segment is started.
+type Store struct {
+	dir         string
+	segmentSize int64
+
+	sync.Mutex
+	logs map[string]*ccLog
+}
+
+type ccLog struct {
+	file      *os.File
+	size      int64
+	followers map[chan string]struct{}
+}
+
+// NewStore returns a store keeping up to maxSize bytes of output per
+// chaincode in dir
+func NewStore(dir string, maxSize int64) (*Store, error) {
+	if maxSize < 2 {
+		return nil, fmt.Errorf("Invalid chaincode log size %d", maxSize)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Error creating chaincode log directory %s: %s", dir, err)
+	}
+	return &Store{dir: dir, segmentSize: maxSize / 2, logs: make(map[string]*ccLog)}, nil
+}
+
+func (s *Store) segmentPath(ccname string, previous bool) string {
+	name := url.QueryEscape(ccname) + ".log"
+	if previous {
+		name += ".1"
+	}
+	return filepath.Join(s.dir, name)
+}
+
+// getLog returns the log of the given chaincode, opening its current segment
+// if needed. Call this under lock.
+func (s *Store) getLog(ccname string) (*ccLog, error) {
+	if l, ok := s.logs[ccname]; ok && l.file != nil {
+		return l, nil
+	}
+	l, ok := s.logs[ccname]
+	if !ok {
+		l = &ccLog{followers: make(map[chan string]struct{})}
+		s.logs[ccname] = l
+	}
+	file, err := os.OpenFile(s.segmentPath(ccname, false), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	l.file = file
+	l.size = info.Size()
+	return l, nil
+}
+
+// rotate makes the current segment of the log the previous one
+func (s *Store) rotate(ccname string, l *ccLog) error {
+	l.file.Close()
+	l.file = nil
+	if err := os.Rename(s.segmentPath(ccname, false), s.segmentPath(ccname, true)); err != nil {
+		return err
+	}
+	_, err := s.getLog(ccname)
+	return err
+}
+
+// Append adds a line of output of the given chaincode to the store
+func (s *Store) Append(ccname string, line string) error {
+	line = strings.TrimRight(line, "\r\n")
+	if int64(len(line)) >= s.segmentSize {
+		// truncate on a rune boundary so as not to split a character
+		cut := int(s.segmentSize - 1)
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		line = line[:cut]
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	l, err := s.getLog(ccname)
+	if err != nil {
+		return fmt.Errorf("Error opening log of chaincode %s: %s", ccname, err)
+	}
+
+	for follower := range l.followers {
+		select {
+		case follower <- line:
+		default:
+			logger.Warningf("Dropping output of chaincode %s for a slow follower", ccname)
+		}
+	}
+
+	record := line + "\n"
+	if l.size > 0 && l.size+int64(len(record)) > s.segmentSize {
+		if err = s.rotate(ccname, l); err != nil {
+			return fmt.Errorf("Error rotating log of chaincode %s: %s", ccname, err)
+		}
+	}
+	n, err := l.file.WriteString(record)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("Error writing log of chaincode %s: %s", ccname, err)
+	}
+	return nil
+}
+
+// Lines returns the output of the given chaincode kept in the store, oldest
+// line first
+func (s *Store) Lines(ccname string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.lines(ccname)
+}
+
+// Follow returns the output of the given chaincode kept in the store and a
+// channel receiving the output to come. Lines are dropped if the receiver
+// lags behind too much. The returned function must be called to stop
+// following the output.
+func (s *Store) Follow(ccname string) ([]string, <-chan string, func(), error) {
+	s.Lock()
+	defer s.Unlock()
+	lines, err := s.lines(ccname)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	l, ok := s.logs[ccname]
+	if !ok {
+		l = &ccLog{followers: make(map[chan string]struct{})}
+		s.logs[ccname] = l
+	}
+	follower := make(chan string, followBufferSize)
+	l.followers[follower] = struct{}{}
+	cancel := func() {
+		s.Lock()
+		defer s.Unlock()
+		delete(l.followers, follower)
+	}
+	return lines, follower, cancel, nil
+}
+
+// lines reads both segments of the log of the given chaincode. Call this
+// under lock.
+func (s *Store) lines(ccname string) ([]string, error) {
+	var lines []string
+	for _, previous := range []bool{true, false} {
+		file, err := os.Open(s.segmentPath(ccname, previous))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("Error reading log of chaincode %s: %s", ccname, err)
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 4096), int(s.segmentSize)+1)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		file.Close()
+		if err = scanner.Err(); err != nil {
+			return nil, fmt.Errorf("Error reading log of chaincode %s: %s", ccname, err)
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cclogs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(t *testing.T, maxSize int64) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "cclogs")
+	assert.NoError(t, err)
+	store, err := NewStore(dir, maxSize)
+	assert.NoError(t, err)
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func TestStoreAppend(t *testing.T) {
+	store, cleanup := newTestStore(t, 1024)
+	defer cleanup()
+
+	lines, err := store.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Empty(t, lines)
+
+	assert.NoError(t, store.Append("mycc", "first line\n"))
+	assert.NoError(t, store.Append("mycc", "second line"))
+	assert.NoError(t, store.Append("othercc", "other line\n"))
+
+	lines, err = store.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first line", "second line"}, lines)
+	lines, err = store.Lines("othercc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other line"}, lines)
+
+	// the output survives the store
+	store2, err := NewStore(store.dir, 1024)
+	assert.NoError(t, err)
+	assert.NoError(t, store2.Append("mycc", "third line"))
+	lines, err = store2.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first line", "second line", "third line"}, lines)
+}
+
+func TestStoreBounded(t *testing.T) {
+	// each line takes 8 bytes and a segment holds 5 lines
+	store, cleanup := newTestStore(t, 80)
+	defer cleanup()
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, store.Append("mycc", fmt.Sprintf("line %02d", i)))
+	}
+	lines, err := store.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Len(t, lines, 10)
+	assert.Equal(t, "line 90", lines[0])
+	assert.Equal(t, "line 99", lines[9])
+
+	// lines longer than a segment are truncated
+	assert.NoError(t, store.Append("mycc", string(make([]byte, 100))))
+	lines, err = store.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Len(t, lines[len(lines)-1], 39)
+
+	// without splitting multi-byte characters
+	assert.NoError(t, store.Append("mycc", strings.Repeat("é", 50)))
+	lines, err = store.Lines("mycc")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("é", 19), lines[len(lines)-1])
+}
+
+func TestStoreFollow(t *testing.T) {
+	store, cleanup := newTestStore(t, 1024)
+	defer cleanup()
+
+	assert.NoError(t, store.Append("mycc", "before"))
+	lines, follow, cancel, err := store.Follow("mycc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before"}, lines)
+
+	assert.NoError(t, store.Append("mycc", "after"))
+	assert.NoError(t, store.Append("othercc", "other"))
+	assert.Equal(t, "after", <-follow)
+	assert.Len(t, follow, 0)
+
+	cancel()
+	assert.NoError(t, store.Append("mycc", "after cancel"))
+	assert.Len(t, follow, 0)
+
+	// following a chaincode without output yet
+	lines, follow, cancel, err = store.Follow("newcc")
+	assert.NoError(t, err)
+	defer cancel()
+	assert.Empty(t, lines)
+	assert.NoError(t, store.Append("newcc", "hello"))
+	assert.Equal(t, "hello", <-follow)
+}
+
+func TestStoreInvalid(t *testing.T) {
+	_, err := NewStore(os.TempDir(), 1)
+	assert.Error(t, err)
+
+	file, err := ioutil.TempFile("", "cclogs")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = NewStore(file.Name(), 1024)
+	assert.Error(t, err)
+	assert.Error(t, InitStore(file.Name(), 1024))
+}
+
+func TestInitStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cclogs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, InitStore(dir, 1024))
+	assert.NotNil(t, GetStore())
+	assert.Equal(t, "chaincode.mycc", LoggerName("mycc"))
+}
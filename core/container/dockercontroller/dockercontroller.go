@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	container "github.com/hyperledger/fabric/core/container/api"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/cclogs"
 	cutil "github.com/hyperledger/fabric/core/container/util"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -151,11 +152,41 @@ func getDockerHostConfig() *docker.HostConfig {
 	return hostConfig
 }
 
+// getChaincodeHostConfig returns the docker HostConfig with the resource
+// limits of the chaincode's execution policy applied, if it has one
+func getChaincodeHostConfig(ccname string) *docker.HostConfig {
+	policy, err := ccintf.GetExecutionPolicy(ccname)
+	if err != nil {
+		dockerLogger.Warningf("load execution policy of chaincode %s failed, error: %s", ccname, err)
+		return getDockerHostConfig()
+	}
+	if policy == nil {
+		return getDockerHostConfig()
+	}
+
+	ccHostConfig := *getDockerHostConfig()
+	if policy.Memory != 0 {
+		ccHostConfig.Memory = policy.Memory
+	}
+	if policy.CPUShares != 0 {
+		ccHostConfig.CPUShares = policy.CPUShares
+	}
+	if policy.CPUQuota != 0 {
+		ccHostConfig.CPUQuota = policy.CPUQuota
+	}
+	if policy.CPUPeriod != 0 {
+		ccHostConfig.CPUPeriod = policy.CPUPeriod
+	}
+	dockerLogger.Debugf("docker container hostconfig for chaincode %s: Memory %d, CPUShares %d, CPUQuota %d, CPUPeriod %d",
+		ccname, ccHostConfig.Memory, ccHostConfig.CPUShares, ccHostConfig.CPUQuota, ccHostConfig.CPUPeriod)
+	return &ccHostConfig
+}
+
 func (vm *DockerVM) createContainer(ctxt context.Context, client dockerClient,
 	imageID string, containerID string, args []string,
-	env []string, attachStdout bool) error {
+	env []string, attachStdout bool, ccHostConfig *docker.HostConfig) error {
 	config := docker.Config{Cmd: args, Image: imageID, Env: env, AttachStdout: attachStdout, AttachStderr: attachStdout}
-	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: getDockerHostConfig()}
+	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: ccHostConfig}
 	dockerLogger.Debugf("Create container: %s", containerID)
 	_, err := client.CreateContainer(copts)
 	if err != nil {
@@ -228,14 +259,18 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 		return err
 	}
 
-	attachStdout := viper.GetBool("vm.docker.attachStdout")
+	// the output of the container is attached either for debugging purposes
+	// or to be captured
+	ccLogStore := cclogs.GetStore()
+	attachStdout := viper.GetBool("vm.docker.attachStdout") || ccLogStore != nil
+	ccHostConfig := getChaincodeHostConfig(ccid.ChaincodeSpec.ChaincodeId.Name)
 
 	//stop,force remove if necessary
 	dockerLogger.Debugf("Cleanup container %s", containerID)
 	vm.stopInternal(ctxt, client, containerID, 0, false, false)
 
 	dockerLogger.Debugf("Start container %s", containerID)
-	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout)
+	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, ccHostConfig)
 	if err != nil {
 		//if image not found try to create image and retry
 		if err == docker.ErrNoSuchImage {
@@ -254,7 +289,7 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 				}
 
 				dockerLogger.Debug("start-recreated image successfully")
-				if err1 = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout); err1 != nil {
+				if err1 = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, ccHostConfig); err1 != nil {
 					dockerLogger.Errorf("start-could not recreate container post recreate image: %s", err1)
 					return err1
 				}
@@ -315,8 +350,10 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 			is := bufio.NewReader(r)
 
 			// Acquire a custom logger for our chaincode, inheriting the level from the peer
-			containerLogger := flogging.MustGetLogger(containerID)
-			logging.SetLevel(logging.GetLevel("peer"), containerID)
+			ccname := ccid.ChaincodeSpec.ChaincodeId.Name
+			loggerName := cclogs.LoggerName(ccname)
+			containerLogger := flogging.MustGetLogger(loggerName)
+			logging.SetLevel(logging.GetLevel("peer"), loggerName)
 
 			for {
 				// Loop forever dumping lines of text into the containerLogger
@@ -334,6 +371,11 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 				}
 
 				containerLogger.Info(line)
+				if ccLogStore != nil {
+					if err2 = ccLogStore.Append(ccname, line); err2 != nil {
+						dockerLogger.Errorf("Error capturing output of container %s: %s", containerID, err2)
+					}
+				}
 			}
 		}()
 	}
@@ -68,6 +68,25 @@ func TestGetDockerHostConfig(t *testing.T) {
 	testutil.AssertEquals(t, hostConfig.CPUShares, int64(1024*1024*1024*2))
 }
 
+func TestGetChaincodeHostConfig(t *testing.T) {
+	coreutil.SetupTestConfig()
+	defer viper.Set("chaincode.overrides", nil)
+	viper.Set("chaincode.overrides", []interface{}{
+		map[interface{}]interface{}{"name": "limited", "memory": 1024 * 1024, "cpuQuota": 5000},
+	})
+
+	defaultConfig := getDockerHostConfig()
+	testutil.AssertSame(t, getChaincodeHostConfig("other"), defaultConfig)
+
+	ccHostConfig := getChaincodeHostConfig("limited")
+	testutil.AssertEquals(t, ccHostConfig.Memory, int64(1024*1024))
+	testutil.AssertEquals(t, ccHostConfig.CPUQuota, int64(5000))
+	testutil.AssertEquals(t, ccHostConfig.CPUShares, defaultConfig.CPUShares)
+	testutil.AssertEquals(t, ccHostConfig.NetworkMode, defaultConfig.NetworkMode)
+	// the peer wide config is left untouched
+	testutil.AssertNotEquals(t, defaultConfig.Memory, int64(1024*1024))
+}
+
 func Test_Deploy(t *testing.T) {
 	dvm := DockerVM{}
 	ccid := ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "simple"}}}
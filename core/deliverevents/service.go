@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deliverevents implements the peer service delivering the blocks
+// committed on a channel, or the chaincode events they contain, starting
+// at a given block
+package deliverevents
+
+import (
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/policy"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("deliverevents")
+
+// Support provides the ledgers of the channels the peer has joined
+type Support interface {
+	// Ledger returns the ledger of the given channel,
+	// or nil if the peer hasn't joined the channel
+	Ledger(channel string) Ledger
+}
+
+// Ledger is the part of the ledger of a channel blocks are delivered from
+type Ledger interface {
+	// GetBlockchainInfo returns basic info about the blockchain
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	// GetBlocksIterator returns a blocking iterator that starts from the given block
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+// deliverStream is the server side of a Deliver or a DeliverChaincodeEvents stream
+type deliverStream interface {
+	Send(*pb.DeliverResponse) error
+	Recv() (*cb.Envelope, error)
+	Context() context.Context
+}
+
+// sendFunc sends a block, or what the client asked for out of it
+type sendFunc func(srv deliverStream, block *cb.Block) error
+
+type server struct {
+	support       Support
+	policyChecker policy.PolicyChecker
+}
+
+// NewServer returns a Deliver service reading blocks from the ledgers of the
+// given support, and authorizing requests with the given policy checker
+func NewServer(support Support, policyChecker policy.PolicyChecker) pb.DeliverServer {
+	return &server{support: support, policyChecker: policyChecker}
+}
+
+// Deliver sends the blocks requested by the client
+func (s *server) Deliver(srv pb.Deliver_DeliverServer) error {
+	return s.handle(srv, sendBlock)
+}
+
+// DeliverChaincodeEvents sends the chaincode events of the blocks requested by the client
+func (s *server) DeliverChaincodeEvents(srv pb.Deliver_DeliverChaincodeEventsServer) error {
+	return s.handle(srv, sendChaincodeEvents)
+}
+
+func (s *server) handle(srv deliverStream, send sendFunc) error {
+	logger.Debugf("Starting new deliver loop")
+	for {
+		envelope, err := srv.Recv()
+		if err == io.EOF {
+			logger.Debugf("Received EOF, hangup")
+			return nil
+		}
+		if err != nil {
+			logger.Warningf("Error reading from stream: %s", err)
+			return err
+		}
+
+		status, err := s.deliverBlocks(srv, envelope, send)
+		if err != nil {
+			return err
+		}
+		if err := sendStatus(srv, status); err != nil {
+			logger.Warningf("Error sending to stream: %s", err)
+			return err
+		}
+	}
+}
+
+// deliverBlocks sends the blocks requested by the given envelope, and returns
+// the status to reply with once done, or an error if the stream broke
+func (s *server) deliverBlocks(srv deliverStream, envelope *cb.Envelope, send sendFunc) (cb.Status, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		logger.Warningf("Received an envelope with no payload: %s", err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	if payload.Header == nil {
+		logger.Warningf("Malformed envelope received with bad header")
+		return cb.Status_BAD_REQUEST, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Warningf("Failed to unmarshal channel header: %s", err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	channel := chdr.ChannelId
+	ledger := s.support.Ledger(channel)
+	if ledger == nil {
+		logger.Debugf("Rejecting deliver because channel %s not found", channel)
+		return cb.Status_NOT_FOUND, nil
+	}
+
+	if err := s.authorize(channel, envelope); err != nil {
+		logger.Warningf("[channel: %s] Received unauthorized deliver request: %s", channel, err)
+		return cb.Status_FORBIDDEN, nil
+	}
+
+	seekInfo := &ab.SeekInfo{}
+	if err = proto.Unmarshal(payload.Data, seekInfo); err != nil {
+		logger.Warningf("[channel: %s] Received a signed deliver request with malformed seekInfo payload: %s", channel, err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	if seekInfo.Start == nil || seekInfo.Stop == nil {
+		logger.Warningf("[channel: %s] Received seekInfo message with missing start or stop %v, %v", channel, seekInfo.Start, seekInfo.Stop)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	height, err := ledgerHeight(ledger)
+	if err != nil {
+		logger.Errorf("[channel: %s] Failed retrieving the ledger height: %s", channel, err)
+		return cb.Status_INTERNAL_SERVER_ERROR, nil
+	}
+	start, ok := seekPosition(seekInfo.Start, height, 0)
+	if !ok {
+		logger.Warningf("[channel: %s] Received seekInfo message with invalid start %v", channel, seekInfo.Start)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	stop, ok := seekPosition(seekInfo.Stop, height, start)
+	if !ok {
+		logger.Warningf("[channel: %s] Received seekInfo message with invalid stop %v", channel, seekInfo.Stop)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	if stop < start {
+		logger.Warningf("[channel: %s] Received invalid seekInfo message: start number %d greater than stop number %d", channel, start, stop)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	logger.Debugf("[channel: %s] Delivering blocks [%d, %d]", channel, start, stop)
+
+	iterator, err := ledger.GetBlocksIterator(start)
+	if err != nil {
+		logger.Errorf("[channel: %s] Failed creating a blocks iterator: %s", channel, err)
+		return cb.Status_INTERNAL_SERVER_ERROR, nil
+	}
+	// The iterator blocks until blocks are committed, so
+	// close it if the client goes away in the meantime
+	var closeOnce sync.Once
+	closeIterator := func() { closeOnce.Do(iterator.Close) }
+	defer closeIterator()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-srv.Context().Done():
+			closeIterator()
+		case <-done:
+		}
+	}()
+
+	for number := start; ; number++ {
+		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY && number >= height {
+			if height, err = ledgerHeight(ledger); err != nil {
+				logger.Errorf("[channel: %s] Failed retrieving the ledger height: %s", channel, err)
+				return cb.Status_INTERNAL_SERVER_ERROR, nil
+			}
+			if number >= height {
+				return cb.Status_NOT_FOUND, nil
+			}
+		}
+
+		result, err := iterator.Next()
+		if err != nil {
+			logger.Errorf("[channel: %s] Error reading block %d from the ledger: %s", channel, number, err)
+			return cb.Status_INTERNAL_SERVER_ERROR, nil
+		}
+		if result == nil {
+			logger.Debugf("[channel: %s] Deliver aborted: %s", channel, srv.Context().Err())
+			return cb.Status_SERVICE_UNAVAILABLE, srv.Context().Err()
+		}
+		block := result.(*cb.Block)
+
+		if err := send(srv, block); err != nil {
+			logger.Warningf("[channel: %s] Error sending to stream: %s", channel, err)
+			return cb.Status_SERVICE_UNAVAILABLE, err
+		}
+
+		if block.Header.Number == stop {
+			return cb.Status_SUCCESS, nil
+		}
+
+		// The readers policy may have changed, so check
+		// the client is still authorized after config blocks
+		if utils.IsConfigBlock(block) {
+			if err := s.authorize(channel, envelope); err != nil {
+				logger.Warningf("[channel: %s] Client authorization revoked for deliver request: %s", channel, err)
+				return cb.Status_FORBIDDEN, nil
+			}
+		}
+	}
+}
+
+func (s *server) authorize(channel string, envelope *cb.Envelope) error {
+	sd, err := envelope.AsSignedData()
+	if err != nil {
+		return err
+	}
+	return s.policyChecker.CheckPolicyBySignedData(channel, policies.ChannelApplicationReaders, sd)
+}
+
+func ledgerHeight(ledger Ledger) (uint64, error) {
+	info, err := ledger.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Height, nil
+}
+
+// seekPosition returns the block number of the given position, given the
+// ledger height and the block number the oldest position resolves to
+func seekPosition(position *ab.SeekPosition, height uint64, oldest uint64) (uint64, bool) {
+	switch position := position.Type.(type) {
+	case *ab.SeekPosition_Oldest:
+		return oldest, true
+	case *ab.SeekPosition_Newest:
+		if height == 0 {
+			return 0, true
+		}
+		return height - 1, true
+	case *ab.SeekPosition_Specified:
+		if position.Specified == nil {
+			return 0, false
+		}
+		return position.Specified.Number, true
+	}
+	return 0, false
+}
+
+func sendStatus(srv deliverStream, status cb.Status) error {
+	return srv.Send(&pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Status{Status: status},
+	})
+}
+
+func sendBlock(srv deliverStream, block *cb.Block) error {
+	return srv.Send(&pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Block{Block: block},
+	})
+}
+
+func sendChaincodeEvents(srv deliverStream, block *cb.Block) error {
+	return srv.Send(&pb.DeliverResponse{
+		Type: &pb.DeliverResponse_ChaincodeEvents{ChaincodeEvents: ChaincodeEvents(block)},
+	})
+}
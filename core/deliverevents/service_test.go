@@ -0,0 +1,365 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverevents
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/events/consumer"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type mockSupport map[string]*mockLedger
+
+func (s mockSupport) Ledger(channel string) Ledger {
+	l, exists := s[channel]
+	if !exists {
+		return nil
+	}
+	return l
+}
+
+type mockLedger struct {
+	sync.Mutex
+	cond   *sync.Cond
+	blocks []*cb.Block
+}
+
+func newMockLedger(blocks ...*cb.Block) *mockLedger {
+	l := &mockLedger{blocks: blocks}
+	l.cond = sync.NewCond(l)
+	return l
+}
+
+func (l *mockLedger) append(block *cb.Block) {
+	l.Lock()
+	defer l.Unlock()
+	l.blocks = append(l.blocks, block)
+	l.cond.Broadcast()
+}
+
+func (l *mockLedger) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
+	l.Lock()
+	defer l.Unlock()
+	return &cb.BlockchainInfo{Height: uint64(len(l.blocks))}, nil
+}
+
+func (l *mockLedger) GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error) {
+	return &mockIterator{ledger: l, next: startBlockNumber}, nil
+}
+
+type mockIterator struct {
+	ledger *mockLedger
+	next   uint64
+	closed bool
+}
+
+func (it *mockIterator) Next() (commonledger.QueryResult, error) {
+	it.ledger.Lock()
+	defer it.ledger.Unlock()
+	for !it.closed && it.next >= uint64(len(it.ledger.blocks)) {
+		it.ledger.cond.Wait()
+	}
+	if it.closed {
+		return nil, nil
+	}
+	block := it.ledger.blocks[it.next]
+	it.next++
+	return block, nil
+}
+
+func (it *mockIterator) Close() {
+	it.ledger.Lock()
+	defer it.ledger.Unlock()
+	it.closed = true
+	it.ledger.cond.Broadcast()
+}
+
+// mockPolicyChecker authorizes the given number of checks, or all checks if negative
+type mockPolicyChecker struct {
+	sync.Mutex
+	allowed int
+}
+
+func (*mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	panic("should not be called")
+}
+
+func (*mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	panic("should not be called")
+}
+
+func (c *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*cb.SignedData) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.allowed == 0 {
+		return errors.New("access denied")
+	}
+	c.allowed--
+	return nil
+}
+
+func newBlock(number uint64, txs ...*cb.Envelope) *cb.Block {
+	block := cb.NewBlock(number, nil)
+	for _, tx := range txs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(tx))
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(txs))
+	return block
+}
+
+func newTx(typ cb.HeaderType, event *pb.ChaincodeEvent) *cb.Envelope {
+	action := &pb.ChaincodeAction{}
+	if event != nil {
+		action.Events = utils.MarshalOrPanic(event)
+	}
+	prp := &pb.ProposalResponsePayload{Extension: utils.MarshalOrPanic(action)}
+	ccPayload := &pb.ChaincodeActionPayload{Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: utils.MarshalOrPanic(prp)}}
+	tx := &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: utils.MarshalOrPanic(ccPayload)}}}
+	payload := &cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(typ)})},
+		Data:   utils.MarshalOrPanic(tx),
+	}
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+}
+
+func seekPositionOf(number uint64) *ab.SeekPosition {
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: number}}}
+}
+
+func seekEnvelope(t *testing.T, channel string, seekInfo *ab.SeekInfo) *cb.Envelope {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_DELIVER_SEEK_INFO, channel, &mockcrypto.LocalSigner{}, seekInfo, 0, 0)
+	assert.NoError(t, err)
+	return env
+}
+
+func startServer(t *testing.T, support Support, checker *mockPolicyChecker) (*grpc.ClientConn, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := grpc.NewServer()
+	pb.RegisterDeliverServer(srv, NewServer(support, checker))
+	go srv.Serve(listener)
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second))
+	assert.NoError(t, err)
+	return conn, func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+// deliver sends the given request, and returns the numbers
+// of the blocks received and the status replied with
+func deliver(t *testing.T, stream pb.Deliver_DeliverClient, env *cb.Envelope) ([]uint64, cb.Status) {
+	assert.NoError(t, stream.Send(env))
+	var numbers []uint64
+	for {
+		resp, err := stream.Recv()
+		assert.NoError(t, err)
+		if err != nil {
+			return numbers, cb.Status_UNKNOWN
+		}
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_Block:
+			numbers = append(numbers, r.Block.Header.Number)
+		case *pb.DeliverResponse_Status:
+			return numbers, r.Status
+		}
+	}
+}
+
+func TestDeliverRequests(t *testing.T) {
+	ledger := newMockLedger(newBlock(0), newBlock(1), newBlock(2))
+	checker := &mockPolicyChecker{allowed: -1}
+	conn, stop := startServer(t, mockSupport{"mychannel": ledger}, checker)
+	defer stop()
+
+	stream, err := pb.NewDeliverClient(conn).Deliver(context.Background())
+	assert.NoError(t, err)
+
+	// A range of blocks
+	numbers, status := deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(1), Stop: seekPositionOf(2)}))
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, []uint64{1, 2}, numbers)
+
+	// The oldest and newest blocks
+	numbers, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{
+		Start: &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}},
+		Stop:  &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}},
+	}))
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, []uint64{0, 1, 2}, numbers)
+
+	// Blocks not committed yet, without waiting for them
+	numbers, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{
+		Start:    seekPositionOf(2),
+		Stop:     seekPositionOf(4),
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}))
+	assert.Equal(t, cb.Status_NOT_FOUND, status)
+	assert.Equal(t, []uint64{2}, numbers)
+
+	// Blocks not committed yet, waiting for them
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ledger.append(newBlock(3))
+	}()
+	numbers, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(2), Stop: seekPositionOf(3)}))
+	assert.Equal(t, cb.Status_SUCCESS, status)
+	assert.Equal(t, []uint64{2, 3}, numbers)
+
+	// Invalid requests
+	_, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(2), Stop: seekPositionOf(1)}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, status)
+	_, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(2)}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, status)
+	_, status = deliver(t, stream, &cb.Envelope{Payload: []byte{1, 2, 3}})
+	assert.Equal(t, cb.Status_BAD_REQUEST, status)
+
+	// Unknown channel
+	_, status = deliver(t, stream, seekEnvelope(t, "otherchannel", &ab.SeekInfo{Start: seekPositionOf(0), Stop: seekPositionOf(0)}))
+	assert.Equal(t, cb.Status_NOT_FOUND, status)
+
+	// Unauthorized client
+	checker.Lock()
+	checker.allowed = 0
+	checker.Unlock()
+	_, status = deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(0), Stop: seekPositionOf(0)}))
+	assert.Equal(t, cb.Status_FORBIDDEN, status)
+}
+
+func TestDeliverAuthorizationRevoked(t *testing.T) {
+	ledger := newMockLedger(newBlock(0), newBlock(1, newTx(cb.HeaderType_CONFIG, nil)), newBlock(2))
+	// The client is authorized by the request, but not anymore after the config block
+	checker := &mockPolicyChecker{allowed: 1}
+	conn, stop := startServer(t, mockSupport{"mychannel": ledger}, checker)
+	defer stop()
+
+	stream, err := pb.NewDeliverClient(conn).Deliver(context.Background())
+	assert.NoError(t, err)
+	numbers, status := deliver(t, stream, seekEnvelope(t, "mychannel", &ab.SeekInfo{Start: seekPositionOf(0), Stop: seekPositionOf(2)}))
+	assert.Equal(t, cb.Status_FORBIDDEN, status)
+	assert.Equal(t, []uint64{0, 1}, numbers)
+}
+
+func TestChaincodeEvents(t *testing.T) {
+	block := newBlock(5,
+		newTx(cb.HeaderType_ENDORSER_TRANSACTION, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx1", EventName: "valid"}),
+		newTx(cb.HeaderType_ENDORSER_TRANSACTION, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx2", EventName: "invalid"}),
+		newTx(cb.HeaderType_ENDORSER_TRANSACTION, nil),
+		newTx(cb.HeaderType_CONFIG, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx4", EventName: "config"}),
+		&cb.Envelope{Payload: []byte{1, 2, 3}},
+	)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER][1] = uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)
+
+	events := ChaincodeEvents(block)
+	assert.Equal(t, uint64(5), events.Number)
+	assert.Len(t, events.ChaincodeEvents, 1)
+	assert.Equal(t, "valid", events.ChaincodeEvents[0].EventName)
+	assert.Equal(t, "tx1", events.ChaincodeEvents[0].TxId)
+}
+
+func TestConsumerCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deliverevents")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	checkpoint := consumer.NewFileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	event := func(name string) *pb.ChaincodeEvent {
+		return &pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: name}
+	}
+	ledger := newMockLedger(
+		newBlock(0),
+		newBlock(1, newTx(cb.HeaderType_ENDORSER_TRANSACTION, event("e1"))),
+		newBlock(2, newTx(cb.HeaderType_ENDORSER_TRANSACTION, event("e2"))),
+	)
+	conn, stop := startServer(t, mockSupport{"mychannel": ledger}, &mockPolicyChecker{allowed: -1})
+	defer stop()
+
+	// The consumer fails processing block 2, so it is not checkpointed
+	errFailed := errors.New("failed")
+	var names []string
+	client := consumer.NewDeliverClient(conn, "mychannel", &mockcrypto.LocalSigner{}, checkpoint)
+	err = client.ConsumeChaincodeEvents(context.Background(), 1, func(events *pb.BlockChaincodeEvents) error {
+		if events.Number == 2 {
+			return errFailed
+		}
+		for _, e := range events.ChaincodeEvents {
+			names = append(names, e.EventName)
+		}
+		return nil
+	})
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, []string{"e1"}, names)
+	number, exists, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(1), number)
+
+	// After a restart, the consumer resumes after the checkpoint and
+	// the start block is ignored
+	received := make(chan uint64, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.ConsumeBlocks(ctx, 0, func(block *cb.Block) error {
+			received <- block.Header.Number
+			return nil
+		})
+	}()
+	assert.Equal(t, uint64(2), <-received)
+	ledger.append(newBlock(3))
+	assert.Equal(t, uint64(3), <-received)
+	cancel()
+	select {
+	case err = <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Consumer didn't stop after the context was cancelled")
+	}
+	number, _, err = checkpoint.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), number)
+
+	// A corrupted checkpoint is reported
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "checkpoint"), []byte("foo"), 0600))
+	err = client.ConsumeBlocks(context.Background(), 0, func(*cb.Block) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestSeekPosition(t *testing.T) {
+	number, ok := seekPosition(&ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}, 0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), number)
+	number, ok = seekPosition(seekPositionOf(math.MaxUint64), 10, 0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(math.MaxUint64), number)
+	_, ok = seekPosition(&ab.SeekPosition{}, 10, 0)
+	assert.False(t, ok)
+	_, ok = seekPosition(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{}}, 10, 0)
+	assert.False(t, ok)
+}
+
+func TestPeerSupportUnknownChannel(t *testing.T) {
+	// A nil ledger must not be wrapped in a non nil interface
+	assert.True(t, NewPeerSupport().Ledger("nonexistent") == nil)
+}
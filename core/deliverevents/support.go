@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliverevents
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/core/peer"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+type peerSupport struct {
+}
+
+// NewPeerSupport returns a Support backed by the ledgers of the peer
+func NewPeerSupport() Support {
+	return &peerSupport{}
+}
+
+// Ledger returns the ledger of the given channel,
+// or nil if the peer hasn't joined the channel
+func (*peerSupport) Ledger(channel string) Ledger {
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return nil
+	}
+	return l
+}
+
+// ChaincodeEvents returns the chaincode events set by the valid
+// endorser transactions of the given block
+func ChaincodeEvents(block *cb.Block) *pb.BlockChaincodeEvents {
+	res := &pb.BlockChaincodeEvents{Number: block.Header.Number}
+	if block.Data == nil {
+		return res
+	}
+	var txsFilter util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for i, envBytes := range block.Data.Data {
+		if i < len(txsFilter) && txsFilter.IsInvalid(i) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			logger.Warningf("Skipping transaction %d of block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil || payload.Header == nil {
+			logger.Warningf("Skipping transaction %d of block %d: invalid payload", i, block.Header.Number)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			logger.Warningf("Skipping transaction %d of block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		if cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		action, err := utils.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			logger.Warningf("Skipping transaction %d of block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		if len(action.Events) == 0 {
+			continue
+		}
+		event, err := utils.GetChaincodeEvents(action.Events)
+		if err != nil {
+			logger.Warningf("Skipping invalid chaincode event of transaction %d of block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		res.ChaincodeEvents = append(res.ChaincodeEvents, event)
+	}
+	return res
+}
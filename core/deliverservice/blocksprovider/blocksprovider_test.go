@@ -67,6 +67,10 @@ func (*mockMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
 
+func (*mockMCS) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 type rcvFunc func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error)
 
 // Used to generate a simple test case to initialize delivery
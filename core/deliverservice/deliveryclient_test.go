@@ -83,6 +83,10 @@ func (*mockMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
 
+func (*mockMCS) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 func TestNewDeliverService(t *testing.T) {
 	defer ensureNoGoroutineLeak(t)()
 	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 1)}
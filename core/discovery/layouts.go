@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+)
+
+// maxLayouts bounds the number of layouts computed for a single policy, to
+// protect the peer from policies with a combinatorial number of layouts
+const maxLayouts = 1024
+
+// layout is a number of endorsing peers per organization
+type layout map[string]int
+
+// key returns a string identifying the layout
+func (l layout) key() string {
+	orgs := make([]string, 0, len(l))
+	for org, n := range l {
+		orgs = append(orgs, fmt.Sprintf("%s:%d", org, n))
+	}
+	sort.Strings(orgs)
+	return strings.Join(orgs, ",")
+}
+
+func (l layout) size() int {
+	size := 0
+	for _, n := range l {
+		size += n
+	}
+	return size
+}
+
+// covers returns whether l requires at least as many peers of every
+// organization as o does
+func (l layout) covers(o layout) bool {
+	for org, n := range o {
+		if l[org] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// merge returns a layout requiring the peers of both l and o
+func (l layout) merge(o layout) layout {
+	res := make(layout, len(l)+len(o))
+	for org, n := range l {
+		res[org] += n
+	}
+	for org, n := range o {
+		res[org] += n
+	}
+	return res
+}
+
+type layoutsBySize []layout
+
+func (l layoutsBySize) Len() int      { return len(l) }
+func (l layoutsBySize) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+func (l layoutsBySize) Less(i, j int) bool {
+	if l[i].size() != l[j].size() {
+		return l[i].size() < l[j].size()
+	}
+	return l[i].key() < l[j].key()
+}
+
+// computeLayouts returns the minimal layouts satisfying the given
+// signature policy envelope, smallest layouts first
+func computeLayouts(envelope *cb.SignaturePolicyEnvelope) ([]layout, error) {
+	if envelope == nil || envelope.Rule == nil {
+		return nil, fmt.Errorf("Empty signature policy")
+	}
+	orgs := make([]string, len(envelope.Identities))
+	for i, principal := range envelope.Identities {
+		org, err := principalOrg(principal)
+		if err != nil {
+			return nil, err
+		}
+		orgs[i] = org
+	}
+	layouts, err := ruleLayouts(envelope.Rule, orgs)
+	if err != nil {
+		return nil, err
+	}
+	return minimize(layouts), nil
+}
+
+// principalOrg returns the MSP ID of the organization whose peers may
+// satisfy the given principal, or an empty string if no peer may.
+// Peers are members of their organization, and peers by role when node
+// OUs are enabled, but neither admins, clients nor orderers.
+func principalOrg(principal *mspprotos.MSPPrincipal) (string, error) {
+	switch principal.PrincipalClassification {
+	case mspprotos.MSPPrincipal_ROLE:
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "", fmt.Errorf("Invalid role principal: %s", err)
+		}
+		switch role.Role {
+		case mspprotos.MSPRole_MEMBER, mspprotos.MSPRole_PEER:
+			return role.MspIdentifier, nil
+		default:
+			return "", nil
+		}
+	case mspprotos.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspprotos.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return "", fmt.Errorf("Invalid organization unit principal: %s", err)
+		}
+		return ou.MspIdentifier, nil
+	default:
+		return "", fmt.Errorf("Principal classification %s is not supported", principal.PrincipalClassification)
+	}
+}
+
+func ruleLayouts(rule *cb.SignaturePolicy, orgs []string) ([]layout, error) {
+	switch t := rule.Type.(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(orgs) {
+			return nil, fmt.Errorf("Identity index %d out of range", t.SignedBy)
+		}
+		if orgs[t.SignedBy] == "" {
+			// No peer can satisfy the principal
+			return nil, nil
+		}
+		return []layout{{orgs[t.SignedBy]: 1}}, nil
+	case *cb.SignaturePolicy_NOutOf_:
+		n := int(t.NOutOf.N)
+		rules := t.NOutOf.Rules
+		if n <= 0 {
+			return []layout{{}}, nil
+		}
+		if n > len(rules) {
+			// The policy cannot be satisfied
+			return nil, nil
+		}
+		subLayouts := make([][]layout, len(rules))
+		for i, subRule := range rules {
+			layouts, err := ruleLayouts(subRule, orgs)
+			if err != nil {
+				return nil, err
+			}
+			subLayouts[i] = minimize(layouts)
+		}
+		var res []layout
+		var err error
+		combinations(len(rules), n, func(chosen []int) bool {
+			res, err = appendProduct(res, subLayouts, chosen)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("Unsupported signature policy type %T", rule.Type)
+	}
+}
+
+// appendProduct appends to res the layouts combining a layout of every
+// chosen sub-rule
+func appendProduct(res []layout, subLayouts [][]layout, chosen []int) ([]layout, error) {
+	product := []layout{{}}
+	for _, i := range chosen {
+		var next []layout
+		for _, l := range product {
+			for _, sub := range subLayouts[i] {
+				next = append(next, l.merge(sub))
+			}
+		}
+		product = next
+		if len(res)+len(product) > maxLayouts {
+			return nil, fmt.Errorf("Policy has more than %d layouts", maxLayouts)
+		}
+	}
+	return append(res, product...), nil
+}
+
+// combinations calls f with every sorted set of k indexes out of n, until f
+// returns false
+func combinations(n, k int, f func([]int) bool) {
+	chosen := make([]int, k)
+	var rec func(start, depth int) bool
+	rec = func(start, depth int) bool {
+		if depth == k {
+			return f(chosen)
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			chosen[depth] = i
+			if !rec(i+1, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	rec(0, 0)
+}
+
+// minimize removes duplicate layouts and layouts covering another one
+func minimize(layouts []layout) []layout {
+	sorted := make([]layout, len(layouts))
+	copy(sorted, layouts)
+	sort.Sort(layoutsBySize(sorted))
+
+	var res []layout
+	for _, l := range sorted {
+		redundant := false
+		for _, kept := range res {
+			if l.covers(kept) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			res = append(res, l)
+		}
+	}
+	return res
+}
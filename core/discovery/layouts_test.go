@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeLayouts(t *testing.T) {
+	for _, test := range []struct {
+		policy   string
+		expected []layout
+	}{
+		{"OR('A.member', 'B.member')", []layout{{"A": 1}, {"B": 1}}},
+		{"AND('A.member', 'B.member')", []layout{{"A": 1, "B": 1}}},
+		{"AND('A.member', 'A.member')", []layout{{"A": 2}}},
+		{"OR('A.member', AND('A.member', 'B.member'))", []layout{{"A": 1}}},
+		{"AND(OR('A.member', 'B.member'), OR('A.member', 'C.member'))", []layout{{"A": 1, "B": 1}, {"A": 1, "C": 1}, {"A": 2}, {"B": 1, "C": 1}}},
+		// Peers satisfy the peer role, but not the roles of other kinds of identities
+		{"AND('A.peer', 'B.member')", []layout{{"A": 1, "B": 1}}},
+		{"OR('A.admin', 'B.member')", []layout{{"B": 1}}},
+		{"OR('A.client', 'A.orderer', 'B.member')", []layout{{"B": 1}}},
+	} {
+		envelope, err := cauthdsl.FromString(test.policy)
+		assert.NoError(t, err, test.policy)
+		layouts, err := computeLayouts(envelope)
+		assert.NoError(t, err, test.policy)
+		assert.Equal(t, test.expected, layouts, test.policy)
+	}
+}
+
+func TestComputeLayoutsNOutOf(t *testing.T) {
+	envelope := cauthdsl.SignedByAnyMember([]string{"A", "B", "C"})
+	envelope.Rule = cauthdsl.NOutOf(2, []*cb.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(1), cauthdsl.SignedBy(2)})
+	layouts, err := computeLayouts(envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, []layout{{"A": 1, "B": 1}, {"A": 1, "C": 1}, {"B": 1, "C": 1}}, layouts)
+}
+
+func TestComputeLayoutsUnsatisfiable(t *testing.T) {
+	envelope := cauthdsl.SignedByMspMember("A")
+	envelope.Rule = cauthdsl.NOutOf(2, []*cb.SignaturePolicy{cauthdsl.SignedBy(0)})
+	layouts, err := computeLayouts(envelope)
+	assert.NoError(t, err)
+	assert.Empty(t, layouts)
+}
+
+func TestComputeLayoutsAdminOnly(t *testing.T) {
+	// Only admins may endorse, so no layout of peers satisfies the policy
+	for _, policy := range []string{"OR('A.admin', 'B.admin')", "AND('A.admin', 'B.member')"} {
+		envelope, err := cauthdsl.FromString(policy)
+		assert.NoError(t, err, policy)
+		layouts, err := computeLayouts(envelope)
+		assert.NoError(t, err, policy)
+		assert.Empty(t, layouts, policy)
+	}
+}
+
+func TestComputeLayoutsErrors(t *testing.T) {
+	_, err := computeLayouts(nil)
+	assert.Error(t, err)
+
+	envelope := cauthdsl.SignedByMspMember("A")
+	envelope.Rule = cauthdsl.SignedBy(1)
+	_, err = computeLayouts(envelope)
+	assert.Error(t, err, "Out of range identity index")
+
+	envelope = cauthdsl.SignedByMspMember("A")
+	envelope.Identities[0].PrincipalClassification = mspprotos.MSPPrincipal_IDENTITY
+	_, err = computeLayouts(envelope)
+	assert.Error(t, err, "Identity principals are not supported")
+}
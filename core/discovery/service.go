@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discovery implements the peer service telling clients which peers
+// to collect endorsements from
+package discovery
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/policy"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("discovery")
+
+// Support provides the channel information the discovery service relies on
+type Support interface {
+	// ChannelExists returns whether the peer has joined the given channel
+	ChannelExists(channel string) bool
+	// Peers returns the peers of the given channel known to this peer,
+	// this peer included
+	Peers(channel string) []*discprotos.Peer
+	// ChaincodePolicy returns the endorsement policy of the given chaincode
+	// instantiated on the given channel
+	ChaincodePolicy(channel string, chaincode string) (*cb.SignaturePolicyEnvelope, error)
+}
+
+type service struct {
+	support       Support
+	policyChecker policy.PolicyChecker
+}
+
+// NewService returns a discovery service answering from the given support,
+// and authenticating requests with the given policy checker
+func NewService(support Support, policyChecker policy.PolicyChecker) discprotos.DiscoveryServer {
+	return &service{support: support, policyChecker: policyChecker}
+}
+
+// Discover returns the peers of the requested channel and the layouts of
+// those peers satisfying the endorsement policy of the requested chaincode
+func (s *service) Discover(ctx context.Context, signedReq *discprotos.SignedRequest) (*discprotos.Response, error) {
+	if signedReq == nil {
+		return nil, fmt.Errorf("Nil request")
+	}
+	req := &discprotos.Request{}
+	if err := proto.Unmarshal(signedReq.Payload, req); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling request: %s", err)
+	}
+	if req.Channel == "" {
+		return nil, fmt.Errorf("Missing channel")
+	}
+	if req.Chaincode == "" {
+		return nil, fmt.Errorf("Missing chaincode")
+	}
+	if !s.support.ChannelExists(req.Channel) {
+		return nil, fmt.Errorf("Channel %s not found", req.Channel)
+	}
+
+	sd := []*cb.SignedData{{
+		Data:      signedReq.Payload,
+		Identity:  req.Creator,
+		Signature: signedReq.Signature,
+	}}
+	if err := s.policyChecker.CheckPolicyBySignedData(req.Channel, policies.ChannelApplicationReaders, sd); err != nil {
+		logger.Warningf("Discovery request on channel %s rejected: %s", req.Channel, err)
+		return nil, fmt.Errorf("Access denied on channel %s", req.Channel)
+	}
+
+	envelope, err := s.support.ChaincodePolicy(req.Channel, req.Chaincode)
+	if err != nil {
+		return nil, fmt.Errorf("Failed retrieving the endorsement policy of chaincode %s on channel %s: %s", req.Chaincode, req.Channel, err)
+	}
+	layouts, err := computeLayouts(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing the layouts of chaincode %s on channel %s: %s", req.Chaincode, req.Channel, err)
+	}
+
+	resp := &discprotos.Response{PeersByOrg: make(map[string]*discprotos.Peers)}
+	for _, peer := range s.support.Peers(req.Channel) {
+		peers, exists := resp.PeersByOrg[peer.MspId]
+		if !exists {
+			peers = &discprotos.Peers{}
+			resp.PeersByOrg[peer.MspId] = peers
+		}
+		peers.Peers = append(peers.Peers, peer)
+	}
+	for _, peers := range resp.PeersByOrg {
+		sort.Sort(peersByHeight(peers.Peers))
+	}
+
+	for _, l := range layouts {
+		if !satisfiable(l, resp.PeersByOrg) {
+			continue
+		}
+		quantities := make(map[string]uint32, len(l))
+		for org, n := range l {
+			quantities[org] = uint32(n)
+		}
+		resp.Layouts = append(resp.Layouts, &discprotos.Layout{QuantitiesByOrg: quantities})
+	}
+	logger.Debugf("Found %d layouts out of %d for chaincode %s on channel %s", len(resp.Layouts), len(layouts), req.Chaincode, req.Channel)
+	return resp, nil
+}
+
+// satisfiable returns whether there are enough peers in every organization
+// of the given layout
+func satisfiable(l layout, peersByOrg map[string]*discprotos.Peers) bool {
+	for org, n := range l {
+		peers, exists := peersByOrg[org]
+		if !exists || len(peers.Peers) < n {
+			return false
+		}
+	}
+	return true
+}
+
+// peersByHeight sorts peers by decreasing ledger height, then by endpoint
+type peersByHeight []*discprotos.Peer
+
+func (p peersByHeight) Len() int      { return len(p) }
+func (p peersByHeight) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p peersByHeight) Less(i, j int) bool {
+	if p[i].LedgerHeight != p[j].LedgerHeight {
+		return p[i].LedgerHeight > p[j].LedgerHeight
+	}
+	return p[i].Endpoint < p[j].Endpoint
+}
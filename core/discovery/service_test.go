@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type mockSupport struct {
+	peers    []*discprotos.Peer
+	policies map[string]*cb.SignaturePolicyEnvelope
+}
+
+func (*mockSupport) ChannelExists(channel string) bool {
+	return channel == "mychannel"
+}
+
+func (s *mockSupport) Peers(channel string) []*discprotos.Peer {
+	return s.peers
+}
+
+func (s *mockSupport) ChaincodePolicy(channel string, chaincode string) (*cb.SignaturePolicyEnvelope, error) {
+	envelope, exists := s.policies[chaincode]
+	if !exists {
+		return nil, errors.New("not instantiated")
+	}
+	return envelope, nil
+}
+
+type mockPolicyChecker struct {
+	checked []*cb.SignedData
+}
+
+func (*mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	return errors.New("unexpected call")
+}
+
+func (*mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	return errors.New("unexpected call")
+}
+
+func (c *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*cb.SignedData) error {
+	if policyName != policies.ChannelApplicationReaders {
+		return errors.New("unexpected policy")
+	}
+	c.checked = append(c.checked, sd...)
+	if string(sd[0].Identity) != "reader" {
+		return errors.New("not a reader")
+	}
+	return nil
+}
+
+func signedRequest(t *testing.T, req *discprotos.Request) *discprotos.SignedRequest {
+	payload, err := proto.Marshal(req)
+	assert.NoError(t, err)
+	return &discprotos.SignedRequest{Payload: payload, Signature: []byte("signature")}
+}
+
+func TestDiscover(t *testing.T) {
+	and, err := cauthdsl.FromString("AND('A.member', 'B.member')")
+	assert.NoError(t, err)
+	or, err := cauthdsl.FromString("OR('A.member', 'B.member', 'C.member')")
+	assert.NoError(t, err)
+	support := &mockSupport{
+		peers: []*discprotos.Peer{
+			{Endpoint: "p0.a:7051", MspId: "A", LedgerHeight: 5},
+			{Endpoint: "p1.a:7051", MspId: "A", LedgerHeight: 10},
+			{Endpoint: "p0.b:7051", MspId: "B", LedgerHeight: 10},
+		},
+		policies: map[string]*cb.SignaturePolicyEnvelope{"and": and, "or": or},
+	}
+	checker := &mockPolicyChecker{}
+	svc := NewService(support, checker)
+
+	resp, err := svc.Discover(context.Background(), signedRequest(t, &discprotos.Request{Creator: []byte("reader"), Channel: "mychannel", Chaincode: "and"}))
+	assert.NoError(t, err)
+	assert.Len(t, resp.PeersByOrg, 2)
+	assert.Equal(t, "p1.a:7051", resp.PeersByOrg["A"].Peers[0].Endpoint, "Peers are sorted by decreasing height")
+	assert.Equal(t, "p0.a:7051", resp.PeersByOrg["A"].Peers[1].Endpoint)
+	assert.Len(t, resp.PeersByOrg["B"].Peers, 1)
+	assert.Equal(t, []*discprotos.Layout{{QuantitiesByOrg: map[string]uint32{"A": 1, "B": 1}}}, resp.Layouts)
+	assert.Equal(t, []byte("signature"), checker.checked[0].Signature)
+
+	// Layouts requiring peers of C are left out as there are none
+	resp, err = svc.Discover(context.Background(), signedRequest(t, &discprotos.Request{Creator: []byte("reader"), Channel: "mychannel", Chaincode: "or"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []*discprotos.Layout{
+		{QuantitiesByOrg: map[string]uint32{"A": 1}},
+		{QuantitiesByOrg: map[string]uint32{"B": 1}},
+	}, resp.Layouts)
+}
+
+func TestDiscoverErrors(t *testing.T) {
+	support := &mockSupport{policies: map[string]*cb.SignaturePolicyEnvelope{"cc": cauthdsl.SignedByMspMember("A")}}
+	svc := NewService(support, &mockPolicyChecker{})
+
+	_, err := svc.Discover(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = svc.Discover(context.Background(), &discprotos.SignedRequest{Payload: []byte("garbage")})
+	assert.Error(t, err)
+
+	for _, req := range []*discprotos.Request{
+		{Creator: []byte("reader"), Chaincode: "cc"},
+		{Creator: []byte("reader"), Channel: "mychannel"},
+		{Creator: []byte("reader"), Channel: "otherchannel", Chaincode: "cc"},
+		{Creator: []byte("stranger"), Channel: "mychannel", Chaincode: "cc"},
+		{Creator: []byte("reader"), Channel: "mychannel", Chaincode: "missing"},
+	} {
+		_, err = svc.Discover(context.Background(), signedRequest(t, req))
+		assert.Error(t, err, req.String())
+	}
+
+	resp, err := svc.Discover(context.Background(), signedRequest(t, &discprotos.Request{Creator: []byte("reader"), Channel: "mychannel", Chaincode: "cc"}))
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Layouts, "No peer of A is known")
+}
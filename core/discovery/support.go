@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	gossipService "github.com/hyperledger/fabric/gossip/service"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+)
+
+type peerSupport struct {
+}
+
+// NewPeerSupport returns a Support backed by the ledgers, the MSPs and the
+// gossip service of the peer
+func NewPeerSupport() Support {
+	return &peerSupport{}
+}
+
+// ChannelExists returns whether the peer has joined the given channel
+func (*peerSupport) ChannelExists(channel string) bool {
+	return peer.GetLedger(channel) != nil
+}
+
+// Peers returns the peers of the given channel whose identity is valid
+// according to the MSPs of the channel, this peer included
+func (s *peerSupport) Peers(channel string) []*discprotos.Peer {
+	var res []*discprotos.Peer
+	if self, err := s.self(channel); err != nil {
+		logger.Warningf("Failed describing this peer on channel %s: %s", channel, err)
+	} else {
+		res = append(res, self)
+	}
+
+	gossip := gossipService.GetGossipService()
+	deserializer := mspmgmt.GetIdentityDeserializer(channel)
+	for _, member := range gossip.PeersOfChannel(gossipCommon.ChainID(channel)) {
+		identity, err := gossip.PeerIdentity(member.PKIid)
+		if err != nil {
+			logger.Debugf("Skipping peer %s: %s", member.Endpoint, err)
+			continue
+		}
+		id, err := deserializer.DeserializeIdentity(identity)
+		if err != nil {
+			logger.Debugf("Skipping peer %s with invalid identity: %s", member.Endpoint, err)
+			continue
+		}
+		if err = id.Validate(); err != nil {
+			logger.Debugf("Skipping peer %s with invalid identity: %s", member.Endpoint, err)
+			continue
+		}
+		res = append(res, &discprotos.Peer{
+			Endpoint:     member.Endpoint,
+			MspId:        id.GetMSPIdentifier(),
+			Identity:     identity,
+			LedgerHeight: member.Properties.GetLedgerHeight(),
+		})
+	}
+	return res
+}
+
+// self describes this peer on the given channel
+func (*peerSupport) self(channel string) (*discprotos.Peer, error) {
+	endpoint, err := peer.GetPeerEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := mspmgmt.GetLocalSigningIdentityOrPanic().Serialize()
+	if err != nil {
+		return nil, err
+	}
+	sId := &mspprotos.SerializedIdentity{}
+	if err = proto.Unmarshal(identity, sId); err != nil {
+		return nil, err
+	}
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return nil, fmt.Errorf("Channel %s not found", channel)
+	}
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	return &discprotos.Peer{
+		Endpoint:     endpoint.Address,
+		MspId:        sId.Mspid,
+		Identity:     identity,
+		LedgerHeight: info.Height,
+	}, nil
+}
+
+// ChaincodePolicy returns the endorsement policy of the given chaincode as
+// recorded by LSCC on the given channel
+func (*peerSupport) ChaincodePolicy(channel string, chaincode string) (*cb.SignaturePolicyEnvelope, error) {
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return nil, fmt.Errorf("Channel %s not found", channel)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	cdbytes, err := qe.GetState("lscc", chaincode)
+	if err != nil {
+		return nil, err
+	}
+	if cdbytes == nil {
+		return nil, fmt.Errorf("Chaincode %s is not instantiated", chaincode)
+	}
+	cd := &ccprovider.ChaincodeData{}
+	if err = proto.Unmarshal(cdbytes, cd); err != nil {
+		return nil, fmt.Errorf("Invalid chaincode data: %s", err)
+	}
+	envelope := &cb.SignaturePolicyEnvelope{}
+	if err = proto.Unmarshal(cd.Policy, envelope); err != nil {
+		return nil, fmt.Errorf("Invalid endorsement policy: %s", err)
+	}
+	return envelope, nil
+}
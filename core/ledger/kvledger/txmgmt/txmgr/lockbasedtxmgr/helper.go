@@ -51,7 +51,7 @@ func (h *queryHelper) getStateMultipleKeys(namespace string, keys []string) ([][
 	h.checkDone()
 	versionedValues, err := h.txmgr.db.GetStateMultipleKeys(namespace, keys)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	values := make([][]byte, len(versionedValues))
 	for i, versionedValue := range versionedValues {
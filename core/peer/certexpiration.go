@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+	"time"
+
+	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/spf13/viper"
+)
+
+// GetCertExpirationWarningThresholds returns the times before expiration at
+// which warnings are logged about the certificates the peer relies on
+func GetCertExpirationWarningThresholds() ([]time.Duration, error) {
+	if !viper.IsSet("peer.certExpirationWarningThresholds") {
+		return crypto.DefaultExpirationWarningThresholds, nil
+	}
+	var thresholds []time.Duration
+	for _, value := range viper.GetStringSlice("peer.certExpirationWarningThresholds") {
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid certificate expiration warning threshold %s: %s", value, err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// InitCertExpirationTracking configures the expiration tracker of the peer
+// and starts tracking the enrollment certificate of its serialized signing
+// identity along with its TLS certificate. An error is returned if the
+// enrollment certificate has already expired.
+func InitCertExpirationTracking(signingIdentity []byte, tlsCert []byte) error {
+	thresholds, err := GetCertExpirationWarningThresholds()
+	if err != nil {
+		return err
+	}
+	if expiresAt := crypto.ExpiresAt(signingIdentity); !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return fmt.Errorf("The enrollment certificate of the local MSP expired on %s, a renewed certificate must be installed before starting the peer", expiresAt)
+	}
+
+	expirations, err := crypto.LocalCertificateExpirations(signingIdentity, tlsCert)
+	if err != nil {
+		return err
+	}
+	tracker := crypto.ExpirationTrackerInstance()
+	tracker.SetThresholds(thresholds)
+	tracker.Track(crypto.LocalSource, expirations)
+	return nil
+}
+
+// trackChannelCertificates tracks the expiration of the certificates of the
+// MSPs defined in the current configuration of a channel
+func trackChannelCertificates(cm configtxapi.Manager) {
+	expirations, err := crypto.ChannelCertificateExpirations(cm.ConfigEnvelope().Config)
+	if err != nil {
+		peerLogger.Warningf("Failed extracting the certificates of channel %s for expiration tracking: %s", cm.ChainID(), err)
+		return
+	}
+	crypto.ExpirationTrackerInstance().Track(cm.ChainID(), expirations)
+}
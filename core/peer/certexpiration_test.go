@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func serializedIdentityExpiringAt(t *testing.T, notAfter time.Time) []byte {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "peer0"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	sID, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   "Org1MSP",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+	assert.NoError(t, err)
+	return sID
+}
+
+func TestGetCertExpirationWarningThresholds(t *testing.T) {
+	defer viper.Set("peer.certExpirationWarningThresholds", nil)
+
+	viper.Set("peer.certExpirationWarningThresholds", []string{"720h", "24h"})
+	thresholds, err := GetCertExpirationWarningThresholds()
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{720 * time.Hour, 24 * time.Hour}, thresholds)
+
+	viper.Set("peer.certExpirationWarningThresholds", []string{"a month"})
+	_, err = GetCertExpirationWarningThresholds()
+	assert.Error(t, err)
+}
+
+func TestInitCertExpirationTracking(t *testing.T) {
+	defer crypto.ExpirationTrackerInstance().Untrack(crypto.LocalSource)
+
+	err := InitCertExpirationTracking(serializedIdentityExpiringAt(t, time.Now().Add(-time.Hour)), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The enrollment certificate of the local MSP expired")
+
+	assert.NoError(t, InitCertExpirationTracking(serializedIdentityExpiringAt(t, time.Now().Add(time.Hour)), nil))
+	var local []crypto.CertificateExpiration
+	for _, ce := range crypto.ExpirationTrackerInstance().Expirations() {
+		if ce.Source == crypto.LocalSource {
+			local = append(local, ce)
+		}
+	}
+	assert.Len(t, local, 1)
+	assert.Equal(t, crypto.EnrollmentCertificate, local[0].Kind)
+	assert.Equal(t, "Org1MSP", local[0].MSPID)
+}
@@ -198,7 +198,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 	configtxManager, err := configtx.NewManagerImpl(
 		envelopeConfig,
 		configtxInitializer,
-		[]func(cm configtxapi.Manager){gossipCallbackWrapper, trustedRootsCallbackWrapper},
+		[]func(cm configtxapi.Manager){gossipCallbackWrapper, trustedRootsCallbackWrapper, trackChannelCertificates},
 	)
 	if err != nil {
 		return err
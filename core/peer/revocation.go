@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/spf13/viper"
+)
+
+// RevocationListSource lists the files or directories holding the online
+// revocation lists of an MSP
+type RevocationListSource struct {
+	MSPID string   `mapstructure:"mspID"`
+	Paths []string `mapstructure:"paths"`
+}
+
+// GetRevocationListSources returns the files or directories of the online
+// revocation lists configured for the peer, by MSP identifier. Relative
+// paths are relative to the peer configuration file.
+func GetRevocationListSources() (map[string][]string, error) {
+	var sources []RevocationListSource
+	if err := viper.UnmarshalKey("peer.revocationLists.sources", &sources); err != nil {
+		return nil, fmt.Errorf("Invalid revocation list sources: %s", err)
+	}
+	configDir := filepath.Dir(viper.ConfigFileUsed())
+	res := make(map[string][]string)
+	for _, source := range sources {
+		if source.MSPID == "" {
+			return nil, fmt.Errorf("Invalid revocation list source %v: missing MSP ID", source.Paths)
+		}
+		for _, path := range source.Paths {
+			res[source.MSPID] = append(res[source.MSPID], config.TranslatePath(configDir, path))
+		}
+	}
+	return res, nil
+}
+
+// InitRevocationListWatcher loads the online revocation lists configured
+// for the peer and starts reloading them, returning nil if none is configured
+func InitRevocationListWatcher() (*msp.RevocationListWatcher, error) {
+	sources, err := GetRevocationListSources()
+	if err != nil || len(sources) == 0 {
+		return nil, err
+	}
+	watcher := msp.NewRevocationListWatcher(sources, viper.GetDuration("peer.revocationLists.refreshInterval"))
+	if err := watcher.Start(); err != nil {
+		return nil, fmt.Errorf("Failed loading the revocation lists: %s", err)
+	}
+	peerLogger.Infof("Watching the revocation lists of MSPs %v", sources)
+	return watcher, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRevocationListSources(t *testing.T) {
+	defer viper.Set("peer.revocationLists.sources", nil)
+	defer viper.Set("peer.revocationLists.refreshInterval", nil)
+
+	sources, err := GetRevocationListSources()
+	assert.NoError(t, err)
+	assert.Empty(t, sources)
+	watcher, err := InitRevocationListWatcher()
+	assert.NoError(t, err)
+	assert.Nil(t, watcher)
+
+	// MSP identifiers keep their case, and the paths of an MSP listed
+	// several times are merged
+	viper.Set("peer.revocationLists.sources", []interface{}{
+		map[string]interface{}{"mspID": "Org1MSP", "paths": []string{"/crls/org1"}},
+		map[string]interface{}{"mspID": "Org2MSP", "paths": []string{"/crls/org2"}},
+		map[string]interface{}{"mspID": "Org1MSP", "paths": []string{"/crls/org1.pem"}},
+	})
+	sources, err = GetRevocationListSources()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Org1MSP": {"/crls/org1", "/crls/org1.pem"},
+		"Org2MSP": {"/crls/org2"},
+	}, sources)
+
+	// relative paths are relative to the configuration file
+	defer viper.SetConfigFile(viper.ConfigFileUsed())
+	viper.SetConfigFile("/etc/hyperledger/fabric/core.yaml")
+	viper.Set("peer.revocationLists.sources", []interface{}{
+		map[string]interface{}{"mspID": "Org1MSP", "paths": []string{"crls/org1"}},
+	})
+	sources, err = GetRevocationListSources()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Org1MSP": {filepath.Join("/etc/hyperledger/fabric", "crls", "org1")},
+	}, sources)
+
+	// the revocation lists must be available at startup
+	viper.Set("peer.revocationLists.sources", []interface{}{
+		map[string]interface{}{"mspID": "Org1MSP", "paths": []string{"/crls/org1"}},
+	})
+	_, err = InitRevocationListWatcher()
+	assert.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "crls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	viper.Set("peer.revocationLists.sources", []interface{}{
+		map[string]interface{}{"mspID": "Org1MSP", "paths": []string{dir}},
+	})
+	viper.Set("peer.revocationLists.refreshInterval", time.Minute)
+	watcher, err = InitRevocationListWatcher()
+	assert.NoError(t, err)
+	assert.NotNil(t, watcher)
+	watcher.Stop()
+
+	viper.Set("peer.revocationLists.sources", []interface{}{
+		map[string]interface{}{"paths": []string{dir}},
+	})
+	_, err = GetRevocationListSources()
+	assert.Error(t, err)
+}
@@ -161,6 +161,10 @@ func (*mockStub) InvokeChaincode(chaincodeName string, args [][]byte, channel st
 	panic("implement me")
 }
 
+func (*mockStub) InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) peer.Response {
+	panic("implement me")
+}
+
 func (s *mockStub) GetState(key string) ([]byte, error) {
 	args := s.Called(key)
 	if args.Get(1) == nil {
@@ -169,6 +173,10 @@ func (s *mockStub) GetState(key string) ([]byte, error) {
 	return nil, args.Get(1).(error)
 }
 
+func (*mockStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	panic("implement me")
+}
+
 func (s *mockStub) PutState(key string, value []byte) error {
 	args := s.Called(key)
 	if args.Get(0) == nil {
@@ -181,6 +189,14 @@ func (*mockStub) DelState(key string) error {
 	panic("implement me")
 }
 
+func (*mockStub) StartWriteBatch() {
+	panic("implement me")
+}
+
+func (*mockStub) FinishWriteBatch() error {
+	panic("implement me")
+}
+
 func (*mockStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
 	panic("implement me")
 }
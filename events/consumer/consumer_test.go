@@ -14,9 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/util"
 	coreutil "github.com/hyperledger/fabric/core/testutil"
 	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
 	ehpb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -39,7 +41,21 @@ type BadAdapter struct {
 }
 
 var peerAddress = "0.0.0.0:7303"
-var ies = []*ehpb.Interest{{EventType: ehpb.EventType_CHAINCODE, RegInfo: &ehpb.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &ehpb.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event1"}}}}
+var ies = []*ehpb.Interest{{EventType: ehpb.EventType_CHAINCODE, RegInfo: &ehpb.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &ehpb.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event1"}}, ChainID: util.GetTestChainID()}}
+
+type mockPolicyChecker struct{}
+
+func (*mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *ehpb.SignedProposal) error {
+	return nil
+}
+
+func (*mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *ehpb.SignedProposal) error {
+	return nil
+}
+
+func (*mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
+	return nil
+}
 
 var adapter *MockAdapter
 var obcEHClient *EventsClient
@@ -66,7 +82,7 @@ func (a *BadAdapter) Disconnected(err error) {
 
 func (a *MockAdapter) GetInterestedEvents() ([]*ehpb.Interest, error) {
 	return []*ehpb.Interest{
-		&ehpb.Interest{EventType: ehpb.EventType_BLOCK},
+		&ehpb.Interest{EventType: ehpb.EventType_BLOCK, ChainID: util.GetTestChainID()},
 	}, nil
 }
 
@@ -256,7 +272,8 @@ func TestMain(m *testing.M) {
 
 	ehServer := producer.NewEventsServer(
 		uint(viper.GetInt("peer.events.buffersize")),
-		viper.GetDuration("peer.events.timeout"))
+		viper.GetDuration("peer.events.timeout"),
+		&mockPolicyChecker{})
 	ehpb.RegisterEventsServer(grpcServer, ehServer)
 
 	go grpcServer.Serve(lis)
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	ehpb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// FileCheckpoint persists the number of the last block processed by a consumer to a file
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint returns a FileCheckpoint persisted to the given file
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the number of the last processed block,
+// and false if no block was processed yet
+func (c *FileCheckpoint) Load() (uint64, bool, error) {
+	bytes, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	number, err := strconv.ParseUint(strings.TrimSpace(string(bytes)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid checkpoint %s: %s", c.path, err)
+	}
+	return number, true, nil
+}
+
+// Save records the given block as the last processed block
+func (c *FileCheckpoint) Save(number uint64) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that a crash
+	// doesn't leave a partially written checkpoint behind
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(number, 10)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// DeliverClient consumes the blocks, or the chaincode events, committed on a
+// channel from the Deliver service of a peer, and resumes from the last
+// processed block after a restart
+type DeliverClient struct {
+	client     ehpb.DeliverClient
+	channel    string
+	signer     crypto.LocalSigner
+	checkpoint *FileCheckpoint
+}
+
+// NewDeliverClient returns a DeliverClient consuming the given channel over the given
+// connection, signing its requests with the given signer, and persisting the last
+// processed block to the given checkpoint, if not nil
+func NewDeliverClient(conn *grpc.ClientConn, channel string, signer crypto.LocalSigner, checkpoint *FileCheckpoint) *DeliverClient {
+	return &DeliverClient{
+		client:     ehpb.NewDeliverClient(conn),
+		channel:    channel,
+		signer:     signer,
+		checkpoint: checkpoint,
+	}
+}
+
+// deliverClientStream is the client side of a Deliver or a DeliverChaincodeEvents stream
+type deliverClientStream interface {
+	Send(*cb.Envelope) error
+	Recv() (*ehpb.DeliverResponse, error)
+	CloseSend() error
+}
+
+// ConsumeBlocks passes the blocks of the channel to the given handler, starting
+// after the checkpointed block, or from the given block if there is no checkpoint.
+// It returns when the handler fails, the stream breaks or the context is done
+func (c *DeliverClient) ConsumeBlocks(ctx context.Context, start uint64, handler func(*cb.Block) error) error {
+	stream, err := c.client.Deliver(ctx)
+	if err != nil {
+		return err
+	}
+	return c.consume(stream, start, func(resp *ehpb.DeliverResponse) (uint64, bool, error) {
+		block := resp.GetBlock()
+		if block == nil || block.Header == nil {
+			return 0, false, nil
+		}
+		return block.Header.Number, true, handler(block)
+	})
+}
+
+// ConsumeChaincodeEvents passes the chaincode events of the blocks of the channel
+// to the given handler, starting after the checkpointed block, or from the given
+// block if there is no checkpoint.
+// It returns when the handler fails, the stream breaks or the context is done
+func (c *DeliverClient) ConsumeChaincodeEvents(ctx context.Context, start uint64, handler func(*ehpb.BlockChaincodeEvents) error) error {
+	stream, err := c.client.DeliverChaincodeEvents(ctx)
+	if err != nil {
+		return err
+	}
+	return c.consume(stream, start, func(resp *ehpb.DeliverResponse) (uint64, bool, error) {
+		events := resp.GetChaincodeEvents()
+		if events == nil {
+			return 0, false, nil
+		}
+		return events.Number, true, handler(events)
+	})
+}
+
+// consume requests blocks from the resume point on, and passes the responses
+// to the given function, which returns the number of the block it handled
+func (c *DeliverClient) consume(stream deliverClientStream, start uint64, handle func(*ehpb.DeliverResponse) (uint64, bool, error)) error {
+	defer stream.CloseSend()
+
+	start, err := c.resumeFrom(start)
+	if err != nil {
+		return err
+	}
+	env, err := c.seekEnvelope(start)
+	if err != nil {
+		return err
+	}
+	if err = stream.Send(env); err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if status, isStatus := resp.Type.(*ehpb.DeliverResponse_Status); isStatus {
+			if status.Status == cb.Status_SUCCESS {
+				return nil
+			}
+			return fmt.Errorf("deliver of channel %s failed with status %s", c.channel, status.Status)
+		}
+		number, handled, err := handle(resp)
+		if err != nil {
+			return err
+		}
+		if !handled {
+			consumerLogger.Warningf("Ignoring unexpected deliver response %v", resp)
+			continue
+		}
+		if c.checkpoint == nil {
+			continue
+		}
+		if err = c.checkpoint.Save(number); err != nil {
+			return fmt.Errorf("failed checkpointing block %d: %s", number, err)
+		}
+	}
+}
+
+// resumeFrom returns the block following the checkpointed block,
+// or the given block if there is no checkpoint
+func (c *DeliverClient) resumeFrom(start uint64) (uint64, error) {
+	if c.checkpoint == nil {
+		return start, nil
+	}
+	number, exists, err := c.checkpoint.Load()
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return start, nil
+	}
+	consumerLogger.Debugf("Resuming channel %s after checkpointed block %d", c.channel, number)
+	return number + 1, nil
+}
+
+// seekEnvelope returns a signed request for all blocks from the given block on
+func (c *DeliverClient) seekEnvelope(start uint64) (*cb.Envelope, error) {
+	seekInfo := &ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: start}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}}},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+	return utils.CreateSignedEnvelope(cb.HeaderType_DELIVER_SEEK_INFO, c.channel, c.signer, seekInfo, int32(0), uint64(0))
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consumer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "consumer", "checkpoint")
+	c := NewFileCheckpoint(path)
+
+	// Nothing is checkpointed yet
+	_, exists, err := c.Load()
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, c.Save(0))
+	number, exists, err := c.Load()
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(0), number)
+
+	assert.NoError(t, c.Save(42))
+	number, _, err = c.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), number)
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	// A corrupted checkpoint is reported
+	assert.NoError(t, ioutil.WriteFile(path, []byte("foo"), 0600))
+	_, _, err = c.Load()
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ package producer
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -107,17 +108,90 @@ func SendProducerBlockEvent(block *common.Block) error {
 	return Send(CreateBlockEvent(bevent))
 }
 
-//CreateBlockEvent creates a Event from a Block
+// SendProducerTxStatusEvents sends the status of the transactions
+// of the given committed block to the clients interested in them,
+// all at once. Malformed transactions, which are committed as
+// invalid, are skipped.
+func SendProducerTxStatusEvents(block *common.Block) error {
+	hl := txStatusHandlers()
+	if hl == nil || hl.empty() {
+		return nil
+	}
+
+	var events []*pb.Event
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Warningf("Not sending the status of tx %d of block %d, error getting it: %s", txIndex, block.Header.Number, err)
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			logger.Warningf("Not sending the status of tx %d of block %d, could not extract payload from envelope: %s", txIndex, block.Header.Number, err)
+			continue
+		}
+		if payload.Header == nil {
+			logger.Warningf("Not sending the status of tx %d of block %d, missing header", txIndex, block.Header.Number)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			logger.Warningf("Not sending the status of tx %d of block %d, invalid channel header: %s", txIndex, block.Header.Number, err)
+			continue
+		}
+		if chdr.TxId == "" || !hl.interested(chdr.TxId) {
+			continue
+		}
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			logger.Warningf("Not sending the status of tx %s of block %d, invalid signature header: %s", chdr.TxId, block.Header.Number, err)
+			continue
+		}
+		// Blocks committed before validation flags were recorded are valid
+		code := pb.TxValidationCode_VALID
+		if txIndex < len(txsFilter) {
+			code = txsFilter.Flag(txIndex)
+		}
+		txStatus := &pb.TxStatus{
+			TxId:           chdr.TxId,
+			BlockNumber:    block.Header.Number,
+			TxIndex:        uint32(txIndex),
+			ValidationCode: code,
+			Creator:        shdr.Creator,
+		}
+		events = append(events, CreateTxStatusEvent(txStatus, chdr.ChannelId))
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	if err := sendEvents(events); err != nil {
+		return fmt.Errorf("could not send the status of %d transactions of block %d: %s", len(events), block.Header.Number, err)
+	}
+	return nil
+}
+
+//CreateBlockEvent creates a Event from a Block, for the channel of the block
 func CreateBlockEvent(te *common.Block) *pb.Event {
-	return &pb.Event{Event: &pb.Event_Block{Block: te}}
+	channelID, err := utils.GetChainIDFromBlock(te)
+	if err != nil {
+		logger.Errorf("could not extract the channel of block event: %s", err)
+	}
+	return &pb.Event{Event: &pb.Event_Block{Block: te}, ChannelId: channelID}
+}
+
+//CreateChaincodeEvent creates a Event from a ChaincodeEvent of the given channel
+func CreateChaincodeEvent(te *pb.ChaincodeEvent, channelID string) *pb.Event {
+	return &pb.Event{Event: &pb.Event_ChaincodeEvent{ChaincodeEvent: te}, ChannelId: channelID}
 }
 
-//CreateChaincodeEvent creates a Event from a ChaincodeEvent
-func CreateChaincodeEvent(te *pb.ChaincodeEvent) *pb.Event {
-	return &pb.Event{Event: &pb.Event_ChaincodeEvent{ChaincodeEvent: te}}
+//CreateRejectionEvent creates an Event from TxResults of the given channel
+func CreateRejectionEvent(tx *pb.Transaction, errorMsg string, channelID string) *pb.Event {
+	return &pb.Event{Event: &pb.Event_Rejection{Rejection: &pb.Rejection{Tx: tx, ErrorMsg: errorMsg}}, ChannelId: channelID}
 }
 
-//CreateRejectionEvent creates an Event from TxResults
-func CreateRejectionEvent(tx *pb.Transaction, errorMsg string) *pb.Event {
-	return &pb.Event{Event: &pb.Event_Rejection{Rejection: &pb.Rejection{Tx: tx, ErrorMsg: errorMsg}}}
+//CreateTxStatusEvent creates an Event from the status of a transaction of the given channel
+func CreateTxStatusEvent(txStatus *pb.TxStatus, channelID string) *pb.Event {
+	return &pb.Event{Event: &pb.Event_TxStatus{TxStatus: txStatus}, ChannelId: channelID}
 }
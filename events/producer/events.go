@@ -22,6 +22,7 @@ import (
 	"time"
 
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 //---- event hub framework ----
@@ -160,6 +161,111 @@ func (hl *chaincodeHandlerList) foreach(e *pb.Event, action func(h *handler)) {
 	}
 }
 
+//txStatusHandlerList holds the handlers interested in the status of a given
+//transaction, by transaction ID, and the handlers interested in the status of
+//the transactions of their client, under the empty transaction ID
+type txStatusHandlerList struct {
+	sync.RWMutex
+	handlers map[string]map[*handler]bool
+}
+
+//getTxStatusKey returns the key of the handlers of the given interest
+func getTxStatusKey(ie *pb.Interest) (string, error) {
+	regInfo := ie.GetTxStatusRegInfo()
+	if regInfo == nil {
+		return "", fmt.Errorf("transaction status information not provided")
+	}
+	if regInfo.MyTransactions {
+		if regInfo.TxId != "" {
+			return "", fmt.Errorf("either a transaction ID or the transactions of the client must be provided")
+		}
+		return "", nil
+	}
+	if regInfo.TxId == "" {
+		return "", fmt.Errorf("transaction ID not provided")
+	}
+	return regInfo.TxId, nil
+}
+
+func (hl *txStatusHandlerList) add(ie *pb.Interest, h *handler) (bool, error) {
+	if h == nil {
+		return false, fmt.Errorf("cannot add nil transaction status handler")
+	}
+	key, err := getTxStatusKey(ie)
+	if err != nil {
+		return false, err
+	}
+
+	hl.Lock()
+	defer hl.Unlock()
+	handlerMap, ok := hl.handlers[key]
+	if !ok {
+		handlerMap = make(map[*handler]bool)
+		hl.handlers[key] = handlerMap
+	} else if _, ok = handlerMap[h]; ok {
+		return false, fmt.Errorf("handler exists for event type")
+	}
+	handlerMap[h] = true
+	return true, nil
+}
+
+func (hl *txStatusHandlerList) del(ie *pb.Interest, h *handler) (bool, error) {
+	key, err := getTxStatusKey(ie)
+	if err != nil {
+		return false, err
+	}
+
+	hl.Lock()
+	defer hl.Unlock()
+	handlerMap, ok := hl.handlers[key]
+	if !ok {
+		return false, fmt.Errorf("transaction ID %s not registered", key)
+	}
+	if _, ok = handlerMap[h]; !ok {
+		return false, fmt.Errorf("handler not registered for transaction ID %s", key)
+	}
+	delete(handlerMap, h)
+	if len(handlerMap) == 0 {
+		delete(hl.handlers, key)
+	}
+	return true, nil
+}
+
+//interested returns whether some handler is interested in the status
+//of the given transaction
+func (hl *txStatusHandlerList) interested(txID string) bool {
+	hl.RLock()
+	defer hl.RUnlock()
+	return len(hl.handlers[""]) > 0 || len(hl.handlers[txID]) > 0
+}
+
+//empty returns whether no handler is interested in transaction statuses
+func (hl *txStatusHandlerList) empty() bool {
+	hl.RLock()
+	defer hl.RUnlock()
+	return len(hl.handlers) == 0
+}
+
+func (hl *txStatusHandlerList) foreach(e *pb.Event, action func(h *handler)) {
+	hl.Lock()
+	defer hl.Unlock()
+
+	if e.GetTxStatus() == nil || e.GetTxStatus().TxId == "" {
+		return
+	}
+	//handlers of the transaction, then handlers of the clients
+	//interested in their own transactions, which are sent the
+	//event once even if they are both
+	for h := range hl.handlers[e.GetTxStatus().TxId] {
+		action(h)
+	}
+	for h := range hl.handlers[""] {
+		if !hl.handlers[e.GetTxStatus().TxId][h] {
+			action(h)
+		}
+	}
+}
+
 func (hl *genericHandlerList) add(ie *pb.Interest, h *handler) (bool, error) {
 	if h == nil {
 		return false, fmt.Errorf("cannot add nil generic handler")
@@ -202,8 +308,14 @@ type eventProcessor struct {
 	sync.RWMutex
 	eventConsumers map[pb.EventType]handlerList
 
-	//we could generalize this with mutiple channels each with its own size
-	eventChannel chan *pb.Event
+	//handlers of the clients which registered for events on some channel,
+	//to re-authorize them when the config of a channel changes
+	handlers map[*handler]bool
+
+	//we could generalize this with mutiple channels each with its own size.
+	//the events sent together, such as the transaction statuses of a block,
+	//take a single slot
+	eventChannel chan []*pb.Event
 
 	//timeout duration for producer to send an event.
 	//if < 0, if buffer full, unblocks immediately and not send
@@ -219,26 +331,47 @@ var gEventProcessor *eventProcessor
 func (ep *eventProcessor) start() {
 	logger.Info("Event processor started")
 	for {
-		//wait for event
-		e := <-ep.eventChannel
-
-		var hl handlerList
-		eType := getMessageType(e)
-		ep.Lock()
-		if hl, _ = ep.eventConsumers[eType]; hl == nil {
-			logger.Errorf("Event of type %s does not exist", eType)
-			ep.Unlock()
-			continue
+		//wait for events
+		for _, e := range <-ep.eventChannel {
+			ep.process(e)
 		}
-		//lock the handler map lock
+	}
+}
+
+//process sends the event to the interested handlers
+func (ep *eventProcessor) process(e *pb.Event) {
+	var hl handlerList
+	eType := getMessageType(e)
+	ep.Lock()
+	if hl, _ = ep.eventConsumers[eType]; hl == nil {
+		logger.Errorf("Event of type %s does not exist", eType)
 		ep.Unlock()
+		return
+	}
+	//lock the handler map lock
+	ep.Unlock()
 
-		hl.foreach(e, func(h *handler) {
-			if e.Event != nil {
-				h.SendMessage(e)
-			}
-		})
+	//the readers policy of the channel may have changed, so
+	//clients are re-authorized before being sent a config block
+	if e.GetBlock() != nil && utils.IsConfigBlock(e.GetBlock()) {
+		reauthorizeHandlers(e.ChannelId)
+	}
+
+	//only send the event to clients registered on its channel
+	var delivered []*handler
+	hl.foreach(e, func(h *handler) {
+		if e.Event != nil && h.accepts(e) {
+			h.SendMessage(e)
+			delivered = append(delivered, h)
+		}
+	})
 
+	//the status of a given transaction is only delivered once, so the
+	//registrations for it are removed rather than kept forever
+	if txStatus := e.GetTxStatus(); txStatus != nil {
+		for _, h := range delivered {
+			h.forgetTxStatus(e.ChannelId, txStatus.TxId)
+		}
 	}
 }
 
@@ -248,7 +381,7 @@ func initializeEvents(bufferSize uint, tout time.Duration) {
 		panic("should not be called twice")
 	}
 
-	gEventProcessor = &eventProcessor{eventConsumers: make(map[pb.EventType]handlerList), eventChannel: make(chan *pb.Event, bufferSize), timeout: tout}
+	gEventProcessor = &eventProcessor{eventConsumers: make(map[pb.EventType]handlerList), handlers: make(map[*handler]bool), eventChannel: make(chan []*pb.Event, bufferSize), timeout: tout}
 
 	addInternalEventTypes()
 
@@ -272,6 +405,8 @@ func AddEventType(eventType pb.EventType) error {
 		gEventProcessor.eventConsumers[eventType] = &chaincodeHandlerList{handlers: make(map[string]map[string]map[*handler]bool)}
 	case pb.EventType_REJECTION:
 		gEventProcessor.eventConsumers[eventType] = &genericHandlerList{handlers: make(map[*handler]bool)}
+	case pb.EventType_TX_STATUS:
+		gEventProcessor.eventConsumers[eventType] = &txStatusHandlerList{handlers: make(map[string]map[*handler]bool)}
 	}
 	gEventProcessor.Unlock()
 
@@ -305,6 +440,46 @@ func deRegisterHandler(ie *pb.Interest, h *handler) error {
 	return nil
 }
 
+//trackHandler records the handler of a client registered on some channel
+func trackHandler(h *handler) {
+	gEventProcessor.Lock()
+	defer gEventProcessor.Unlock()
+	gEventProcessor.handlers[h] = true
+}
+
+//untrackHandler forgets the handler of a client which went away
+func untrackHandler(h *handler) {
+	gEventProcessor.Lock()
+	defer gEventProcessor.Unlock()
+	delete(gEventProcessor.handlers, h)
+}
+
+//txStatusHandlers returns the handlers interested in transaction statuses,
+//or nil if events are not initialized
+func txStatusHandlers() *txStatusHandlerList {
+	if gEventProcessor == nil {
+		return nil
+	}
+	gEventProcessor.RLock()
+	defer gEventProcessor.RUnlock()
+	hl, _ := gEventProcessor.eventConsumers[pb.EventType_TX_STATUS].(*txStatusHandlerList)
+	return hl
+}
+
+//reauthorizeHandlers re-authorizes the clients registered on the given channel
+func reauthorizeHandlers(channelID string) {
+	gEventProcessor.RLock()
+	handlers := make([]*handler, 0, len(gEventProcessor.handlers))
+	for h := range gEventProcessor.handlers {
+		handlers = append(handlers, h)
+	}
+	gEventProcessor.RUnlock()
+
+	for _, h := range handlers {
+		h.reauthorize(channelID)
+	}
+}
+
 //------------- producer API's -------------------------------
 
 //Send sends the event to interested consumers
@@ -316,6 +491,12 @@ func Send(e *pb.Event) error {
 		return fmt.Errorf("event not set")
 	}
 
+	return sendEvents([]*pb.Event{e})
+}
+
+//sendEvents sends the events to interested consumers at once, taking
+//a single slot of the buffer of the event processor
+func sendEvents(events []*pb.Event) error {
 	if gEventProcessor == nil {
 		logger.Debugf("Event processor is nil")
 		return nil
@@ -324,17 +505,17 @@ func Send(e *pb.Event) error {
 	if gEventProcessor.timeout < 0 {
 		logger.Debugf("Event processor timeout < 0")
 		select {
-		case gEventProcessor.eventChannel <- e:
+		case gEventProcessor.eventChannel <- events:
 		default:
 			return fmt.Errorf("could not send the blocking event")
 		}
 	} else if gEventProcessor.timeout == 0 {
 		logger.Debugf("Event processor timeout = 0")
-		gEventProcessor.eventChannel <- e
+		gEventProcessor.eventChannel <- events
 	} else {
 		logger.Debugf("Event processor timeout > 0")
 		select {
-		case gEventProcessor.eventChannel <- e:
+		case gEventProcessor.eventChannel <- events:
 		case <-time.After(gEventProcessor.timeout):
 			return fmt.Errorf("could not send the blocking event")
 		}
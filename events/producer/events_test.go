@@ -18,11 +18,15 @@ package producer
 
 import (
 	"context"
+	"errors"
 	"io"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	ehpb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -33,6 +37,61 @@ import (
 
 var peerAddress = "0.0.0.0:60303"
 
+// mockPolicyChecker authorizes clients on all channels but the denied ones
+type mockPolicyChecker struct {
+	sync.RWMutex
+	denied map[string]bool
+}
+
+func (*mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *peer.SignedProposal) error {
+	panic("not implemented")
+}
+
+func (*mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *peer.SignedProposal) error {
+	panic("not implemented")
+}
+
+func (c *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
+	c.RLock()
+	defer c.RUnlock()
+	if c.denied[channelID] {
+		return errors.New("access denied")
+	}
+	return nil
+}
+
+func (c *mockPolicyChecker) deny(channelID string) {
+	c.Lock()
+	defer c.Unlock()
+	if c.denied == nil {
+		c.denied = make(map[string]bool)
+	}
+	c.denied[channelID] = true
+}
+
+// recordingStream records the events sent to the client
+type recordingStream struct {
+	*mockstream
+	events chan *peer.Event
+}
+
+func (s *recordingStream) Send(e *peer.Event) error {
+	s.events <- e
+	return nil
+}
+
+func createBlock(channelID string, headerType common.HeaderType) *common.Block {
+	block := common.NewBlock(1, nil)
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(headerType), ChannelId: channelID}),
+		},
+	}
+	env := &common.Envelope{Payload: utils.MarshalOrPanic(payload)}
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return block
+}
+
 type client struct {
 	conn   *grpc.ClientConn
 	stream peer.Events_ChatClient
@@ -135,7 +194,7 @@ func TestRegister(t *testing.T) {
 	// attempt to register valid handler
 	recvChan := make(chan *streamEvent)
 	stream := &mockstream{c: recvChan}
-	handler, err := newEventHandler(stream)
+	handler, err := newEventHandler(stream, &mockPolicyChecker{})
 	assert.Nil(t, err, "error should have been nil")
 	assert.NoError(t, registerHandler(&peer.Interest{EventType: peer.EventType_BLOCK}, handler))
 }
@@ -143,9 +202,9 @@ func TestRegister(t *testing.T) {
 func TestProcessEvents(t *testing.T) {
 	cl := newClient()
 	interests := []*peer.Interest{
-		{EventType: peer.EventType_BLOCK},
-		{EventType: peer.EventType_CHAINCODE, RegInfo: &peer.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &peer.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event1"}}},
-		{EventType: peer.EventType_CHAINCODE, RegInfo: &peer.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &peer.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event2"}}},
+		{EventType: peer.EventType_BLOCK, ChainID: util.GetTestChainID()},
+		{EventType: peer.EventType_CHAINCODE, RegInfo: &peer.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &peer.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event1"}}, ChainID: util.GetTestChainID()},
+		{EventType: peer.EventType_CHAINCODE, RegInfo: &peer.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &peer.ChaincodeReg{ChaincodeId: "0xffffffff", EventName: "event2"}}, ChainID: util.GetTestChainID()},
 	}
 	cl.register(interests)
 	e, err := createEvent()
@@ -168,3 +227,178 @@ func TestInitializeEvents_twice(t *testing.T) {
 func TestAddEventType_alreadyDefined(t *testing.T) {
 	assert.Error(t, AddEventType(ehpb.EventType_CHAINCODE), "chaincode type already defined")
 }
+
+func TestChannelAuthorization(t *testing.T) {
+	checker := &mockPolicyChecker{}
+	checker.deny("forbiddenchannel")
+	stream := &recordingStream{events: make(chan *peer.Event, 10)}
+	h, err := newEventHandler(stream, checker)
+	assert.NoError(t, err)
+	defer h.Stop()
+
+	ccInterest := func(channelID string) *peer.Interest {
+		return &peer.Interest{
+			EventType: peer.EventType_CHAINCODE,
+			RegInfo:   &peer.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &peer.ChaincodeReg{ChaincodeId: "mycc"}},
+			ChainID:   channelID,
+		}
+	}
+	credentials := []*common.SignedData{{Data: []byte("data"), Identity: []byte("identity"), Signature: []byte("signature")}}
+	assert.NoError(t, h.register([]*peer.Interest{
+		{EventType: peer.EventType_BLOCK, ChainID: "authchannel"},
+		{EventType: peer.EventType_BLOCK, ChainID: "otherchannel"},
+		{EventType: peer.EventType_BLOCK, ChainID: "forbiddenchannel"},
+		{EventType: peer.EventType_BLOCK},
+		ccInterest("authchannel"),
+	}, credentials))
+
+	// Only events of the channels the client registered
+	// on and is authorized on are accepted
+	assert.True(t, h.accepts(CreateBlockEvent(createBlock("authchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.True(t, h.accepts(CreateBlockEvent(createBlock("otherchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.False(t, h.accepts(CreateBlockEvent(createBlock("forbiddenchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.False(t, h.accepts(CreateBlockEvent(createBlock("unknownchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.False(t, h.accepts(CreateBlockEvent(common.NewBlock(1, nil))))
+	assert.True(t, h.accepts(CreateChaincodeEvent(&peer.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"}, "authchannel")))
+	assert.False(t, h.accepts(CreateChaincodeEvent(&peer.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"}, "otherchannel")))
+	assert.False(t, h.accepts(CreateChaincodeEvent(&peer.ChaincodeEvent{ChaincodeId: "othercc", EventName: "event"}, "authchannel")))
+
+	// Events are delivered to the client
+	assert.NoError(t, Send(CreateBlockEvent(createBlock("authchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	select {
+	case e := <-stream.events:
+		assert.Equal(t, "authchannel", e.ChannelId)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Didn't receive block event")
+	}
+
+	// The client isn't authorized anymore after a config change,
+	// so the config block isn't delivered to the client
+	checker.deny("authchannel")
+	assert.NoError(t, Send(CreateBlockEvent(createBlock("authchannel", common.HeaderType_CONFIG))))
+	assert.NoError(t, Send(CreateBlockEvent(createBlock("otherchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	select {
+	case e := <-stream.events:
+		assert.Equal(t, "otherchannel", e.ChannelId)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Didn't receive block event")
+	}
+	assert.False(t, h.accepts(CreateBlockEvent(createBlock("authchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.False(t, h.accepts(CreateChaincodeEvent(&peer.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"}, "authchannel")))
+	assert.True(t, h.accepts(CreateBlockEvent(createBlock("otherchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+
+	// Deregistering the last channel deregisters the handler
+	assert.NoError(t, h.deregister([]*peer.Interest{{EventType: peer.EventType_BLOCK, ChainID: "otherchannel"}}))
+	assert.False(t, h.accepts(CreateBlockEvent(createBlock("otherchannel", common.HeaderType_ENDORSER_TRANSACTION))))
+	assert.Error(t, deRegisterHandler(&peer.Interest{EventType: peer.EventType_BLOCK}, h))
+}
+
+func TestTxStatusEvents(t *testing.T) {
+	stream := &recordingStream{events: make(chan *peer.Event, 10)}
+	h, err := newEventHandler(stream, &mockPolicyChecker{})
+	assert.NoError(t, err)
+	defer h.Stop()
+
+	txStatusInterest := func(txID string, mine bool) *peer.Interest {
+		return &peer.Interest{
+			EventType: peer.EventType_TX_STATUS,
+			RegInfo:   &peer.Interest_TxStatusRegInfo{TxStatusRegInfo: &peer.TxStatusReg{TxId: txID, MyTransactions: mine}},
+			ChainID:   "txchannel",
+		}
+	}
+	credentials := []*common.SignedData{{Data: []byte("data"), Identity: []byte("alice"), Signature: []byte("signature")}}
+	assert.NoError(t, h.register([]*peer.Interest{
+		txStatusInterest("tx1", false),
+		txStatusInterest("", true),
+		// Invalid registrations
+		txStatusInterest("", false),
+		txStatusInterest("tx3", true),
+		{EventType: peer.EventType_TX_STATUS, ChainID: "txchannel"},
+	}, credentials))
+	assert.Len(t, h.interestedEvents, 2)
+
+	// A block with a malformed transaction, a transaction of bob the client
+	// registered for, an invalid transaction of alice, the client, and a
+	// transaction of bob
+	block := common.NewBlock(7, nil)
+	block.Data.Data = [][]byte{[]byte("garbage")}
+	for _, tx := range []struct{ txID, creator string }{{"tx1", "bob"}, {"tx2", "alice"}, {"tx3", "bob"}} {
+		payload := &common.Payload{
+			Header: &common.Header{
+				ChannelHeader:   utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION), ChannelId: "txchannel", TxId: tx.txID}),
+				SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte(tx.creator)}),
+			},
+		}
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(&common.Envelope{Payload: utils.MarshalOrPanic(payload)}))
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{
+		uint8(peer.TxValidationCode_BAD_PAYLOAD),
+		uint8(peer.TxValidationCode_VALID),
+		uint8(peer.TxValidationCode_MVCC_READ_CONFLICT),
+		uint8(peer.TxValidationCode_VALID),
+	}
+	assert.NoError(t, SendProducerTxStatusEvents(block))
+
+	for _, expected := range []*peer.TxStatus{
+		{TxId: "tx1", BlockNumber: 7, TxIndex: 1, ValidationCode: peer.TxValidationCode_VALID, Creator: []byte("bob")},
+		{TxId: "tx2", BlockNumber: 7, TxIndex: 2, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Creator: []byte("alice")},
+	} {
+		select {
+		case e := <-stream.events:
+			assert.Equal(t, "txchannel", e.ChannelId)
+			assert.Equal(t, expected, e.GetTxStatus())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Didn't receive the status of %s", expected.TxId)
+		}
+	}
+	select {
+	case e := <-stream.events:
+		t.Fatalf("Received unexpected event %v", e)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// The registration for tx1 is removed once its status is delivered,
+	// the one for the transactions of the client is kept
+	deadline := time.Now().Add(5 * time.Second)
+	for h.accepts(CreateTxStatusEvent(&peer.TxStatus{TxId: "tx1"}, "txchannel")) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, h.accepts(CreateTxStatusEvent(&peer.TxStatus{TxId: "tx1"}, "txchannel")))
+	assert.Len(t, h.interestedEvents, 1)
+	hl := gEventProcessor.eventConsumers[peer.EventType_TX_STATUS].(*txStatusHandlerList)
+	hl.RLock()
+	_, registered := hl.handlers["tx1"]
+	hl.RUnlock()
+	assert.False(t, registered, "The handlers of a delivered transaction status should be removed")
+
+	// Events of other channels aren't delivered
+	assert.False(t, h.accepts(CreateTxStatusEvent(&peer.TxStatus{TxId: "tx1"}, "otherchannel")))
+	assert.False(t, h.accepts(CreateTxStatusEvent(&peer.TxStatus{TxId: "tx4", Creator: []byte("alice")}, "otherchannel")))
+	assert.True(t, h.accepts(CreateTxStatusEvent(&peer.TxStatus{TxId: "tx4", Creator: []byte("alice")}, "txchannel")))
+}
+
+func TestTxStatusHandlerListInterest(t *testing.T) {
+	hl := &txStatusHandlerList{handlers: make(map[string]map[*handler]bool)}
+	h := &handler{}
+	txStatusInterest := func(txID string, mine bool) *peer.Interest {
+		return &peer.Interest{
+			EventType: peer.EventType_TX_STATUS,
+			RegInfo:   &peer.Interest_TxStatusRegInfo{TxStatusRegInfo: &peer.TxStatusReg{TxId: txID, MyTransactions: mine}},
+		}
+	}
+
+	// No status is built when no client is interested in them
+	assert.True(t, hl.empty())
+	assert.False(t, hl.interested("tx1"))
+
+	_, err := hl.add(txStatusInterest("tx1", false), h)
+	assert.NoError(t, err)
+	assert.False(t, hl.empty())
+	assert.True(t, hl.interested("tx1"))
+	assert.False(t, hl.interested("tx2"))
+
+	// Clients interested in their own transactions may have submitted any of them
+	_, err = hl.add(txStatusInterest("", true), h)
+	assert.NoError(t, err)
+	assert.True(t, hl.interested("tx2"))
+}
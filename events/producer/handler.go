@@ -17,32 +17,51 @@ limitations under the License.
 package producer
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type handler struct {
-	ChatStream       pb.Events_ChatServer
+	sync.RWMutex
+	ChatStream    pb.Events_ChatServer
+	policyChecker policy.PolicyChecker
+	// regLock serializes the changes to the registrations of the client
+	regLock sync.Mutex
+	// interestedEvents are the interests of the client, by channel and interest key
 	interestedEvents map[string]*pb.Interest
+	// credentials are the signed registrations the client
+	// was authorized with, by channel
+	credentials map[string][]*common.SignedData
 }
 
-func newEventHandler(stream pb.Events_ChatServer) (*handler, error) {
+func newEventHandler(stream pb.Events_ChatServer, policyChecker policy.PolicyChecker) (*handler, error) {
 	d := &handler{
-		ChatStream: stream,
+		ChatStream:    stream,
+		policyChecker: policyChecker,
 	}
 	d.interestedEvents = make(map[string]*pb.Interest)
+	d.credentials = make(map[string][]*common.SignedData)
 	return d, nil
 }
 
 // Stop stops this handler
 func (d *handler) Stop() error {
 	d.deregisterAll()
+	untrackHandler(d)
+	d.Lock()
 	d.interestedEvents = nil
+	d.credentials = nil
+	d.Unlock()
 	return nil
 }
 
@@ -54,7 +73,9 @@ func getInterestKey(interest pb.Interest) string {
 	case pb.EventType_REJECTION:
 		key = "/" + strconv.Itoa(int(pb.EventType_REJECTION))
 	case pb.EventType_CHAINCODE:
-		key = "/" + strconv.Itoa(int(pb.EventType_CHAINCODE)) + "/" + interest.GetChaincodeRegInfo().ChaincodeId + "/" + interest.GetChaincodeRegInfo().EventName
+		key = "/" + strconv.Itoa(int(pb.EventType_CHAINCODE)) + "/" + interest.GetChaincodeRegInfo().GetChaincodeId() + "/" + interest.GetChaincodeRegInfo().GetEventName()
+	case pb.EventType_TX_STATUS:
+		key = "/" + strconv.Itoa(int(pb.EventType_TX_STATUS)) + "/" + interest.GetTxStatusRegInfo().GetTxId() + "/" + strconv.FormatBool(interest.GetTxStatusRegInfo().GetMyTransactions())
 	default:
 		logger.Errorf("unknown interest type %s", interest.EventType)
 	}
@@ -62,39 +83,232 @@ func getInterestKey(interest pb.Interest) string {
 	return key
 }
 
-func (d *handler) register(iMsg []*pb.Interest) error {
+// getChannelInterestKey returns the key of the given interest on its channel
+func getChannelInterestKey(interest *pb.Interest) string {
+	return interest.ChainID + getInterestKey(*interest)
+}
+
+// isInterested returns whether the client registered the given channel interest key
+func (d *handler) isInterested(channelKey string) bool {
+	d.RLock()
+	defer d.RUnlock()
+	_, exists := d.interestedEvents[channelKey]
+	return exists
+}
+
+// isListening returns whether the client registered the
+// given interest key on any channel
+func (d *handler) isListening(key string) bool {
+	d.RLock()
+	defer d.RUnlock()
+	for _, v := range d.interestedEvents {
+		if getInterestKey(*v) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks the given credentials satisfy the readers policy of the given channel
+func (d *handler) authorize(channelID string, credentials []*common.SignedData) error {
+	if d.policyChecker == nil {
+		return fmt.Errorf("no policy checker to authorize registrations with")
+	}
+	return d.policyChecker.CheckPolicyBySignedData(channelID, policies.ChannelApplicationReaders, credentials)
+}
+
+func (d *handler) register(iMsg []*pb.Interest, credentials []*common.SignedData) error {
+	d.regLock.Lock()
+	defer d.regLock.Unlock()
+
 	// Could consider passing interest array to registerHandler
 	// and only lock once for entire array here
 	for _, v := range iMsg {
-		if err := registerHandler(v, d); err != nil {
-			logger.Errorf("could not register %s: %s", v, err)
+		if v.ChainID == "" {
+			logger.Errorf("could not register %s: channel not specified", v)
+			continue
+		}
+		if err := d.authorize(v.ChainID, credentials); err != nil {
+			logger.Warningf("could not register %s: client not authorized on channel %s: %s", v, v.ChainID, err)
+			continue
+		}
+		key := getInterestKey(*v)
+		if d.isInterested(v.ChainID + key) {
+			logger.Errorf("could not register %s: already registered", v)
 			continue
 		}
-		d.interestedEvents[getInterestKey(*v)] = v
+		// The handler is registered once for all channels
+		if !d.isListening(key) {
+			if err := registerHandler(v, d); err != nil {
+				logger.Errorf("could not register %s: %s", v, err)
+				continue
+			}
+		}
+		d.Lock()
+		d.interestedEvents[v.ChainID+key] = v
+		d.credentials[v.ChainID] = credentials
+		d.Unlock()
+		trackHandler(d)
 	}
 
 	return nil
 }
 
 func (d *handler) deregister(iMsg []*pb.Interest) error {
+	d.regLock.Lock()
+	defer d.regLock.Unlock()
+	d.removeInterests(iMsg)
+	return nil
+}
+
+// removeInterests removes the given interests of the client, and deregisters
+// the handler from the interests which aren't registered on any channel anymore
+func (d *handler) removeInterests(iMsg []*pb.Interest) {
 	for _, v := range iMsg {
-		if err := deRegisterHandler(v, d); err != nil {
-			logger.Errorf("could not deregister %s", v)
+		channelKey := getChannelInterestKey(v)
+		if !d.isInterested(channelKey) {
+			logger.Errorf("could not deregister %s: not registered", v)
 			continue
 		}
-		delete(d.interestedEvents, getInterestKey(*v))
+		d.Lock()
+		delete(d.interestedEvents, channelKey)
+		d.Unlock()
+		if !d.isListening(getInterestKey(*v)) {
+			if err := deRegisterHandler(v, d); err != nil {
+				logger.Errorf("could not deregister %s", v)
+			}
+		}
+		d.forgetChannelIfUnused(v.ChainID)
+	}
+}
+
+// forgetTxStatus removes the registration of the client for the status
+// of the given transaction on the given channel, if any
+func (d *handler) forgetTxStatus(channelID string, txID string) {
+	channelKey := channelID + getInterestKey(pb.Interest{
+		EventType: pb.EventType_TX_STATUS,
+		RegInfo:   &pb.Interest_TxStatusRegInfo{TxStatusRegInfo: &pb.TxStatusReg{TxId: txID}},
+	})
+	d.RLock()
+	interest, exists := d.interestedEvents[channelKey]
+	d.RUnlock()
+	if exists {
+		d.deregister([]*pb.Interest{interest})
 	}
-	return nil
+}
+
+// forgetChannelIfUnused discards the credentials of the given
+// channel if the client has no interests on it anymore
+func (d *handler) forgetChannelIfUnused(channelID string) {
+	d.Lock()
+	defer d.Unlock()
+	for _, v := range d.interestedEvents {
+		if v.ChainID == channelID {
+			return
+		}
+	}
+	delete(d.credentials, channelID)
 }
 
 func (d *handler) deregisterAll() {
-	for k, v := range d.interestedEvents {
-		if err := deRegisterHandler(v, d); err != nil {
-			logger.Errorf("could not deregister %s", v)
-			continue
+	d.regLock.Lock()
+	defer d.regLock.Unlock()
+
+	d.RLock()
+	var interests []*pb.Interest
+	for _, v := range d.interestedEvents {
+		interests = append(interests, v)
+	}
+	d.RUnlock()
+	d.removeInterests(interests)
+}
+
+// reauthorize checks the client is still authorized on the given channel,
+// and removes its interests on the channel if not, e.g. after the readers
+// policy of the channel changed
+func (d *handler) reauthorize(channelID string) {
+	d.regLock.Lock()
+	defer d.regLock.Unlock()
+
+	d.RLock()
+	credentials, registered := d.credentials[channelID]
+	d.RUnlock()
+	if !registered {
+		return
+	}
+	err := d.authorize(channelID, credentials)
+	if err == nil {
+		return
+	}
+	logger.Warningf("client is not authorized on channel %s anymore, removing its registrations: %s", channelID, err)
+
+	d.RLock()
+	var interests []*pb.Interest
+	for _, v := range d.interestedEvents {
+		if v.ChainID == channelID {
+			interests = append(interests, v)
+		}
+	}
+	d.RUnlock()
+	d.removeInterests(interests)
+}
+
+// accepts returns whether the client registered for the given event on its channel
+func (d *handler) accepts(e *pb.Event) bool {
+	if e.ChannelId == "" {
+		return false
+	}
+	var keys []string
+	switch e.Event.(type) {
+	case *pb.Event_Block:
+		keys = append(keys, getInterestKey(pb.Interest{EventType: pb.EventType_BLOCK}))
+	case *pb.Event_Rejection:
+		keys = append(keys, getInterestKey(pb.Interest{EventType: pb.EventType_REJECTION}))
+	case *pb.Event_ChaincodeEvent:
+		// Clients may register for all the events of a chaincode
+		for _, eventName := range []string{e.GetChaincodeEvent().EventName, ""} {
+			keys = append(keys, getInterestKey(pb.Interest{
+				EventType: pb.EventType_CHAINCODE,
+				RegInfo: &pb.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &pb.ChaincodeReg{
+					ChaincodeId: e.GetChaincodeEvent().ChaincodeId,
+					EventName:   eventName,
+				}},
+			}))
+		}
+	case *pb.Event_TxStatus:
+		keys = append(keys, getInterestKey(pb.Interest{
+			EventType: pb.EventType_TX_STATUS,
+			RegInfo:   &pb.Interest_TxStatusRegInfo{TxStatusRegInfo: &pb.TxStatusReg{TxId: e.GetTxStatus().TxId}},
+		}))
+		if d.isCreator(e.ChannelId, e.GetTxStatus().Creator) {
+			keys = append(keys, getInterestKey(pb.Interest{
+				EventType: pb.EventType_TX_STATUS,
+				RegInfo:   &pb.Interest_TxStatusRegInfo{TxStatusRegInfo: &pb.TxStatusReg{MyTransactions: true}},
+			}))
+		}
+	}
+	for _, key := range keys {
+		if d.isInterested(e.ChannelId + key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCreator returns whether the client registered on the
+// given channel with the given identity
+func (d *handler) isCreator(channelID string, identity []byte) bool {
+	if len(identity) == 0 {
+		return false
+	}
+	d.RLock()
+	defer d.RUnlock()
+	for _, sd := range d.credentials[channelID] {
+		if bytes.Equal(sd.Identity, identity) {
+			return true
 		}
-		delete(d.interestedEvents, k)
 	}
+	return false
 }
 
 // HandleMessage handles the Openchain messages for the Peer.
@@ -107,7 +321,14 @@ func (d *handler) HandleMessage(msg *pb.SignedEvent) error {
 	switch evt.Event.(type) {
 	case *pb.Event_Register:
 		eventsObj := evt.GetRegister()
-		if err := d.register(eventsObj.Events); err != nil {
+		// The registration is authorized on each channel, and
+		// re-authorized whenever the config of a channel changes
+		credentials := []*common.SignedData{{
+			Data:      msg.EventBytes,
+			Identity:  evt.Creator,
+			Signature: msg.Signature,
+		}}
+		if err := d.register(eventsObj.Events, credentials); err != nil {
 			return fmt.Errorf("could not register events %s", err)
 		}
 	case *pb.Event_Unregister:
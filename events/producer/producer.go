@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/policy"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -29,17 +30,19 @@ var logger = flogging.MustGetLogger("eventhub_producer")
 
 // EventsServer implementation of the Peer service
 type EventsServer struct {
+	policyChecker policy.PolicyChecker
 }
 
 //singleton - if we want to create multiple servers, we need to subsume events.gEventConsumers into EventsServer
 var globalEventsServer *EventsServer
 
-// NewEventsServer returns a EventsServer
-func NewEventsServer(bufferSize uint, timeout time.Duration) *EventsServer {
+// NewEventsServer returns a EventsServer, which authorizes the registrations
+// of clients on channels with the given policy checker
+func NewEventsServer(bufferSize uint, timeout time.Duration, policyChecker policy.PolicyChecker) *EventsServer {
 	if globalEventsServer != nil {
 		panic("Cannot create multiple event hub servers")
 	}
-	globalEventsServer = new(EventsServer)
+	globalEventsServer = &EventsServer{policyChecker: policyChecker}
 	initializeEvents(bufferSize, timeout)
 	//initializeCCEventProcessor(bufferSize, timeout)
 	return globalEventsServer
@@ -47,7 +50,7 @@ func NewEventsServer(bufferSize uint, timeout time.Duration) *EventsServer {
 
 // Chat implementation of the Chat bidi streaming RPC function
 func (p *EventsServer) Chat(stream pb.Events_ChatServer) error {
-	handler, err := newEventHandler(stream)
+	handler, err := newEventHandler(stream, p.policyChecker)
 	if err != nil {
 		return fmt.Errorf("error creating handler during handleChat initiation: %s", err)
 	}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 
+	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/events/consumer"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -32,12 +33,13 @@ import (
 )
 
 type adapter struct {
-	notfy chan *pb.Event_Block
+	notfy     chan *pb.Event_Block
+	channelID string
 }
 
 //GetInterestedEvents implements consumer.EventAdapter interface for registering interested events
 func (a *adapter) GetInterestedEvents() ([]*pb.Interest, error) {
-	return []*pb.Interest{{EventType: pb.EventType_BLOCK}}, nil
+	return []*pb.Interest{{EventType: pb.EventType_BLOCK, ChainID: a.channelID}}, nil
 }
 
 //Recv implements consumer.EventAdapter interface for receiving events
@@ -55,11 +57,11 @@ func (a *adapter) Disconnected(err error) {
 	os.Exit(1)
 }
 
-func createEventClient(eventAddress string, _ string) *adapter {
+func createEventClient(eventAddress string, channelID string) *adapter {
 	var obcEHClient *consumer.EventsClient
 
 	done := make(chan *pb.Event_Block)
-	adapter := &adapter{notfy: done}
+	adapter := &adapter{notfy: done, channelID: channelID}
 	obcEHClient, _ = consumer.NewEventsClient(eventAddress, 5, adapter)
 	if err := obcEHClient.Start(); err != nil {
 		fmt.Printf("could not start chat. err: %s\n", err)
@@ -137,10 +139,12 @@ func getChainCodeEvents(tdata []byte) (*pb.ChaincodeEvent, error) {
 func main() {
 	var eventAddress string
 	var chaincodeID string
+	var channelID string
 	var mspDir string
 	var mspId string
 	flag.StringVar(&eventAddress, "events-address", "0.0.0.0:7053", "address of events server")
 	flag.StringVar(&chaincodeID, "events-from-chaincode", "", "listen to events from given chaincode")
+	flag.StringVar(&channelID, "events-channel", commonutil.GetTestChainID(), "listen to events of given channel")
 	flag.StringVar(&mspDir, "events-mspdir", "", "set up the msp direction")
 	flag.StringVar(&mspId, "events-mspid", "", "set up the mspid")
 	flag.Parse()
@@ -163,7 +167,7 @@ func main() {
 
 	fmt.Printf("Event Address: %s\n", eventAddress)
 
-	a := createEventClient(eventAddress, chaincodeID)
+	a := createEventClient(eventAddress, channelID)
 	if a == nil {
 		fmt.Println("Error creating event client")
 		return
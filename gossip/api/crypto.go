@@ -17,6 +17,8 @@ limitations under the License.
 package api
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/gossip/common"
 	"google.golang.org/grpc"
 )
@@ -57,6 +59,10 @@ type MessageCryptoService interface {
 	// If the identity is invalid, revoked, expired it returns an error.
 	// Else, returns nil
 	ValidateIdentity(peerIdentity PeerIdentityType) error
+
+	// Expiration returns the time at which the identity of a remote peer expires.
+	// A zero time is returned if the identity doesn't expire.
+	Expiration(peerIdentity PeerIdentityType) (time.Time, error)
 }
 
 // PeerIdentityType is the peer's certificate
@@ -66,6 +66,10 @@ func (*naiveSecProvider) ValidateIdentity(peerIdentity api.PeerIdentityType) err
 	return nil
 }
 
+func (*naiveSecProvider) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveSecProvider) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -18,6 +18,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -80,6 +81,9 @@ type NetworkMember struct {
 	Metadata         []byte
 	PKIid            common.PKIidType
 	InternalEndpoint string
+	// Properties are the channel related properties the peer publishes,
+	// they are only set for the members of a channel
+	Properties *proto.Properties
 }
 
 // String returns a string representation of the NetworkMember
@@ -97,6 +101,14 @@ func (n NetworkMember) PreferredEndpoint() string {
 	return n.Endpoint
 }
 
+// MemberInfo is a network member along with its
+// liveness, as seen by the discovery module
+type MemberInfo struct {
+	NetworkMember
+	Alive    bool
+	LastSeen time.Time
+}
+
 // PeerIdentification encompasses a remote peer's
 // PKI-ID and whether its in the same org as the current
 // peer or not
@@ -128,6 +140,14 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetMembershipInfo returns the alive and dead members known, along
+	// with the last time they were seen alive
+	GetMembershipInfo() []MemberInfo
+
+	// Purge removes the member with the given PKI-ID from the membership,
+	// along with the alive messages it sent
+	Purge(pkiID common.PKIidType)
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
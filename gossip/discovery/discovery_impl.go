@@ -928,6 +928,50 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+// GetMembershipInfo returns the alive and dead members known, along
+// with the last time they were seen alive
+func (d *gossipDiscoveryImpl) GetMembershipInfo() []MemberInfo {
+	if d.toDie() {
+		return []MemberInfo{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []MemberInfo{}
+	appendMembers := func(lastTS map[string]*timestamp, alive bool) {
+		for id, ts := range lastTS {
+			member, exists := d.id2Member[id]
+			if !exists {
+				continue
+			}
+			response = append(response, MemberInfo{
+				NetworkMember: *member,
+				Alive:         alive,
+				LastSeen:      ts.lastSeen,
+			})
+		}
+	}
+	appendMembers(d.aliveLastTS, true)
+	appendMembers(d.deadLastTS, false)
+	return response
+}
+
+// Purge removes the member with the given PKI-ID from the membership,
+// along with the alive messages it sent
+func (d *gossipDiscoveryImpl) Purge(pkiID common.PKIidType) {
+	d.msgStore.Purge(func(m interface{}) bool {
+		return equalPKIid(m.(*proto.SignedGossipMessage).GetAliveMsg().Membership.PkiId, pkiID)
+	})
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.aliveMembership.Remove(pkiID)
+	d.deadMembership.Remove(pkiID)
+	delete(d.id2Member, string(pkiID))
+	delete(d.aliveLastTS, string(pkiID))
+	delete(d.deadLastTS, string(pkiID))
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -524,6 +524,21 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	// The stopped instances are reported as dead, and the others as alive
+	info := instances[0].GetMembershipInfo()
+	assert.Len(t, info, nodeNum-1)
+	for _, member := range info {
+		stopped := member.Endpoint == instances[nodeNum-1].Self().Endpoint || member.Endpoint == instances[nodeNum-2].Self().Endpoint
+		assert.Equal(t, !stopped, member.Alive, "Wrong liveness of %s", member.Endpoint)
+		assert.False(t, member.LastSeen.IsZero())
+	}
+
+	// Purged members are removed from the membership
+	purged := instances[nodeNum-1].Self().PKIid
+	instances[0].Purge(purged)
+	assert.Nil(t, instances[0].Lookup(purged))
+	assert.Len(t, instances[0].GetMembershipInfo(), nodeNum-2)
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+)
+
+// PersistedMember is a member of the membership as persisted to the file system
+type PersistedMember struct {
+	Endpoint         string           `json:"endpoint"`
+	InternalEndpoint string           `json:"internalEndpoint,omitempty"`
+	PKIid            common.PKIidType `json:"pkiID"`
+	Identity         []byte           `json:"identity,omitempty"`
+	LastSeen         time.Time        `json:"lastSeen"`
+}
+
+// MembershipPersistence persists alive members to a file, in order to
+// use them as bootstrap candidates after a restart
+type MembershipPersistence struct {
+	sync.Mutex
+	path       string
+	expiration time.Duration
+}
+
+// NewMembershipPersistence returns a MembershipPersistence which persists
+// members to the given file, and discards members that weren't seen
+// alive for longer than the given expiration
+func NewMembershipPersistence(path string, expiration time.Duration) *MembershipPersistence {
+	return &MembershipPersistence{path: path, expiration: expiration}
+}
+
+// Load returns the persisted members which were seen alive recently enough
+func (p *MembershipPersistence) Load() ([]PersistedMember, error) {
+	p.Lock()
+	defer p.Unlock()
+	return p.load(time.Now())
+}
+
+// Save persists the given alive members, along with the previously
+// persisted members which haven't expired yet
+func (p *MembershipPersistence) Save(alive []PersistedMember) error {
+	p.Lock()
+	defer p.Unlock()
+
+	now := time.Now()
+	persisted, err := p.load(now)
+	if err != nil {
+		return err
+	}
+	members := make(map[string]PersistedMember)
+	for _, member := range persisted {
+		members[string(member.PKIid)] = member
+	}
+	for _, member := range alive {
+		if member.LastSeen.IsZero() {
+			member.LastSeen = now
+		}
+		if previous, exists := members[string(member.PKIid)]; exists && len(member.Identity) == 0 {
+			member.Identity = previous.Identity
+		}
+		members[string(member.PKIid)] = member
+	}
+
+	res := make([]PersistedMember, 0, len(members))
+	for _, member := range members {
+		res = append(res, member)
+	}
+	sort.Sort(membersByEndpoint(res))
+
+	bytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that a crash
+	// doesn't leave a partially written file behind
+	tmp := p.path + ".tmp"
+	if err = ioutil.WriteFile(tmp, bytes, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+func (p *MembershipPersistence) load(now time.Time) ([]PersistedMember, error) {
+	bytes, err := ioutil.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var members []PersistedMember
+	if err = json.Unmarshal(bytes, &members); err != nil {
+		return nil, err
+	}
+	var res []PersistedMember
+	for _, member := range members {
+		if len(member.PKIid) == 0 || (member.Endpoint == "" && member.InternalEndpoint == "") {
+			continue
+		}
+		if now.Sub(member.LastSeen) > p.expiration {
+			continue
+		}
+		res = append(res, member)
+	}
+	return res, nil
+}
+
+type membersByEndpoint []PersistedMember
+
+func (m membersByEndpoint) Len() int      { return len(m) }
+func (m membersByEndpoint) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m membersByEndpoint) Less(i, j int) bool {
+	if m[i].Endpoint != m[j].Endpoint {
+		return m[i].Endpoint < m[j].Endpoint
+	}
+	return m[i].InternalEndpoint < m[j].InternalEndpoint
+}
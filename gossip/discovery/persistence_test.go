@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "gossip", "membership.json")
+
+	p := NewMembershipPersistence(path, time.Hour)
+
+	// Nothing is persisted yet
+	members, err := p.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	assert.NoError(t, p.Save([]PersistedMember{
+		{Endpoint: "p1:7051", PKIid: common.PKIidType("p1"), Identity: []byte("id1")},
+		{InternalEndpoint: "p0:7051", PKIid: common.PKIidType("p0"), Identity: []byte("id0")},
+	}))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, "p0:7051", members[0].InternalEndpoint)
+	assert.Equal(t, []byte("id0"), members[0].Identity)
+	assert.Equal(t, "p1:7051", members[1].Endpoint)
+	assert.False(t, members[1].LastSeen.IsZero())
+
+	// Members which aren't alive anymore are kept, and identities
+	// of alive members are kept if they aren't known anymore
+	assert.NoError(t, p.Save([]PersistedMember{
+		{Endpoint: "p1:7051", PKIid: common.PKIidType("p1")},
+		{Endpoint: "p2:7051", PKIid: common.PKIidType("p2"), Identity: []byte("id2")},
+	}))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 3)
+	assert.Equal(t, []byte("id1"), members[1].Identity)
+
+	// Members not seen alive for longer than the expiration are discarded
+	assert.NoError(t, p.Save([]PersistedMember{
+		{Endpoint: "p2:7051", PKIid: common.PKIidType("p2"), LastSeen: time.Now().Add(-2 * time.Hour)},
+	}))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	for _, member := range members {
+		assert.NotEqual(t, "p2:7051", member.Endpoint)
+	}
+
+	// Invalid entries are ignored
+	bytes, _ := json.Marshal([]PersistedMember{
+		{Endpoint: "p3:7051", LastSeen: time.Now()},
+		{PKIid: common.PKIidType("p4"), LastSeen: time.Now()},
+	})
+	assert.NoError(t, ioutil.WriteFile(path, bytes, 0600))
+	members, err = p.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	// A corrupted file is reported
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = p.Load()
+	assert.Error(t, err)
+	assert.Error(t, p.Save(nil))
+}
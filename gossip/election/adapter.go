@@ -44,6 +44,10 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) Weight() uint32 {
+	return mi.msg.GetLeadershipMsg().Weight
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
 }
@@ -130,13 +134,14 @@ func (ai *adapterImpl) Accept() <-chan Msg {
 	return msgCh
 }
 
-func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
+func (ai *adapterImpl) CreateMessage(isDeclaration bool, weight uint32) Msg {
 	ai.seqNum++
 	seqNum := ai.seqNum
 
 	leadershipMsg := &proto.LeadershipMessage{
 		PkiId:         ai.selfPKIid,
 		IsDeclaration: isDeclaration,
+		Weight:        weight,
 		Timestamp: &proto.PeerTime{
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
@@ -57,7 +57,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	mockGossip := newGossip("peer0", selfNetworkMember)
 
 	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"))
-	msg := adapter.CreateMessage(true)
+	msg := adapter.CreateMessage(true, 0)
 
 	if !msg.(*msgImpl).msg.IsLeadershipMsg() {
 		t.Error("Newly created message should be LeadershipMsg")
@@ -67,7 +67,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 		t.Error("Newly created msg should be Declaration msg")
 	}
 
-	msg = adapter.CreateMessage(false)
+	msg = adapter.CreateMessage(false, 7)
 
 	if !msg.(*msgImpl).msg.IsLeadershipMsg() {
 		t.Error("Newly created message should be LeadershipMsg")
@@ -76,6 +76,10 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	if !msg.IsProposal() || msg.IsDeclaration() {
 		t.Error("Newly created msg should be Proposal msg")
 	}
+
+	if msg.Weight() != 7 {
+		t.Error("Newly created msg should carry the given weight")
+	}
 }
 
 func TestAdapterImpl_Peers(t *testing.T) {
@@ -127,7 +131,7 @@ func TestAdapterImpl_Gossip(t *testing.T) {
 
 	sender := adapters[fmt.Sprintf("Peer%d", 0)]
 
-	sender.Gossip(sender.CreateMessage(true))
+	sender.Gossip(sender.CreateMessage(true, 0))
 
 	totalMsg := 0
 
@@ -77,6 +77,19 @@ import (
 // 	If a proposal message from a peer with an ID lower
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// Weights and multiple leaders:
+// - Each peer has a leadership weight, and a peer with a higher weight
+//   is a better candidate than a peer with a lower weight. Among peers with
+//   the same weight, the peer with the lower ID is the better candidate.
+// - Up to N peers may be leaders at the same time. A leader steps down only
+//   when N better candidates have declared themselves leaders, and a peer
+//   runs an election whenever less than N leaders are known.
+//   It then becomes a leader if there are less better candidates than
+//   the number of missing leaders.
+// - A follower with a weight strictly higher than the weight of one of the
+//   leaders declares itself a leader, unless N better leaders are known.
+//   The worse leader then steps down.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -87,8 +100,9 @@ type LeaderElectionAdapter interface {
 	// Accept returns a channel that emits messages
 	Accept() <-chan Msg
 
-	// CreateProposalMessage
-	CreateMessage(isDeclaration bool) Msg
+	// CreateMessage creates a proposal or a declaration
+	// message that carries the given leadership weight
+	CreateMessage(isDeclaration bool, weight uint32) Msg
 
 	// Peers returns a list of peers considered alive
 	Peers() []Peer
@@ -107,6 +121,10 @@ type LeaderElectionService interface {
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Leader returns the ID of the current leader,
+	// or nil if no leader is known
+	Leader() []byte
 }
 
 type peerID []byte
@@ -125,19 +143,24 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// Weight returns the leadership weight of the peer sent the message
+	Weight() uint32
 }
 
 func noopCallback(_ bool) {
 }
 
-// NewLeaderElectionService returns a new LeaderElectionService
-func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+// NewLeaderElectionService returns a new LeaderElectionService, which
+// participates in the leader election with the given leadership weight
+func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, weight uint32, callback leadershipCallback) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
-		proposals:     util.NewSet(),
+		weight:        weight,
+		proposals:     make(map[string]uint32),
+		leaders:       make(map[string]*declaredLeader),
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
@@ -153,10 +176,18 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 	return le
 }
 
+// declaredLeader is a remote peer that declared itself a leader
+type declaredLeader struct {
+	weight     uint32
+	declaredAt time.Time
+}
+
 // leaderElectionSvcImpl is an implementation of a LeaderElectionService
 type leaderElectionSvcImpl struct {
 	id        peerID
-	proposals *util.Set
+	weight    uint32
+	proposals map[string]uint32
+	leaders   map[string]*declaredLeader
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -209,13 +240,17 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals[string(msg.SenderID())] = msg.Weight()
 	} else if msg.IsDeclaration() {
-		atomic.StoreInt32(&le.leaderExists, int32(1))
-		if le.sleeping && len(le.interruptChan) == 0 {
-			le.interruptChan <- struct{}{}
+		le.leaders[string(msg.SenderID())] = &declaredLeader{weight: msg.Weight(), declaredAt: time.Now()}
+		aliveLeaders, betterLeaders := le.countAliveLeaders()
+		if aliveLeaders >= getLeaderCount() {
+			atomic.StoreInt32(&le.leaderExists, int32(1))
+			if le.sleeping && len(le.interruptChan) == 0 {
+				le.interruptChan <- struct{}{}
+			}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if betterLeaders >= getLeaderCount() && le.IsLeader() {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -260,6 +295,10 @@ func (le *leaderElectionSvcImpl) run() {
 		if le.isLeaderExists() && le.isYielding() {
 			le.stopYielding()
 		}
+		if !le.IsLeader() && le.shouldPreempt() {
+			le.logger.Debug(le.id, ": Preempting a leader with a lower weight")
+			le.beLeader()
+		}
 		if le.shouldStop() {
 			return
 		}
@@ -294,16 +333,25 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		le.logger.Debug(le.id, ": Aborting leader election because yielding")
 		return
 	}
-	// Leader doesn't exist, let's see if there is a better candidate than us
-	// for being a leader
-	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
-			return
+	// Not enough leaders exist, let's see if there are enough better
+	// candidates than us for being leaders
+	le.Lock()
+	aliveLeaders, _ := le.countAliveLeaders()
+	betterCandidates := 0
+	for id, weight := range le.proposals {
+		if le.isAliveLeader(id) {
+			continue
+		}
+		if le.isBetterThanSelf(peerID(id), weight) {
+			betterCandidates++
 		}
 	}
-	// If we got here, there is no one that proposed being a leader
-	// that's a better candidate than us.
+	le.Unlock()
+	if betterCandidates >= getLeaderCount()-aliveLeaders {
+		return
+	}
+	// If we got here, there are less candidates that are better than us
+	// than the number of missing leaders.
 	le.beLeader()
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
@@ -312,7 +360,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 func (le *leaderElectionSvcImpl) propose() {
 	le.logger.Debug(le.id, ": Entering")
 	le.logger.Debug(le.id, ": Exiting")
-	leadershipProposal := le.adapter.CreateMessage(false)
+	leadershipProposal := le.adapter.CreateMessage(false, le.weight)
 	le.adapter.Gossip(leadershipProposal)
 }
 
@@ -320,7 +368,9 @@ func (le *leaderElectionSvcImpl) follower() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
 
-	le.proposals.Clear()
+	le.Lock()
+	le.proposals = make(map[string]uint32)
+	le.Unlock()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
@@ -330,7 +380,7 @@ func (le *leaderElectionSvcImpl) follower() {
 }
 
 func (le *leaderElectionSvcImpl) leader() {
-	leaderDeclaration := le.adapter.CreateMessage(true)
+	leaderDeclaration := le.adapter.CreateMessage(true, le.weight)
 	le.adapter.Gossip(leaderDeclaration)
 	le.waitForInterrupt(getLeadershipDeclarationInterval())
 }
@@ -370,6 +420,60 @@ func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	return false
 }
 
+// countAliveLeaders returns the number of remote peers that declared themselves
+// leaders recently enough, and how many of them are better candidates than us.
+// Must be called while holding the lock.
+func (le *leaderElectionSvcImpl) countAliveLeaders() (alive int, better int) {
+	for id, leader := range le.leaders {
+		if !le.isAliveLeader(id) {
+			delete(le.leaders, id)
+			continue
+		}
+		alive++
+		if le.isBetterThanSelf(peerID(id), leader.weight) {
+			better++
+		}
+	}
+	return alive, better
+}
+
+// isAliveLeader returns whether the peer with the given ID declared itself
+// a leader recently enough. Must be called while holding the lock.
+func (le *leaderElectionSvcImpl) isAliveLeader(id string) bool {
+	leader, exists := le.leaders[id]
+	return exists && time.Since(leader.declaredAt) <= getLeaderAliveThreshold()
+}
+
+// isBetterThanSelf returns whether the peer with the given ID and weight is
+// a better candidate for being a leader than us
+func (le *leaderElectionSvcImpl) isBetterThanSelf(id peerID, weight uint32) bool {
+	if weight != le.weight {
+		return weight > le.weight
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
+// shouldPreempt returns whether we should declare ourselves a leader because
+// we have a higher weight than one of the leaders, and less better leaders
+// than the number of leaders exist
+func (le *leaderElectionSvcImpl) shouldPreempt() bool {
+	if le.isYielding() {
+		return false
+	}
+	le.Lock()
+	defer le.Unlock()
+	aliveLeaders, betterLeaders := le.countAliveLeaders()
+	if aliveLeaders == 0 || betterLeaders >= getLeaderCount() {
+		return false
+	}
+	for id, leader := range le.leaders {
+		if le.isAliveLeader(id) && leader.weight < le.weight {
+			return true
+		}
+	}
+	return false
+}
+
 func (le *leaderElectionSvcImpl) isLeaderExists() bool {
 	return atomic.LoadInt32(&le.leaderExists) == int32(1)
 }
@@ -381,6 +485,28 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 	return isLeader
 }
 
+// Leader returns the ID of the current leader, which is either this peer
+// or the best candidate among the peers that declared themselves leaders
+// recently enough
+func (le *leaderElectionSvcImpl) Leader() []byte {
+	if le.IsLeader() {
+		return le.id
+	}
+	le.Lock()
+	defer le.Unlock()
+	var best peerID
+	var bestWeight uint32
+	for id, leader := range le.leaders {
+		if !le.isAliveLeader(id) {
+			continue
+		}
+		if best == nil || leader.weight > bestWeight || (leader.weight == bestWeight && bytes.Compare(peerID(id), best) < 0) {
+			best, bestWeight = peerID(id), leader.weight
+		}
+	}
+	return best
+}
+
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Debug(le.id, ": Becoming a leader")
 	atomic.StoreInt32(&le.isLeader, int32(1))
@@ -455,6 +581,12 @@ func SetLeaderAliveThreshold(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderAliveThreshold", t)
 }
 
+// SetLeaderCount configures the number of peers of an organization
+// that are simultaneously leaders
+func SetLeaderCount(n int) {
+	viper.Set("peer.gossip.election.leaderCount", n)
+}
+
 // SetLeaderElectionDuration configures expected leadership election duration,
 // interval to wait until leader election will be completed
 func SetLeaderElectionDuration(t time.Duration) {
@@ -473,6 +605,13 @@ func getLeaderAliveThreshold() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", time.Second*10)
 }
 
+func getLeaderCount() int {
+	if n := viper.GetInt("peer.gossip.election.leaderCount"); n > 1 {
+		return n
+	}
+	return 1
+}
+
 func getLeadershipDeclarationInterval() time.Duration {
 	return time.Duration(getLeaderAliveThreshold() / 2)
 }
@@ -47,6 +47,7 @@ func init() {
 type msg struct {
 	sender   string
 	proposal bool
+	weight   uint32
 }
 
 func (m *msg) SenderID() peerID {
@@ -61,6 +62,10 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) Weight() uint32 {
+	return m.weight
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
@@ -113,8 +118,8 @@ func (p *peer) Accept() <-chan Msg {
 	return (<-chan Msg)(p.msgChan)
 }
 
-func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+func (p *peer) CreateMessage(isDeclaration bool, weight uint32) Msg {
+	return &msg{proposal: !isDeclaration, sender: p.id, weight: weight}
 }
 
 func (p *peer) Peers() []Peer {
@@ -153,11 +158,15 @@ func (p *peer) isCallbackInvoked() bool {
 }
 
 func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
+	return createWeightedPeers(spawnInterval, make(map[int]uint32), ids...)
+}
+
+func createWeightedPeers(spawnInterval time.Duration, weights map[int]uint32, ids ...int) []*peer {
 	peers := make([]*peer, len(ids))
 	peerMap := make(map[string]*peer)
 	l := &sync.RWMutex{}
 	for i, id := range ids {
-		p := createPeer(id, peerMap, l)
+		p := createPeer(id, weights[id], peerMap, l)
 		if spawnInterval != 0 {
 			time.Sleep(spawnInterval)
 		}
@@ -166,11 +175,11 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
-func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+func createPeer(id int, weight uint32, peerMap map[string]*peer, l *sync.RWMutex) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
 	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p.LeaderElectionService = NewLeaderElectionService(p, idStr, weight, p.leaderCallback)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -353,6 +362,78 @@ func TestYield(t *testing.T) {
 	waitForBoolFunc(t, ensureP0isNotAleader, true)
 }
 
+func TestLeader(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers spawn and a leader is elected.
+	// Expected outcome: all peers report the elected peer as the leader
+	peers := createPeers(0, 0, 1, 2)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p0", leaders[0])
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool {
+			return string(p.Leader()) == "p0"
+		}, true, "Wrong leader reported by", p.id)
+	}
+}
+
+func TestWeightedLeader(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers spawn at the same time, and p2 has the highest weight.
+	// Expected outcome: p2 is the leader although its ID isn't the lowest
+	peers := createWeightedPeers(0, map[int]uint32{1: 5, 2: 10}, 0, 1, 2, 3)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p2", leaders[0])
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool {
+			return string(p.Leader()) == "p2"
+		}, true, "Wrong leader reported by", p.id)
+	}
+}
+
+func TestWeightedLeaderPreemption(t *testing.T) {
+	t.Parallel()
+	// Scenario: p0 spawns and becomes a leader, and then p1 which has
+	// a higher weight spawns.
+	// Expected outcome: p1 takes over the leadership and p0 steps down
+	peers := createWeightedPeers(getStartupGracePeriod()+getLeadershipDeclarationInterval(), map[int]uint32{1: 5}, 0, 1)
+	waitForBoolFunc(t, func() bool {
+		return peers[1].IsLeader() && !peers[0].IsLeader()
+	}, true, "p1 should have taken over the leadership")
+	waitForBoolFunc(t, peers[0].isLeaderFromCallback, false, "Leadership callback result is wrong for ", peers[0].id)
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p1"}, leaders)
+}
+
+func TestMultipleLeaders(t *testing.T) {
+	// Not parallel, as the leader count is a global setting
+	SetLeaderCount(2)
+	defer SetLeaderCount(1)
+	// Scenario: Peers spawn at the same time, and 2 leaders are configured.
+	// After a while, one of the leaders stops.
+	// Expected outcome: the 2 peers with the lowest IDs are the leaders,
+	// and then the peer with the next lowest ID takes over
+	peers := createPeers(0, 5, 4, 3, 2, 1, 0)
+	leaders := waitForMultipleLeadersElection(t, peers, 2)
+	assert.Len(t, leaders, 2, "2 leaders should have been elected")
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	leaders = waitForMultipleLeadersElection(t, peers, 2)
+	assert.Equal(t, []string{"p1", "p0"}, leaders)
+
+	peers[len(peers)-1].Stop()
+	time.Sleep(getLeadershipDeclarationInterval() + getLeaderAliveThreshold()*3)
+	leaders = waitForMultipleLeadersElection(t, peers[:len(peers)-1], 2)
+	assert.Equal(t, []string{"p2", "p1"}, leaders)
+
+	for _, p := range peers[:len(peers)-1] {
+		p.Stop()
+	}
+}
+
 func TestYieldSinglePeer(t *testing.T) {
 	t.Parallel()
 	// Scenario: spawn a single peer and have it yield.
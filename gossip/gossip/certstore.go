@@ -143,12 +143,14 @@ func (cs *certStore) createIdentityMessage() (*proto.SignedGossipMessage, error)
 	return sMsg, err
 }
 
-func (cs *certStore) listRevokedPeers(isSuspected api.PeerSuspector) []common.PKIidType {
-	revokedPeers := cs.idMapper.ListInvalidIdentities(isSuspected)
-	for _, pkiID := range revokedPeers {
+// listRevokedPeers removes the identities that have been revoked or expired,
+// returned first, and the identities that haven't been used for a long time
+func (cs *certStore) listRevokedPeers(isSuspected api.PeerSuspector) ([]common.PKIidType, []common.PKIidType) {
+	revokedPeers, unusedPeers := cs.idMapper.ListInvalidIdentities(isSuspected)
+	for _, pkiID := range append(append([]common.PKIidType{}, revokedPeers...), unusedPeers...) {
 		cs.pull.Remove(string(pkiID))
 	}
-	return revokedPeers
+	return revokedPeers, unusedPeers
 }
 
 func (cs *certStore) stop() {
@@ -80,6 +80,10 @@ type GossipChannel interface {
 	// that are eligible to be in the channel
 	ConfigureChannel(joinMsg api.JoinChannelMessage)
 
+	// MessageStoreSizes returns the amount of messages
+	// held by each message store of the channel
+	MessageStoreSizes() map[string]int
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -243,6 +247,7 @@ func (gc *gossipChannel) GetPeers() []discovery.NetworkMember {
 			continue
 		}
 		member.Metadata = stateInf.GetStateInfo().Metadata
+		member.Properties = stateInf.GetStateInfo().Properties
 		members = append(members, member)
 	}
 	return members
@@ -356,6 +361,16 @@ func (gc *gossipChannel) AddToMsgStore(msg *proto.SignedGossipMessage) {
 	}
 }
 
+// MessageStoreSizes returns the amount of messages
+// held by each message store of the channel
+func (gc *gossipChannel) MessageStoreSizes() map[string]int {
+	return map[string]int{
+		"blocks":     gc.blockMsgStore.Size(),
+		"stateInfo":  gc.stateInfoMsgStore.MessageStore.Size(),
+		"leadership": gc.leaderMsgStore.Size(),
+	}
+}
+
 // ConfigureChannel (re)configures the list of organizations
 // that are eligible to be in the channel
 func (gc *gossipChannel) ConfigureChannel(joinMsg api.JoinChannelMessage) {
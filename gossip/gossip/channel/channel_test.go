@@ -145,6 +145,10 @@ func (cs *cryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) err
 	panic("Should not be called in this test")
 }
 
+func (*cryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	panic("Should not be called in this test")
+}
+
 type receivedMsg struct {
 	PKIID common.PKIidType
 	msg   *proto.SignedGossipMessage
@@ -828,6 +832,11 @@ func TestChannelAddToMessageStore(t *testing.T) {
 
 	gc.HandleMessage(&receivedMsg{msg: createStateInfoMsg(10, pkiIDInOrg1, channelA), PKIID: pkiIDInOrg1})
 	assert.True(t, gc.EligibleForChannel(discovery.NetworkMember{PKIid: pkiIDInOrg1}))
+
+	sizes := gc.MessageStoreSizes()
+	assert.Equal(t, 2, sizes["blocks"])
+	assert.Equal(t, 1, sizes["stateInfo"])
+	assert.Equal(t, 0, sizes["leadership"])
 }
 
 func TestChannelBlockExpiration(t *testing.T) {
@@ -49,6 +49,18 @@ type Gossip interface {
 	// publishes to other peers about its channel-related state
 	UpdateChannelMetadata(metadata []byte, chainID common.ChainID)
 
+	// UpdateLedgerHeight updates the ledger height the peer
+	// publishes to other peers in the channel
+	UpdateLedgerHeight(height uint64, chainID common.ChainID)
+
+	// UpdateChaincodes updates the chaincodes the peer
+	// publishes to other peers in the channel
+	UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID)
+
+	// UpdateLeaderStatus updates whether the peer publishes to
+	// other peers that it is the leader of its organization in the channel
+	UpdateLeaderStatus(isLeader bool, chainID common.ChainID)
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -65,10 +77,26 @@ type Gossip interface {
 	// any connections to peers with identities that are found invalid
 	SuspectPeers(s api.PeerSuspector)
 
+	// Members returns the alive and dead members known, along with
+	// their organization and the last time they were seen alive
+	Members() []MemberInfo
+
+	// MessageStoreSizes returns the amount of messages held by each message store
+	// of the given channel, or of the gossip component if the channel is empty
+	MessageStoreSizes(chainID common.ChainID) map[string]int
+
 	// Stop stops the gossip component
 	Stop()
 }
 
+// MemberInfo describes a member known to the gossip component
+type MemberInfo struct {
+	discovery.MemberInfo
+	// Org is the organization of the member,
+	// or nil if its identity isn't known
+	Org api.OrgIdentityType
+}
+
 // Config is the configuration of the gossip component
 type Config struct {
 	BindPort            int      // Port we bind to, used only for tests
@@ -94,4 +122,8 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	MembershipPersistencePath     string        // File alive members are persisted to, persistence is disabled if empty
+	MembershipPersistenceInterval time.Duration // Determines frequency of persisting alive members
+	MembershipExpiration          time.Duration // Time after which persisted members that weren't seen alive are discarded
 }
@@ -76,6 +76,16 @@ type gossipServiceImpl struct {
 	disSecAdap        *discoverySecurityAdapter
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
+	selfStateLock     sync.Mutex
+	selfState         map[string]*selfChannelState
+	persistence       *discovery.MembershipPersistence
+}
+
+// selfChannelState is the state the peer publishes to other peers
+// about itself in a channel
+type selfChannelState struct {
+	metadata   []byte
+	properties *proto.Properties
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -114,6 +124,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		selfState:             make(map[string]*selfChannelState),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -137,6 +148,12 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 	go g.periodicalIdentityValidationAndExpiration()
 	go g.connect2BootstrapPeers()
 
+	if conf.MembershipPersistencePath != "" {
+		g.persistence = discovery.NewMembershipPersistence(conf.MembershipPersistencePath, conf.MembershipExpiration)
+		go g.connect2PersistedPeers()
+		go g.periodicalPersistMembership()
+	}
+
 	return g
 }
 
@@ -201,9 +218,17 @@ func (g *gossipServiceImpl) JoinChan(joinMsg api.JoinChannelMessage, chainID com
 }
 
 // SuspectPeers makes the gossip instance validate identities of suspected peers, and close
-// any connections to peers with identities that are found invalid
+// any connections to peers with identities that are found invalid. Peers with identities
+// that are revoked or expired are also purged from the membership, while peers with
+// identities that are only unused remain members.
 func (g *gossipServiceImpl) SuspectPeers(isSuspected api.PeerSuspector) {
-	for _, pkiID := range g.certStore.listRevokedPeers(isSuspected) {
+	revokedPeers, unusedPeers := g.certStore.listRevokedPeers(isSuspected)
+	for _, pkiID := range revokedPeers {
+		g.logger.Warning("Identity of", pkiID, "is invalid or expired, purging it from the membership")
+		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
+		g.disc.Purge(pkiID)
+	}
+	for _, pkiID := range unusedPeers {
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 	}
 }
@@ -683,6 +708,10 @@ func (g *gossipServiceImpl) Stop() {
 	}
 	atomic.StoreInt32(&g.stopFlag, int32(1))
 	g.logger.Info("Stopping gossip")
+	// Persist the members learned since the last periodical persistence
+	if g.persistence != nil {
+		g.persistMembership()
+	}
 	comWG := sync.WaitGroup{}
 	comWG.Add(1)
 	go func() {
@@ -701,6 +730,34 @@ func (g *gossipServiceImpl) Stop() {
 	comWG.Wait()
 }
 
+// Members returns the alive and dead members known, along with
+// their organization and the last time they were seen alive
+func (g *gossipServiceImpl) Members() []MemberInfo {
+	var members []MemberInfo
+	for _, member := range g.disc.GetMembershipInfo() {
+		members = append(members, MemberInfo{
+			MemberInfo: member,
+			Org:        g.getOrgOfPeer(member.PKIid),
+		})
+	}
+	return members
+}
+
+// MessageStoreSizes returns the amount of messages held by each message store
+// of the given channel, or of the gossip component if the channel is empty
+func (g *gossipServiceImpl) MessageStoreSizes(chainID common.ChainID) map[string]int {
+	if len(chainID) == 0 {
+		return map[string]int{
+			"stateInfo": g.stateInfoMsgStore.Size(),
+		}
+	}
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		return nil
+	}
+	return gc.MessageStoreSizes()
+}
+
 func (g *gossipServiceImpl) UpdateMetadata(md []byte) {
 	g.disc.UpdateMetadata(md)
 }
@@ -708,12 +765,57 @@ func (g *gossipServiceImpl) UpdateMetadata(md []byte) {
 // UpdateChannelMetadata updates the self metadata the peer
 // publishes to other peers about its channel-related state
 func (g *gossipServiceImpl) UpdateChannelMetadata(md []byte, chainID common.ChainID) {
+	g.updateSelfState(chainID, func(state *selfChannelState) {
+		state.metadata = md
+	})
+}
+
+// UpdateLedgerHeight updates the ledger height the peer
+// publishes to other peers in the channel
+func (g *gossipServiceImpl) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
+	g.updateSelfState(chainID, func(state *selfChannelState) {
+		state.properties.LedgerHeight = height
+	})
+}
+
+// UpdateChaincodes updates the chaincodes the peer
+// publishes to other peers in the channel
+func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+	g.updateSelfState(chainID, func(state *selfChannelState) {
+		state.properties.Chaincodes = chaincodes
+	})
+}
+
+// UpdateLeaderStatus updates whether the peer publishes to
+// other peers that it is the leader of its organization in the channel
+func (g *gossipServiceImpl) UpdateLeaderStatus(isLeader bool, chainID common.ChainID) {
+	g.updateSelfState(chainID, func(state *selfChannelState) {
+		state.properties.Leader = isLeader
+	})
+}
+
+// updateSelfState applies the given update to the state the peer publishes
+// about itself in the channel, and publishes the updated state
+func (g *gossipServiceImpl) updateSelfState(chainID common.ChainID, update func(*selfChannelState)) {
 	gc := g.chanState.getGossipChannelByChainID(chainID)
 	if gc == nil {
 		g.logger.Debug("No such channel", chainID)
 		return
 	}
-	stateInfMsg, err := g.createStateInfoMsg(md, chainID)
+
+	g.selfStateLock.Lock()
+	defer g.selfStateLock.Unlock()
+	state, exists := g.selfState[string(chainID)]
+	if !exists {
+		state = &selfChannelState{properties: &proto.Properties{}}
+		g.selfState[string(chainID)] = state
+	}
+	// The properties are copied as the published message refers to them
+	props := *state.properties
+	state.properties = &props
+	update(state)
+
+	stateInfMsg, err := g.createStateInfoMsg(state.metadata, state.properties, chainID)
 	if err != nil {
 		g.logger.Error("Failed creating StateInfo message")
 		return
@@ -1068,11 +1170,87 @@ func (g *gossipServiceImpl) connect2BootstrapPeers() {
 
 }
 
-func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, chainID common.ChainID) (*proto.SignedGossipMessage, error) {
+// connect2PersistedPeers connects to the members persisted before the
+// last restart, in addition to the bootstrap peers
+func (g *gossipServiceImpl) connect2PersistedPeers() {
+	members, err := g.persistence.Load()
+	if err != nil {
+		g.logger.Warning("Failed loading persisted membership:", err)
+		return
+	}
+	bootstrapPeers := make(map[string]struct{})
+	for _, endpoint := range g.conf.BootstrapPeers {
+		bootstrapPeers[endpoint] = struct{}{}
+	}
+	for _, member := range members {
+		endpoint := discovery.NetworkMember{Endpoint: member.Endpoint, InternalEndpoint: member.InternalEndpoint}.PreferredEndpoint()
+		if _, isBootstrapPeer := bootstrapPeers[endpoint]; isBootstrapPeer || bytes.Equal(member.PKIid, g.comm.GetPKIid()) {
+			continue
+		}
+		if len(member.Identity) != 0 {
+			if err := g.idMapper.Put(member.PKIid, member.Identity); err != nil {
+				g.logger.Warning("Skipping persisted member", endpoint, "with invalid identity:", err)
+				continue
+			}
+		}
+		g.logger.Debug("Connecting to persisted member", endpoint)
+		identifier := func() (*discovery.PeerIdentification, error) {
+			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+			if err != nil {
+				return nil, err
+			}
+			pkiID := g.mcs.GetPKIidOfCert(remotePeerIdentity)
+			if len(pkiID) == 0 {
+				return nil, fmt.Errorf("Wasn't able to extract PKI-ID of remote peer with identity of %v", remotePeerIdentity)
+			}
+			return &discovery.PeerIdentification{
+				ID:      pkiID,
+				SelfOrg: bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity)),
+			}, nil
+		}
+		g.disc.Connect(discovery.NetworkMember{
+			InternalEndpoint: endpoint, Endpoint: endpoint}, identifier)
+	}
+}
+
+func (g *gossipServiceImpl) periodicalPersistMembership() {
+	for {
+		select {
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
+			return
+		case <-time.After(g.conf.MembershipPersistenceInterval):
+			g.persistMembership()
+		}
+	}
+}
+
+// persistMembership persists the alive members along with their identities
+func (g *gossipServiceImpl) persistMembership() {
+	var members []discovery.PersistedMember
+	for _, member := range g.disc.GetMembership() {
+		identity, err := g.idMapper.Get(member.PKIid)
+		if err != nil {
+			g.logger.Debug("Persisting", member.PreferredEndpoint(), "without its identity:", err)
+		}
+		members = append(members, discovery.PersistedMember{
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			PKIid:            member.PKIid,
+			Identity:         identity,
+		})
+	}
+	if err := g.persistence.Save(members); err != nil {
+		g.logger.Warning("Failed persisting membership:", err)
+	}
+}
+
+func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, properties *proto.Properties, chainID common.ChainID) (*proto.SignedGossipMessage, error) {
 	pkiID := g.comm.GetPKIid()
 	stateInfMsg := &proto.StateInfo{
 		Channel_MAC: channel.GenerateMAC(pkiID, chainID),
 		Metadata:    metadata,
+		Properties:  properties,
 		PkiId:       g.comm.GetPKIid(),
 		Timestamp: &proto.PeerTime{
 			IncNum: uint64(g.incTime.UnixNano()),
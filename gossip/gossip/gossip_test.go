@@ -20,7 +20,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -152,6 +155,10 @@ func (cs *naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType
 	return nil
 }
 
+func (*naiveCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -509,6 +516,160 @@ func TestMembership(t *testing.T) {
 	testWG.Done()
 }
 
+func TestChannelProperties(t *testing.T) {
+	t.Parallel()
+	portPrefix := 14610
+	// Scenario: a peer publishes its properties in a channel and
+	// ensure they are exposed by the other peer of the channel,
+	// along with the channel metadata published before
+
+	boot := newGossipInstance(portPrefix, 0, 100)
+	defer boot.Stop()
+	p1 := newGossipInstance(portPrefix, 1, 100, 0)
+	defer p1.Stop()
+	boot.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	p1.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	p1.UpdateChannelMetadata([]byte{}, common.ChainID("A"))
+
+	boot.UpdateChannelMetadata([]byte("bla bla"), common.ChainID("A"))
+	boot.UpdateLedgerHeight(10, common.ChainID("A"))
+	boot.UpdateChaincodes([]*proto.Chaincode{{Name: "mycc", Version: "1.0"}}, common.ChainID("A"))
+	boot.UpdateLeaderStatus(true, common.ChainID("A"))
+
+	propertiesPublished := func() bool {
+		for _, member := range p1.PeersOfChannel(common.ChainID("A")) {
+			props := member.Properties
+			if props == nil || !props.Leader || len(props.Chaincodes) != 1 {
+				continue
+			}
+			return props.LedgerHeight == 10 && props.Chaincodes[0].Name == "mycc" &&
+				string(member.Metadata) == "bla bla"
+		}
+		return false
+	}
+	waitUntilOrFail(t, propertiesPublished)
+}
+
+func TestMembershipPersistence(t *testing.T) {
+	t.Parallel()
+	portPrefix := 14710
+	// Scenario: p1 learns about p2 through the bootstrap peer and persists it.
+	// Then the bootstrap peer and p1 are stopped, and when p1 is restarted
+	// it reconnects to p2 even though its bootstrap peer is down
+
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "membership.json")
+
+	newPersistingInstance := func() Gossip {
+		port := portPrefix + 1
+		conf := &Config{
+			BindPort:                      port,
+			BootstrapPeers:                bootPeers(portPrefix, 0),
+			ID:                            "p1",
+			MaxBlockCountToStore:          100,
+			MaxPropagationBurstLatency:    time.Duration(500) * time.Millisecond,
+			MaxPropagationBurstSize:       20,
+			PropagateIterations:           1,
+			PropagatePeerNum:              3,
+			PullInterval:                  time.Duration(2) * time.Second,
+			PullPeerNum:                   5,
+			InternalEndpoint:              fmt.Sprintf("localhost:%d", port),
+			ExternalEndpoint:              fmt.Sprintf("1.2.3.4:%d", port),
+			PublishCertPeriod:             time.Duration(4) * time.Second,
+			PublishStateInfoInterval:      time.Duration(1) * time.Second,
+			RequestStateInfoInterval:      time.Duration(1) * time.Second,
+			MembershipPersistencePath:     path,
+			MembershipPersistenceInterval: time.Duration(500) * time.Millisecond,
+			MembershipExpiration:          time.Hour,
+		}
+		cryptoService := &naiveCryptoService{}
+		selfId := api.PeerIdentityType(conf.InternalEndpoint)
+		idMapper := identity.NewIdentityMapper(cryptoService, selfId)
+		return NewGossipServiceWithServer(conf, &orgCryptoService{}, cryptoService, idMapper, selfId, nil)
+	}
+
+	boot := newGossipInstance(portPrefix, 0, 100)
+	p1 := newPersistingInstance()
+	p2 := newGossipInstance(portPrefix, 2, 100, 0)
+	defer p2.Stop()
+
+	waitUntilOrFail(t, checkPeersMembership(t, []Gossip{p1}, 2))
+	persistence := discovery.NewMembershipPersistence(path, time.Hour)
+	waitUntilOrFail(t, func() bool {
+		members, err := persistence.Load()
+		return err == nil && len(members) == 2
+	})
+
+	boot.Stop()
+	p1.Stop()
+
+	p1 = newPersistingInstance()
+	defer p1.Stop()
+	waitUntilOrFail(t, func() bool {
+		for _, member := range p1.Peers() {
+			if member.InternalEndpoint == fmt.Sprintf("localhost:%d", portPrefix+2) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestMembershipPersistedOnStop(t *testing.T) {
+	t.Parallel()
+	portPrefix := 14810
+	// Scenario: p1 persists its membership rarely, and learns about p2 after
+	// its first persistence. The membership is persisted once more when p1
+	// is stopped, so p2 isn't lost
+
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "membership.json")
+
+	port := portPrefix + 1
+	conf := &Config{
+		BindPort:                      port,
+		BootstrapPeers:                bootPeers(portPrefix, 0),
+		ID:                            "p1",
+		MaxBlockCountToStore:          100,
+		MaxPropagationBurstLatency:    time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:       20,
+		PropagateIterations:           1,
+		PropagatePeerNum:              3,
+		PullInterval:                  time.Duration(2) * time.Second,
+		PullPeerNum:                   5,
+		InternalEndpoint:              fmt.Sprintf("localhost:%d", port),
+		ExternalEndpoint:              fmt.Sprintf("1.2.3.4:%d", port),
+		PublishCertPeriod:             time.Duration(4) * time.Second,
+		PublishStateInfoInterval:      time.Duration(1) * time.Second,
+		RequestStateInfoInterval:      time.Duration(1) * time.Second,
+		MembershipPersistencePath:     path,
+		MembershipPersistenceInterval: time.Hour,
+		MembershipExpiration:          time.Hour,
+	}
+	cryptoService := &naiveCryptoService{}
+	selfId := api.PeerIdentityType(conf.InternalEndpoint)
+	idMapper := identity.NewIdentityMapper(cryptoService, selfId)
+
+	boot := newGossipInstance(portPrefix, 0, 100)
+	defer boot.Stop()
+	p1 := NewGossipServiceWithServer(conf, &orgCryptoService{}, cryptoService, idMapper, selfId, nil)
+	p2 := newGossipInstance(portPrefix, 2, 100, 0)
+	defer p2.Stop()
+
+	waitUntilOrFail(t, checkPeersMembership(t, []Gossip{p1}, 2))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "The membership shouldn't have been persisted yet")
+
+	p1.Stop()
+	members, err := discovery.NewMembershipPersistence(path, time.Hour).Load()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
 func TestDissemination(t *testing.T) {
 	t.Parallel()
 	portPrefix := 3610
@@ -70,6 +70,10 @@ func (*configurableCryptoService) ValidateIdentity(peerIdentity api.PeerIdentity
 	return nil
 }
 
+func (*configurableCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*configurableCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -26,6 +26,8 @@ import (
 
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/op/go-logging"
 )
 
 var (
@@ -56,9 +58,10 @@ type Mapper interface {
 	GetPKIidOfCert(api.PeerIdentityType) common.PKIidType
 
 	// ListInvalidIdentities returns a list of PKI-IDs that their corresponding
-	// peer identities have been revoked, expired or haven't been used
-	// for a long time
-	ListInvalidIdentities(isSuspected api.PeerSuspector) []common.PKIidType
+	// peer identities have been revoked or expired, and separately a list of
+	// PKI-IDs that their corresponding peer identities haven't been used
+	// for a long time. The identities of both lists are removed.
+	ListInvalidIdentities(isSuspected api.PeerSuspector) (revoked []common.PKIidType, unused []common.PKIidType)
 }
 
 // identityMapperImpl is a struct that implements Mapper
@@ -67,6 +70,7 @@ type identityMapperImpl struct {
 	pkiID2Cert map[string]*storedIdentity
 	sync.RWMutex
 	selfPKIID string
+	logger    *logging.Logger
 }
 
 // NewIdentityMapper method, all we need is a reference to a MessageCryptoService
@@ -76,6 +80,7 @@ func NewIdentityMapper(mcs api.MessageCryptoService, selfIdentity api.PeerIdenti
 		mcs:        mcs,
 		pkiID2Cert: make(map[string]*storedIdentity),
 		selfPKIID:  string(selfPKIID),
+		logger:     util.GetLogger(util.LoggingIdentityModule, ""),
 	}
 	if err := idMapper.Put(selfPKIID, selfIdentity); err != nil {
 		panic(fmt.Errorf("Failed putting our own identity into the identity mapper: %v", err))
@@ -102,9 +107,22 @@ func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdent
 		return errors.New("identity doesn't match the computed pkiID")
 	}
 
+	expiresAt, err := is.mcs.Expiration(identity)
+	if err != nil {
+		return fmt.Errorf("failed retrieving expiration of identity: %v", err)
+	}
+	if isExpired(expiresAt, time.Now()) {
+		// Our own identity is never purged, and whether an expired
+		// identity is acceptable for the peer is decided at startup
+		if string(id) != is.selfPKIID {
+			return fmt.Errorf("identity expired at %v", expiresAt)
+		}
+		is.logger.Warningf("Our own identity expired at %v", expiresAt)
+	}
+
 	is.Lock()
 	defer is.Unlock()
-	is.pkiID2Cert[string(id)] = newStoredIdentity(identity)
+	is.pkiID2Cert[string(id)] = newStoredIdentity(identity, expiresAt)
 	return nil
 }
 
@@ -141,55 +159,72 @@ func (is *identityMapperImpl) GetPKIidOfCert(identity api.PeerIdentityType) comm
 }
 
 // ListInvalidIdentities returns a list of PKI-IDs that their corresponding
-// peer identities have been revoked, expired or haven't been used
-// for a long time
-func (is *identityMapperImpl) ListInvalidIdentities(isSuspected api.PeerSuspector) []common.PKIidType {
-	revokedIds := is.validateIdentities(isSuspected)
-	if len(revokedIds) == 0 {
-		return nil
+// peer identities have been revoked or expired, and separately a list of
+// PKI-IDs that their corresponding peer identities haven't been used
+// for a long time. The identities of both lists are removed.
+func (is *identityMapperImpl) ListInvalidIdentities(isSuspected api.PeerSuspector) ([]common.PKIidType, []common.PKIidType) {
+	revokedIds, unusedIds := is.validateIdentities(isSuspected)
+	if len(revokedIds) == 0 && len(unusedIds) == 0 {
+		return nil, nil
 	}
 	is.Lock()
 	defer is.Unlock()
-	for _, pkiID := range revokedIds {
+	for _, pkiID := range append(append([]common.PKIidType{}, revokedIds...), unusedIds...) {
 		delete(is.pkiID2Cert, string(pkiID))
 	}
-	return revokedIds
+	return revokedIds, unusedIds
 }
 
-// validateIdentities returns a list of identities that have been revoked, expired or haven't been
-// used for a long time
-func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) []common.PKIidType {
+// validateIdentities returns a list of identities that have been revoked or expired,
+// and a list of the remaining identities that haven't been used for a long time
+func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) ([]common.PKIidType, []common.PKIidType) {
 	now := time.Now()
 	is.RLock()
 	defer is.RUnlock()
-	var revokedIds []common.PKIidType
+	var revokedIds, unusedIds []common.PKIidType
 	for pkiID, storedIdentity := range is.pkiID2Cert {
-		if pkiID != is.selfPKIID && storedIdentity.fetchLastAccessTime().Add(usageThreshold).Before(now) {
+		isSelf := pkiID == is.selfPKIID
+		// Checked before the identity is accessed for validation below
+		unused := !isSelf && storedIdentity.fetchLastAccessTime().Add(usageThreshold).Before(now)
+		// Expired identities are purged regardless of whether they are suspected,
+		// as checking for expiration doesn't require consulting the MSPs
+		if !isSelf && isExpired(storedIdentity.expiresAt, now) {
 			revokedIds = append(revokedIds, common.PKIidType(pkiID))
 			continue
 		}
-		if !isSuspected(storedIdentity.fetchIdentity()) {
-			continue
+		if isSuspected(storedIdentity.peerIdentity) {
+			if err := is.mcs.ValidateIdentity(storedIdentity.peerIdentity); err != nil {
+				revokedIds = append(revokedIds, common.PKIidType(pkiID))
+				continue
+			}
 		}
-		if err := is.mcs.ValidateIdentity(storedIdentity.fetchIdentity()); err != nil {
-			revokedIds = append(revokedIds, common.PKIidType(pkiID))
+		if unused {
+			unusedIds = append(unusedIds, common.PKIidType(pkiID))
 		}
 	}
-	return revokedIds
+	return revokedIds, unusedIds
 }
 
 type storedIdentity struct {
 	lastAccessTime int64
 	peerIdentity   api.PeerIdentityType
+	expiresAt      time.Time
 }
 
-func newStoredIdentity(identity api.PeerIdentityType) *storedIdentity {
+func newStoredIdentity(identity api.PeerIdentityType, expiresAt time.Time) *storedIdentity {
 	return &storedIdentity{
 		lastAccessTime: time.Now().UnixNano(),
 		peerIdentity:   identity,
+		expiresAt:      expiresAt,
 	}
 }
 
+// isExpired returns whether an identity that expires at the given time
+// has expired. A zero expiration time means the identity never expires.
+func isExpired(expiresAt time.Time, now time.Time) bool {
+	return !expiresAt.IsZero() && expiresAt.Before(now)
+}
+
 func (si *storedIdentity) fetchIdentity() api.PeerIdentityType {
 	atomic.StoreInt64(&si.lastAccessTime, time.Now().UnixNano())
 	return si.peerIdentity
@@ -31,12 +31,13 @@ import (
 )
 
 var (
-	msgCryptoService = &naiveCryptoService{revokedIdentities: map[string]struct{}{}}
+	msgCryptoService = &naiveCryptoService{revokedIdentities: map[string]struct{}{}, expirations: map[string]time.Time{}}
 	dummyID          = api.PeerIdentityType{}
 )
 
 type naiveCryptoService struct {
 	revokedIdentities map[string]struct{}
+	expirations       map[string]time.Time
 }
 
 func init() {
@@ -50,6 +51,13 @@ func (cs *naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType
 	return nil
 }
 
+func (cs *naiveCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	if string(peerIdentity) == "invalid" {
+		return time.Time{}, errors.New("invalid certificate")
+	}
+	return cs.expirations[string(peerIdentity)], nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -95,6 +103,26 @@ func TestPut(t *testing.T) {
 	assert.Error(t, idStore.Put(pkiID2, nil))
 	assert.Error(t, idStore.Put(pkiID2, identity))
 	assert.Error(t, idStore.Put(pkiID, identity2))
+
+	// Identities that can't be classified, or that have already expired are rejected
+	invalid := api.PeerIdentityType("invalid")
+	assert.Error(t, idStore.Put(msgCryptoService.GetPKIidOfCert(invalid), invalid))
+	expired := api.PeerIdentityType("expired")
+	msgCryptoService.expirations[string(expired)] = time.Now().Add(-time.Second)
+	defer delete(msgCryptoService.expirations, string(expired))
+	err := idStore.Put(msgCryptoService.GetPKIidOfCert(expired), expired)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+
+	// Our own identity is kept even if it has expired
+	msgCryptoService.expirations[string(dummyID)] = time.Now().Add(-time.Second)
+	defer delete(msgCryptoService.expirations, string(dummyID))
+	assert.NotPanics(t, func() {
+		idStore = NewIdentityMapper(msgCryptoService, dummyID)
+	})
+	self, err := idStore.Get(msgCryptoService.GetPKIidOfCert(dummyID))
+	assert.NoError(t, err)
+	assert.Equal(t, dummyID, self)
 }
 
 func TestGet(t *testing.T) {
@@ -192,3 +220,53 @@ func TestListInvalidIdentities(t *testing.T) {
 	assert.NotNil(t, cert)
 	stopChan <- struct{}{}
 }
+
+func TestListInvalidIdentitiesExpired(t *testing.T) {
+	idStore := NewIdentityMapper(msgCryptoService, dummyID)
+	identity := api.PeerIdentityType("expiresSoon")
+	pkiID := msgCryptoService.GetPKIidOfCert(identity)
+	msgCryptoService.expirations[string(identity)] = time.Now().Add(time.Millisecond * 500)
+	defer delete(msgCryptoService.expirations, string(identity))
+	assert.NoError(t, idStore.Put(pkiID, identity))
+
+	notSuspected := func(_ api.PeerIdentityType) bool {
+		return false
+	}
+	revoked, unused := idStore.ListInvalidIdentities(notSuspected)
+	assert.Empty(t, revoked)
+	assert.Empty(t, unused)
+	_, err := idStore.Get(pkiID)
+	assert.NoError(t, err)
+
+	// Once the identity expires, it is purged even if it isn't suspected
+	time.Sleep(time.Second)
+	revoked, unused = idStore.ListInvalidIdentities(notSuspected)
+	assert.Equal(t, []common.PKIidType{pkiID}, revoked)
+	assert.Empty(t, unused)
+	_, err = idStore.Get(pkiID)
+	assert.Error(t, err)
+}
+
+func TestListInvalidIdentitiesUnused(t *testing.T) {
+	defer SetIdentityUsageThreshold(usageThreshold)
+	idStore := NewIdentityMapper(msgCryptoService, dummyID)
+	idle := api.PeerIdentityType("idle")
+	idlePKIID := msgCryptoService.GetPKIidOfCert(idle)
+	revokedIdle := api.PeerIdentityType("revokedIdle")
+	revokedPKIID := msgCryptoService.GetPKIidOfCert(revokedIdle)
+	assert.NoError(t, idStore.Put(idlePKIID, idle))
+	assert.NoError(t, idStore.Put(revokedPKIID, revokedIdle))
+	msgCryptoService.revokedIdentities[string(revokedPKIID)] = struct{}{}
+	defer delete(msgCryptoService.revokedIdentities, string(revokedPKIID))
+
+	// Identities that are only unused are reported apart from the revoked ones
+	SetIdentityUsageThreshold(time.Millisecond * 100)
+	time.Sleep(time.Millisecond * 200)
+	revoked, unused := idStore.ListInvalidIdentities(func(_ api.PeerIdentityType) bool {
+		return true
+	})
+	assert.Equal(t, []common.PKIidType{revokedPKIID}, revoked)
+	assert.Equal(t, []common.PKIidType{idlePKIID}, unused)
+	_, err := idStore.Get(idlePKIID)
+	assert.Error(t, err)
+}
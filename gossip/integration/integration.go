@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -55,7 +56,7 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		cert = &certTmp
 	}
 
-	return &gossip.Config{
+	conf := &gossip.Config{
 		BindPort:                   int(port),
 		BootstrapPeers:             bootPeers,
 		ID:                         selfEndpoint,
@@ -73,7 +74,15 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSServerCert:              cert,
-	}, nil
+	}
+
+	if viper.GetBool("peer.gossip.membership.persist") {
+		conf.MembershipPersistencePath = filepath.Join(config.GetPath("peer.fileSystemPath"), "gossip", "membership.json")
+		conf.MembershipPersistenceInterval = util.GetDurationOrDefault("peer.gossip.membership.persistInterval", 30*time.Second)
+		conf.MembershipExpiration = util.GetDurationOrDefault("peer.gossip.membership.expiration", 24*time.Hour)
+	}
+
+	return conf, nil
 }
 
 // NewGossipComponent creates a gossip component that attaches itself to the given gRPC server
@@ -21,6 +21,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -139,3 +140,7 @@ func (s *cryptoService) VerifyByChannel(chainID common.ChainID, peerIdentity api
 func (s *cryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
+
+func (*cryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/core/committer"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -32,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
@@ -55,6 +57,16 @@ type GossipService interface {
 	GetBlock(chainID string, index uint64) *common.Block
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *proto.Payload) error
+	// PeerIdentity returns the identity of the peer with the given PKI-ID
+	PeerIdentity(pkiID gossipCommon.PKIidType) (api.PeerIdentityType, error)
+	// MembersInfo returns the members known to the gossip component of the peer
+	MembersInfo() *pb.GossipMembersResponse
+	// ChannelInfo returns the members of the given channel, as seen
+	// by the gossip component of the peer
+	ChannelInfo(chainID string) (*pb.GossipChannelResponse, error)
+	// LeaderInfo returns the leader of the organization of the peer in the given
+	// channel, as elected by the leader election or statically configured
+	LeaderInfo(chainID string) (*pb.GossipLeaderResponse, error)
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -88,6 +100,10 @@ type gossipServiceImpl struct {
 	mcs             api.MessageCryptoService
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
+	// endpoint and externalEndpoint are the endpoints the peer
+	// publishes to peers inside and outside of its organization
+	endpoint         string
+	externalEndpoint string
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -155,13 +171,20 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 			idMapper:        idMapper,
 			peerIdentity:    peerIdentity,
 			secAdv:          secAdv,
+
+			endpoint:         endpoint,
+			externalEndpoint: viper.GetString("peer.gossip.externalEndpoint"),
 		}
+		ccprovider.RegisterInstallListener(gossipServiceInstance)
 	})
 	return err
 }
 
 // GetGossipService returns an instance of gossip service
 func GetGossipService() GossipService {
+	if gossipServiceInstance == nil {
+		return nil
+	}
 	return gossipServiceInstance
 }
 
@@ -177,6 +200,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", chainID)
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, g, committer, g.mcs)
+	g.publishInstalledChaincodes(chainID)
 	if g.deliveryService == nil {
 		var err error
 		g.deliveryService, err = g.deliveryFactory.Service(gossipServiceInstance, endpoints, g.mcs)
@@ -207,6 +231,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 		} else if isStaticOrgLeader {
 			logger.Debug("This peer is configured to connect to ordering service for blocks delivery, channel", chainID)
 			g.deliveryService.StartDeliverForChannel(chainID, committer, func() {})
+			g.UpdateLeaderStatus(true, gossipCommon.ChainID(chainID))
 		} else {
 			logger.Debug("This peer is not configured to connect to ordering service for blocks delivery, channel", chainID)
 		}
@@ -239,6 +264,13 @@ func (g *gossipServiceImpl) configUpdated(config Config) {
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", config.ChainID())
 	g.JoinChan(jcm, gossipCommon.ChainID(config.ChainID()))
+
+	// The MSPs of the channel might have been updated with new CRLs or
+	// root certificates, so re-validate identities of peers of its orgs
+	g.SuspectPeers(func(identity api.PeerIdentityType) bool {
+		_, inChannel := jcm.members2AnchorPeers[string(g.secAdv.OrgByPeerIdentity(identity))]
+		return inChannel
+	})
 }
 
 // GetBlock returns block for given chain
@@ -255,6 +287,37 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *proto.Payload) e
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// ChaincodeInstalled publishes the chaincodes installed on the peer in all
+// the channels the peer has joined
+func (g *gossipServiceImpl) ChaincodeInstalled(ccname string, ccversion string) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	logger.Debug("Chaincode", ccname, ccversion, "installed, publishing installed chaincodes")
+	for chainID := range g.chains {
+		g.publishInstalledChaincodes(chainID)
+	}
+}
+
+// publishInstalledChaincodes publishes the chaincodes installed on
+// the peer in the given channel
+func (g *gossipServiceImpl) publishInstalledChaincodes(chainID string) {
+	installed, err := ccprovider.GetInstalledChaincodes()
+	if err != nil {
+		logger.Debug("Failed listing installed chaincodes:", err)
+		return
+	}
+	var chaincodes []*proto.Chaincode
+	for _, cc := range installed.Chaincodes {
+		chaincodes = append(chaincodes, &proto.Chaincode{Name: cc.Name, Version: cc.Version})
+	}
+	g.UpdateChaincodes(chaincodes, gossipCommon.ChainID(chainID))
+}
+
+// PeerIdentity returns the identity of the peer with the given PKI-ID
+func (g *gossipServiceImpl) PeerIdentity(pkiID gossipCommon.PKIidType) (api.PeerIdentityType, error) {
+	return g.idMapper.Get(pkiID)
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
@@ -277,7 +340,11 @@ func (g *gossipServiceImpl) Stop() {
 func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool)) election.LeaderElectionService {
 	PKIid := g.idMapper.GetPKIidOfCert(g.peerIdentity)
 	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID))
-	return election.NewLeaderElectionService(adapter, string(PKIid), callback)
+	weight := viper.GetInt("peer.gossip.election.weight")
+	if weight < 0 {
+		weight = 0
+	}
+	return election.NewLeaderElectionService(adapter, string(PKIid), uint32(weight), callback)
 }
 
 func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
@@ -291,6 +358,7 @@ func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 
 func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer blocksprovider.LedgerInfo) func(bool) {
 	return func(isLeader bool) {
+		g.UpdateLeaderStatus(isLeader, gossipCommon.ChainID(chainID))
 		if isLeader {
 			yield := func() {
 				g.lock.RLock()
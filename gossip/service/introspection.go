@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+)
+
+// MembersInfo returns the members known to the gossip component of the peer
+func (g *gossipServiceImpl) MembersInfo() *pb.GossipMembersResponse {
+	resp := &pb.GossipMembersResponse{
+		Self:              g.selfMember(),
+		MessageStoreSizes: toInt32Map(g.MessageStoreSizes(nil)),
+	}
+	for _, member := range g.Members() {
+		resp.Members = append(resp.Members, toGossipMember(member))
+	}
+	sort.Sort(membersByEndpoint(resp.Members))
+	return resp
+}
+
+// ChannelInfo returns the members of the given channel, as seen
+// by the gossip component of the peer
+func (g *gossipServiceImpl) ChannelInfo(chainID string) (*pb.GossipChannelResponse, error) {
+	sizes := g.MessageStoreSizes(gossipCommon.ChainID(chainID))
+	if sizes == nil {
+		return nil, fmt.Errorf("Channel %s not found", chainID)
+	}
+	known := make(map[string]gossip.MemberInfo)
+	for _, member := range g.Members() {
+		known[string(member.PKIid)] = member
+	}
+
+	resp := &pb.GossipChannelResponse{
+		Channel:           chainID,
+		MessageStoreSizes: toInt32Map(sizes),
+	}
+	for _, member := range g.PeersOfChannel(gossipCommon.ChainID(chainID)) {
+		info, exists := known[string(member.PKIid)]
+		if !exists {
+			info = gossip.MemberInfo{MemberInfo: discovery.MemberInfo{NetworkMember: member, Alive: true}}
+		}
+		gm := toGossipMember(info)
+		gm.LedgerHeight = member.Properties.GetLedgerHeight()
+		gm.Leader = member.Properties.GetLeader()
+		resp.Members = append(resp.Members, gm)
+	}
+	sort.Sort(membersByEndpoint(resp.Members))
+	return resp, nil
+}
+
+// LeaderInfo returns the leader of the organization of the peer in the given
+// channel, as elected by the leader election or statically configured
+func (g *gossipServiceImpl) LeaderInfo(chainID string) (*pb.GossipLeaderResponse, error) {
+	channel, err := g.ChannelInfo(chainID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GossipLeaderResponse{Channel: chainID}
+	self := g.selfMember()
+
+	g.lock.RLock()
+	le, elected := g.leaderElection[chainID]
+	g.lock.RUnlock()
+
+	var leader []byte
+	if elected {
+		resp.Election = true
+		leader = le.Leader()
+	} else if viper.GetBool("peer.gossip.orgLeader") {
+		leader = self.PkiId
+	} else {
+		// Static leaders advertise their leadership in the channel
+		for _, member := range channel.Members {
+			if member.Leader && member.Org == self.Org {
+				leader = member.PkiId
+				break
+			}
+		}
+	}
+
+	if leader == nil {
+		return resp, nil
+	}
+	if bytes.Equal(leader, self.PkiId) {
+		self.Leader = true
+		resp.Leader = self
+		resp.Self = true
+		return resp, nil
+	}
+	for _, member := range channel.Members {
+		if bytes.Equal(leader, member.PkiId) {
+			resp.Leader = member
+			return resp, nil
+		}
+	}
+	resp.Leader = &pb.GossipMember{PkiId: leader}
+	return resp, nil
+}
+
+func (g *gossipServiceImpl) selfMember() *pb.GossipMember {
+	return &pb.GossipMember{
+		Endpoint:         g.externalEndpoint,
+		InternalEndpoint: g.endpoint,
+		PkiId:            g.idMapper.GetPKIidOfCert(g.peerIdentity),
+		Org:              string(g.secAdv.OrgByPeerIdentity(g.peerIdentity)),
+		Alive:            true,
+	}
+}
+
+func toGossipMember(member gossip.MemberInfo) *pb.GossipMember {
+	gm := &pb.GossipMember{
+		Endpoint:         member.Endpoint,
+		InternalEndpoint: member.InternalEndpoint,
+		PkiId:            member.PKIid,
+		Org:              string(member.Org),
+		Alive:            member.Alive,
+	}
+	if !member.LastSeen.IsZero() {
+		gm.LastSeen = &timestamp.Timestamp{
+			Seconds: member.LastSeen.Unix(),
+			Nanos:   int32(member.LastSeen.Nanosecond()),
+		}
+	}
+	return gm
+}
+
+func toInt32Map(m map[string]int) map[string]int32 {
+	res := make(map[string]int32, len(m))
+	for k, v := range m {
+		res[k] = int32(v)
+	}
+	return res
+}
+
+// membersByEndpoint sorts members by endpoint, then by internal endpoint
+type membersByEndpoint []*pb.GossipMember
+
+func (m membersByEndpoint) Len() int      { return len(m) }
+func (m membersByEndpoint) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m membersByEndpoint) Less(i, j int) bool {
+	if m[i].Endpoint != m[j].Endpoint {
+		return m[i].Endpoint < m[j].Endpoint
+	}
+	return m[i].InternalEndpoint < m[j].InternalEndpoint
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -48,10 +49,18 @@ type gossipMock struct {
 	mock.Mock
 }
 
-func (*gossipMock) SuspectPeers(s api.PeerSuspector) {
+func (*gossipMock) Members() []gossip.MemberInfo {
 	panic("implement me")
 }
 
+func (*gossipMock) MessageStoreSizes(chainID common.ChainID) map[string]int {
+	panic("implement me")
+}
+
+func (g *gossipMock) SuspectPeers(s api.PeerSuspector) {
+	g.Called(s)
+}
+
 func (*gossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
@@ -72,6 +81,18 @@ func (*gossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateLeaderStatus(isLeader bool, chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
@@ -147,6 +168,7 @@ func TestJoinChannelConfig(t *testing.T) {
 	g2SvcMock.On("JoinChan", mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		succChan <- struct{}{}
 	})
+	g2SvcMock.On("SuspectPeers", mock.Anything)
 	g2 := &gossipServiceImpl{secAdv: &secAdvMock{}, peerIdentity: api.PeerIdentityType("Org0"), gossipSvc: g2SvcMock}
 	g2.configUpdated(&configMock{
 		orgs2AppOrgs: map[string]config.ApplicationOrg{
@@ -178,6 +200,13 @@ func TestJoinChannelNoAnchorPeers(t *testing.T) {
 		assert.Contains(t, jcm.Members(), api.OrgIdentityType("Org1"))
 		assert.Equal(t, "A", string(channel))
 	})
+	// Identities of peers of the orgs of the channel are re-validated
+	gMock.On("SuspectPeers", mock.Anything).Run(func(args mock.Arguments) {
+		isSuspected := args.Get(0).(api.PeerSuspector)
+		assert.True(t, isSuspected(api.PeerIdentityType("Org0")))
+		assert.True(t, isSuspected(api.PeerIdentityType("Org1")))
+		assert.False(t, isSuspected(api.PeerIdentityType("Org2")))
+	})
 
 	g := &gossipServiceImpl{secAdv: &secAdvMock{}, peerIdentity: api.PeerIdentityType("Org0"), gossipSvc: gMock}
 
@@ -195,4 +224,5 @@ func TestJoinChannelNoAnchorPeers(t *testing.T) {
 		},
 	})
 	joinChanCalled.Wait()
+	gMock.AssertCalled(t, "SuspectPeers", mock.Anything)
 }
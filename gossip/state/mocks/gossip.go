@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,6 +30,14 @@ type GossipMock struct {
 	mock.Mock
 }
 
+func (*GossipMock) Members() []gossip.MemberInfo {
+	panic("implement me")
+}
+
+func (*GossipMock) MessageStoreSizes(chainID common.ChainID) map[string]int {
+	panic("implement me")
+}
+
 func (*GossipMock) SuspectPeers(s api.PeerSuspector) {
 	panic("implement me")
 }
@@ -53,6 +62,18 @@ func (*GossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID
 
 }
 
+func (*GossipMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
+
+}
+
+func (*GossipMock) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+
+}
+
+func (*GossipMock) UpdateLeaderStatus(isLeader bool, chainID common.ChainID) {
+
+}
+
 func (*GossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
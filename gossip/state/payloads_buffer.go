@@ -51,6 +51,14 @@ type PayloadsBuffer interface {
 	Close()
 }
 
+// duplicatePayloadError is returned when pushing a payload with a sequence
+// number that was already pushed into the buffer
+type duplicatePayloadError uint64
+
+func (e duplicatePayloadError) Error() string {
+	return fmt.Sprintf("Payload with sequence number = %s has been already processed", strconv.FormatUint(uint64(e), 10))
+}
+
 // PayloadsBufferImpl structure to implement PayloadsBuffer interface
 // store inner state of available payloads and sequence numbers
 type PayloadsBufferImpl struct {
@@ -92,8 +100,7 @@ func (b *PayloadsBufferImpl) Push(payload *proto.Payload) error {
 	seqNum := payload.SeqNum
 
 	if seqNum < b.next || b.buf[seqNum] != nil {
-		return fmt.Errorf("Payload with sequence number = %s has been already processed",
-			strconv.FormatUint(payload.SeqNum, 10))
+		return duplicatePayloadError(seqNum)
 	}
 
 	b.buf[seqNum] = payload
@@ -81,6 +81,11 @@ func TestPayloadsBufferImpl_Push(t *testing.T) {
 	assert.Equal(t, buffer.Next(), uint64(5))
 	t.Log("Check block buffer size")
 	assert.Equal(t, buffer.Size(), 1)
+
+	// Pushing the same payload again is reported as a duplicate
+	err = buffer.Push(payload)
+	assert.Equal(t, duplicatePayloadError(5), err)
+	assert.Equal(t, buffer.Size(), 1)
 }
 
 func TestPayloadsBufferImpl_Ready(t *testing.T) {
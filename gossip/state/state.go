@@ -58,6 +58,11 @@ const (
 	defAntiEntropyMaxRetries = 3
 
 	defMaxBlockDistance = 100
+
+	// Maximum number of state requests awaiting a response at any time
+	defAntiEntropyMaxInflight = 4
+	// Time during which a peer which sent invalid blocks isn't asked for blocks
+	defPeerPenaltyDuration = time.Minute
 )
 
 // GossipAdapter defines gossip/communication required interface for state provider
@@ -75,6 +80,10 @@ type GossipAdapter interface {
 	// publishes to other peers about its channel-related state
 	UpdateChannelMetadata(metadata []byte, chainID common2.ChainID)
 
+	// UpdateLedgerHeight updates the ledger height the peer
+	// publishes to other peers in the channel
+	UpdateLedgerHeight(height uint64, chainID common2.ChainID)
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common2.ChainID) []discovery.NetworkMember
@@ -114,6 +123,25 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	// Peers which sent invalid blocks, mapped to the time
+	// they may be asked for blocks again
+	penalized map[string]time.Time
+
+	penalizedLock sync.Mutex
+}
+
+// blockRange is a range of block sequence numbers [start...end]
+type blockRange struct {
+	start uint64
+	end   uint64
+}
+
+// inflightRequest is a state request awaiting a response
+type inflightRequest struct {
+	blockRange
+	peer   *comm.RemotePeer
+	sentAt time.Time
 }
 
 var logger *logging.Logger // package-level logger
@@ -199,21 +227,14 @@ func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer
 		stateTransferActive: 0,
 
 		once: sync.Once{},
-	}
-
-	nodeMetastate := NewNodeMetastate(height - 1)
-
-	logger.Infof("Updating node metadata information, "+
-		"current ledger sequence is at = %d, next expected block is = %d", nodeMetastate.LedgerHeight, s.payloads.Next())
 
-	b, err := nodeMetastate.Bytes()
-	if err == nil {
-		logger.Debug("Updating gossip metadate nodeMetastate", nodeMetastate)
-		g.UpdateChannelMetadata(b, common2.ChainID(s.chainID))
-	} else {
-		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %s", err)
+		penalized: make(map[string]time.Time),
 	}
 
+	logger.Infof("Updating node properties, "+
+		"current ledger sequence is at = %d, next expected block is = %d", height-1, s.payloads.Next())
+	s.updateLedgerHeight(height)
+
 	s.done.Add(4)
 
 	// Listen for incoming communication
@@ -472,85 +493,176 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 			}
 			max := s.maxAvailableLedgerHeight()
 
-			if current-1 >= max {
+			if current >= max {
 				continue
 			}
 
-			s.requestBlocksInRange(uint64(current), uint64(max))
+			s.requestBlocksInRange(uint64(current), uint64(max-1))
 		}
 	}
 }
 
-// Iterate over all available peers and check advertised meta state to
+// Iterate over all available peers and check advertised properties to
 // find maximum available ledger height across peers
 func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	max := uint64(0)
 	for _, p := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
-		if nodeMetastate, err := FromBytes(p.Metadata); err == nil {
-			if max < nodeMetastate.LedgerHeight {
-				max = nodeMetastate.LedgerHeight
-			}
+		if height, ok := ledgerHeightOf(p); ok && max < height {
+			max = height
 		}
 	}
 	return max
 }
 
-// GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
+// updateLedgerHeight publishes the ledger height in the node properties, and
+// in the node meta state read by the peers that do not read the properties yet
+func (s *GossipStateProviderImpl) updateLedgerHeight(height uint64) {
+	nodeMetastate := NewNodeMetastate(height - 1)
+	b, err := nodeMetastate.Bytes()
+	if err == nil {
+		logger.Debug("Updating gossip metadate nodeMetastate", nodeMetastate)
+		s.gossip.UpdateChannelMetadata(b, common2.ChainID(s.chainID))
+	} else {
+		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %s", err)
+	}
+	s.gossip.UpdateLedgerHeight(height, common2.ChainID(s.chainID))
+}
+
+// ledgerHeightOf returns the ledger height advertised by the given peer,
+// falling back to the meta state published by peers that do not advertise
+// their properties yet
+func ledgerHeightOf(peer discovery.NetworkMember) (uint64, bool) {
+	if peer.Properties != nil {
+		return peer.Properties.LedgerHeight, true
+	}
+	nodeMetastate, err := FromBytes(peer.Metadata)
+	if err != nil {
+		logger.Debugf("Unable to de-serialize node meta state of %s, error = %s", peer.Endpoint, err)
+		return 0, false
+	}
+	// The meta state holds the sequence of the last block
+	return nodeMetastate.LedgerHeight + 1, true
+}
+
+// requestBlocksInRange acquires blocks with sequence numbers in the range [start...end].
+// The range is split into batches which are requested in parallel from the peers
+// advertising them, with at most defAntiEntropyMaxInflight requests awaiting a response.
+// Received blocks are verified and pushed into the payloads buffer, which delivers them
+// to the committer in order.
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
-	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
-
-		responseReceived := false
-		tryCounts := 0
+	var pending []blockRange
+	for prev := start; prev <= end; prev += defAntiEntropyBatchSize + 1 {
+		pending = append(pending, blockRange{start: prev, end: min(end, prev+defAntiEntropyBatchSize)})
+	}
+	inflight := make(map[uint64]*inflightRequest)
+	tryCounts := make(map[uint64]int)
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
+	for len(pending) > 0 || len(inflight) > 0 {
+		for len(pending) > 0 && len(inflight) < defAntiEntropyMaxInflight {
+			r := pending[0]
+			if tryCounts[r.start] > defAntiEntropyMaxRetries {
 				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
-				return
+					r.start, r.end, tryCounts[r.start])
+				pending = nil
+				break
 			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
+			// Select peer to ask for blocks
+			peer, err := s.selectPeerToRequestFrom(r.end, inflight)
 			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to",
-					prev, next, err)
-				return
+				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %s",
+					r.start, r.end, err)
+				pending = nil
+				break
 			}
+			pending = pending[1:]
 
 			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
+				"for chainID %s", peer.Endpoint, r.start, r.end, s.chainID)
 
+			gossipMsg := s.stateRequestMessage(r.start, r.end)
 			s.gossip.Send(gossipMsg, peer)
-			tryCounts++
+			tryCounts[r.start]++
+			inflight[gossipMsg.Nonce] = &inflightRequest{blockRange: r, peer: peer, sentAt: time.Now()}
+		}
 
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
+		if len(inflight) == 0 {
+			return
+		}
+
+		// Wait until the earliest timeout or response arrival
+		select {
+		case msg := <-s.stateResponseCh:
+			req, exists := inflight[msg.GetGossipMessage().Nonce]
+			if !exists {
+				continue
+			}
+			if !bytes.Equal(msg.GetConnectionInfo().ID, req.peer.PKIID) {
+				logger.Warningf("Got state response for blocks [%d...%d] from %s, while expecting it from %s",
+					req.start, req.end, msg.GetConnectionInfo().Endpoint, req.peer.Endpoint)
+				continue
+			}
+			delete(inflight, msg.GetGossipMessage().Nonce)
+
+			index, err := s.handleStateResponse(msg)
+			if err != nil {
+				logger.Warningf("Wasn't able to process state response for "+
+					"blocks [%d...%d] from %s, due to %s", req.start, req.end, req.peer.Endpoint, err)
+				if len(msg.GetGossipMessage().GetStateResponse().GetPayloads()) > 0 {
+					// The peer sent blocks which failed verification
+					s.penalize(req.peer)
 				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %s", prev, next, err)
-					continue
+				pending = requeue(pending, req.blockRange)
+				continue
+			}
+			if index < req.start {
+				pending = requeue(pending, req.blockRange)
+			} else if index < req.end {
+				// Ask for the remaining blocks of the batch
+				pending = requeue(pending, blockRange{start: index + 1, end: req.end})
+			}
+		case <-time.After(earliestTimeout(inflight)):
+			for nonce, req := range inflight {
+				if time.Since(req.sentAt) >= defAntiEntropyStateResponseTimeout {
+					logger.Debugf("State request for blocks [%d...%d] to %s timed out", req.start, req.end, req.peer.Endpoint)
+					delete(inflight, nonce)
+					pending = requeue(pending, req.blockRange)
 				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
 			}
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			return
+		}
+	}
+}
+
+// requeue inserts the given range into the pending ranges, keeping them
+// ordered by sequence number so lower blocks are requested first
+func requeue(pending []blockRange, r blockRange) []blockRange {
+	i := 0
+	for i < len(pending) && pending[i].start < r.start {
+		i++
+	}
+	pending = append(pending, blockRange{})
+	copy(pending[i+1:], pending[i:])
+	pending[i] = r
+	return pending
+}
+
+// earliestTimeout returns the time left until the first of the given requests times out
+func earliestTimeout(inflight map[uint64]*inflightRequest) time.Duration {
+	timeout := defAntiEntropyStateResponseTimeout
+	for _, req := range inflight {
+		if left := defAntiEntropyStateResponseTimeout - time.Since(req.sentAt); left < timeout {
+			timeout = left
 		}
 	}
+	if timeout < 0 {
+		return 0
+	}
+	return timeout
 }
 
 // Generate state request message for given blocks in range [beginSeq...endSeq]
@@ -568,44 +680,74 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
+// Select peer which has required blocks to ask missing blocks from. Requests are
+// spread among all the peers advertising the blocks by preferring the ones with the
+// fewest requests in flight, and among those the most up-to-date ones. Peers which
+// recently sent invalid blocks are skipped
+func (s *GossipStateProviderImpl) selectPeerToRequestFrom(seqNum uint64, inflight map[uint64]*inflightRequest) (*comm.RemotePeer, error) {
+	load := make(map[string]int)
+	for _, req := range inflight {
+		load[string(req.peer.PKIID)]++
+	}
+
 	// Filter peers which posses required range of missing blocks
-	peers := s.filterPeers(s.hasRequiredHeight(height))
+	var candidates []*comm.RemotePeer
+	minLoad, maxHeight := -1, uint64(0)
+	hasRequiredHeight := s.hasRequiredHeight(seqNum + 1)
+	for _, member := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
+		if !hasRequiredHeight(member) || s.isPenalized(member.PKIid) {
+			continue
+		}
+		l := load[string(member.PKIid)]
+		height, _ := ledgerHeightOf(member)
+		if minLoad != -1 && (l > minLoad || (l == minLoad && height < maxHeight)) {
+			continue
+		}
+		if l != minLoad || height != maxHeight {
+			minLoad, maxHeight = l, height
+			candidates = nil
+		}
+		candidates = append(candidates, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
+	}
 
-	n := len(peers)
+	n := len(candidates)
 	if n == 0 {
 		return nil, errors.New("there are no peers to ask for missing blocks from")
 	}
 
 	// Select peers to ask for blocks
-	return peers[util.RandomInt(n)], nil
+	return candidates[util.RandomInt(n)], nil
 }
 
-// filterPeers return list of peers which aligns the predicate provided
-func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
-	var peers []*comm.RemotePeer
+// penalize prevents asking the given peer for blocks for defPeerPenaltyDuration
+func (s *GossipStateProviderImpl) penalize(peer *comm.RemotePeer) {
+	s.penalizedLock.Lock()
+	defer s.penalizedLock.Unlock()
+	logger.Warningf("Not asking %s for blocks for the next %s", peer.Endpoint, defPeerPenaltyDuration)
+	s.penalized[string(peer.PKIID)] = time.Now().Add(defPeerPenaltyDuration)
+}
 
-	for _, member := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
-		if predicate(member) {
-			peers = append(peers, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
-		}
+// isPenalized returns whether the given peer recently sent invalid blocks
+func (s *GossipStateProviderImpl) isPenalized(pkiID common2.PKIidType) bool {
+	s.penalizedLock.Lock()
+	defer s.penalizedLock.Unlock()
+	until, exists := s.penalized[string(pkiID)]
+	if !exists {
+		return false
 	}
-
-	return peers
+	if time.Now().After(until) {
+		delete(s.penalized, string(pkiID))
+		return false
+	}
+	return true
 }
 
-// hasRequiredHeight returns predicate which is capable to filter peers with ledger height above than indicated
-// by provided input parameter
+// hasRequiredHeight returns predicate which is capable to filter peers with ledger height
+// at least as high as indicated by provided input parameter
 func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
 	return func(peer discovery.NetworkMember) bool {
-		if nodeMetadata, err := FromBytes(peer.Metadata); err != nil {
-			logger.Errorf("Unable to de-serialize node meta state, error = %s", err)
-		} else if nodeMetadata.LedgerHeight >= height {
-			return true
-		}
-
-		return false
+		peerHeight, ok := ledgerHeightOf(peer)
+		return ok && peerHeight >= height
 	}
 }
 
@@ -631,11 +773,23 @@ func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 		return fmt.Errorf("Failed obtaining ledger height: %v", err)
 	}
 
+	// When there are several leaders in the organization, the same block
+	// is received from each of them, so duplicates are silently dropped
+	if payload.SeqNum < height {
+		logger.Debug("Block", payload.SeqNum, "was already committed, ignoring it")
+		return nil
+	}
+
 	if payload.SeqNum-height >= defMaxBlockDistance {
 		return fmt.Errorf("Ledger height is at %d, cannot enqueue block with sequence of %d", height, payload.SeqNum)
 	}
 
-	return s.payloads.Push(payload)
+	err = s.payloads.Push(payload)
+	if _, isDuplicate := err.(duplicatePayloadError); isDuplicate {
+		logger.Debug("Block", payload.SeqNum, "was already received, ignoring it")
+		return nil
+	}
+	return err
 }
 
 func (s *GossipStateProviderImpl) commitBlock(block *common.Block) error {
@@ -644,16 +798,8 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block) error {
 		return err
 	}
 
-	// Update ledger level within node metadata
-	nodeMetastate := NewNodeMetastate(block.Header.Number)
-	// Decode nodeMetastate to byte array
-	b, err := nodeMetastate.Bytes()
-	if err == nil {
-		s.gossip.UpdateChannelMetadata(b, common2.ChainID(s.chainID))
-	} else {
-
-		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %s", err)
-	}
+	// Update ledger height within node properties
+	s.updateLedgerHeight(block.Header.Number + 1)
 
 	logger.Debugf("Channel [%s]: Created block [%d] with %d transaction(s)",
 		s.chainID, block.Header.Number, len(block.Data.Data))
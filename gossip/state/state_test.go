@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/state/mocks"
@@ -141,6 +142,10 @@ func (*cryptoServiceMock) ValidateIdentity(peerIdentity api.PeerIdentityType) er
 	return nil
 }
 
+func (*cryptoServiceMock) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 func bootPeers(ids ...int) []string {
 	peers := []string{}
 	for _, id := range ids {
@@ -319,6 +324,29 @@ func TestAddPayloadLedgerUnavailable(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot query ledger")
 }
 
+func TestAddPayloadDuplicates(t *testing.T) {
+	// Scenario: The same blocks are added several times, as happens
+	// when there are several leaders in the organization.
+	// Duplicates should be silently dropped.
+	mc := &mockCommitter{}
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(5), nil)
+	g := &mocks.GossipMock{}
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, make(<-chan proto.ReceivedMessage))
+	p := newPeerNodeWithGossip(newGossipConfig(0), mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+
+	for _, seq := range []uint64{3, 7, 7, 3} {
+		rawblock := pcomm.NewBlock(seq, []byte{})
+		b, _ := pb.Marshal(rawblock)
+		assert.NoError(t, p.s.AddPayload(&proto.Payload{
+			SeqNum: seq,
+			Data:   b,
+		}))
+	}
+	assert.Equal(t, 1, p.s.(*GossipStateProviderImpl).payloads.Size())
+}
+
 func TestOverPopulation(t *testing.T) {
 	// Scenario: Add to the state provider blocks
 	// with a gap in between, and ensure that the payload buffer
@@ -876,6 +904,345 @@ func TestNewGossipStateProvider_BatchingOfStateRequest(t *testing.T) {
 	}
 }
 
+type peersOfChannelMock struct {
+	*mocks.GossipMock
+	members []discovery.NetworkMember
+}
+
+func (m *peersOfChannelMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	return m.members
+}
+
+func TestSelectPeerPrefersMostUpToDate(t *testing.T) {
+	legacyMetastate, _ := NewNodeMetastate(19).Bytes()
+	g := &peersOfChannelMock{GossipMock: &mocks.GossipMock{}, members: []discovery.NetworkMember{
+		{Endpoint: "p0", PKIid: common.PKIidType("p0"), Properties: &proto.Properties{LedgerHeight: 5}},
+		{Endpoint: "p1", PKIid: common.PKIidType("p1"), Properties: &proto.Properties{LedgerHeight: 20}},
+		{Endpoint: "p2", PKIid: common.PKIidType("p2"), Metadata: legacyMetastate},
+		{Endpoint: "p3", PKIid: common.PKIidType("p3"), Metadata: []byte("garbage")},
+	}}
+	s := &GossipStateProviderImpl{chainID: "A", gossip: g, penalized: make(map[string]time.Time)}
+
+	assert.Equal(t, uint64(20), s.maxAvailableLedgerHeight())
+
+	for i := 0; i < 10; i++ {
+		peer, err := s.selectPeerToRequestFrom(3, nil)
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"p1", "p2"}, peer.Endpoint, "Peers advertising the highest height are preferred")
+	}
+
+	_, err := s.selectPeerToRequestFrom(20, nil)
+	assert.Error(t, err, "No peer has block 20")
+}
+
+func TestSelectPeerToRequestFrom(t *testing.T) {
+	legacyMetastate, _ := NewNodeMetastate(19).Bytes()
+	g := &peersOfChannelMock{GossipMock: &mocks.GossipMock{}, members: []discovery.NetworkMember{
+		{Endpoint: "p0", PKIid: common.PKIidType("p0"), Properties: &proto.Properties{LedgerHeight: 5}},
+		{Endpoint: "p1", PKIid: common.PKIidType("p1"), Properties: &proto.Properties{LedgerHeight: 20}},
+		{Endpoint: "p2", PKIid: common.PKIidType("p2"), Metadata: legacyMetastate},
+		{Endpoint: "p3", PKIid: common.PKIidType("p3"), Metadata: []byte("garbage")},
+	}}
+	s := &GossipStateProviderImpl{chainID: "A", gossip: g, penalized: make(map[string]time.Time)}
+
+	assert.Equal(t, uint64(20), s.maxAvailableLedgerHeight())
+
+	for i := 0; i < 10; i++ {
+		peer, err := s.selectPeerToRequestFrom(10, nil)
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"p1", "p2"}, peer.Endpoint, "Only peers advertising block 10 may be asked for it")
+	}
+
+	// Among the most up-to-date peers, the ones with the fewest requests
+	// in flight are preferred
+	inflight := map[uint64]*inflightRequest{
+		1: {peer: &comm.RemotePeer{Endpoint: "p1", PKIID: common.PKIidType("p1")}},
+	}
+	peer, err := s.selectPeerToRequestFrom(3, inflight)
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", peer.Endpoint)
+
+	// Penalized peers aren't asked for blocks. Less up-to-date peers having
+	// the blocks are asked rather than piling requests up on a single peer
+	s.penalize(&comm.RemotePeer{Endpoint: "p2", PKIID: common.PKIidType("p2")})
+	assert.True(t, s.isPenalized(common.PKIidType("p2")))
+	for i := 0; i < 10; i++ {
+		peer, err := s.selectPeerToRequestFrom(3, inflight)
+		assert.NoError(t, err)
+		assert.Equal(t, "p0", peer.Endpoint)
+	}
+	peer, err = s.selectPeerToRequestFrom(3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", peer.Endpoint)
+	s.penalize(&comm.RemotePeer{Endpoint: "p1", PKIID: common.PKIidType("p1")})
+	peer, err = s.selectPeerToRequestFrom(3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "p0", peer.Endpoint)
+	delete(s.penalized, "p1")
+
+	// Penalties expire
+	s.penalized["p2"] = time.Now().Add(-time.Second)
+	assert.False(t, s.isPenalized(common.PKIidType("p2")))
+
+	_, err = s.selectPeerToRequestFrom(20, nil)
+	assert.Error(t, err, "No peer has block 20")
+}
+
+func TestSelectPeerSpreadsBatchesAmongHeights(t *testing.T) {
+	g := &peersOfChannelMock{GossipMock: &mocks.GossipMock{}, members: []discovery.NetworkMember{
+		{Endpoint: "p0", PKIid: common.PKIidType("p0"), Properties: &proto.Properties{LedgerHeight: 5}},
+		{Endpoint: "p1", PKIid: common.PKIidType("p1"), Properties: &proto.Properties{LedgerHeight: 10}},
+		{Endpoint: "p2", PKIid: common.PKIidType("p2"), Properties: &proto.Properties{LedgerHeight: 20}},
+	}}
+	s := &GossipStateProviderImpl{chainID: "A", gossip: g, penalized: make(map[string]time.Time)}
+
+	// Batches are assigned to the most up-to-date peers first, and then
+	// to every peer whose height covers them
+	inflight := make(map[uint64]*inflightRequest)
+	var selected []string
+	for i := uint64(0); i < 3; i++ {
+		peer, err := s.selectPeerToRequestFrom(3, inflight)
+		assert.NoError(t, err)
+		selected = append(selected, peer.Endpoint)
+		inflight[i] = &inflightRequest{peer: peer}
+	}
+	assert.Equal(t, []string{"p2", "p1", "p0"}, selected)
+
+	// Batches beyond the height of some peers are only assigned to the others
+	for i := 0; i < 10; i++ {
+		peer, err := s.selectPeerToRequestFrom(8, inflight)
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"p1", "p2"}, peer.Endpoint)
+	}
+}
+
+type selfStateMock struct {
+	*mocks.GossipMock
+	sync.Mutex
+	metadata []byte
+	height   uint64
+}
+
+func (m *selfStateMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
+	m.Lock()
+	defer m.Unlock()
+	m.metadata = metadata
+}
+
+func (m *selfStateMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
+	m.Lock()
+	defer m.Unlock()
+	m.height = height
+}
+
+func (m *selfStateMock) self() discovery.NetworkMember {
+	m.Lock()
+	defer m.Unlock()
+	return discovery.NetworkMember{Metadata: m.metadata, Properties: &proto.Properties{LedgerHeight: m.height}}
+}
+
+func TestLedgerHeightPublishedToOlderPeers(t *testing.T) {
+	// Scenario: during a rolling upgrade, peers which only read the node meta
+	// state of the StateInfo metadata must still see the ledger height of
+	// the upgraded peers, which also advertise it in the properties
+	mc := &mockCommitter{}
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(5), nil)
+	mc.On("Commit", mock.Anything)
+	g := &selfStateMock{GossipMock: &mocks.GossipMock{}}
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, make(<-chan proto.ReceivedMessage))
+	p := newPeerNodeWithGossip(newGossipConfig(0), mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+
+	assertPublished := func(height uint64) {
+		self := g.self()
+		assert.Equal(t, height, self.Properties.LedgerHeight)
+		// older peers read the sequence of the last block from the meta state
+		nodeMetastate, err := FromBytes(self.Metadata)
+		assert.NoError(t, err)
+		assert.Equal(t, height-1, nodeMetastate.LedgerHeight)
+		// and upgraded peers read the same height from either
+		legacy := discovery.NetworkMember{Metadata: self.Metadata}
+		legacyHeight, ok := ledgerHeightOf(legacy)
+		assert.True(t, ok)
+		assert.Equal(t, height, legacyHeight)
+	}
+	assertPublished(5)
+
+	assert.NoError(t, p.s.(*GossipStateProviderImpl).commitBlock(pcomm.NewBlock(5, []byte{})))
+	assertPublished(6)
+}
+
+type stateResponseMsg struct {
+	msg      *proto.SignedGossipMessage
+	connInfo *proto.ConnectionInfo
+}
+
+func (m *stateResponseMsg) Respond(msg *proto.GossipMessage) {
+	panic("implement me")
+}
+
+func (m *stateResponseMsg) GetGossipMessage() *proto.SignedGossipMessage {
+	return m.msg
+}
+
+func (m *stateResponseMsg) GetSourceEnvelope() *proto.Envelope {
+	return m.msg.Envelope
+}
+
+func (m *stateResponseMsg) GetConnectionInfo() *proto.ConnectionInfo {
+	return m.connInfo
+}
+
+// stateTransferMock answers state requests on behalf of the peers of the channel,
+// the peer "bad" answering with blocks which fail verification
+type stateTransferMock struct {
+	*peersOfChannelMock
+	sync.Mutex
+	s        *GossipStateProviderImpl
+	requests map[string]int
+}
+
+func (m *stateTransferMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
+	m.Lock()
+	defer m.Unlock()
+	request := msg.GetStateRequest()
+	for _, peer := range peers {
+		m.requests[peer.Endpoint]++
+		response := &proto.RemoteStateResponse{}
+		for seqNum := request.StartSeqNum; seqNum <= request.EndSeqNum; seqNum++ {
+			data := []byte("block")
+			if peer.Endpoint == "bad" {
+				data = []byte("invalid")
+			}
+			response.Payloads = append(response.Payloads, &proto.Payload{SeqNum: seqNum, Data: data})
+		}
+		sMsg, _ := (&proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Tag:     proto.GossipMessage_CHAN_OR_ORG,
+			Channel: msg.Channel,
+			Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+		}).NoopSign()
+		m.s.stateResponseCh <- &stateResponseMsg{
+			msg:      sMsg,
+			connInfo: &proto.ConnectionInfo{ID: peer.PKIID, Endpoint: peer.Endpoint},
+		}
+	}
+}
+
+type blockVerifierMock struct {
+	cryptoServiceMock
+}
+
+func (*blockVerifierMock) VerifyBlock(chainID common.ChainID, seqNum uint64, signedBlock []byte) error {
+	if bytes.Equal(signedBlock, []byte("invalid")) {
+		return errors.New("invalid block")
+	}
+	return nil
+}
+
+func TestRequestBlocksInRangeFromManyPeers(t *testing.T) {
+	var members []discovery.NetworkMember
+	for _, endpoint := range []string{"bad", "p1", "p2", "p3"} {
+		members = append(members, discovery.NetworkMember{
+			Endpoint:   endpoint,
+			PKIid:      common.PKIidType(endpoint),
+			Properties: &proto.Properties{LedgerHeight: 101},
+		})
+	}
+	g := &stateTransferMock{
+		peersOfChannelMock: &peersOfChannelMock{GossipMock: &mocks.GossipMock{}, members: members},
+		requests:           make(map[string]int),
+	}
+	s := &GossipStateProviderImpl{
+		chainID:         "A",
+		gossip:          g,
+		mcs:             &blockVerifierMock{},
+		payloads:        NewPayloadsBuffer(1),
+		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		stopCh:          make(chan struct{}, 1),
+		penalized:       make(map[string]time.Time),
+	}
+	g.s = s
+
+	s.requestBlocksInRange(1, 100)
+
+	assert.Equal(t, 100, s.payloads.Size(), "All blocks should have been received")
+	assert.True(t, s.isPenalized(common.PKIidType("bad")), "The peer which sent invalid blocks should be penalized")
+	assert.Equal(t, 1, g.requests["bad"], "The penalized peer shouldn't be asked for blocks again")
+	for _, endpoint := range []string{"p1", "p2", "p3"} {
+		assert.True(t, g.requests[endpoint] > 1, "Blocks should have been requested from %s", endpoint)
+	}
+}
+
+// Start several peers having the same blocks and a new peer lagging
+// behind them, and make sure it catches up by requesting blocks from
+// several of them
+func TestNewGossipStateProvider_CatchUpFromManyPeers(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/tests/ledger/node")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	sourcesSize := 4
+	msgCount := 4 * (defAntiEntropyBatchSize + 1)
+
+	var sources []*peerNode
+	var bootIds []int
+	for i := 0; i < sourcesSize; i++ {
+		source := newPeerNode(newGossipConfig(i, bootIds...), newCommitter(i), noopPeerIdentityAcceptor)
+		defer source.shutdown()
+		sources = append(sources, source)
+		bootIds = append(bootIds, i)
+	}
+
+	for i := 1; i <= msgCount; i++ {
+		rawblock := pcomm.NewBlock(uint64(i), []byte{})
+		b, err := pb.Marshal(rawblock)
+		assert.NoError(t, err)
+		for _, source := range sources {
+			source.s.AddPayload(&proto.Payload{SeqNum: uint64(i), Data: b})
+		}
+	}
+
+	waitUntilTrueOrTimeout(t, func() bool {
+		for _, source := range sources {
+			if height, err := source.commit.LedgerHeight(); err != nil || height != uint64(msgCount+1) {
+				return false
+			}
+		}
+		return true
+	}, 30*time.Second)
+
+	// Count the state requests every source answers
+	var lock sync.Mutex
+	requestsBySource := make(map[int]int)
+	stateRequestPredicate := func(message interface{}) bool {
+		return message.(proto.ReceivedMessage).GetGossipMessage().GetStateRequest() != nil
+	}
+	for i, source := range sources {
+		_, requestCh := source.g.Accept(stateRequestPredicate, true)
+		go func(i int, requestCh <-chan proto.ReceivedMessage) {
+			for range requestCh {
+				lock.Lock()
+				requestsBySource[i]++
+				lock.Unlock()
+			}
+		}(i, requestCh)
+	}
+
+	peer := newPeerNode(newGossipConfig(sourcesSize, bootIds...), newCommitter(sourcesSize), noopPeerIdentityAcceptor)
+	defer peer.shutdown()
+
+	waitUntilTrueOrTimeout(t, func() bool {
+		height, err := peer.commit.LedgerHeight()
+		return err == nil && height == uint64(msgCount+1)
+	}, 2*defAntiEntropyInterval+30*time.Second)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.True(t, len(requestsBySource) > 1, "Blocks should have been requested from several peers, got %v", requestsBySource)
+}
+
 func waitUntilTrueOrTimeout(t *testing.T, predicate func() bool, timeout time.Duration) {
 	ch := make(chan struct{})
 	go func() {
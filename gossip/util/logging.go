@@ -30,6 +30,7 @@ const (
 	LoggingDiscoveryModule = "gossip/discovery"
 	LoggingElectionModule  = "gossip/election"
 	LoggingGossipModule    = "gossip/gossip"
+	LoggingIdentityModule  = "gossip/identity"
 	LoggingMockModule      = "gossip/comm/mock"
 	LoggingPullModule      = "gossip/pull"
 	LoggingServiceModule   = "gossip/service"
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// Identity Mixer Credential is a list of attributes certified (signed) by the issuer
+// A credential also contains a user secret key blindly signed by the issuer
+// Without the secret key the credential cannot be used
+
+// NewCredential issues a new credential, which is the last step of the interactive issuance protocol
+// All attribute values are added by the issuer at this step and then signed together with a commitment to
+// the user's secret key from a credential request
+func NewCredential(key *IssuerKey, m *CredRequest, attrs []*amcl.BIG, rng *amcl.RAND) (*Credential, error) {
+	// check the credential request that contains
+	err := m.Check(key.Ipk)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attrs) != len(key.Ipk.AttributeNames) {
+		return nil, fmt.Errorf("incorrect number of attribute values passed, expected %d, got %d", len(key.Ipk.AttributeNames), len(attrs))
+	}
+
+	d := &decoder{}
+	ISk := d.big(key.Isk)
+	Nym := d.ecp(m.Nym)
+	HRand := d.ecp(key.Ipk.HRand)
+	HAttrs := make([]*amcl.ECP, len(key.Ipk.HAttrs))
+	for i, h := range key.Ipk.HAttrs {
+		HAttrs[i] = d.ecp(h)
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid issuer key: %s", d.err)
+	}
+
+	// Place a BBS+ signature on the user key and the attribute values
+	// (For BBS+, see e.g. "Constant-Size Dynamic k-TAA" by Man Ho Au, Willy Susilo, Yi Mu)
+	E := RandModOrder(rng)
+	S := RandModOrder(rng)
+
+	// B = g1 * HRand^S * Nym * HAttrs[i]^attrs[i]
+	B := GenG1()
+	B.Add(Nym)
+	B.Add(g1Mul(HRand, S))
+	for i := range attrs {
+		B.Add(g1Mul(HAttrs[i], attrs[i]))
+	}
+
+	// A = B^(1/(E+ISk))
+	Exp := modAdd(ISk, E)
+	Exp.Invmodp(GroupOrder())
+	A := g1Mul(B, Exp)
+
+	CredAttrs := make([][]byte, len(attrs))
+	for i, attr := range attrs {
+		CredAttrs[i] = BigToBytes(attr)
+	}
+
+	return &Credential{
+		A:     EcpToBytes(A),
+		B:     EcpToBytes(B),
+		E:     BigToBytes(E),
+		S:     BigToBytes(S),
+		Attrs: CredAttrs}, nil
+}
+
+// Ver cryptographically verifies the credential by verifying the signature
+// on the attribute values and user's secret key
+func (cred *Credential) Ver(sk *amcl.BIG, ipk *IssuerPublicKey) error {
+	if len(cred.Attrs) != len(ipk.AttributeNames) || len(ipk.HAttrs) != len(ipk.AttributeNames) {
+		return errors.New("credential has incorrect number of attributes")
+	}
+
+	d := &decoder{}
+	A := d.ecp(cred.A)
+	B := d.ecp(cred.B)
+	E := d.big(cred.E)
+	S := d.big(cred.S)
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	W := d.ecp2(ipk.W)
+	attrs := make([]*amcl.BIG, len(cred.Attrs))
+	for i := range cred.Attrs {
+		attrs[i] = d.big(cred.Attrs[i])
+	}
+	HAttrs := make([]*amcl.ECP, len(ipk.HAttrs))
+	for i, h := range ipk.HAttrs {
+		HAttrs[i] = d.ecp(h)
+	}
+	if d.err != nil {
+		return fmt.Errorf("invalid credential: %s", d.err)
+	}
+
+	// Verify that B is made of the user secret, the randomness and the attribute values
+	BPrime := GenG1()
+	BPrime.Add(g1Mul(HSk, sk))
+	BPrime.Add(g1Mul(HRand, S))
+	for i := range attrs {
+		BPrime.Add(g1Mul(HAttrs[i], attrs[i]))
+	}
+	if !B.Equals(BPrime) {
+		return errors.New("b-value from credential does not match the attribute values")
+	}
+
+	// Verify BBS+ signature, namely that e(A, W * g2^E) = e(B, g2)
+	a := g2Mul(GenG2(), E)
+	a.Add(W)
+	if !pairingsEqual(a, A, GenG2(), B) {
+		return errors.New("credential is not cryptographically valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// credRequestLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a credential request
+const credRequestLabel = "credRequest"
+
+// Credential issuance is an interactive protocol between a user and an issuer
+// The issuer takes its secret and public keys and user attribute values as input
+// The user takes the issuer public key and user secret as input
+// The issuance protocol consists of the following steps:
+// 1) The issuer sends a random nonce to the user
+// 2) The user creates a Credential Request using the public key of the issuer, user secret, and the nonce as input
+//    The request consists of a commitment to the user secret (can be seen as a public key) and a zero-knowledge proof
+//     of knowledge of the user secret key
+//    The user sends the credential request to the issuer
+// 3) The issuer verifies the credential request by verifying the zero-knowledge proof
+//    If the request is valid, the issuer issues a credential to the user by signing the commitment to the secret key
+//    together with the attribute values and sends the credential back to the user
+// 4) The user verifies the issuer's signature and stores the credential that consists of
+//    the signature value, a randomness used to create the signature, the user secret, and the attribute values
+
+// NewCredRequest creates a new Credential Request, the first message of the interactive credential issuance protocol
+// (from user to issuer)
+func NewCredRequest(sk *amcl.BIG, IssuerNonce []byte, ipk *IssuerPublicKey, rng *amcl.RAND) (*CredRequest, error) {
+	HSk, err := EcpFromBytes(ipk.HSk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer public key: %s", err)
+	}
+
+	// Nym = HSk^sk is the commitment to the user secret
+	Nym := g1Mul(HSk, sk)
+
+	// prove knowledge of sk such that Nym = HSk^sk
+	r := RandModOrder(rng)
+	t := g1Mul(HSk, r)
+	ProofC := challenge([]byte(credRequestLabel), EcpToBytes(t), EcpToBytes(HSk), EcpToBytes(Nym), IssuerNonce, ipk.Hash)
+	ProofS := modAdd(r, modMul(ProofC, sk))
+
+	return &CredRequest{
+		Nym:         EcpToBytes(Nym),
+		IssuerNonce: IssuerNonce,
+		ProofC:      BigToBytes(ProofC),
+		ProofS:      BigToBytes(ProofS),
+	}, nil
+}
+
+// Check cryptographically verifies the credential request
+func (m *CredRequest) Check(ipk *IssuerPublicKey) error {
+	d := &decoder{}
+	Nym := d.ecp(m.Nym)
+	ProofC := d.big(m.ProofC)
+	ProofS := d.big(m.ProofS)
+	HSk := d.ecp(ipk.HSk)
+	if d.err != nil {
+		return fmt.Errorf("invalid credential request: %s", d.err)
+	}
+
+	// t = HSk^s * Nym^(-c)
+	t := g1Mul(HSk, ProofS)
+	t.Sub(g1Mul(Nym, ProofC))
+	c := challenge([]byte(credRequestLabel), EcpToBytes(t), EcpToBytes(HSk), EcpToBytes(Nym), m.IssuerNonce, ipk.Hash)
+	if !c.Equals(ProofC) {
+		return errors.New("zero knowledge proof of the credential request is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: idemix/idemix.proto
+
+/*
+Package idemix is a generated protocol buffer package.
+
+It is generated from these files:
+
+	idemix/idemix.proto
+
+It has these top-level messages:
+
+	IssuerPublicKey
+	IssuerKey
+	Credential
+	CredRequest
+	Signature
+	NymSignature
+*/
+package idemix
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// IssuerPublicKey specifies an issuer public key that consists of
+// attribute_names - a list of the attribute names of a credential issued by the issuer
+// h_sk, h_rand, h_attrs, w, bar_g1, bar_g2 - group elements corresponding to the signing key, randomness, and attributes
+// proof_c, proof_s compose a zero-knowledge proof of knowledge of the secret key
+// hash is a hash of the public key appended to it
+type IssuerPublicKey struct {
+	AttributeNames []string `protobuf:"bytes,1,rep,name=attribute_names,json=attributeNames" json:"attribute_names,omitempty"`
+	HSk            []byte   `protobuf:"bytes,2,opt,name=h_sk,json=hSk,proto3" json:"h_sk,omitempty"`
+	HRand          []byte   `protobuf:"bytes,3,opt,name=h_rand,json=hRand,proto3" json:"h_rand,omitempty"`
+	HAttrs         [][]byte `protobuf:"bytes,4,rep,name=h_attrs,json=hAttrs,proto3" json:"h_attrs,omitempty"`
+	W              []byte   `protobuf:"bytes,5,opt,name=w,proto3" json:"w,omitempty"`
+	BarG1          []byte   `protobuf:"bytes,6,opt,name=bar_g1,json=barG1,proto3" json:"bar_g1,omitempty"`
+	BarG2          []byte   `protobuf:"bytes,7,opt,name=bar_g2,json=barG2,proto3" json:"bar_g2,omitempty"`
+	ProofC         []byte   `protobuf:"bytes,8,opt,name=proof_c,json=proofC,proto3" json:"proof_c,omitempty"`
+	ProofS         []byte   `protobuf:"bytes,9,opt,name=proof_s,json=proofS,proto3" json:"proof_s,omitempty"`
+	Hash           []byte   `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *IssuerPublicKey) Reset()                    { *m = IssuerPublicKey{} }
+func (m *IssuerPublicKey) String() string            { return proto.CompactTextString(m) }
+func (*IssuerPublicKey) ProtoMessage()               {}
+func (*IssuerPublicKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *IssuerPublicKey) GetAttributeNames() []string {
+	if m != nil {
+		return m.AttributeNames
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetHSk() []byte {
+	if m != nil {
+		return m.HSk
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetHRand() []byte {
+	if m != nil {
+		return m.HRand
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetHAttrs() [][]byte {
+	if m != nil {
+		return m.HAttrs
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetW() []byte {
+	if m != nil {
+		return m.W
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetBarG1() []byte {
+	if m != nil {
+		return m.BarG1
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetBarG2() []byte {
+	if m != nil {
+		return m.BarG2
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetProofC() []byte {
+	if m != nil {
+		return m.ProofC
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetProofS() []byte {
+	if m != nil {
+		return m.ProofS
+	}
+	return nil
+}
+
+func (m *IssuerPublicKey) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// IssuerKey specifies an issuer key pair that consists of
+// isk - the issuer secret key and
+// ipk - the issuer public key
+type IssuerKey struct {
+	Isk []byte           `protobuf:"bytes,1,opt,name=isk,proto3" json:"isk,omitempty"`
+	Ipk *IssuerPublicKey `protobuf:"bytes,2,opt,name=ipk" json:"ipk,omitempty"`
+}
+
+func (m *IssuerKey) Reset()                    { *m = IssuerKey{} }
+func (m *IssuerKey) String() string            { return proto.CompactTextString(m) }
+func (*IssuerKey) ProtoMessage()               {}
+func (*IssuerKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *IssuerKey) GetIsk() []byte {
+	if m != nil {
+		return m.Isk
+	}
+	return nil
+}
+
+func (m *IssuerKey) GetIpk() *IssuerPublicKey {
+	if m != nil {
+		return m.Ipk
+	}
+	return nil
+}
+
+// Credential specifies a credential object that consists of
+// a, b, e, s - signature value
+// attrs - attribute values
+type Credential struct {
+	A     []byte   `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B     []byte   `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	E     []byte   `protobuf:"bytes,3,opt,name=e,proto3" json:"e,omitempty"`
+	S     []byte   `protobuf:"bytes,4,opt,name=s,proto3" json:"s,omitempty"`
+	Attrs [][]byte `protobuf:"bytes,5,rep,name=attrs,proto3" json:"attrs,omitempty"`
+}
+
+func (m *Credential) Reset()                    { *m = Credential{} }
+func (m *Credential) String() string            { return proto.CompactTextString(m) }
+func (*Credential) ProtoMessage()               {}
+func (*Credential) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Credential) GetA() []byte {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *Credential) GetB() []byte {
+	if m != nil {
+		return m.B
+	}
+	return nil
+}
+
+func (m *Credential) GetE() []byte {
+	if m != nil {
+		return m.E
+	}
+	return nil
+}
+
+func (m *Credential) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func (m *Credential) GetAttrs() [][]byte {
+	if m != nil {
+		return m.Attrs
+	}
+	return nil
+}
+
+// CredRequest specifies a credential request object that consists of
+// nym - a pseudonym, which is a commitment to the user secret
+// issuer_nonce - a random nonce provided by the issuer
+// proof_c, proof_s - a zero-knowledge proof of knowledge of the
+// user secret inside nym
+type CredRequest struct {
+	Nym         []byte `protobuf:"bytes,1,opt,name=nym,proto3" json:"nym,omitempty"`
+	IssuerNonce []byte `protobuf:"bytes,2,opt,name=issuer_nonce,json=issuerNonce,proto3" json:"issuer_nonce,omitempty"`
+	ProofC      []byte `protobuf:"bytes,3,opt,name=proof_c,json=proofC,proto3" json:"proof_c,omitempty"`
+	ProofS      []byte `protobuf:"bytes,4,opt,name=proof_s,json=proofS,proto3" json:"proof_s,omitempty"`
+}
+
+func (m *CredRequest) Reset()                    { *m = CredRequest{} }
+func (m *CredRequest) String() string            { return proto.CompactTextString(m) }
+func (*CredRequest) ProtoMessage()               {}
+func (*CredRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *CredRequest) GetNym() []byte {
+	if m != nil {
+		return m.Nym
+	}
+	return nil
+}
+
+func (m *CredRequest) GetIssuerNonce() []byte {
+	if m != nil {
+		return m.IssuerNonce
+	}
+	return nil
+}
+
+func (m *CredRequest) GetProofC() []byte {
+	if m != nil {
+		return m.ProofC
+	}
+	return nil
+}
+
+func (m *CredRequest) GetProofS() []byte {
+	if m != nil {
+		return m.ProofS
+	}
+	return nil
+}
+
+// Signature specifies a signature object that consists of
+// a_prime, a_bar, b_prime, proof_* - randomized credential signature values
+// and a zero-knowledge proof of knowledge of a credential
+// and the corresponding user secret together with the attribute values
+// nonce - a fresh nonce used for the signature
+// nym - a fresh pseudonym (a commitment to the user secret)
+type Signature struct {
+	APrime       []byte   `protobuf:"bytes,1,opt,name=a_prime,json=aPrime,proto3" json:"a_prime,omitempty"`
+	ABar         []byte   `protobuf:"bytes,2,opt,name=a_bar,json=aBar,proto3" json:"a_bar,omitempty"`
+	BPrime       []byte   `protobuf:"bytes,3,opt,name=b_prime,json=bPrime,proto3" json:"b_prime,omitempty"`
+	ProofC       []byte   `protobuf:"bytes,4,opt,name=proof_c,json=proofC,proto3" json:"proof_c,omitempty"`
+	ProofSSk     []byte   `protobuf:"bytes,5,opt,name=proof_s_sk,json=proofSSk,proto3" json:"proof_s_sk,omitempty"`
+	ProofSE      []byte   `protobuf:"bytes,6,opt,name=proof_s_e,json=proofSE,proto3" json:"proof_s_e,omitempty"`
+	ProofSR2     []byte   `protobuf:"bytes,7,opt,name=proof_s_r2,json=proofSR2,proto3" json:"proof_s_r2,omitempty"`
+	ProofSR3     []byte   `protobuf:"bytes,8,opt,name=proof_s_r3,json=proofSR3,proto3" json:"proof_s_r3,omitempty"`
+	ProofSSPrime []byte   `protobuf:"bytes,9,opt,name=proof_s_s_prime,json=proofSSPrime,proto3" json:"proof_s_s_prime,omitempty"`
+	ProofSAttrs  [][]byte `protobuf:"bytes,10,rep,name=proof_s_attrs,json=proofSAttrs,proto3" json:"proof_s_attrs,omitempty"`
+	Nonce        []byte   `protobuf:"bytes,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Nym          []byte   `protobuf:"bytes,12,opt,name=nym,proto3" json:"nym,omitempty"`
+	ProofSRNym   []byte   `protobuf:"bytes,13,opt,name=proof_s_r_nym,json=proofSRNym,proto3" json:"proof_s_r_nym,omitempty"`
+}
+
+func (m *Signature) Reset()                    { *m = Signature{} }
+func (m *Signature) String() string            { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()               {}
+func (*Signature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Signature) GetAPrime() []byte {
+	if m != nil {
+		return m.APrime
+	}
+	return nil
+}
+
+func (m *Signature) GetABar() []byte {
+	if m != nil {
+		return m.ABar
+	}
+	return nil
+}
+
+func (m *Signature) GetBPrime() []byte {
+	if m != nil {
+		return m.BPrime
+	}
+	return nil
+}
+
+func (m *Signature) GetProofC() []byte {
+	if m != nil {
+		return m.ProofC
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSSk() []byte {
+	if m != nil {
+		return m.ProofSSk
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSE() []byte {
+	if m != nil {
+		return m.ProofSE
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSR2() []byte {
+	if m != nil {
+		return m.ProofSR2
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSR3() []byte {
+	if m != nil {
+		return m.ProofSR3
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSSPrime() []byte {
+	if m != nil {
+		return m.ProofSSPrime
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSAttrs() [][]byte {
+	if m != nil {
+		return m.ProofSAttrs
+	}
+	return nil
+}
+
+func (m *Signature) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *Signature) GetNym() []byte {
+	if m != nil {
+		return m.Nym
+	}
+	return nil
+}
+
+func (m *Signature) GetProofSRNym() []byte {
+	if m != nil {
+		return m.ProofSRNym
+	}
+	return nil
+}
+
+// NymSignature specifies a signature object that signs a message
+// with respect to a pseudonym. It differs from the standard idemix.signature in the fact that
+// the standard signature object also proves that the pseudonym is based on a secret certified by
+// a CA (issuer), whereas NymSignature only proves that the owner of the pseudonym
+// signed the message
+type NymSignature struct {
+	// proof_c is the Fiat-Shamir challenge of the ZKP
+	ProofC []byte `protobuf:"bytes,1,opt,name=proof_c,json=proofC,proto3" json:"proof_c,omitempty"`
+	// proof_s_sk is the s-value proving knowledge of the user secret key
+	ProofSSk []byte `protobuf:"bytes,2,opt,name=proof_s_sk,json=proofSSk,proto3" json:"proof_s_sk,omitempty"`
+	// proof_s_r_nym is the s-value proving knowledge of the pseudonym secret
+	ProofSRNym []byte `protobuf:"bytes,3,opt,name=proof_s_r_nym,json=proofSRNym,proto3" json:"proof_s_r_nym,omitempty"`
+	// nonce is a fresh nonce used for the signature
+	Nonce []byte `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (m *NymSignature) Reset()                    { *m = NymSignature{} }
+func (m *NymSignature) String() string            { return proto.CompactTextString(m) }
+func (*NymSignature) ProtoMessage()               {}
+func (*NymSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *NymSignature) GetProofC() []byte {
+	if m != nil {
+		return m.ProofC
+	}
+	return nil
+}
+
+func (m *NymSignature) GetProofSSk() []byte {
+	if m != nil {
+		return m.ProofSSk
+	}
+	return nil
+}
+
+func (m *NymSignature) GetProofSRNym() []byte {
+	if m != nil {
+		return m.ProofSRNym
+	}
+	return nil
+}
+
+func (m *NymSignature) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*IssuerPublicKey)(nil), "idemix.IssuerPublicKey")
+	proto.RegisterType((*IssuerKey)(nil), "idemix.IssuerKey")
+	proto.RegisterType((*Credential)(nil), "idemix.Credential")
+	proto.RegisterType((*CredRequest)(nil), "idemix.CredRequest")
+	proto.RegisterType((*Signature)(nil), "idemix.Signature")
+	proto.RegisterType((*NymSignature)(nil), "idemix.NymSignature")
+}
+
+func init() { proto.RegisterFile("idemix/idemix.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x54, 0xcb, 0x6e, 0xda, 0x40,
+	0x14, 0x95, 0xf1, 0x23, 0x70, 0x71, 0x4a, 0x3b, 0x49, 0x95, 0x51, 0xd5, 0x05, 0xb5, 0xfa, 0xa0,
+	0x1b, 0x50, 0xe0, 0x0b, 0x9a, 0xa8, 0x6a, 0xab, 0x4a, 0x28, 0x32, 0xbb, 0x6c, 0xac, 0x19, 0x98,
+	0xe0, 0x11, 0xd8, 0xa6, 0x33, 0xb6, 0x52, 0x6f, 0xfa, 0xdb, 0x5d, 0xb6, 0x9a, 0x87, 0xc1, 0x50,
+	0x35, 0x2b, 0x38, 0xe7, 0xbe, 0xce, 0x9c, 0x7b, 0x65, 0xb8, 0xe0, 0x2b, 0x96, 0xf1, 0x9f, 0x13,
+	0xf3, 0x33, 0xde, 0x89, 0xa2, 0x2c, 0x50, 0x60, 0x50, 0xf4, 0xc7, 0x81, 0xc1, 0x37, 0x29, 0x2b,
+	0x26, 0xee, 0x2a, 0xba, 0xe5, 0xcb, 0xef, 0xac, 0x46, 0x1f, 0x60, 0x40, 0xca, 0x52, 0x70, 0x5a,
+	0x95, 0x2c, 0xc9, 0x49, 0xc6, 0x24, 0x76, 0x86, 0xee, 0xa8, 0x17, 0x3f, 0xdb, 0xd3, 0x73, 0xc5,
+	0xa2, 0x17, 0xe0, 0xa5, 0x89, 0xdc, 0xe0, 0xce, 0xd0, 0x19, 0x85, 0xb1, 0x9b, 0x2e, 0x36, 0xe8,
+	0x25, 0x04, 0x69, 0x22, 0x48, 0xbe, 0xc2, 0xae, 0x26, 0xfd, 0x34, 0x26, 0xf9, 0x0a, 0x5d, 0xc1,
+	0x59, 0x9a, 0xa8, 0x6a, 0x89, 0xbd, 0xa1, 0x3b, 0x0a, 0xe3, 0x20, 0xfd, 0xa4, 0x10, 0x0a, 0xc1,
+	0x79, 0xc4, 0xbe, 0x4e, 0x75, 0x1e, 0x55, 0x35, 0x25, 0x22, 0x59, 0x5f, 0xe3, 0xc0, 0x54, 0x53,
+	0x22, 0xbe, 0x5c, 0xef, 0xe9, 0x29, 0x3e, 0x3b, 0xd0, 0x53, 0xd5, 0x74, 0x27, 0x8a, 0xe2, 0x21,
+	0x59, 0xe2, 0xae, 0xe6, 0x03, 0x0d, 0x6f, 0x0f, 0x01, 0x89, 0x7b, 0xad, 0xc0, 0x02, 0x21, 0xf0,
+	0x52, 0x22, 0x53, 0x0c, 0x9a, 0xd5, 0xff, 0xa3, 0xaf, 0xd0, 0x33, 0x06, 0xa8, 0xa7, 0x3f, 0x07,
+	0x97, 0xcb, 0x0d, 0x76, 0xcc, 0x83, 0xb8, 0xdc, 0xa0, 0x8f, 0xe0, 0xf2, 0x9d, 0x79, 0x62, 0x7f,
+	0x7a, 0x35, 0xb6, 0x26, 0x9e, 0x58, 0x16, 0xab, 0x9c, 0xe8, 0x1e, 0xe0, 0x56, 0xb0, 0x15, 0xcb,
+	0x4b, 0x4e, 0xb6, 0xea, 0x65, 0xc4, 0x36, 0x72, 0x88, 0x42, 0xd4, 0xfa, 0xe4, 0x50, 0x85, 0x98,
+	0x35, 0xc8, 0x61, 0x0a, 0x29, 0x5b, 0x34, 0x92, 0xe8, 0x12, 0x7c, 0x63, 0x94, 0xaf, 0x8d, 0x32,
+	0x20, 0x2a, 0xa1, 0xaf, 0x7a, 0xc7, 0xec, 0x47, 0xc5, 0x64, 0xa9, 0x74, 0xe6, 0x75, 0xd6, 0xe8,
+	0xcc, 0xeb, 0x0c, 0xbd, 0x81, 0x90, 0x6b, 0x51, 0x49, 0x5e, 0xe4, 0x4b, 0x66, 0x67, 0xf5, 0x0d,
+	0x37, 0x57, 0x54, 0xdb, 0x2f, 0xf7, 0x7f, 0x7e, 0x79, 0x6d, 0xbf, 0xa2, 0xdf, 0x1d, 0xe8, 0x2d,
+	0xf8, 0x3a, 0x27, 0x65, 0x25, 0x74, 0x3d, 0x49, 0x76, 0x82, 0x67, 0xcc, 0x0e, 0x0e, 0xc8, 0x9d,
+	0x42, 0xe8, 0x02, 0x7c, 0x92, 0x50, 0x22, 0xec, 0x50, 0x8f, 0xdc, 0x10, 0xa1, 0xb2, 0xa9, 0xcd,
+	0xb6, 0xd3, 0xa8, 0xc9, 0x6e, 0xc9, 0xf0, 0x8e, 0x64, 0xbc, 0x06, 0xb0, 0x32, 0xd4, 0x51, 0x99,
+	0xa3, 0xe8, 0x1a, 0x25, 0x8b, 0x0d, 0x7a, 0x05, 0xbd, 0x26, 0xca, 0xec, 0x79, 0x98, 0x3e, 0x8b,
+	0xcf, 0xed, 0x4a, 0xd1, 0x1c, 0x89, 0xad, 0x8c, 0xa7, 0x47, 0xd1, 0x19, 0xee, 0x1e, 0x45, 0x67,
+	0xe8, 0x1d, 0x0c, 0xf6, 0x53, 0xad, 0x5e, 0x73, 0x34, 0xa1, 0x1d, 0x6d, 0x54, 0x47, 0x70, 0xde,
+	0xa4, 0x99, 0xf5, 0x80, 0x5e, 0x4f, 0xdf, 0x24, 0x99, 0x63, 0xbe, 0x04, 0xdf, 0x98, 0xdf, 0x37,
+	0x67, 0xaa, 0x41, 0xb3, 0xab, 0xb0, 0xbd, 0xab, 0x7d, 0x2f, 0x91, 0xa8, 0xd8, 0xb9, 0x8e, 0x81,
+	0xd5, 0x34, 0xaf, 0xb3, 0xe8, 0x17, 0x84, 0xf3, 0x3a, 0x3b, 0xf2, 0xbe, 0x31, 0xcd, 0x79, 0xc2,
+	0xb4, 0xce, 0x89, 0x69, 0xff, 0x4c, 0x72, 0x4f, 0x27, 0x1d, 0x44, 0x7b, 0x2d, 0xd1, 0x37, 0xef,
+	0xef, 0xdf, 0xae, 0x79, 0x99, 0x56, 0x74, 0xbc, 0x2c, 0xb2, 0x49, 0x5a, 0xef, 0x98, 0xd8, 0xb2,
+	0xd5, 0x9a, 0x89, 0xc9, 0x03, 0xa1, 0x82, 0x2f, 0xed, 0xd7, 0x84, 0x06, 0xfa, 0x73, 0x32, 0xfb,
+	0x3b, 0x00, 0x7d, 0x37, 0xff, 0x13, 0x65, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"testing"
+
+	"github.com/manudrijvers/amcl/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdemix(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	// Test issuer key generation
+	_, err = NewIssuerKey([]string{"Attr1", "Attr1"}, rng)
+	assert.Error(t, err, "Duplicate attribute names should be rejected")
+
+	AttributeNames := []string{"Attr1", "Attr2", "Attr3", "Attr4", "Attr5"}
+	attrs := make([]*amcl.BIG, len(AttributeNames))
+	for i := range AttributeNames {
+		attrs[i] = amcl.NewBIGint(i)
+	}
+
+	key, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, key.Ipk.Check(), "Issuer public key should be valid")
+
+	// Test a tampered issuer public key
+	tampered := *key.Ipk
+	tampered.AttributeNames = []string{"Attr1", "Attr2", "Attr3", "Attr4", "Other"}
+	assert.Error(t, tampered.Check(), "Issuer public key with modified attribute names should be rejected")
+	tampered = *key.Ipk
+	tampered.W = Ecp2ToBytes(GenG2())
+	assert.NoError(t, tampered.SetHash())
+	assert.Error(t, tampered.Check(), "Issuer public key with an invalid proof should be rejected")
+
+	// Test credential issuance
+	sk := RandModOrder(rng)
+	IssuerNonce := BigToBytes(RandModOrder(rng))
+	m, err := NewCredRequest(sk, IssuerNonce, key.Ipk, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Check(key.Ipk), "Credential request should be valid")
+
+	otherRequest, err := NewCredRequest(sk, BigToBytes(RandModOrder(rng)), key.Ipk, rng)
+	assert.NoError(t, err)
+	otherRequest.IssuerNonce = IssuerNonce
+	assert.Error(t, otherRequest.Check(key.Ipk), "Credential request for another nonce should be rejected")
+
+	_, err = NewCredential(key, m, attrs[1:], rng)
+	assert.Error(t, err, "Credential with a wrong number of attributes should not be issued")
+
+	cred, err := NewCredential(key, m, attrs, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, cred.Ver(sk, key.Ipk), "Credential should be valid")
+	assert.Error(t, cred.Ver(RandModOrder(rng), key.Ipk), "Credential should not be valid for another secret")
+
+	// Test signing without disclosure
+	Nym, RNym, err := MakeNym(sk, key.Ipk, rng)
+	assert.NoError(t, err)
+
+	disclosure := []byte{0, 0, 0, 0, 0}
+	msg := []byte{1, 2, 3, 4, 5}
+	sig, err := NewSignature(cred, sk, Nym, RNym, key.Ipk, disclosure, msg, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, make([]*amcl.BIG, len(attrs))), "Signature should be valid")
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, []byte{1}, make([]*amcl.BIG, len(attrs))), "Signature should not be valid for another message")
+
+	// Test signing with selective disclosure
+	disclosure = []byte{0, 1, 1, 1, 0}
+	sig, err = NewSignature(cred, sk, Nym, RNym, key.Ipk, disclosure, msg, rng)
+	assert.NoError(t, err)
+	values := []*amcl.BIG{nil, attrs[1], attrs[2], attrs[3], nil}
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, values), "Signature with selective disclosure should be valid")
+
+	values[2] = amcl.NewBIGint(42)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, values), "Signature should not be valid for other attribute values")
+	values[2] = nil
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, values), "Signature verification requires the disclosed values")
+
+	// Test that a signature is bound to the issuer
+	otherKey, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, otherKey.Ipk, msg, []*amcl.BIG{nil, attrs[1], attrs[2], attrs[3], nil}), "Signature should not be valid for another issuer")
+
+	// Test pseudonym signatures
+	nymsig, err := NewNymSignature(sk, Nym, RNym, key.Ipk, msg, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, nymsig.Ver(Nym, key.Ipk, msg), "Pseudonym signature should be valid")
+	assert.Error(t, nymsig.Ver(Nym, key.Ipk, []byte{1}), "Pseudonym signature should not be valid for another message")
+
+	otherNym, _, err := MakeNym(sk, key.Ipk, rng)
+	assert.NoError(t, err)
+	assert.Error(t, nymsig.Ver(otherNym, key.Ipk, msg), "Pseudonym signature should not be valid for another pseudonym")
+	assert.NotEqual(t, EcpToBytes(Nym), EcpToBytes(otherNym), "Pseudonyms should be unlinkable")
+}
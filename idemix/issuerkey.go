@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/manudrijvers/amcl/go"
+)
+
+// The Issuer secret ISk and public IPk keys are used to issue credentials and
+// to verify signatures created using the credentials
+
+// NewIssuerKey creates a new issuer key pair taking an array of attribute names
+// that will be contained in credentials certified by this issuer (a credential specification)
+func NewIssuerKey(AttributeNames []string, rng *amcl.RAND) (*IssuerKey, error) {
+	attributeNamesMap := map[string]bool{}
+	for _, name := range AttributeNames {
+		if attributeNamesMap[name] {
+			return nil, fmt.Errorf("attribute %s appears multiple times in AttributeNames", name)
+		}
+		attributeNamesMap[name] = true
+	}
+
+	// generate the secret key x and the public key W = g2^x
+	ISk := RandModOrder(rng)
+	W := g2Mul(GenG2(), ISk)
+
+	// generate the bases of the credentials: a base for each attribute,
+	// for the user secret and for the randomness
+	HAttrs := make([][]byte, len(AttributeNames))
+	for i := range AttributeNames {
+		HAttrs[i] = EcpToBytes(g1Mul(GenG1(), RandModOrder(rng)))
+	}
+	HSk := g1Mul(GenG1(), RandModOrder(rng))
+	HRand := g1Mul(GenG1(), RandModOrder(rng))
+
+	// BarG1 and BarG2 = BarG1^x allow to check signatures without pairings on W
+	BarG1 := g1Mul(GenG1(), RandModOrder(rng))
+	BarG2 := g1Mul(BarG1, ISk)
+
+	// prove knowledge of x such that W = g2^x and BarG2 = BarG1^x
+	r := RandModOrder(rng)
+	t1 := g2Mul(GenG2(), r)
+	t2 := g1Mul(BarG1, r)
+	ProofC := challenge(Ecp2ToBytes(t1), EcpToBytes(t2), Ecp2ToBytes(GenG2()), EcpToBytes(BarG1), Ecp2ToBytes(W), EcpToBytes(BarG2))
+	ProofS := modAdd(r, modMul(ProofC, ISk))
+
+	ipk := &IssuerPublicKey{
+		AttributeNames: AttributeNames,
+		HSk:            EcpToBytes(HSk),
+		HRand:          EcpToBytes(HRand),
+		HAttrs:         HAttrs,
+		W:              Ecp2ToBytes(W),
+		BarG1:          EcpToBytes(BarG1),
+		BarG2:          EcpToBytes(BarG2),
+		ProofC:         BigToBytes(ProofC),
+		ProofS:         BigToBytes(ProofS),
+	}
+	if err := ipk.SetHash(); err != nil {
+		return nil, err
+	}
+
+	return &IssuerKey{Isk: BigToBytes(ISk), Ipk: ipk}, nil
+}
+
+// SetHash appends a hash of a serialized public key
+func (IPk *IssuerPublicKey) SetHash() error {
+	IPk.Hash = nil
+	serializedIPk, err := proto.Marshal(IPk)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issuer public key: %s", err)
+	}
+	IPk.Hash = BigToBytes(HashModOrder(serializedIPk))
+	return nil
+}
+
+// Check checks that this issuer public key is valid, i.e.
+// that all components are present and a ZK proofs verifies
+func (IPk *IssuerPublicKey) Check() error {
+	d := &decoder{}
+	HSk := d.ecp(IPk.HSk)
+	HRand := d.ecp(IPk.HRand)
+	W := d.ecp2(IPk.W)
+	BarG1 := d.ecp(IPk.BarG1)
+	BarG2 := d.ecp(IPk.BarG2)
+	ProofC := d.big(IPk.ProofC)
+	ProofS := d.big(IPk.ProofS)
+	for _, h := range IPk.HAttrs {
+		d.ecp(h)
+	}
+	if d.err != nil {
+		return fmt.Errorf("invalid issuer public key: %s", d.err)
+	}
+	if HSk == nil || HRand == nil || len(IPk.HAttrs) != len(IPk.AttributeNames) {
+		return errors.New("invalid issuer public key: some part of the public key is undefined")
+	}
+
+	// verify the proof of knowledge of the secret key:
+	// t1 = g2^s * W^(-c), t2 = BarG1^s * BarG2^(-c)
+	t1 := g2Mul(GenG2(), ProofS)
+	t1.Sub(g2Mul(W, ProofC))
+	t2 := g1Mul(BarG1, ProofS)
+	t2.Sub(g1Mul(BarG2, ProofC))
+	c := challenge(Ecp2ToBytes(t1), EcpToBytes(t2), Ecp2ToBytes(GenG2()), EcpToBytes(BarG1), Ecp2ToBytes(W), EcpToBytes(BarG2))
+	if !c.Equals(ProofC) {
+		return errors.New("invalid issuer public key: the zero-knowledge proof of the secret key does not verify")
+	}
+
+	// verify the hash
+	hash := IPk.Hash
+	if err := IPk.SetHash(); err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, IPk.Hash) {
+		IPk.Hash = hash
+		return errors.New("invalid issuer public key: the hash does not match")
+	}
+
+	return nil
+}
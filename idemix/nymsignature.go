@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// nymSignLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a pseudonym signature
+const nymSignLabel = "nymSign"
+
+// A NymSignature proves knowledge of the opening of a pseudonym Nym = HSk^sk * HRand^RNym
+// and thereby signs a message on behalf of the pseudonym. It does not prove anything
+// about the credential, which is done once per pseudonym with a Signature
+
+// NewNymSignature creates a new idemix pseudonym signature
+func NewNymSignature(sk *amcl.BIG, Nym *amcl.ECP, RNym *amcl.BIG, ipk *IssuerPublicKey, msg []byte, rng *amcl.RAND) (*NymSignature, error) {
+	d := &decoder{}
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid issuer public key: %s", d.err)
+	}
+
+	rSk := RandModOrder(rng)
+	rRNym := RandModOrder(rng)
+
+	t := g1Mul(HSk, rSk)
+	t.Add(g1Mul(HRand, rRNym))
+
+	Nonce := RandModOrder(rng)
+	ProofC := challenge([]byte(nymSignLabel), EcpToBytes(t), EcpToBytes(Nym), ipk.Hash, msg, BigToBytes(Nonce))
+
+	return &NymSignature{
+		ProofC:     BigToBytes(ProofC),
+		ProofSSk:   BigToBytes(modAdd(rSk, modMul(ProofC, sk))),
+		ProofSRNym: BigToBytes(modAdd(rRNym, modMul(ProofC, RNym))),
+		Nonce:      BigToBytes(Nonce),
+	}, nil
+}
+
+// Ver verifies an idemix pseudonym signature
+func (sig *NymSignature) Ver(Nym *amcl.ECP, ipk *IssuerPublicKey, msg []byte) error {
+	d := &decoder{}
+	ProofC := d.big(sig.ProofC)
+	ProofSSk := d.big(sig.ProofSSk)
+	ProofSRNym := d.big(sig.ProofSRNym)
+	d.big(sig.Nonce)
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	if d.err != nil {
+		return fmt.Errorf("invalid pseudonym signature or issuer public key: %s", d.err)
+	}
+
+	// t = HSk^SSk * HRand^SRNym * Nym^(-c)
+	t := g1Mul(HSk, ProofSSk)
+	t.Add(g1Mul(HRand, ProofSRNym))
+	t.Sub(g1Mul(Nym, ProofC))
+
+	c := challenge([]byte(nymSignLabel), EcpToBytes(t), EcpToBytes(Nym), ipk.Hash, msg, sig.Nonce)
+	if !c.Equals(ProofC) {
+		return errors.New("pseudonym signature is invalid")
+	}
+
+	return nil
+}
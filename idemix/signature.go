@@ -0,0 +1,264 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// signLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a signature of knowledge
+const signLabel = "sign"
+
+// A signature that is produced using an Identity Mixer credential is a so-called signature of knowledge
+// (for details see C.P.Schnorr "Efficient Identification and Signatures for Smart Cards")
+// An Identity Mixer signature is a signature of knowledge that signs a message and proves (in zero-knowledge)
+// the knowledge of the user secret (and possibly attributes) signed inside a credential
+// that was issued by a certain issuer (referred to with the issuer public key)
+// The signature is verified using the message being signed and the public key of the issuer
+// Some of the attributes from the credential can be selectively disclosed or different statements can be proven about
+// credential attributes without disclosing them in the clear
+// The difference between a standard signature using X.509 certificates and an Identity Mixer signature is
+// the advanced privacy features provided by Identity Mixer (due to zero-knowledge proofs):
+//  - Unlinkability of the signatures produced with the same credential
+//  - Selective attribute disclosure and predicates over attributes
+
+// MakeNym creates a new unlinkable pseudonym for the user secret sk.
+// It returns the pseudonym Nym = HSk^sk * HRand^RNym and its randomness RNym
+func MakeNym(sk *amcl.BIG, ipk *IssuerPublicKey, rng *amcl.RAND) (*amcl.ECP, *amcl.BIG, error) {
+	d := &decoder{}
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	if d.err != nil {
+		return nil, nil, fmt.Errorf("invalid issuer public key: %s", d.err)
+	}
+
+	RNym := RandModOrder(rng)
+	Nym := g1Mul(HSk, sk)
+	Nym.Add(g1Mul(HRand, RNym))
+	return Nym, RNym, nil
+}
+
+// NewSignature creates a new idemix signature (Schnorr-type signature)
+// The []byte Disclosure steers which attributes are disclosed:
+// if Disclosure[i] == 0 then attribute i remains hidden and otherwise it is disclosed.
+// The signature proves, for the pseudonym Nym created with MakeNym, that its owner
+// holds a credential of the issuer of ipk on the same user secret
+func NewSignature(cred *Credential, sk *amcl.BIG, Nym *amcl.ECP, RNym *amcl.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rng *amcl.RAND) (*Signature, error) {
+	if len(Disclosure) != len(ipk.AttributeNames) || len(cred.Attrs) != len(ipk.AttributeNames) || len(ipk.HAttrs) != len(ipk.AttributeNames) {
+		return nil, errors.New("disclosure, credential and issuer public key do not have the same number of attributes")
+	}
+
+	d := &decoder{}
+	A := d.ecp(cred.A)
+	B := d.ecp(cred.B)
+	E := d.big(cred.E)
+	S := d.big(cred.S)
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	attrs := make([]*amcl.BIG, len(cred.Attrs))
+	for i := range cred.Attrs {
+		attrs[i] = d.big(cred.Attrs[i])
+	}
+	HAttrs := make([]*amcl.ECP, len(ipk.HAttrs))
+	for i, h := range ipk.HAttrs {
+		HAttrs[i] = d.ecp(h)
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid credential or issuer public key: %s", d.err)
+	}
+
+	HiddenIndices := hiddenIndices(Disclosure)
+
+	// Randomize the credential
+	r1 := RandModOrder(rng)
+	r2 := RandModOrder(rng)
+	r3 := amcl.NewBIGcopy(r1)
+	r3.Invmodp(GroupOrder())
+
+	APrime := g1Mul(A, r1)
+
+	// ABar = B^r1 * APrime^(-e)
+	ABar := g1Mul(B, r1)
+	ABar.Sub(g1Mul(APrime, E))
+
+	// BPrime = B^r1 * HRand^(-r2)
+	BPrime := g1Mul(B, r1)
+	BPrime.Sub(g1Mul(HRand, r2))
+
+	// sPrime = s - r2*r3
+	sPrime := modSub(S, modMul(r2, r3))
+
+	// Construct the zero-knowledge proof
+	rSk := RandModOrder(rng)
+	re := RandModOrder(rng)
+	rR2 := RandModOrder(rng)
+	rR3 := RandModOrder(rng)
+	rSPrime := RandModOrder(rng)
+	rRNym := RandModOrder(rng)
+	rAttrs := make([]*amcl.BIG, len(HiddenIndices))
+	for i := range HiddenIndices {
+		rAttrs[i] = RandModOrder(rng)
+	}
+
+	t1 := g1Mul(APrime, re)
+	t1.Add(g1Mul(HRand, rR2))
+
+	t2 := g1Mul(HRand, rSPrime)
+	t2.Add(g1Mul(BPrime, rR3))
+	t2.Add(g1Mul(HSk, rSk))
+	for i, j := range HiddenIndices {
+		t2.Add(g1Mul(HAttrs[j], rAttrs[i]))
+	}
+
+	t3 := g1Mul(HSk, rSk)
+	t3.Add(g1Mul(HRand, rRNym))
+
+	Nonce := RandModOrder(rng)
+	ProofC := signatureChallenge(t1, t2, t3, APrime, ABar, BPrime, Nym, ipk, Disclosure, msg, BigToBytes(Nonce))
+
+	ProofSAttrs := make([][]byte, len(HiddenIndices))
+	for i, j := range HiddenIndices {
+		ProofSAttrs[i] = BigToBytes(modAdd(rAttrs[i], modMul(ProofC, attrs[j])))
+	}
+
+	return &Signature{
+		APrime:       EcpToBytes(APrime),
+		ABar:         EcpToBytes(ABar),
+		BPrime:       EcpToBytes(BPrime),
+		ProofC:       BigToBytes(ProofC),
+		ProofSSk:     BigToBytes(modAdd(rSk, modMul(ProofC, sk))),
+		ProofSE:      BigToBytes(modSub(re, modMul(ProofC, E))),
+		ProofSR2:     BigToBytes(modAdd(rR2, modMul(ProofC, r2))),
+		ProofSR3:     BigToBytes(modSub(rR3, modMul(ProofC, r3))),
+		ProofSSPrime: BigToBytes(modAdd(rSPrime, modMul(ProofC, sPrime))),
+		ProofSAttrs:  ProofSAttrs,
+		Nonce:        BigToBytes(Nonce),
+		Nym:          EcpToBytes(Nym),
+		ProofSRNym:   BigToBytes(modAdd(rRNym, modMul(ProofC, RNym))),
+	}, nil
+}
+
+// Ver verifies an idemix signature
+// Disclosure steers which attributes it expects to be disclosed
+// attributeValues[i] contains the desired attribute value for the i-th attribute if its disclosed
+func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*amcl.BIG) error {
+	if len(Disclosure) != len(ipk.AttributeNames) || len(attributeValues) != len(ipk.AttributeNames) || len(ipk.HAttrs) != len(ipk.AttributeNames) {
+		return errors.New("disclosure, attribute values and issuer public key do not have the same number of attributes")
+	}
+
+	HiddenIndices := hiddenIndices(Disclosure)
+	if len(sig.ProofSAttrs) != len(HiddenIndices) {
+		return errors.New("signature has an incorrect number of attribute proofs")
+	}
+	for i, disclosed := range Disclosure {
+		if disclosed != 0 && attributeValues[i] == nil {
+			return fmt.Errorf("no value given for disclosed attribute %s", ipk.AttributeNames[i])
+		}
+	}
+
+	d := &decoder{}
+	APrime := d.ecp(sig.APrime)
+	ABar := d.ecp(sig.ABar)
+	BPrime := d.ecp(sig.BPrime)
+	Nym := d.ecp(sig.Nym)
+	ProofC := d.big(sig.ProofC)
+	ProofSSk := d.big(sig.ProofSSk)
+	ProofSE := d.big(sig.ProofSE)
+	ProofSR2 := d.big(sig.ProofSR2)
+	ProofSR3 := d.big(sig.ProofSR3)
+	ProofSSPrime := d.big(sig.ProofSSPrime)
+	ProofSRNym := d.big(sig.ProofSRNym)
+	ProofSAttrs := make([]*amcl.BIG, len(sig.ProofSAttrs))
+	for i, s := range sig.ProofSAttrs {
+		ProofSAttrs[i] = d.big(s)
+	}
+	HSk := d.ecp(ipk.HSk)
+	HRand := d.ecp(ipk.HRand)
+	W := d.ecp2(ipk.W)
+	HAttrs := make([]*amcl.ECP, len(ipk.HAttrs))
+	for i, h := range ipk.HAttrs {
+		HAttrs[i] = d.ecp(h)
+	}
+	d.big(sig.Nonce)
+	if d.err != nil {
+		return fmt.Errorf("invalid signature or issuer public key: %s", d.err)
+	}
+
+	// Check that the randomized credential is a valid BBS+ signature: e(APrime, W) = e(ABar, g2)
+	if !pairingsEqual(W, APrime, GenG2(), ABar) {
+		return errors.New("signature is not valid for the issuer public key")
+	}
+
+	// Recompute the commitments of the zero-knowledge proof
+	// t1 = APrime^SE * HRand^SR2 * (ABar / BPrime)^(-c)
+	t1 := g1Mul(APrime, ProofSE)
+	t1.Add(g1Mul(HRand, ProofSR2))
+	tmp := amcl.NewECP()
+	tmp.Copy(ABar)
+	tmp.Sub(BPrime)
+	t1.Sub(g1Mul(tmp, ProofC))
+
+	// t2 = HRand^SSPrime * BPrime^SR3 * HSk^SSk * Prod_hidden HAttrs[j]^SAttrs[j] * (g1 * Prod_disclosed HAttrs[j]^a[j])^c
+	t2 := g1Mul(HRand, ProofSSPrime)
+	t2.Add(g1Mul(BPrime, ProofSR3))
+	t2.Add(g1Mul(HSk, ProofSSk))
+	for i, j := range HiddenIndices {
+		t2.Add(g1Mul(HAttrs[j], ProofSAttrs[i]))
+	}
+	tmp = GenG1()
+	for i, disclosed := range Disclosure {
+		if disclosed != 0 {
+			tmp.Add(g1Mul(HAttrs[i], attributeValues[i]))
+		}
+	}
+	t2.Add(g1Mul(tmp, ProofC))
+
+	// t3 = HSk^SSk * HRand^SRNym * Nym^(-c)
+	t3 := g1Mul(HSk, ProofSSk)
+	t3.Add(g1Mul(HRand, ProofSRNym))
+	t3.Sub(g1Mul(Nym, ProofC))
+
+	c := signatureChallenge(t1, t2, t3, APrime, ABar, BPrime, Nym, ipk, Disclosure, msg, sig.Nonce)
+	if !c.Equals(ProofC) {
+		return errors.New("signature is invalid")
+	}
+
+	return nil
+}
+
+// signatureChallenge computes the Fiat-Shamir challenge of a signature
+func signatureChallenge(t1, t2, t3, APrime, ABar, BPrime, Nym *amcl.ECP, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, nonce []byte) *amcl.BIG {
+	return challenge(
+		[]byte(signLabel),
+		EcpToBytes(t1), EcpToBytes(t2), EcpToBytes(t3),
+		EcpToBytes(APrime), EcpToBytes(ABar), EcpToBytes(BPrime), EcpToBytes(Nym),
+		ipk.Hash, Disclosure, msg, nonce)
+}
+
+// hiddenIndices returns the indices of the attributes that are not disclosed
+func hiddenIndices(Disclosure []byte) []int {
+	HiddenIndices := make([]int, 0)
+	for index, disclose := range Disclosure {
+		if disclose == 0 {
+			HiddenIndices = append(HiddenIndices, index)
+		}
+	}
+	return HiddenIndices
+}
@@ -0,0 +1,225 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// The amcl types are not safe for concurrent use, even when only read:
+// points are normalized in place and the modulus of a reduction is
+// shifted back and forth. Therefore the group order and generators are
+// created anew whenever needed, and the group elements of the messages
+// of this package are kept in their byte encoding and decoded on use.
+
+// FieldBytes is the length in bytes of an element of Zr or Fp
+const FieldBytes = int(amcl.MODBYTES)
+
+// G1Bytes is the length in bytes of an encoded element of G1
+const G1Bytes = 2*FieldBytes + 1
+
+// G2Bytes is the length in bytes of an encoded element of G2
+const G2Bytes = 4 * FieldBytes
+
+// GroupOrder returns the order of the groups G1, G2 and GT
+func GroupOrder() *amcl.BIG {
+	return amcl.NewBIGints(amcl.CURVE_Order)
+}
+
+// GenG1 returns the generator of G1
+func GenG1() *amcl.ECP {
+	return amcl.NewECPbigs(amcl.NewBIGints(amcl.CURVE_Gx), amcl.NewBIGints(amcl.CURVE_Gy))
+}
+
+// GenG2 returns the generator of G2
+func GenG2() *amcl.ECP2 {
+	return amcl.NewECP2fp2s(
+		amcl.NewFP2bigs(amcl.NewBIGints(amcl.CURVE_Pxa), amcl.NewBIGints(amcl.CURVE_Pxb)),
+		amcl.NewFP2bigs(amcl.NewBIGints(amcl.CURVE_Pya), amcl.NewBIGints(amcl.CURVE_Pyb)))
+}
+
+// GetRand returns a random number generator seeded from crypto/rand
+func GetRand() (*amcl.RAND, error) {
+	seed := make([]byte, 2*FieldBytes)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed seeding the random number generator: %s", err)
+	}
+	rng := amcl.NewRAND()
+	rng.Clean()
+	rng.Seed(len(seed), seed)
+	return rng, nil
+}
+
+// RandModOrder returns a random element of Zr
+func RandModOrder(rng *amcl.RAND) *amcl.BIG {
+	return amcl.Randomnum(GroupOrder(), rng)
+}
+
+// HashModOrder hashes the given data into an element of Zr
+func HashModOrder(data []byte) *amcl.BIG {
+	digest := sha256.Sum256(data)
+	res := amcl.FromBytes(digest[:])
+	res.Mod(GroupOrder())
+	return res
+}
+
+// BigToBytes returns the encoding of the given element of Zr
+func BigToBytes(big *amcl.BIG) []byte {
+	res := make([]byte, FieldBytes)
+	amcl.NewBIGcopy(big).ToBytes(res)
+	return res
+}
+
+// BigFromBytes decodes an element of Zr
+func BigFromBytes(b []byte) (*amcl.BIG, error) {
+	if len(b) != FieldBytes {
+		return nil, fmt.Errorf("invalid length %d of an element of Zr", len(b))
+	}
+	res := amcl.FromBytes(b)
+	res.Mod(GroupOrder())
+	return res, nil
+}
+
+// EcpToBytes returns the encoding of the given element of G1
+func EcpToBytes(p *amcl.ECP) []byte {
+	res := make([]byte, G1Bytes)
+	c := amcl.NewECP()
+	c.Copy(p)
+	c.ToBytes(res)
+	return res
+}
+
+// EcpFromBytes decodes an element of G1, which may not be the identity
+func EcpFromBytes(b []byte) (*amcl.ECP, error) {
+	if len(b) != G1Bytes {
+		return nil, fmt.Errorf("invalid length %d of an element of G1", len(b))
+	}
+	p := amcl.ECP_fromBytes(b)
+	if p.Is_infinity() {
+		return nil, fmt.Errorf("invalid element of G1")
+	}
+	return p, nil
+}
+
+// Ecp2ToBytes returns the encoding of the given element of G2
+func Ecp2ToBytes(p *amcl.ECP2) []byte {
+	res := make([]byte, G2Bytes)
+	c := amcl.NewECP2()
+	c.Copy(p)
+	c.ToBytes(res)
+	return res
+}
+
+// Ecp2FromBytes decodes an element of G2, which may not be the identity
+func Ecp2FromBytes(b []byte) (*amcl.ECP2, error) {
+	if len(b) != G2Bytes {
+		return nil, fmt.Errorf("invalid length %d of an element of G2", len(b))
+	}
+	p := amcl.ECP2_fromBytes(b)
+	if p.Is_infinity() {
+		return nil, fmt.Errorf("invalid element of G2")
+	}
+	return p, nil
+}
+
+// decoder decodes encoded group elements, and keeps the first error
+type decoder struct {
+	err error
+}
+
+func (d *decoder) big(b []byte) *amcl.BIG {
+	if d.err != nil {
+		return nil
+	}
+	var res *amcl.BIG
+	res, d.err = BigFromBytes(b)
+	return res
+}
+
+func (d *decoder) ecp(b []byte) *amcl.ECP {
+	if d.err != nil {
+		return nil
+	}
+	var res *amcl.ECP
+	res, d.err = EcpFromBytes(b)
+	return res
+}
+
+func (d *decoder) ecp2(b []byte) *amcl.ECP2 {
+	if d.err != nil {
+		return nil
+	}
+	var res *amcl.ECP2
+	res, d.err = Ecp2FromBytes(b)
+	return res
+}
+
+// modAdd returns a+b mod the group order
+func modAdd(a, b *amcl.BIG) *amcl.BIG {
+	return amcl.Modadd(amcl.NewBIGcopy(a), amcl.NewBIGcopy(b), GroupOrder())
+}
+
+// modSub returns a-b mod the group order
+func modSub(a, b *amcl.BIG) *amcl.BIG {
+	return amcl.Modsub(amcl.NewBIGcopy(a), amcl.NewBIGcopy(b), GroupOrder())
+}
+
+// modMul returns a*b mod the group order
+func modMul(a, b *amcl.BIG) *amcl.BIG {
+	return amcl.Modmul(amcl.NewBIGcopy(a), amcl.NewBIGcopy(b), GroupOrder())
+}
+
+// g1Mul returns p^e, that is p multiplied by e in additive notation
+func g1Mul(p *amcl.ECP, e *amcl.BIG) *amcl.ECP {
+	c := amcl.NewECP()
+	c.Copy(p)
+	return amcl.G1mul(c, amcl.NewBIGcopy(e))
+}
+
+// g2Mul returns p^e, that is p multiplied by e in additive notation
+func g2Mul(p *amcl.ECP2, e *amcl.BIG) *amcl.ECP2 {
+	c := amcl.NewECP2()
+	c.Copy(p)
+	return amcl.G2mul(c, amcl.NewBIGcopy(e))
+}
+
+// pairingsEqual returns whether e(p1, q1) = e(p2, q2)
+func pairingsEqual(p1 *amcl.ECP2, q1 *amcl.ECP, p2 *amcl.ECP2, q2 *amcl.ECP) bool {
+	left := amcl.Ate(p1, q1)
+	right := amcl.Ate(p2, q2)
+	right.Inverse()
+	left.Mul(right)
+	return amcl.Fexp(left).Isunity()
+}
+
+// challenge hashes the given elements into a Fiat-Shamir challenge,
+// prefixing each element with its length so that the encoding is unambiguous
+func challenge(elements ...[]byte) *amcl.BIG {
+	var data []byte
+	length := make([]byte, 4)
+	for _, e := range elements {
+		binary.BigEndian.PutUint32(length, uint32(len(e)))
+		data = append(data, length...)
+		data = append(data, e...)
+	}
+	return HashModOrder(data)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/msp"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+)
+
+const (
+	deserializeIdentityCacheSize = 100
+	validateIdentityCacheSize    = 100
+	satisfiesPrincipalCacheSize  = 100
+	verifyCacheSize              = 1000
+)
+
+var mspLogger = flogging.MustGetLogger("msp/cache")
+
+// New returns an MSP caching the results of the operations of o.
+// Identities are deserialized once per serialized form, and the successful
+// validations, principal checks and signature verifications are remembered.
+// The caches are purged when the MSP is set up again, and the validations
+// and principal checks when its online revocation lists are updated.
+func New(o msp.MSP) (msp.MSP, error) {
+	mspLogger.Debugf("Creating cache for MSP type %d", o.GetType())
+
+	return &cachedMSP{
+		MSP:                      o,
+		deserializeIdentityCache: newLRUCache(deserializeIdentityCacheSize),
+		validateIdentityCache:    newLRUCache(validateIdentityCacheSize),
+		satisfiesPrincipalCache:  newLRUCache(satisfiesPrincipalCacheSize),
+		verifyCache:              newLRUCache(verifyCacheSize),
+	}, nil
+}
+
+type cachedMSP struct {
+	msp.MSP
+
+	// cache for DeserializeIdentity, keyed by serialized identity
+	deserializeIdentityCache *lruCache
+	// cache of the identities known to be valid, keyed by serialized identity
+	validateIdentityCache *lruCache
+	// cache of the satisfied principals, keyed by serialized identity and principal
+	satisfiesPrincipalCache *lruCache
+	// cache of the valid signatures, keyed by a hash of identity, message and signature
+	verifyCache *lruCache
+
+	// version of the online revocation lists the validations
+	// and principal checks in the caches were made with
+	crlLock    sync.Mutex
+	crlVersion uint64
+}
+
+type cachedIdentity struct {
+	msp.Identity
+
+	serialized []byte
+	cache      *cachedMSP
+}
+
+// Validate uses the cache of the MSP that deserialized this identity
+func (id *cachedIdentity) Validate() error {
+	return id.cache.Validate(id)
+}
+
+// SatisfiesPrincipal uses the cache of the MSP that deserialized this identity
+func (id *cachedIdentity) SatisfiesPrincipal(principal *pmsp.MSPPrincipal) error {
+	return id.cache.SatisfiesPrincipal(id, principal)
+}
+
+// Verify remembers the signatures found to be valid
+func (id *cachedIdentity) Verify(msg []byte, sig []byte) error {
+	key := verifyKey(id.serialized, msg, sig)
+	if _, ok := id.cache.verifyCache.get(key); ok {
+		return nil
+	}
+
+	if err := id.Identity.Verify(msg, sig); err != nil {
+		return err
+	}
+
+	id.cache.verifyCache.add(key, true)
+	return nil
+}
+
+// DeserializeIdentity returns the identity cached for serializedIdentity,
+// deserializing it the first time
+func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if id, ok := c.deserializeIdentityCache.get(string(serializedIdentity)); ok {
+		return id.(*cachedIdentity), nil
+	}
+
+	id, err := c.MSP.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &cachedIdentity{Identity: id, serialized: serializedIdentity, cache: c}
+	c.deserializeIdentityCache.add(string(serializedIdentity), cached)
+	return cached, nil
+}
+
+// Setup sets up the underlying MSP and purges the caches,
+// since their content depends on the previous configuration
+func (c *cachedMSP) Setup(config *pmsp.MSPConfig) error {
+	c.purge()
+
+	return c.MSP.Setup(config)
+}
+
+// Validate checks id, remembering it if it is valid
+func (c *cachedMSP) Validate(id msp.Identity) error {
+	serialized, err := id.Serialize()
+	if err != nil {
+		return fmt.Errorf("Could not serialize identity: %s", err)
+	}
+
+	version := c.checkRevocationLists()
+	if _, ok := c.validateIdentityCache.get(string(serialized)); ok {
+		return nil
+	}
+
+	if err := c.MSP.Validate(unwrap(id)); err != nil {
+		return err
+	}
+
+	// don't remember a validation made with outdated revocation lists
+	if c.checkRevocationLists() == version {
+		c.validateIdentityCache.add(string(serialized), true)
+	}
+	return nil
+}
+
+// SatisfiesPrincipal checks id against principal, remembering
+// the principals id satisfies
+func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrincipal) error {
+	serialized, err := id.Serialize()
+	if err != nil {
+		return fmt.Errorf("Could not serialize identity: %s", err)
+	}
+	principalBytes, err := proto.Marshal(principal)
+	if err != nil {
+		return fmt.Errorf("Could not marshal principal: %s", err)
+	}
+	key := string(serialized) + string(principalBytes)
+
+	version := c.checkRevocationLists()
+	if _, ok := c.satisfiesPrincipalCache.get(key); ok {
+		return nil
+	}
+
+	if err := c.MSP.SatisfiesPrincipal(unwrap(id), principal); err != nil {
+		return err
+	}
+
+	if c.checkRevocationLists() == version {
+		c.satisfiesPrincipalCache.add(key, true)
+	}
+	return nil
+}
+
+// checkRevocationLists purges the validations and principal checks, which
+// depend on the revocation lists, when the online revocation lists of the
+// MSP have been updated since they were made. It returns the current version
+// of the online revocation lists.
+func (c *cachedMSP) checkRevocationLists() uint64 {
+	mspID, err := c.MSP.GetIdentifier()
+	if err != nil {
+		return 0
+	}
+	version := msp.OnlineRevocationListsVersion(mspID)
+
+	c.crlLock.Lock()
+	defer c.crlLock.Unlock()
+	if version != c.crlVersion {
+		mspLogger.Debugf("Revocation lists of MSP %s updated, purging the validations", mspID)
+		c.validateIdentityCache.purge()
+		c.satisfiesPrincipalCache.purge()
+		c.crlVersion = version
+	}
+	return version
+}
+
+func (c *cachedMSP) purge() {
+	c.deserializeIdentityCache.purge()
+	c.validateIdentityCache.purge()
+	c.satisfiesPrincipalCache.purge()
+	c.verifyCache.purge()
+}
+
+// unwrap returns the identity of the underlying MSP, which
+// may only accept the identities it created
+func unwrap(id msp.Identity) msp.Identity {
+	if cached, ok := id.(*cachedIdentity); ok {
+		return cached.Identity
+	}
+	return id
+}
+
+// verifyKey hashes identity, message and signature, length-prefixing
+// each of them so that distinct triples never collide
+func verifyKey(serialized, msg, sig []byte) string {
+	h := sha256.New()
+	for _, b := range [][]byte{serialized, msg, sig} {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(b)))
+		h.Write(l[:])
+		h.Write(b)
+	}
+	return string(h.Sum(nil))
+}
@@ -0,0 +1,243 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingMSP counts the calls reaching the underlying MSP
+type countingMSP struct {
+	msp.MSP
+
+	deserializations int
+	validations      int
+	principalChecks  int
+}
+
+func (c *countingMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	c.deserializations++
+	return c.MSP.DeserializeIdentity(serializedIdentity)
+}
+
+func (c *countingMSP) Validate(id msp.Identity) error {
+	c.validations++
+	return c.MSP.Validate(id)
+}
+
+func (c *countingMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrincipal) error {
+	c.principalChecks++
+	return c.MSP.SatisfiesPrincipal(id, principal)
+}
+
+func setupMSP(t *testing.T) (*countingMSP, msp.MSP, *pmsp.MSPConfig) {
+	conf, err := msp.GetIdemixMspConfig("../testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	idemixMsp, err := msp.NewIdemixMsp()
+	assert.NoError(t, err)
+
+	counting := &countingMSP{MSP: idemixMsp}
+	cached, err := New(counting)
+	assert.NoError(t, err)
+	assert.NoError(t, cached.Setup(conf))
+	return counting, cached, conf
+}
+
+func TestCachedDeserializeAndValidate(t *testing.T) {
+	counting, cached, conf := setupMSP(t)
+
+	signer, err := cached.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+
+	id, err := cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	id2, err := cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.True(t, id == id2, "The same identity should be returned from the cache")
+	assert.Equal(t, 1, counting.deserializations)
+
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, cached.Validate(id2))
+	assert.Equal(t, 1, counting.validations)
+
+	_, err = cached.DeserializeIdentity([]byte("garbage"))
+	assert.Error(t, err)
+
+	// setting up the MSP again invalidates the caches
+	assert.NoError(t, cached.Setup(conf))
+	id, err = cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	assert.Equal(t, 3, counting.deserializations, "Failed deserializations should not be cached")
+	assert.Equal(t, 2, counting.validations)
+}
+
+func TestCachedValidateFailure(t *testing.T) {
+	counting, cached, _ := setupMSP(t)
+
+	signer, err := cached.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+
+	// an identity claiming another OU does not validate,
+	// and the failure is not cached
+	sID := &pmsp.SerializedIdentity{}
+	assert.NoError(t, proto.Unmarshal(serialized, sID))
+	idemixID := &pmsp.SerializedIdemixIdentity{}
+	assert.NoError(t, proto.Unmarshal(sID.IdBytes, idemixID))
+	idemixID.Ou = "OU2"
+	sID.IdBytes, _ = proto.Marshal(idemixID)
+	tampered, _ := proto.Marshal(sID)
+
+	id, err := cached.DeserializeIdentity(tampered)
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+	assert.Error(t, id.Validate())
+	assert.Equal(t, 2, counting.validations)
+}
+
+func TestCachedSatisfiesPrincipal(t *testing.T) {
+	counting, cached, _ := setupMSP(t)
+
+	signer, err := cached.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+	id, err := cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+
+	principal := func(mspID string) *pmsp.MSPPrincipal {
+		bytes, err := proto.Marshal(&pmsp.MSPRole{MspIdentifier: mspID, Role: pmsp.MSPRole_MEMBER})
+		assert.NoError(t, err)
+		return &pmsp.MSPPrincipal{PrincipalClassification: pmsp.MSPPrincipal_ROLE, Principal: bytes}
+	}
+
+	assert.NoError(t, id.SatisfiesPrincipal(principal("MSP1")))
+	assert.NoError(t, cached.SatisfiesPrincipal(id, principal("MSP1")))
+	assert.Equal(t, 1, counting.principalChecks)
+
+	assert.Error(t, id.SatisfiesPrincipal(principal("MSP2")))
+	assert.Error(t, id.SatisfiesPrincipal(principal("MSP2")))
+	assert.Equal(t, 3, counting.principalChecks)
+}
+
+func TestCachePurgedOnRevocationListsUpdate(t *testing.T) {
+	counting, cached, _ := setupMSP(t)
+	defer msp.SetOnlineRevocationLists("MSP1", nil)
+
+	signer, err := cached.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+	id, err := cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	roleBytes, err := proto.Marshal(&pmsp.MSPRole{MspIdentifier: "MSP1", Role: pmsp.MSPRole_MEMBER})
+	assert.NoError(t, err)
+	principal := &pmsp.MSPPrincipal{PrincipalClassification: pmsp.MSPPrincipal_ROLE, Principal: roleBytes}
+
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, id.SatisfiesPrincipal(principal))
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, id.SatisfiesPrincipal(principal))
+	assert.Equal(t, 1, counting.validations)
+	assert.Equal(t, 1, counting.principalChecks)
+
+	// the revocation lists of another MSP don't affect the caches
+	assert.NoError(t, msp.SetOnlineRevocationLists("MSP2", nil))
+	assert.NoError(t, id.Validate())
+	assert.Equal(t, 1, counting.validations)
+
+	// updating the online revocation lists purges the validations
+	// and principal checks, but not the deserialized identities
+	assert.NoError(t, msp.SetOnlineRevocationLists("MSP1", nil))
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, id.SatisfiesPrincipal(principal))
+	assert.Equal(t, 2, counting.validations)
+	assert.Equal(t, 2, counting.principalChecks)
+	_, err = cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, counting.deserializations)
+}
+
+func TestCachedVerify(t *testing.T) {
+	_, cached, _ := setupMSP(t)
+
+	signer, err := cached.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+	id, err := cached.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+
+	msg := []byte("hello world")
+	sig, err := signer.Sign(msg)
+	assert.NoError(t, err)
+
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Equal(t, 1, cached.(*cachedMSP).verifyCache.len())
+	assert.NoError(t, id.Verify(msg, sig))
+
+	// invalid signatures are never cached
+	assert.Error(t, id.Verify([]byte("other message"), sig))
+	assert.Error(t, id.Verify(msg, []byte("garbage")))
+	assert.Equal(t, 1, cached.(*cachedMSP).verifyCache.len())
+}
+
+func TestVerifyKey(t *testing.T) {
+	assert.NotEqual(t, verifyKey([]byte("ab"), []byte("c"), nil), verifyKey([]byte("a"), []byte("bc"), nil))
+	assert.Equal(t, verifyKey([]byte("a"), []byte("b"), []byte("c")), verifyKey([]byte("a"), []byte("b"), []byte("c")))
+}
+
+func BenchmarkDeserializeAndValidate(b *testing.B) {
+	conf, err := msp.GetIdemixMspConfig("../testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(b, err)
+
+	for _, tc := range []struct {
+		name   string
+		cached bool
+	}{{"NoCache", false}, {"Cache", true}} {
+		b.Run(tc.name, func(b *testing.B) {
+			m, err := msp.NewIdemixMsp()
+			assert.NoError(b, err)
+			if tc.cached {
+				m, err = New(m)
+				assert.NoError(b, err)
+			}
+			assert.NoError(b, m.Setup(conf))
+
+			signer, err := m.GetDefaultSigningIdentity()
+			assert.NoError(b, err)
+			serialized, err := signer.Serialize()
+			assert.NoError(b, err)
+			msg := []byte("hello world")
+			sig, err := signer.Sign(msg)
+			assert.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				id, err := m.DeserializeIdentity(serialized)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := id.Validate(); err != nil {
+					b.Fatal(err)
+				}
+				if err := id.Verify(msg, sig); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a cache of bounded size evicting the least recently
+// used entry when full. It is safe for concurrent use.
+type lruCache struct {
+	lock  sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the value stored under key and marks it as recently used
+func (c *lruCache) get(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+// add stores value under key, evicting the least recently used
+// entry if the cache is full
+func (c *lruCache) add(key string, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		e.Value.(*lruEntry).value = value
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, value: value})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// purge removes all the entries
+func (c *lruCache) purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// len returns the number of entries
+func (c *lruCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.ll.Len()
+}
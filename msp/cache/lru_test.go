@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache(2)
+
+	c.add("a", 1)
+	c.add("b", 2)
+	v, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// b is the least recently used entry
+	c.add("c", 3)
+	assert.Equal(t, 2, c.len())
+	_, ok = c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+
+	c.add("c", 4)
+	v, _ = c.get("c")
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 2, c.len())
+
+	c.purge()
+	assert.Equal(t, 0, c.len())
+	_, ok = c.get("a")
+	assert.False(t, ok)
+}
+
+func TestLRUCacheConcurrency(t *testing.T) {
+	c := newLRUCache(10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d-%d", i, j%20)
+				c.add(key, j)
+				c.get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, c.len())
+}
@@ -74,6 +74,10 @@ func isECDSASignedCert(cert *x509.Certificate) bool {
 		cert.SignatureAlgorithm == x509.ECDSAWithSHA512
 }
 
+func isEd25519SignedCert(cert *x509.Certificate) bool {
+	return cert.SignatureAlgorithm == x509.PureEd25519
+}
+
 // sanitizeECDSASignedCert checks that the signatures signing a cert
 // is in low-S. This is checked against the public key of parentCert.
 // If the signature is not in low-S, then a new certificate is generated
@@ -86,7 +90,12 @@ func sanitizeECDSASignedCert(cert *x509.Certificate, parentCert *x509.Certificat
 		return nil, errors.New("Parent certificate must be different from nil.")
 	}
 
-	expectedSig, err := sw.SignatureToLowS(parentCert.PublicKey.(*ecdsa.PublicKey), cert.Signature)
+	parentPK, ok := parentCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Parent certificate of an ECDSA signed certificate must have an ECDSA public key.")
+	}
+
+	expectedSig, err := sw.SignatureToLowS(parentPK, cert.Signature)
 	if err != nil {
 		return nil, err
 	}
@@ -38,8 +38,20 @@ type OrganizationalUnitIdentifiersConfiguration struct {
 	OrganizationalUnitIdentifier string `yaml:"OrganizationalUnitIdentifier,omitempty"`
 }
 
+// NodeOUs contains the configuration to tell apart clients, peers,
+// orderers and admins by the OUs of their certificates.
+// The Certificate of a node OU identifier is optional.
+type NodeOUs struct {
+	Enable              bool                                        `yaml:"Enable,omitempty"`
+	ClientOUIdentifier  *OrganizationalUnitIdentifiersConfiguration `yaml:"ClientOUIdentifier,omitempty"`
+	PeerOUIdentifier    *OrganizationalUnitIdentifiersConfiguration `yaml:"PeerOUIdentifier,omitempty"`
+	OrdererOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"OrdererOUIdentifier,omitempty"`
+	AdminOUIdentifier   *OrganizationalUnitIdentifiersConfiguration `yaml:"AdminOUIdentifier,omitempty"`
+}
+
 type Configuration struct {
 	OrganizationalUnitIdentifiers []*OrganizationalUnitIdentifiersConfiguration `yaml:"OrganizationalUnitIdentifiers,omitempty"`
+	NodeOUs                       *NodeOUs                                      `yaml:"NodeOUs,omitempty"`
 }
 
 func readFile(file string) ([]byte, error) {
@@ -121,11 +133,14 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 			bccspConfig.SwOpts = factory.GetDefaultOpts().SwOpts
 		}
 
-		// Only override the KeyStorePath if it was left empty
-		if bccspConfig.SwOpts.FileKeystore == nil ||
-			bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+		// Only override the KeyStorePath if it was left empty,
+		// keeping the password options of the keystore
+		if bccspConfig.SwOpts.FileKeystore == nil {
+			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{}
+		}
+		if bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
 			bccspConfig.SwOpts.Ephemeral = false
-			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+			bccspConfig.SwOpts.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -216,6 +231,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	// if the configuration file is there then load it
 	// otherwise skip it
 	var ouis []*msp.FabricOUIdentifier
+	var nodeOUs *msp.FabricNodeOUs
 	_, err = os.Stat(configFile)
 	if err == nil {
 		// load the file, if there is a failure in loading it then
@@ -246,6 +262,23 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 				ouis = append(ouis, oui)
 			}
 		}
+
+		// Prepare NodeOUs
+		if configuration.NodeOUs != nil && configuration.NodeOUs.Enable {
+			nodeOUs = &msp.FabricNodeOUs{Enable: true}
+			if nodeOUs.ClientOuIdentifier, err = getNodeOUIdentifier(dir, configuration.NodeOUs.ClientOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.PeerOuIdentifier, err = getNodeOUIdentifier(dir, configuration.NodeOUs.PeerOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.OrdererOuIdentifier, err = getNodeOUIdentifier(dir, configuration.NodeOUs.OrdererOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.AdminOuIdentifier, err = getNodeOUIdentifier(dir, configuration.NodeOUs.AdminOUIdentifier); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		mspLogger.Debugf("MSP configuration file not found at [%s]: [%s]", configFile, err)
 	}
@@ -268,6 +301,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		CryptoConfig:                  cryptoConfig,
 		TlsRootCerts:                  tlsCACerts,
 		TlsIntermediateCerts:          tlsIntermediateCerts,
+		FabricNodeOus:                 nodeOUs,
 	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
@@ -276,3 +310,94 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 
 	return mspconf, nil
 }
+
+// getNodeOUIdentifier loads the given node OU identifier,
+// whose certificate is optional
+func getNodeOUIdentifier(dir string, ouID *OrganizationalUnitIdentifiersConfiguration) (*msp.FabricOUIdentifier, error) {
+	if ouID == nil {
+		return nil, nil
+	}
+
+	oui := &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: ouID.OrganizationalUnitIdentifier}
+	if ouID.Certificate != "" {
+		f := filepath.Join(dir, ouID.Certificate)
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading NodeOUs certificate at [%s]: [%s]", f, err)
+		}
+		oui.Certificate = raw
+	}
+
+	return oui, nil
+}
+
+const (
+	// IdemixConfigDirMsp is the directory of an idemix MSP
+	// configuration containing the issuer public key
+	IdemixConfigDirMsp = "msp"
+
+	// IdemixConfigDirUser is the directory of an idemix MSP
+	// configuration containing the (optional) signer config
+	IdemixConfigDirUser = "user"
+
+	// IdemixConfigFileIssuerPublicKey is the file name of the
+	// serialized issuer public key
+	IdemixConfigFileIssuerPublicKey = "IssuerPublicKey"
+
+	// IdemixConfigFileSigner is the file name of the
+	// serialized signer config
+	IdemixConfigFileSigner = "SignerConfig"
+)
+
+// GetIdemixMspConfig returns the configuration for the Idemix MSP
+// whose issuer public key and optional signer config are stored
+// in the given directory
+func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
+	return getIdemixMspConfig(dir, ID, true)
+}
+
+// GetVerifyingIdemixMspConfig returns the configuration for the Idemix
+// MSP whose issuer public key is stored in the given directory, leaving
+// out any signer config
+func GetVerifyingIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
+	return getIdemixMspConfig(dir, ID, false)
+}
+
+func getIdemixMspConfig(dir string, ID string, withSigner bool) (*msp.MSPConfig, error) {
+	ipkBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileIssuerPublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	idemixConfig := &msp.IdemixMSPConfig{
+		Name: ID,
+		Ipk:  ipkBytes,
+	}
+
+	if !withSigner {
+		return marshalIdemixMspConfig(idemixConfig)
+	}
+
+	signerBytes, err := ioutil.ReadFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
+	if err == nil {
+		signerConfig := &msp.IdemixMSPSignerConfig{}
+		err = proto.Unmarshal(signerBytes, signerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal the signer config: %s", err)
+		}
+		idemixConfig.Signer = signerConfig
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Failed to read the signer config: %s", err)
+	}
+
+	return marshalIdemixMspConfig(idemixConfig)
+}
+
+func marshalIdemixMspConfig(idemixConfig *msp.IdemixMSPConfig) (*msp.MSPConfig, error) {
+	confBytes, err := proto.Marshal(idemixConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &msp.MSPConfig{Config: confBytes, Type: int32(IDEMIX)}, nil
+}
@@ -19,6 +19,7 @@ package msp
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,3 +45,23 @@ func TestReadFileUtils(t *testing.T) {
 	_, err = readPemFile("/dev/null")
 	assert.Error(t, err)
 }
+
+func TestSetupBCCSPKeystoreConfig(t *testing.T) {
+	conf := SetupBCCSPKeystoreConfig(nil, "/msp/keystore")
+	assert.Equal(t, "/msp/keystore", conf.SwOpts.FileKeystore.KeyStorePath)
+
+	// the password options of the keystore are kept
+	conf = &factory.FactoryOpts{
+		ProviderName: "SW",
+		SwOpts: &factory.SwOpts{
+			FileKeystore: &factory.FileKeystoreOpts{PasswordEnv: "KEYSTORE_PASSWORD"},
+		},
+	}
+	conf = SetupBCCSPKeystoreConfig(conf, "/msp/keystore")
+	assert.Equal(t, "/msp/keystore", conf.SwOpts.FileKeystore.KeyStorePath)
+	assert.Equal(t, "KEYSTORE_PASSWORD", conf.SwOpts.FileKeystore.PasswordEnv)
+
+	// and an explicit keystore path is not overridden
+	conf = SetupBCCSPKeystoreConfig(conf, "/other/keystore")
+	assert.Equal(t, "/msp/keystore", conf.SwOpts.FileKeystore.KeyStorePath)
+}
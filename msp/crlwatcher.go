@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Online revocation lists are certificate revocation lists obtained outside
+// of the MSP configurations, from files or directories watched by the node,
+// so that revocations take effect without a channel configuration update.
+// They are kept by MSP identifier and apply to all the MSP instances with
+// that identifier, in addition to the revocation lists of their configuration.
+// As for the latter, a revocation list only applies to the certificates
+// issued by the CA of the MSP which signed it.
+var onlineCRLs = &onlineRevocationLists{byMSP: make(map[string]*mspRevocationLists)}
+
+type onlineRevocationLists struct {
+	sync.RWMutex
+	byMSP   map[string]*mspRevocationLists
+	version uint64
+}
+
+type mspRevocationLists struct {
+	crls    []*pkix.CertificateList
+	version uint64
+}
+
+// SetOnlineRevocationLists replaces the online revocation lists of the MSPs
+// with identifier mspID by the given DER or PEM encoded CRLs
+func SetOnlineRevocationLists(mspID string, crls [][]byte) error {
+	parsed := make([]*pkix.CertificateList, len(crls))
+	for i, crlBytes := range crls {
+		crl, err := x509.ParseCRL(crlBytes)
+		if err != nil {
+			return fmt.Errorf("Could not parse revocation list of MSP %s, err %s", mspID, err)
+		}
+		if _, err := getAuthorityKeyIdentifierFromCrl(crl); err != nil {
+			return fmt.Errorf("Could not obtain Authority Key Identifier for revocation list of MSP %s, err %s", mspID, err)
+		}
+		parsed[i] = crl
+	}
+
+	onlineCRLs.Lock()
+	defer onlineCRLs.Unlock()
+	onlineCRLs.version++
+	onlineCRLs.byMSP[mspID] = &mspRevocationLists{crls: parsed, version: onlineCRLs.version}
+	return nil
+}
+
+// OnlineRevocationListsVersion returns a number which changes every time
+// the online revocation lists of the MSPs with identifier mspID are replaced
+func OnlineRevocationListsVersion(mspID string) uint64 {
+	onlineCRLs.RLock()
+	defer onlineCRLs.RUnlock()
+	if lists, exists := onlineCRLs.byMSP[mspID]; exists {
+		return lists.version
+	}
+	return 0
+}
+
+func getOnlineRevocationLists(mspID string) []*pkix.CertificateList {
+	onlineCRLs.RLock()
+	defer onlineCRLs.RUnlock()
+	if lists, exists := onlineCRLs.byMSP[mspID]; exists {
+		return lists.crls
+	}
+	return nil
+}
+
+// RevocationListWatcher loads the online revocation lists of MSPs from files
+// or directories, and reloads them whenever their content changes
+type RevocationListWatcher struct {
+	// files or directories holding the revocation lists, by MSP identifier
+	sources  map[string][]string
+	interval time.Duration
+	// the revocation lists last loaded, by MSP identifier
+	loaded map[string][][]byte
+	stop   chan struct{}
+}
+
+// NewRevocationListWatcher returns a watcher loading the online revocation
+// lists of each MSP identifier from the given files or directories, every
+// interval. The files hold one or more PEM encoded CRLs, or a DER encoded
+// CRL. A zero interval disables the reloading.
+func NewRevocationListWatcher(sources map[string][]string, interval time.Duration) *RevocationListWatcher {
+	return &RevocationListWatcher{
+		sources:  sources,
+		interval: interval,
+		loaded:   make(map[string][][]byte),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start loads the revocation lists, failing if any of them cannot be
+// loaded, and then keeps reloading them until Stop is called. A revocation
+// list which cannot be reloaded is logged and the previous one kept in use.
+func (w *RevocationListWatcher) Start() error {
+	for _, mspID := range w.mspIDs() {
+		if err := w.load(mspID); err != nil {
+			return err
+		}
+	}
+	if w.interval <= 0 || len(w.sources) == 0 {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, mspID := range w.mspIDs() {
+					if err := w.load(mspID); err != nil {
+						mspLogger.Warningf("Failed reloading the revocation lists of MSP %s, keeping the previous ones: %s", mspID, err)
+					}
+				}
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops reloading the revocation lists, which remain in use
+func (w *RevocationListWatcher) Stop() {
+	close(w.stop)
+}
+
+func (w *RevocationListWatcher) mspIDs() []string {
+	mspIDs := make([]string, 0, len(w.sources))
+	for mspID := range w.sources {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	return mspIDs
+}
+
+// load reads the revocation lists of mspID and applies them if they changed
+func (w *RevocationListWatcher) load(mspID string) error {
+	var crls [][]byte
+	for _, source := range w.sources[mspID] {
+		sourceCRLs, err := readRevocationLists(source)
+		if err != nil {
+			return err
+		}
+		crls = append(crls, sourceCRLs...)
+	}
+
+	if previous, exists := w.loaded[mspID]; exists && equalRevocationLists(previous, crls) {
+		return nil
+	}
+	if err := SetOnlineRevocationLists(mspID, crls); err != nil {
+		return err
+	}
+	w.loaded[mspID] = crls
+	mspLogger.Infof("Loaded %d revocation lists for MSP %s", len(crls), mspID)
+	return nil
+}
+
+// readRevocationLists reads the CRLs of a file, or of the files of a directory
+func readRevocationLists(source string) ([][]byte, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("Could not access revocation lists at %s, err %s", source, err)
+	}
+	if !info.IsDir() {
+		return readRevocationListFile(source)
+	}
+
+	files, err := ioutil.ReadDir(source)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read directory %s, err %s", source, err)
+	}
+	var crls [][]byte
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		fileCRLs, err := readRevocationListFile(filepath.Join(source, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, fileCRLs...)
+	}
+	return crls, nil
+}
+
+func readRevocationListFile(file string) ([][]byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read revocation list file %s, err %s", file, err)
+	}
+
+	var crls [][]byte
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		crls = append(crls, block.Bytes)
+	}
+	if len(crls) == 0 {
+		// not PEM encoded, the file holds a DER encoded CRL
+		crls = append(crls, data)
+	}
+	for _, crl := range crls {
+		if _, err := x509.ParseDERCRL(crl); err != nil {
+			return nil, fmt.Errorf("Could not parse revocation list file %s, err %s", file, err)
+		}
+	}
+	return crls, nil
+}
+
+func equalRevocationLists(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+type crlTestCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newCRLTestCA(t *testing.T, ski []byte) *crlTestCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		SubjectKeyId:          ski,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &crlTestCA{cert: cert, key: key}
+}
+
+func (ca *crlTestCA) issue(t *testing.T, serial int64) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "peer0"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func (ca *crlTestCA) revoke(t *testing.T, serials ...int64) []byte {
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+	for _, serial := range serials {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries,
+			x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.cert, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func setupCRLTestMSP(t *testing.T, mspID string, ca *crlTestCA) MSP {
+	fabricConfig := &msp.FabricMSPConfig{
+		Name:      mspID,
+		RootCerts: [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})},
+	}
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	err = thisMSP.Setup(&msp.MSPConfig{Config: marshalOrPanic(fabricConfig)})
+	assert.NoError(t, err)
+	return thisMSP
+}
+
+func deserializeCRLTestIdentity(t *testing.T, thisMSP MSP, mspID string, certPEM []byte) Identity {
+	serialized, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: certPEM})
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	return id
+}
+
+func marshalOrPanic(pb proto.Message) []byte {
+	b, err := proto.Marshal(pb)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestOnlineRevocationLists(t *testing.T) {
+	mspID := "OnlineCRLMSP"
+	defer SetOnlineRevocationLists(mspID, nil)
+
+	ca := newCRLTestCA(t, []byte{1, 2, 3, 4})
+	thisMSP := setupCRLTestMSP(t, mspID, ca)
+	revoked := deserializeCRLTestIdentity(t, thisMSP, mspID, ca.issue(t, 10))
+	other := deserializeCRLTestIdentity(t, thisMSP, mspID, ca.issue(t, 11))
+	assert.NoError(t, thisMSP.Validate(revoked))
+
+	dir, err := ioutil.TempDir("", "crls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "crl.pem"), ca.revoke(t, 10), 0644))
+
+	version := OnlineRevocationListsVersion(mspID)
+	watcher := NewRevocationListWatcher(map[string][]string{mspID: {dir}}, 0)
+	assert.NoError(t, watcher.Start())
+	assert.NotEqual(t, version, OnlineRevocationListsVersion(mspID))
+	assert.Error(t, thisMSP.Validate(revoked), "The identity revoked online should be invalid")
+	assert.NoError(t, thisMSP.Validate(other))
+
+	// the online revocation lists apply to the MSP instances set up later,
+	// such as those of a channel configuration update
+	newMSP := setupCRLTestMSP(t, mspID, ca)
+	assert.Error(t, newMSP.Validate(deserializeCRLTestIdentity(t, newMSP, mspID, ca.issue(t, 10))))
+
+	// and not to the MSPs with another identifier
+	otherMSP := setupCRLTestMSP(t, "OtherMSP", ca)
+	assert.NoError(t, otherMSP.Validate(deserializeCRLTestIdentity(t, otherMSP, "OtherMSP", ca.issue(t, 10))))
+
+	// a revocation list not signed by the CA of the MSP is ignored
+	impostor := newCRLTestCA(t, []byte{1, 2, 3, 4})
+	assert.NoError(t, SetOnlineRevocationLists(mspID, [][]byte{impostor.revoke(t, 10)}))
+	assert.NoError(t, thisMSP.Validate(revoked), "A CRL with an invalid signature should be ignored")
+
+	assert.Error(t, SetOnlineRevocationLists(mspID, [][]byte{[]byte("not a CRL")}))
+}
+
+func TestRevocationListWatcherReload(t *testing.T) {
+	mspID := "ReloadCRLMSP"
+	defer SetOnlineRevocationLists(mspID, nil)
+
+	ca := newCRLTestCA(t, []byte{5, 6, 7, 8})
+	thisMSP := setupCRLTestMSP(t, mspID, ca)
+	id := deserializeCRLTestIdentity(t, thisMSP, mspID, ca.issue(t, 20))
+
+	dir, err := ioutil.TempDir("", "crls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	crlFile := filepath.Join(dir, "crl.pem")
+	assert.NoError(t, ioutil.WriteFile(crlFile, ca.revoke(t), 0644))
+
+	watcher := NewRevocationListWatcher(map[string][]string{mspID: {crlFile}}, 10*time.Millisecond)
+	assert.NoError(t, watcher.Start())
+	defer watcher.Stop()
+	assert.NoError(t, thisMSP.Validate(id))
+
+	waitFor := func(cond func() bool) bool {
+		for i := 0; i < 200; i++ {
+			if cond() {
+				return true
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return false
+	}
+
+	// a DER encoded CRL revoking the identity is picked up
+	crlBlock, _ := pem.Decode(ca.revoke(t, 20))
+	assert.NoError(t, ioutil.WriteFile(crlFile, crlBlock.Bytes, 0644))
+	assert.True(t, waitFor(func() bool { return thisMSP.Validate(id) != nil }), "The revocation should have been picked up")
+
+	// a broken file leaves the previous revocation lists in use
+	assert.NoError(t, ioutil.WriteFile(crlFile, []byte("garbage"), 0644))
+	time.Sleep(50 * time.Millisecond)
+	assert.Error(t, thisMSP.Validate(id))
+
+	assert.NoError(t, ioutil.WriteFile(crlFile, ca.revoke(t), 0644))
+	assert.True(t, waitFor(func() bool { return thisMSP.Validate(id) == nil }), "The new revocation list should have been picked up")
+}
+
+func TestRevocationListWatcherErrors(t *testing.T) {
+	watcher := NewRevocationListWatcher(map[string][]string{"ErrMSP": {"/nonexistent/crls"}}, 0)
+	assert.Error(t, watcher.Start())
+
+	dir, err := ioutil.TempDir("", "crls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "crl.pem"), []byte("not a CRL"), 0644))
+	watcher = NewRevocationListWatcher(map[string][]string{"ErrMSP": {dir}}, 0)
+	assert.Error(t, watcher.Start())
+}
@@ -0,0 +1,552 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/manudrijvers/amcl/go"
+	"github.com/op/go-logging"
+)
+
+// The attributes certified by the issuer of an idemix MSP,
+// in the order in which they appear in the issuer public key
+const (
+	// AttributeNameOU is the attribute name of the Organization Unit attribute
+	AttributeNameOU = "OU"
+
+	// AttributeNameRole is the attribute name of the Role attribute
+	AttributeNameRole = "Role"
+
+	// AttributeNameEnrollmentId is the attribute name of the Enrollment ID attribute
+	AttributeNameEnrollmentId = "EnrollmentID"
+)
+
+// index of the attributes in the credential
+const (
+	attributeIndexOU = iota
+	attributeIndexRole
+	attributeIndexEnrollmentId
+)
+
+// IdemixAttributeNames are the attribute names that the issuer
+// public key of an idemix MSP must define
+var IdemixAttributeNames = []string{AttributeNameOU, AttributeNameRole, AttributeNameEnrollmentId}
+
+// IdemixAttributeValues returns the encoding of the attributes certified
+// in the credential of an idemix identity
+func IdemixAttributeValues(ou string, role int32, enrollmentID string) []*amcl.BIG {
+	values := make([]*amcl.BIG, len(IdemixAttributeNames))
+	values[attributeIndexOU] = idemix.HashModOrder([]byte(ou))
+	values[attributeIndexRole] = amcl.NewBIGint(int(role))
+	values[attributeIndexEnrollmentId] = idemix.HashModOrder([]byte(enrollmentID))
+	return values
+}
+
+// This is an instantiation of an MSP that uses anonymous
+// credentials (identity mixer) instead of X.509 certificates.
+// Identities are unlinkable pseudonyms that prove possession of
+// a credential of the issuer, and may disclose their OU and role.
+type idemixmsp struct {
+	// the issuer public key the credentials are checked against
+	ipk *idemix.IssuerPublicKey
+
+	// the crypto material of the default signer (if any)
+	signer *m.IdemixMSPSignerConfig
+
+	// the name of this MSP
+	name string
+}
+
+// NewIdemixMsp returns an MSP instance backed by
+// anonymous credentials of an idemix issuer
+func NewIdemixMsp() (MSP, error) {
+	mspLogger.Debugf("Creating Idemix-based MSP instance")
+
+	return &idemixmsp{}, nil
+}
+
+// Setup sets up the internal data structures
+// for this MSP, given an MSPConfig ref; it
+// returns nil in case of success or an error otherwise
+func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
+	if conf1 == nil {
+		return fmt.Errorf("Setup error: nil conf reference")
+	}
+
+	if conf1.Type != int32(IDEMIX) {
+		return fmt.Errorf("Setup error: config is not of type IDEMIX")
+	}
+
+	conf := &m.IdemixMSPConfig{}
+	err := proto.Unmarshal(conf1.Config, conf)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling idemix msp config, err %s", err)
+	}
+
+	msp.name = conf.Name
+	mspLogger.Debugf("Setting up Idemix MSP instance %s", msp.name)
+
+	// setup the issuer public key
+	ipk := &idemix.IssuerPublicKey{}
+	err = proto.Unmarshal(conf.Ipk, ipk)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal the issuer public key, err %s", err)
+	}
+	if err = ipk.Check(); err != nil {
+		return fmt.Errorf("Invalid issuer public key, err %s", err)
+	}
+	if len(ipk.AttributeNames) != len(IdemixAttributeNames) {
+		return fmt.Errorf("Issuer public key must have %d attributes, got %d", len(IdemixAttributeNames), len(ipk.AttributeNames))
+	}
+	for i, name := range IdemixAttributeNames {
+		if ipk.AttributeNames[i] != name {
+			return fmt.Errorf("Issuer public key must have attribute %s at position %d, got %s", name, i, ipk.AttributeNames[i])
+		}
+	}
+	msp.ipk = ipk
+
+	// setup the signer (if present)
+	if conf.Signer == nil {
+		mspLogger.Debug("idemix msp setup as verification only msp (no key material found)")
+		return nil
+	}
+
+	cred := &idemix.Credential{}
+	err = proto.Unmarshal(conf.Signer.Cred, cred)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal the credential of the default signer, err %s", err)
+	}
+	sk, err := idemix.BigFromBytes(conf.Signer.Sk)
+	if err != nil {
+		return fmt.Errorf("Invalid secret key of the default signer, err %s", err)
+	}
+
+	// the credential must certify the attributes of the signer config
+	values := IdemixAttributeValues(conf.Signer.OrganizationalUnitIdentifier, conf.Signer.Role, conf.Signer.EnrollmentId)
+	if len(cred.Attrs) != len(values) {
+		return fmt.Errorf("Credential of the default signer has %d attributes, expected %d", len(cred.Attrs), len(values))
+	}
+	for i, value := range values {
+		if !bytes.Equal(cred.Attrs[i], idemix.BigToBytes(value)) {
+			return fmt.Errorf("Credential of the default signer does not contain the configured %s", IdemixAttributeNames[i])
+		}
+	}
+	if err = cred.Ver(sk, msp.ipk); err != nil {
+		return fmt.Errorf("Credential of the default signer is not valid, err %s", err)
+	}
+
+	msp.signer = conf.Signer
+
+	return nil
+}
+
+// GetType returns the type for this MSP
+func (msp *idemixmsp) GetType() ProviderType {
+	return IDEMIX
+}
+
+// GetIdentifier returns the MSP identifier for this instance
+func (msp *idemixmsp) GetIdentifier() (string, error) {
+	return msp.name, nil
+}
+
+// GetTLSRootCerts returns nil as idemix MSPs do not
+// handle TLS certificates
+func (msp *idemixmsp) GetTLSRootCerts() [][]byte {
+	return nil
+}
+
+// GetTLSIntermediateCerts returns nil as idemix MSPs do not
+// handle TLS certificates
+func (msp *idemixmsp) GetTLSIntermediateCerts() [][]byte {
+	return nil
+}
+
+// GetDefaultSigningIdentity returns a default signing identity
+// for this MSP (if any). Each call returns a signing identity with
+// a fresh pseudonym, which is unlinkable to the previous ones
+func (msp *idemixmsp) GetDefaultSigningIdentity() (SigningIdentity, error) {
+	mspLogger.Debugf("Obtaining default idemix signing identity")
+
+	if msp.signer == nil {
+		return nil, fmt.Errorf("This MSP does not possess a valid default signing identity")
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &idemix.Credential{}
+	err = proto.Unmarshal(msp.signer.Cred, cred)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal the credential of the default signer, err %s", err)
+	}
+	sk, err := idemix.BigFromBytes(msp.signer.Sk)
+	if err != nil {
+		return nil, err
+	}
+
+	Nym, RNym, err := idemix.MakeNym(sk, msp.ipk, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosure := make([]byte, len(IdemixAttributeNames))
+	ou, role := "", int32(0)
+	if msp.signer.DiscloseOu {
+		disclosure[attributeIndexOU] = 1
+		ou = msp.signer.OrganizationalUnitIdentifier
+	}
+	if msp.signer.DiscloseRole {
+		disclosure[attributeIndexRole] = 1
+		role = msp.signer.Role
+	}
+
+	// prove that the pseudonym belongs to a
+	// credential issued by the issuer of this MSP
+	sig, err := idemix.NewSignature(cred, sk, Nym, RNym, msp.ipk, disclosure, nil, rng)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the proof of the signing identity, err %s", err)
+	}
+	proof, err := proto.Marshal(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	id := newIdemixIdentity(msp, idemix.EcpToBytes(Nym), ou, role, disclosure, proof)
+
+	return &idemixSigningIdentity{idemixidentity: id, sk: msp.signer.Sk, rNym: idemix.BigToBytes(RNym)}, nil
+}
+
+// GetSigningIdentity returns a specific signing
+// identity identified by the supplied identifier
+func (msp *idemixmsp) GetSigningIdentity(identifier *IdentityIdentifier) (SigningIdentity, error) {
+	return nil, fmt.Errorf("No signing identity for %#v", identifier)
+}
+
+// Validate attempts to determine whether the supplied identity
+// holds a valid credential of the issuer of this MSP; it returns
+// nil in case the identity is valid or an error otherwise
+func (msp *idemixmsp) Validate(id Identity) error {
+	mspLogger.Debugf("MSP %s validating identity", msp.name)
+
+	switch id := id.(type) {
+	case *idemixidentity:
+		return msp.validateIdemixIdentity(id)
+	case *idemixSigningIdentity:
+		return msp.validateIdemixIdentity(id.idemixidentity)
+	default:
+		return fmt.Errorf("Identity type not recognized")
+	}
+}
+
+func (msp *idemixmsp) validateIdemixIdentity(id *idemixidentity) error {
+	if id.GetMSPIdentifier() != msp.name {
+		return fmt.Errorf("The supplied identity does not belong to this msp")
+	}
+
+	sig := &idemix.Signature{}
+	err := proto.Unmarshal(id.proof, sig)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal the proof of the identity, err %s", err)
+	}
+
+	// the proof must be for the pseudonym of the identity
+	if !bytes.Equal(sig.Nym, id.nym) {
+		return errors.New("The proof of the identity is not for its pseudonym")
+	}
+
+	if len(id.disclosure) != len(IdemixAttributeNames) || id.disclosure[attributeIndexEnrollmentId] != 0 {
+		return errors.New("The identity discloses an invalid set of attributes")
+	}
+	values := IdemixAttributeValues(id.ou, id.role, "")
+	values[attributeIndexEnrollmentId] = nil
+	for i, disclosed := range id.disclosure {
+		if disclosed == 0 {
+			values[i] = nil
+		}
+	}
+
+	err = sig.Ver(id.disclosure, msp.ipk, nil, values)
+	if err != nil {
+		return fmt.Errorf("The identity does not have a valid credential, err %s", err)
+	}
+
+	return nil
+}
+
+// DeserializeIdentity returns an Identity given the byte-level
+// representation of a SerializedIdentity struct
+func (msp *idemixmsp) DeserializeIdentity(serializedID []byte) (Identity, error) {
+	mspLogger.Infof("Obtaining idemix identity")
+
+	sId := &m.SerializedIdentity{}
+	err := proto.Unmarshal(serializedID, sId)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deserialize a SerializedIdentity, err %s", err)
+	}
+
+	if sId.Mspid != msp.name {
+		return nil, fmt.Errorf("Expected MSP ID %s, received %s", msp.name, sId.Mspid)
+	}
+
+	serialized := &m.SerializedIdemixIdentity{}
+	err = proto.Unmarshal(sId.IdBytes, serialized)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deserialize a SerializedIdemixIdentity, err %s", err)
+	}
+
+	if _, err = idemix.EcpFromBytes(serialized.Nym); err != nil {
+		return nil, fmt.Errorf("Invalid pseudonym of the identity, err %s", err)
+	}
+
+	if len(serialized.Disclosure) != len(IdemixAttributeNames) {
+		return nil, fmt.Errorf("Invalid disclosure of the identity, expected %d attributes, got %d", len(IdemixAttributeNames), len(serialized.Disclosure))
+	}
+
+	return newIdemixIdentity(msp, serialized.Nym, serialized.Ou, serialized.Role, serialized.Disclosure, serialized.Proof), nil
+}
+
+// SatisfiesPrincipal checks whether the identity matches
+// the description supplied in MSPPrincipal. Roles and
+// organizational units can only be checked if the identity
+// discloses them
+func (msp *idemixmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal) error {
+	idemixId, ok := id.(*idemixidentity)
+	if !ok {
+		signingId, ok := id.(*idemixSigningIdentity)
+		if !ok {
+			return fmt.Errorf("Identity type not recognized")
+		}
+		idemixId = signingId.idemixidentity
+	}
+
+	switch principal.PrincipalClassification {
+	case m.MSPPrincipal_ROLE:
+		mspRole := &m.MSPRole{}
+		err := proto.Unmarshal(principal.Principal, mspRole)
+		if err != nil {
+			return fmt.Errorf("Could not unmarshal MSPRole from principal, err %s", err)
+		}
+
+		if mspRole.MspIdentifier != msp.name {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", mspRole.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		switch mspRole.Role {
+		case m.MSPRole_MEMBER:
+			mspLogger.Debugf("Checking if identity satisfies MEMBER role for %s", msp.name)
+			return msp.Validate(idemixId)
+		case m.MSPRole_ADMIN, m.MSPRole_CLIENT, m.MSPRole_PEER, m.MSPRole_ORDERER:
+			mspLogger.Debugf("Checking if identity satisfies %s role for %s", mspRole.Role, msp.name)
+			if len(idemixId.disclosure) <= attributeIndexRole || idemixId.disclosure[attributeIndexRole] == 0 {
+				return errors.New("The identity does not disclose its role")
+			}
+			if idemixId.role != int32(mspRole.Role) {
+				return fmt.Errorf("The identity is not of role %s", mspRole.Role)
+			}
+			return msp.Validate(idemixId)
+		default:
+			return fmt.Errorf("Invalid MSP role type %d", int32(mspRole.Role))
+		}
+	case m.MSPPrincipal_IDENTITY:
+		principalId, err := msp.DeserializeIdentity(principal.Principal)
+		if err != nil {
+			return fmt.Errorf("Invalid identity principal, not an idemix identity. Error %s", err)
+		}
+
+		if bytes.Equal(idemixId.nym, principalId.(*idemixidentity).nym) {
+			return principalId.Validate()
+		}
+
+		return errors.New("The identities do not match")
+	case m.MSPPrincipal_ORGANIZATION_UNIT:
+		OU := &m.OrganizationUnit{}
+		err := proto.Unmarshal(principal.Principal, OU)
+		if err != nil {
+			return fmt.Errorf("Could not unmarshal OrganizationUnit from principal, err %s", err)
+		}
+
+		if OU.MspIdentifier != msp.name {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", OU.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		err = msp.Validate(idemixId)
+		if err != nil {
+			return err
+		}
+
+		for _, ou := range idemixId.GetOrganizationalUnits() {
+			if ou.OrganizationalUnitIdentifier == OU.OrganizationalUnitIdentifier &&
+				bytes.Equal(ou.CertifiersIdentifier, OU.CertifiersIdentifier) {
+				return nil
+			}
+		}
+
+		return errors.New("The identities do not match")
+	default:
+		return fmt.Errorf("Invalid principal type %d", int32(principal.PrincipalClassification))
+	}
+}
+
+type idemixidentity struct {
+	// the serialized pseudonym of this identity
+	nym []byte
+
+	// the disclosed organizational unit and role
+	ou   string
+	role int32
+
+	// which attributes of the credential are disclosed
+	disclosure []byte
+
+	// the serialized idemix signature proving that the
+	// pseudonym belongs to a credential of the issuer
+	proof []byte
+
+	id *IdentityIdentifier
+
+	msp *idemixmsp
+}
+
+func newIdemixIdentity(msp *idemixmsp, nym []byte, ou string, role int32, disclosure []byte, proof []byte) *idemixidentity {
+	digest := sha256.Sum256(nym)
+	id := &IdentityIdentifier{Mspid: msp.name, Id: hex.EncodeToString(digest[:])}
+
+	return &idemixidentity{nym: nym, ou: ou, role: role, disclosure: disclosure, proof: proof, id: id, msp: msp}
+}
+
+// GetIdentifier returns the identifier (MSPID/IDID) for this instance
+func (id *idemixidentity) GetIdentifier() *IdentityIdentifier {
+	return id.id
+}
+
+// GetMSPIdentifier returns the MSP identifier for this instance
+func (id *idemixidentity) GetMSPIdentifier() string {
+	return id.id.Mspid
+}
+
+// Validate returns nil if this instance is a valid identity or an error otherwise
+func (id *idemixidentity) Validate() error {
+	return id.msp.Validate(id)
+}
+
+// GetOrganizationalUnits returns the OU of this instance if it is disclosed.
+// Its certifiers identifier is the hash of the issuer public key
+func (id *idemixidentity) GetOrganizationalUnits() []*OUIdentifier {
+	if len(id.disclosure) <= attributeIndexOU || id.disclosure[attributeIndexOU] == 0 {
+		return nil
+	}
+
+	return []*OUIdentifier{{CertifiersIdentifier: id.msp.ipk.Hash, OrganizationalUnitIdentifier: id.ou}}
+}
+
+// Verify checks against a signature and a message
+// to determine whether this identity produced the
+// signature; it returns nil if so or an error otherwise
+func (id *idemixidentity) Verify(msg []byte, sig []byte) error {
+	if mspIdentityLogger.IsEnabledFor(logging.DEBUG) {
+		mspIdentityLogger.Debugf("Verify: idemix signature = %s", hex.Dump(sig))
+	}
+
+	nym, err := idemix.EcpFromBytes(id.nym)
+	if err != nil {
+		return err
+	}
+
+	nymSig := &idemix.NymSignature{}
+	err = proto.Unmarshal(sig, nymSig)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal the pseudonym signature, err %s", err)
+	}
+
+	return nymSig.Ver(nym, id.msp.ipk, msg)
+}
+
+// SatisfiesPrincipal checks whether this instance matches
+// the description supplied in MSPPrincipal
+func (id *idemixidentity) SatisfiesPrincipal(principal *m.MSPPrincipal) error {
+	return id.msp.SatisfiesPrincipal(id, principal)
+}
+
+// Serialize returns a byte array representation of this identity
+func (id *idemixidentity) Serialize() ([]byte, error) {
+	serialized := &m.SerializedIdemixIdentity{
+		Nym:        id.nym,
+		Ou:         id.ou,
+		Role:       id.role,
+		Disclosure: id.disclosure,
+		Proof:      id.proof,
+	}
+	idBytes, err := proto.Marshal(serialized)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal a SerializedIdemixIdentity, err %s", err)
+	}
+
+	sId := &m.SerializedIdentity{Mspid: id.id.Mspid, IdBytes: idBytes}
+	idBytes, err = proto.Marshal(sId)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal a SerializedIdentity structure for identity %s, err %s", id.id, err)
+	}
+
+	return idBytes, nil
+}
+
+type idemixSigningIdentity struct {
+	*idemixidentity
+
+	// the secret key of the credential
+	sk []byte
+
+	// the randomness of the pseudonym
+	rNym []byte
+}
+
+// Sign produces a signature over msg, signed by the pseudonym of this instance
+func (id *idemixSigningIdentity) Sign(msg []byte) ([]byte, error) {
+	mspIdentityLogger.Debugf("Idemix identity %s is signing", id.id)
+
+	sk, err := idemix.BigFromBytes(id.sk)
+	if err != nil {
+		return nil, err
+	}
+	rNym, err := idemix.BigFromBytes(id.rNym)
+	if err != nil {
+		return nil, err
+	}
+	nym, err := idemix.EcpFromBytes(id.nym)
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := idemix.NewNymSignature(sk, nym, rNym, id.msp.ipk, msg, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(sig)
+}
+
+// GetPublicVersion returns the public version of this identity,
+// namely, the one that is only able to verify messages and not sign them
+func (id *idemixSigningIdentity) GetPublicVersion() Identity {
+	return id.idemixidentity
+}
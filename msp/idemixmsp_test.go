@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupIdemixMSP(t *testing.T, dir string, ID string) MSP {
+	conf, err := GetIdemixMspConfig(dir, ID)
+	assert.NoError(t, err)
+
+	idemixMsp, err := NewIdemixMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, idemixMsp.Setup(conf))
+	assert.Equal(t, IDEMIX, idemixMsp.GetType())
+
+	return idemixMsp
+}
+
+func getIdemixSigner(t *testing.T, idemixMsp MSP) SigningIdentity {
+	id, err := idemixMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	return id
+}
+
+func TestIdemixSetup(t *testing.T) {
+	idemixMsp, err := NewIdemixMsp()
+	assert.NoError(t, err)
+
+	assert.Error(t, idemixMsp.Setup(nil))
+
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	conf.Type = int32(FABRIC)
+	assert.Error(t, idemixMsp.Setup(conf), "Setup should fail for a non-idemix config")
+
+	_, err = GetIdemixMspConfig("testdata/idemix/nonexistent", "MSP1")
+	assert.Error(t, err)
+
+	// an issuer public key with a broken proof is rejected
+	conf, err = GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	idemixConf := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixConf))
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(idemixConf.Ipk, ipk))
+	ipk.ProofS = idemix.BigToBytes(idemix.HashModOrder([]byte("tampered")))
+	idemixConf.Ipk, _ = proto.Marshal(ipk)
+	conf.Config, _ = proto.Marshal(idemixConf)
+	assert.Error(t, idemixMsp.Setup(conf), "Setup should fail for an invalid issuer public key")
+
+	// a signer whose credential certifies another OU is rejected
+	conf, err = GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	idemixConf = &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixConf))
+	idemixConf.Signer.OrganizationalUnitIdentifier = "OU2"
+	conf.Config, _ = proto.Marshal(idemixConf)
+	assert.Error(t, idemixMsp.Setup(conf), "Setup should fail for a signer with a different OU")
+
+	// a verifying MSP has no default signer
+	verifier := setupIdemixMSP(t, "testdata/idemix/MSP1Verifier", "MSP1")
+	_, err = verifier.GetDefaultSigningIdentity()
+	assert.Error(t, err)
+	assert.Nil(t, verifier.GetTLSRootCerts())
+}
+
+func TestIdemixSignAndVerify(t *testing.T) {
+	idemixMsp := setupIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1")
+	id := getIdemixSigner(t, idemixMsp)
+	assert.NoError(t, id.Validate())
+
+	msg := []byte("TestMessage")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Error(t, id.Verify([]byte("OtherMessage"), sig))
+	assert.Error(t, id.Verify(msg, []byte("garbage")))
+
+	// signatures can be verified by the deserialized identity
+	serialized, err := id.Serialize()
+	assert.NoError(t, err)
+	verifier := setupIdemixMSP(t, "testdata/idemix/MSP1Verifier", "MSP1")
+	deserialized, err := verifier.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, deserialized.Validate())
+	assert.NoError(t, deserialized.Verify(msg, sig))
+	assert.Equal(t, id.GetIdentifier(), deserialized.GetIdentifier())
+
+	// each signing identity has a fresh, unlinkable pseudonym
+	other := getIdemixSigner(t, idemixMsp)
+	assert.NotEqual(t, id.GetIdentifier(), other.GetIdentifier())
+	assert.Error(t, other.Verify(msg, sig))
+}
+
+func TestIdemixDeserializeInvalid(t *testing.T) {
+	idemixMsp := setupIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1")
+	id := getIdemixSigner(t, idemixMsp)
+	serialized, err := id.Serialize()
+	assert.NoError(t, err)
+
+	// identities of another MSP ID are rejected
+	otherMsp := setupIdemixMSP(t, "testdata/idemix/MSP1Verifier", "MSP2")
+	_, err = otherMsp.DeserializeIdentity(serialized)
+	assert.Error(t, err)
+
+	_, err = idemixMsp.DeserializeIdentity([]byte("garbage"))
+	assert.Error(t, err)
+
+	// identities of another issuer do not validate
+	msp2 := setupIdemixMSP(t, "testdata/idemix/MSP2OU1", "MSP1")
+	id2 := getIdemixSigner(t, msp2)
+	serialized2, err := id2.Serialize()
+	assert.NoError(t, err)
+	deserialized, err := idemixMsp.DeserializeIdentity(serialized2)
+	assert.NoError(t, err)
+	assert.Error(t, deserialized.Validate())
+
+	// identities claiming another OU do not validate
+	sId := &msp.SerializedIdentity{}
+	assert.NoError(t, proto.Unmarshal(serialized, sId))
+	idemixId := &msp.SerializedIdemixIdentity{}
+	assert.NoError(t, proto.Unmarshal(sId.IdBytes, idemixId))
+	idemixId.Ou = "OU2"
+	sId.IdBytes, _ = proto.Marshal(idemixId)
+	tampered, _ := proto.Marshal(sId)
+	deserialized, err = idemixMsp.DeserializeIdentity(tampered)
+	assert.NoError(t, err)
+	assert.Error(t, deserialized.Validate())
+
+	// identities with a truncated disclosure are rejected rather than
+	// evaluated against principals
+	adminPrincipal, _ := proto.Marshal(&msp.MSPRole{MspIdentifier: "MSP1", Role: msp.MSPRole_ADMIN})
+	for _, disclosure := range [][]byte{nil, {1}} {
+		idemixId.Ou = "OU1"
+		idemixId.Disclosure = disclosure
+		sId.IdBytes, _ = proto.Marshal(idemixId)
+		truncated, _ := proto.Marshal(sId)
+		_, err = idemixMsp.DeserializeIdentity(truncated)
+		assert.Error(t, err)
+		assert.Error(t, idemixMsp.SatisfiesPrincipal(&idemixidentity{msp: idemixMsp.(*idemixmsp), disclosure: disclosure},
+			&msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: adminPrincipal}))
+	}
+}
+
+func TestIdemixSatisfiesPrincipal(t *testing.T) {
+	memberMsp := setupIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1")
+	adminMsp := setupIdemixMSP(t, "testdata/idemix/MSP1OU1Admin", "MSP1")
+	hiddenMsp := setupIdemixMSP(t, "testdata/idemix/MSP1OU1Hidden", "MSP1")
+
+	member := getIdemixSigner(t, memberMsp)
+	admin := getIdemixSigner(t, adminMsp)
+	hidden := getIdemixSigner(t, hiddenMsp)
+
+	role := func(mspID string, r msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+		bytes, err := proto.Marshal(&msp.MSPRole{MspIdentifier: mspID, Role: r})
+		assert.NoError(t, err)
+		return &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: bytes}
+	}
+
+	assert.NoError(t, member.SatisfiesPrincipal(role("MSP1", msp.MSPRole_MEMBER)))
+	assert.NoError(t, admin.SatisfiesPrincipal(role("MSP1", msp.MSPRole_MEMBER)))
+	assert.NoError(t, hidden.SatisfiesPrincipal(role("MSP1", msp.MSPRole_MEMBER)))
+	assert.Error(t, member.SatisfiesPrincipal(role("MSP2", msp.MSPRole_MEMBER)))
+
+	assert.NoError(t, admin.SatisfiesPrincipal(role("MSP1", msp.MSPRole_ADMIN)))
+	assert.Error(t, member.SatisfiesPrincipal(role("MSP1", msp.MSPRole_ADMIN)))
+	assert.Error(t, admin.SatisfiesPrincipal(role("MSP1", msp.MSPRole_PEER)))
+	assert.Error(t, hidden.SatisfiesPrincipal(role("MSP1", msp.MSPRole_ADMIN)), "An identity hiding its role cannot satisfy an admin principal")
+
+	ou := func(name string, certifiersIdentifier []byte) *msp.MSPPrincipal {
+		bytes, err := proto.Marshal(&msp.OrganizationUnit{MspIdentifier: "MSP1", OrganizationalUnitIdentifier: name, CertifiersIdentifier: certifiersIdentifier})
+		assert.NoError(t, err)
+		return &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT, Principal: bytes}
+	}
+
+	ous := member.GetOrganizationalUnits()
+	assert.Len(t, ous, 1)
+	assert.NoError(t, member.SatisfiesPrincipal(ou("OU1", ous[0].CertifiersIdentifier)))
+	assert.Error(t, member.SatisfiesPrincipal(ou("OU2", ous[0].CertifiersIdentifier)))
+	assert.Error(t, member.SatisfiesPrincipal(ou("OU1", []byte("other issuer"))))
+	assert.Empty(t, hidden.GetOrganizationalUnits())
+	assert.Error(t, hidden.SatisfiesPrincipal(ou("OU1", ous[0].CertifiersIdentifier)))
+
+	serialized, err := member.Serialize()
+	assert.NoError(t, err)
+	principal := &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_IDENTITY, Principal: serialized}
+	assert.NoError(t, member.SatisfiesPrincipal(principal))
+	assert.Error(t, admin.SatisfiesPrincipal(principal))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/cache"
 )
 
 // LoadLocalMsp loads the local MSP from the specified directory
@@ -139,6 +140,10 @@ func GetLocalMSP() msp.MSP {
 			if err != nil {
 				mspLogger.Fatalf("Failed to initialize local MSP, received err %s", err)
 			}
+			lclMsp, err = cache.New(lclMsp)
+			if err != nil {
+				mspLogger.Fatalf("Failed to initialize local MSP cache, received err %s", err)
+			}
 			localMsp = lclMsp
 		}
 	}
@@ -187,4 +187,5 @@ type ProviderType int
 const (
 	FABRIC ProviderType = iota // MSP is of FABRIC type
 	OTHER                      // MSP is of OTHER TYPE
+	IDEMIX                     // MSP is of IDEMIX type
 )
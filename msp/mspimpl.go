@@ -68,6 +68,14 @@ type bccspmsp struct {
 	// list of OUs
 	ouIdentifiers map[string][][]byte
 
+	// NodeOUs tells whether identities are classified into
+	// clients, peers, orderers and admins by their OUs
+	NodeOUs bool
+
+	// The OUs of the clients, peers, orderers and admins, when NodeOUs is set.
+	// A nil CertifiersIdentifier matches identities issued by any CA of the MSP
+	clientOU, peerOU, ordererOU, adminOU *OUIdentifier
+
 	// cryptoConfig contains
 	cryptoConfig *m.FabricCryptoConfig
 }
@@ -325,6 +333,11 @@ func (msp *bccspmsp) Setup(conf1 *m.MSPConfig) error {
 		return err
 	}
 
+	// setup the node OUs
+	if err := msp.setupNodeOUs(conf); err != nil {
+		return err
+	}
+
 	// setup TLS CAs
 	if err := msp.setupTLSCAs(conf); err != nil {
 		return err
@@ -501,7 +514,27 @@ func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 				}
 			}
 
+			// when node OUs are enabled, admins can also
+			// be told apart by the admin OU
+			if msp.NodeOUs && msp.adminOU != nil {
+				if err := msp.Validate(id); err != nil {
+					return err
+				}
+				if msp.hasOU(id, msp.adminOU) {
+					return nil
+				}
+			}
+
 			return errors.New("This identity is not an admin")
+		case m.MSPRole_CLIENT:
+			mspLogger.Debugf("Checking if identity satisfies CLIENT role for %s", msp.name)
+			return msp.satisfiesNodeOU(id, msp.clientOU, "client")
+		case m.MSPRole_PEER:
+			mspLogger.Debugf("Checking if identity satisfies PEER role for %s", msp.name)
+			return msp.satisfiesNodeOU(id, msp.peerOU, "peer")
+		case m.MSPRole_ORDERER:
+			mspLogger.Debugf("Checking if identity satisfies ORDERER role for %s", msp.name)
+			return msp.satisfiesNodeOU(id, msp.ordererOU, "orderer")
 		default:
 			return fmt.Errorf("Invalid MSP role type %d", int32(mspRole.Role))
 		}
@@ -839,79 +872,171 @@ func (msp *bccspmsp) setupOUs(conf *m.FabricMSPConfig) error {
 	msp.ouIdentifiers = make(map[string][][]byte)
 	for _, ou := range conf.OrganizationalUnitIdentifiers {
 
-		// 1. check that certificate is registered in msp.rootCerts or msp.intermediateCerts
-		cert, err := msp.getCertFromPem(ou.Certificate)
-		if err != nil {
-			return fmt.Errorf("Failed getting certificate for [%v]: [%s]", ou, err)
-		}
-
-		// 2. Sanitize it to ensure like for like comparison
-		cert, err = msp.sanitizeCert(cert)
+		certifiersIdentitifer, err := msp.getCertifiersIdentifier(ou.Certificate)
 		if err != nil {
-			return fmt.Errorf("sanitizeCert failed %s", err)
+			return fmt.Errorf("Failed adding OU [%v]: [%s]", ou, err)
 		}
 
+		// Check for duplicates
 		found := false
-		root := false
-		// Search among root certificates
-		for _, v := range msp.rootCerts {
-			if v.(*identity).cert.Equal(cert) {
+		for _, id := range msp.ouIdentifiers[ou.OrganizationalUnitIdentifier] {
+			if bytes.Equal(id, certifiersIdentitifer) {
+				mspLogger.Warningf("Duplicate found in ou identifiers [%s, %v]", ou.OrganizationalUnitIdentifier, id)
 				found = true
-				root = true
 				break
 			}
 		}
+
 		if !found {
-			// Search among root intermediate certificates
-			for _, v := range msp.intermediateCerts {
-				if v.(*identity).cert.Equal(cert) {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			// Certificate not valid, reject configuration
-			return fmt.Errorf("Failed adding OU. Certificate [%v] not in root or intermediate certs.", ou.Certificate)
+			// No duplicates found, add it
+			msp.ouIdentifiers[ou.OrganizationalUnitIdentifier] = append(
+				msp.ouIdentifiers[ou.OrganizationalUnitIdentifier],
+				certifiersIdentitifer,
+			)
 		}
+	}
 
-		// 3. get the certification path for it
-		var certifiersIdentitifer []byte
-		var chain []*x509.Certificate
-		if root {
-			chain = []*x509.Certificate{cert}
-		} else {
-			chain, err = msp.getValidationChain(cert, true)
-			if err != nil {
-				return fmt.Errorf("Failed computing validation chain for [%v]. [%s]", cert, err)
+	return nil
+}
+
+// getCertifiersIdentifier returns the identifier of the certification chain
+// of the given root or intermediate CA certificate of this MSP
+func (msp *bccspmsp) getCertifiersIdentifier(certRaw []byte) ([]byte, error) {
+	// 1. check that certificate is registered in msp.rootCerts or msp.intermediateCerts
+	cert, err := msp.getCertFromPem(certRaw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting certificate for [%v]: [%s]", certRaw, err)
+	}
+
+	// 2. Sanitize it to ensure like for like comparison
+	cert, err = msp.sanitizeCert(cert)
+	if err != nil {
+		return nil, fmt.Errorf("sanitizeCert failed %s", err)
+	}
+
+	found := false
+	root := false
+	// Search among root certificates
+	for _, v := range msp.rootCerts {
+		if v.(*identity).cert.Equal(cert) {
+			found = true
+			root = true
+			break
+		}
+	}
+	if !found {
+		// Search among root intermediate certificates
+		for _, v := range msp.intermediateCerts {
+			if v.(*identity).cert.Equal(cert) {
+				found = true
+				break
 			}
 		}
+	}
+	if !found {
+		// Certificate not valid, reject configuration
+		return nil, fmt.Errorf("Certificate [%v] not in root or intermediate certs.", certRaw)
+	}
 
-		// 4. compute the hash of the certification path
-		certifiersIdentitifer, err = msp.getCertificationChainIdentifierFromChain(chain)
+	// 3. get the certification path for it
+	var chain []*x509.Certificate
+	if root {
+		chain = []*x509.Certificate{cert}
+	} else {
+		chain, err = msp.getValidationChain(cert, true)
 		if err != nil {
-			return fmt.Errorf("Failed computing Certifiers Identifier for [%v]. [%s]", ou.Certificate, err)
+			return nil, fmt.Errorf("Failed computing validation chain for [%v]. [%s]", cert, err)
 		}
+	}
 
-		// Check for duplicates
-		found = false
-		for _, id := range msp.ouIdentifiers[ou.OrganizationalUnitIdentifier] {
-			if bytes.Equal(id, certifiersIdentitifer) {
-				mspLogger.Warningf("Duplicate found in ou identifiers [%s, %v]", ou.OrganizationalUnitIdentifier, id)
-				found = true
-				break
-			}
+	// 4. compute the hash of the certification path
+	certifiersIdentitifer, err := msp.getCertificationChainIdentifierFromChain(chain)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing Certifiers Identifier for [%v]. [%s]", certRaw, err)
+	}
+
+	return certifiersIdentitifer, nil
+}
+
+func (msp *bccspmsp) setupNodeOUs(conf *m.FabricMSPConfig) error {
+	msp.NodeOUs = false
+	msp.clientOU, msp.peerOU, msp.ordererOU, msp.adminOU = nil, nil, nil, nil
+	if conf.FabricNodeOus == nil || !conf.FabricNodeOus.Enable {
+		return nil
+	}
+
+	var err error
+	nodeOUs := conf.FabricNodeOus
+	if msp.clientOU, err = msp.getNodeOUIdentifier(nodeOUs.ClientOuIdentifier); err != nil {
+		return fmt.Errorf("Failed setting up client OU: [%s]", err)
+	}
+	if msp.peerOU, err = msp.getNodeOUIdentifier(nodeOUs.PeerOuIdentifier); err != nil {
+		return fmt.Errorf("Failed setting up peer OU: [%s]", err)
+	}
+	if msp.ordererOU, err = msp.getNodeOUIdentifier(nodeOUs.OrdererOuIdentifier); err != nil {
+		return fmt.Errorf("Failed setting up orderer OU: [%s]", err)
+	}
+	if msp.adminOU, err = msp.getNodeOUIdentifier(nodeOUs.AdminOuIdentifier); err != nil {
+		return fmt.Errorf("Failed setting up admin OU: [%s]", err)
+	}
+	if msp.clientOU == nil && msp.peerOU == nil && msp.ordererOU == nil && msp.adminOU == nil {
+		return errors.New("Node OUs are enabled but no node OU is defined")
+	}
+
+	msp.NodeOUs = true
+	return nil
+}
+
+// getNodeOUIdentifier returns the OUIdentifier of the given node OU,
+// or nil if the node OU is not defined
+func (msp *bccspmsp) getNodeOUIdentifier(ou *m.FabricOUIdentifier) (*OUIdentifier, error) {
+	if ou == nil || ou.OrganizationalUnitIdentifier == "" {
+		return nil, nil
+	}
+
+	res := &OUIdentifier{OrganizationalUnitIdentifier: ou.OrganizationalUnitIdentifier}
+	if len(ou.Certificate) != 0 {
+		certifiersIdentifier, err := msp.getCertifiersIdentifier(ou.Certificate)
+		if err != nil {
+			return nil, err
 		}
+		res.CertifiersIdentifier = certifiersIdentifier
+	}
 
-		if !found {
-			// No duplicates found, add it
-			msp.ouIdentifiers[ou.OrganizationalUnitIdentifier] = append(
-				msp.ouIdentifiers[ou.OrganizationalUnitIdentifier],
-				certifiersIdentitifer,
-			)
+	return res, nil
+}
+
+// hasOU returns whether the given identity has the given node OU
+func (msp *bccspmsp) hasOU(id Identity, nodeOU *OUIdentifier) bool {
+	for _, ou := range id.GetOrganizationalUnits() {
+		if ou.OrganizationalUnitIdentifier != nodeOU.OrganizationalUnitIdentifier {
+			continue
+		}
+		if nodeOU.CertifiersIdentifier == nil || bytes.Equal(ou.CertifiersIdentifier, nodeOU.CertifiersIdentifier) {
+			return true
 		}
 	}
 
+	return false
+}
+
+// satisfiesNodeOU checks that the given identity is valid
+// and has the given node OU
+func (msp *bccspmsp) satisfiesNodeOU(id Identity, nodeOU *OUIdentifier, role string) error {
+	if !msp.NodeOUs {
+		return fmt.Errorf("NodeOUs not activated. Cannot tell apart identities.")
+	}
+	if nodeOU == nil {
+		return fmt.Errorf("No %s OU is defined in MSP %s", role, msp.name)
+	}
+
+	if err := msp.Validate(id); err != nil {
+		return err
+	}
+	if !msp.hasOU(id, nodeOU) {
+		return fmt.Errorf("The identity is not a %s", role)
+	}
+
 	return nil
 }
 
@@ -969,6 +1094,12 @@ func (msp *bccspmsp) setupTLSCAs(conf *m.FabricMSPConfig) error {
 // do have signatures in Low-S. If this is not the case, the certificate
 // is regenerated to have a Low-S signature.
 func (msp *bccspmsp) sanitizeCert(cert *x509.Certificate) (*x509.Certificate, error) {
+	if isEd25519SignedCert(cert) {
+		// Ed25519 signatures are not malleable,
+		// hence there is nothing to sanitize
+		return cert, nil
+	}
+
 	if isECDSASignedCert(cert) {
 		// Lookup for a parent certificate to perform the sanitization
 		var parentCert *x509.Certificate
@@ -1070,9 +1201,10 @@ func (msp *bccspmsp) validateCertAgainstChain(cert *x509.Certificate, validation
 		return fmt.Errorf("Could not obtain Subject Key Identifier for signer cert, err %s", err)
 	}
 
-	// check whether one of the CRLs we have has this cert's
-	// SKI as its AuthorityKeyIdentifier
-	for _, crl := range msp.CRL {
+	// check whether one of the CRLs we have, from our configuration
+	// or obtained online, has this cert's SKI as its AuthorityKeyIdentifier
+	crls := append(append([]*pkix.CertificateList{}, msp.CRL...), getOnlineRevocationLists(msp.name)...)
+	for _, crl := range crls {
 		aki, err := getAuthorityKeyIdentifierFromCrl(crl)
 		if err != nil {
 			return fmt.Errorf("Could not obtain Authority Key Identifier for crl, err %s", err)
@@ -1139,6 +1271,20 @@ func (msp *bccspmsp) validateIdentityOUs(id *identity) error {
 		}
 	}
 
+	// When node OUs are enabled, the identity must be exactly
+	// one of a client, a peer, an orderer or an admin
+	if msp.NodeOUs {
+		counter := 0
+		for _, nodeOU := range []*OUIdentifier{msp.clientOU, msp.peerOU, msp.ordererOU, msp.adminOU} {
+			if nodeOU != nil && msp.hasOU(id, nodeOU) {
+				counter++
+			}
+		}
+		if counter != 1 {
+			return fmt.Errorf("The identity must have exactly one of the node OUs of MSP %s, found %d", msp.name, counter)
+		}
+	}
+
 	return nil
 }
 
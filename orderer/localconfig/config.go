@@ -80,6 +80,25 @@ type General struct {
 	LocalMSPDir    string
 	LocalMSPID     string
 	BCCSP          *bccsp.FactoryOpts
+	// CertExpirationWarningThresholds are the times before expiration at
+	// which warnings are logged about the certificates the orderer relies on
+	CertExpirationWarningThresholds []time.Duration
+	RevocationLists                 RevocationLists
+}
+
+// RevocationLists contains configuration for the online revocation lists of
+// MSPs, which are loaded from files and apply in addition to the revocation
+// lists of the channel configurations.
+type RevocationLists struct {
+	RefreshInterval time.Duration
+	Sources         []RevocationListSource
+}
+
+// RevocationListSource lists the files or directories holding the online
+// revocation lists of an MSP.
+type RevocationListSource struct {
+	MSPID string
+	Paths []string
 }
 
 // TLS contains config for TLS connections.
@@ -172,10 +191,14 @@ var defaults = TopLevel{
 			Enabled: false,
 			Address: "0.0.0.0:6060",
 		},
-		LogLevel:    "INFO",
-		LocalMSPDir: "msp",
-		LocalMSPID:  "DEFAULT",
-		BCCSP:       bccsp.GetDefaultOpts(),
+		LogLevel:                        "INFO",
+		LocalMSPDir:                     "msp",
+		LocalMSPID:                      "DEFAULT",
+		BCCSP:                           bccsp.GetDefaultOpts(),
+		CertExpirationWarningThresholds: []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour},
+		RevocationLists: RevocationLists{
+			RefreshInterval: time.Minute,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -251,6 +274,19 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		cf.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		cf.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		cf.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		if c.General.BCCSP != nil && c.General.BCCSP.RemoteOpts != nil {
+			remoteOpts := c.General.BCCSP.RemoteOpts
+			for _, p := range []*string{&remoteOpts.RootCert, &remoteOpts.ClientCert, &remoteOpts.ClientKey} {
+				if *p != "" {
+					cf.TranslatePathInPlace(configDir, p)
+				}
+			}
+		}
+		for _, source := range c.General.RevocationLists.Sources {
+			for i := range source.Paths {
+				cf.TranslatePathInPlace(configDir, &source.Paths[i])
+			}
+		}
 	}()
 
 	for {
@@ -276,6 +312,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			c.General.GenesisFile = defaults.General.GenesisFile
 		case c.General.GenesisProfile == "":
 			c.General.GenesisProfile = defaults.General.GenesisProfile
+		case c.General.CertExpirationWarningThresholds == nil:
+			logger.Infof("General.CertExpirationWarningThresholds unset, setting to %v", defaults.General.CertExpirationWarningThresholds)
+			c.General.CertExpirationWarningThresholds = defaults.General.CertExpirationWarningThresholds
+		case c.General.RevocationLists.RefreshInterval == 0:
+			logger.Infof("General.RevocationLists.RefreshInterval unset, setting to %v", defaults.General.RevocationLists.RefreshInterval)
+			c.General.RevocationLists.RefreshInterval = defaults.General.RevocationLists.RefreshInterval
 
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
@@ -21,9 +21,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	bccsp "github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/remote"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,3 +119,50 @@ func TestProfileConfig(t *testing.T) {
 	uconf.completeInitialization(DummyPath)
 	assert.Equal(t, defaults.General.Profile.Address, uconf.General.Profile.Address, "Expected profile address to be filled with default value")
 }
+
+func TestRevocationListsConfig(t *testing.T) {
+	uconf := &TopLevel{}
+	uconf.completeInitialization(DummyPath)
+	assert.Equal(t, defaults.General.RevocationLists.RefreshInterval, uconf.General.RevocationLists.RefreshInterval,
+		"Expected revocation lists refresh interval to be filled with default value")
+
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	sample, err := ioutil.ReadFile(filepath.Join("..", "..", "sampleconfig", "orderer.yaml"))
+	assert.NoError(t, err, "Error reading the sample configuration")
+	ordererYaml := strings.Replace(string(sample), `        Sources:
+`, `        Sources:
+            - MSPID: Org1MSP
+              Paths:
+                - crls/org1
+                - /etc/crls/org1.pem
+`, 1)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(name, "orderer.yaml"), []byte(ordererYaml), 0600))
+	os.Setenv("FABRIC_CFG_PATH", name)
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+
+	config := Load()
+	assert.Equal(t, time.Minute, config.General.RevocationLists.RefreshInterval)
+	assert.Equal(t, []RevocationListSource{{
+		MSPID: "Org1MSP",
+		Paths: []string{filepath.Join(name, "crls", "org1"), "/etc/crls/org1.pem"},
+	}}, config.General.RevocationLists.Sources)
+}
+
+func TestRemoteBCCSPConfig(t *testing.T) {
+	uconf := &TopLevel{General: General{BCCSP: &bccsp.FactoryOpts{
+		ProviderName: "REMOTE",
+		RemoteOpts: &remote.RemoteOpts{
+			RootCert:   "tls/ca.pem",
+			ClientCert: "/etc/hyperledger/tls/client.pem",
+		},
+	}}}
+	uconf.completeInitialization(DummyPath)
+
+	remoteOpts := uconf.General.BCCSP.RemoteOpts
+	assert.Equal(t, filepath.Join(DummyPath, "tls", "ca.pem"), remoteOpts.RootCert, "Relative paths must be relative to the config file")
+	assert.Equal(t, "/etc/hyperledger/tls/client.pem", remoteOpts.ClientCert, "Absolute paths must be left unchanged")
+	assert.Equal(t, "", remoteOpts.ClientKey, "Unset paths must stay unset")
+}
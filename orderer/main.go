@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -24,6 +25,7 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"time"
 
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
@@ -43,6 +45,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	logging "github.com/op/go-logging"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -71,6 +74,8 @@ func main() {
 		initializeProfilingService(conf)
 		grpcServer := initializeGrpcServer(conf)
 		initializeLocalMsp(conf)
+		initializeCertExpirationTracking(conf)
+		initializeRevocationLists(conf)
 		signer := localmsp.NewSigner()
 		manager := initializeMultiChainManager(conf, signer)
 		server := NewServer(manager, signer)
@@ -203,6 +208,58 @@ func initializeLocalMsp(conf *config.TopLevel) {
 	}
 }
 
+// Track the expiration of the local certificates, refusing to start with an
+// expired enrollment certificate, and serve the expiry dates of the tracked
+// certificates along with the profiling service
+func initializeCertExpirationTracking(conf *config.TopLevel) {
+	serializedIdentity, err := mspmgmt.GetLocalSigningIdentityOrPanic().Serialize()
+	if err != nil {
+		logger.Fatal("Failed serializing the local signing identity:", err)
+	}
+	if expiresAt := crypto.ExpiresAt(serializedIdentity); !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		logger.Fatalf("The enrollment certificate of the local MSP expired on %s, a renewed certificate must be installed before starting the orderer", expiresAt)
+	}
+
+	var tlsCert []byte
+	if conf.General.TLS.Enabled {
+		if tlsCert, err = ioutil.ReadFile(conf.General.TLS.Certificate); err != nil {
+			logger.Fatalf("Failed to load ServerCertificate file '%s' (%s)", conf.General.TLS.Certificate, err)
+		}
+	}
+	expirations, err := crypto.LocalCertificateExpirations(serializedIdentity, tlsCert)
+	if err != nil {
+		logger.Fatal("Failed to track the expiration of the local certificates:", err)
+	}
+	tracker := crypto.ExpirationTrackerInstance()
+	tracker.SetThresholds(conf.General.CertExpirationWarningThresholds)
+	tracker.Track(crypto.LocalSource, expirations)
+
+	http.HandleFunc("/certificates", serveCertificateExpirations)
+}
+
+// Load the online revocation lists of the MSPs and keep reloading them
+func initializeRevocationLists(conf *config.TopLevel) {
+	sources := make(map[string][]string)
+	for _, source := range conf.General.RevocationLists.Sources {
+		sources[source.MSPID] = append(sources[source.MSPID], source.Paths...)
+	}
+	if len(sources) == 0 {
+		return
+	}
+	watcher := msp.NewRevocationListWatcher(sources, conf.General.RevocationLists.RefreshInterval)
+	if err := watcher.Start(); err != nil {
+		logger.Fatal("Failed loading the revocation lists:", err)
+	}
+	logger.Infof("Watching the revocation lists of MSPs %v", sources)
+}
+
+func serveCertificateExpirations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(crypto.ExpirationTrackerInstance().Expirations()); err != nil {
+		logger.Warningf("Failed serving certificate expirations: %s", err)
+	}
+}
+
 func initializeMultiChainManager(conf *config.TopLevel, signer crypto.LocalSigner) multichain.Manager {
 	lf, _ := createLedgerFactory(conf)
 	// Are we bootstrapping?
@@ -148,7 +148,7 @@ func (ml *multiLedger) GetChain(chainID string) (ChainSupport, bool) {
 
 func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
 	initializer := configtx.NewInitializer()
-	configManager, err := configtx.NewManagerImpl(configTx, initializer, nil)
+	configManager, err := configtx.NewManagerImpl(configTx, initializer, []func(configtxapi.Manager){trackChannelCertificates})
 	if err != nil {
 		logger.Panicf("Error creating configtx manager and handlers: %s", err)
 	}
@@ -166,6 +166,17 @@ func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) *ledgerResource
 	}
 }
 
+// trackChannelCertificates tracks the expiration of the certificates of the
+// MSPs defined in the current configuration of a chain
+func trackChannelCertificates(cm configtxapi.Manager) {
+	expirations, err := crypto.ChannelCertificateExpirations(cm.ConfigEnvelope().Config)
+	if err != nil {
+		logger.Warningf("Failed extracting the certificates of chain %s for expiration tracking: %s", cm.ChainID(), err)
+		return
+	}
+	crypto.ExpirationTrackerInstance().Track(cm.ChainID(), expirations)
+}
+
 func (ml *multiLedger) newChain(configtx *cb.Envelope) {
 	ledgerResources := ml.newLedgerResources(configtx)
 	ledgerResources.ledger.Append(ledger.CreateNextBlock(ledgerResources.ledger, []*cb.Envelope{configtx}))
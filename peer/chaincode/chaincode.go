@@ -28,8 +28,8 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	shortDes      = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade."
-	longDes       = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade."
+	shortDes      = "Operate a chaincode: install|instantiate|invoke|logs|package|query|signpackage|upgrade."
+	longDes       = "Operate a chaincode: install|instantiate|invoke|logs|package|query|signpackage|upgrade."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -49,6 +49,7 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(installCmd(cf))
 	chaincodeCmd.AddCommand(instantiateCmd(cf))
 	chaincodeCmd.AddCommand(invokeCmd(cf))
+	chaincodeCmd.AddCommand(logsCmd(cf))
 	chaincodeCmd.AddCommand(packageCmd(cf, nil))
 	chaincodeCmd.AddCommand(queryCmd(cf))
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
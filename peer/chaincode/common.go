@@ -230,6 +230,7 @@ type ChaincodeCmdFactory struct {
 	EndorserClient  pb.EndorserClient
 	Signer          msp.SigningIdentity
 	BroadcastClient common.BroadcastClient
+	AdminClient     pb.AdminClient
 }
 
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var chaincodeLogsFollow bool
+
+// logsCmd returns the cobra command for Chaincode Logs
+func logsCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeLogsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: fmt.Sprintf("Print the output of the specified %s.", chainFuncName),
+		Long:  fmt.Sprintf("Print the output of the specified %s captured by the peer from its containers, when chaincode.logging.capture is enabled on the peer.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeLogs(cmd, cf)
+		},
+	}
+	attachFlags(chaincodeLogsCmd, []string{"name"})
+
+	chaincodeLogsCmd.Flags().BoolVarP(&chaincodeLogsFollow, "follow", "f", false,
+		"If true, keep printing the output of the chaincode as it comes")
+
+	return chaincodeLogsCmd
+}
+
+func chaincodeLogs(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply value for %s name parameter.", chainFuncName)
+	}
+
+	if cf == nil {
+		adminClient, err := common.GetAdminClient()
+		if err != nil {
+			return fmt.Errorf("Error getting admin client: %s", err)
+		}
+		cf = &ChaincodeCmdFactory{AdminClient: adminClient}
+	}
+
+	stream, err := cf.AdminClient.GetChaincodeLogs(context.Background(), &pb.ChaincodeLogsRequest{ChaincodeName: chaincodeName, Follow: chaincodeLogsFollow})
+	if err != nil {
+		return fmt.Errorf("Error getting %s logs: %s", chainFuncName, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Error getting %s logs: %s", chainFuncName, err)
+		}
+		for _, line := range resp.Lines {
+			fmt.Println(line)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsCmd(t *testing.T) {
+	mockCF := &ChaincodeCmdFactory{AdminClient: common.GetMockAdminClient(nil)}
+
+	// Success case
+	cmd := logsCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02"})
+	assert.NoError(t, cmd.Execute(), "Run chaincode logs cmd error")
+
+	// Success case: run logs command with --follow option
+	cmd = logsCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02", "--follow"})
+	assert.NoError(t, cmd.Execute(), "Run chaincode logs cmd error")
+
+	// Failure case: admin client returns an error
+	mockCF = &ChaincodeCmdFactory{AdminClient: common.GetMockAdminClient(errors.New("logs error"))}
+	cmd = logsCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02"})
+	assert.Error(t, cmd.Execute(), "Expected error executing logs command")
+
+	// Failure case: no chaincode name
+	resetFlags()
+	cmd = logsCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.Error(t, cmd.Execute(), "Expected error executing logs command without name")
+}
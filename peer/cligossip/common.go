@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cligossip
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// GossipCmdFactory holds the clients used by GossipCmd
+type GossipCmdFactory struct {
+	AdminClient pb.AdminClient
+}
+
+// InitCmdFactory init the GossipCmdFactory with default admin client
+func InitCmdFactory() (*GossipCmdFactory, error) {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return nil, err
+	}
+	return &GossipCmdFactory{
+		AdminClient: adminClient,
+	}, nil
+}
+
+func initCmdFactoryIfNeeded(cf *GossipCmdFactory) (*GossipCmdFactory, error) {
+	if cf != nil {
+		return cf, nil
+	}
+	return InitCmdFactory()
+}
+
+func checkChannelID() error {
+	if channelID == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply channel ID")
+	}
+	return nil
+}
+
+func printResponse(resp proto.Message) error {
+	out, err := (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(resp)
+	if err != nil {
+		return fmt.Errorf("Error marshalling response: %s", err)
+	}
+	fmt.Println(out)
+	return nil
+}
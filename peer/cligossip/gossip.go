@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cligossip
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const (
+	gossipFuncName = "gossip"
+	shortDes       = "Gossip introspection: members|channel|leader."
+	longDes        = "Inspect the gossip membership, the per channel membership and the leader of the organization of the peer."
+)
+
+var channelID string
+
+// Cmd returns the cobra command for Gossip
+func Cmd(cf *GossipCmdFactory) *cobra.Command {
+	gossipCmd := &cobra.Command{
+		Use:   gossipFuncName,
+		Short: shortDes,
+		Long:  longDes,
+	}
+	gossipCmd.AddCommand(membersCmd(cf))
+	gossipCmd.AddCommand(channelCmd(cf))
+	gossipCmd.AddCommand(leaderCmd(cf))
+
+	return gossipCmd
+}
+
+func membersCmd(cf *GossipCmdFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "members",
+		Short: "Lists the alive and dead members known to the peer.",
+		Long:  "Lists the alive and dead members known to the peer, along with their endpoints, organizations, last seen times and the sizes of the gossip message stores.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return members(cf)
+		},
+	}
+}
+
+func channelCmd(cf *GossipCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channel",
+		Short: "Lists the members of a channel known to the peer.",
+		Long:  "Lists the members of a channel known to the peer, along with their ledger heights and the sizes of the message stores of the channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return channel(cf)
+		},
+	}
+	addChannelFlag(cmd)
+	return cmd
+}
+
+func leaderCmd(cf *GossipCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leader",
+		Short: "Shows the leader of the organization of the peer in a channel.",
+		Long:  "Shows the leader of the organization of the peer in a channel, and whether it was elected or statically configured.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return leader(cf)
+		},
+	}
+	addChannelFlag(cmd)
+	return cmd
+}
+
+func addChannelFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "The channel to inspect")
+}
+
+func members(cf *GossipCmdFactory) error {
+	cf, err := initCmdFactoryIfNeeded(cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.AdminClient.GetGossipMembers(context.Background(), &empty.Empty{})
+	if err != nil {
+		return fmt.Errorf("Error retrieving gossip members: %s", err)
+	}
+	return printResponse(resp)
+}
+
+func channel(cf *GossipCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	cf, err := initCmdFactoryIfNeeded(cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.AdminClient.GetGossipChannel(context.Background(), &pb.GossipChannelRequest{Channel: channelID})
+	if err != nil {
+		return fmt.Errorf("Error retrieving gossip members of channel %s: %s", channelID, err)
+	}
+	return printResponse(resp)
+}
+
+func leader(cf *GossipCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	cf, err := initCmdFactoryIfNeeded(cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.AdminClient.GetGossipLeader(context.Background(), &pb.GossipChannelRequest{Channel: channelID})
+	if err != nil {
+		return fmt.Errorf("Error retrieving leader of channel %s: %s", channelID, err)
+	}
+	return printResponse(resp)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGossipCmd(t *testing.T) {
+	cf := &GossipCmdFactory{AdminClient: common.GetMockAdminClient(nil)}
+
+	for _, tc := range []struct {
+		name      string
+		args      []string
+		shouldErr bool
+	}{
+		{"Members", []string{"members"}, false},
+		{"ChannelNoChannelID", []string{"channel"}, true},
+		{"Channel", []string{"channel", "-c", "mychannel"}, false},
+		{"LeaderNoChannelID", []string{"leader", "-c", common.UndefinedParamValue}, true},
+		{"Leader", []string{"leader", "--channelID", "mychannel"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := Cmd(cf)
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if tc.shouldErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGossipCmdAdminError(t *testing.T) {
+	cf := &GossipCmdFactory{AdminClient: common.GetMockAdminClient(errors.New("Gossip service is not initialized"))}
+	for _, args := range [][]string{
+		{"members"},
+		{"channel", "-c", "mychannel"},
+		{"leader", "-c", "mychannel"},
+	} {
+		cmd := Cmd(cf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Gossip service is not initialized")
+	}
+}
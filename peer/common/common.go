@@ -19,6 +19,7 @@ package common
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -32,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	pcommon "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
@@ -100,6 +102,16 @@ func InitCrypto(mspMgrConfigDir string, localMSPID string) error {
 	if err != nil {
 		return fmt.Errorf("could not parse YAML config [%s]", err)
 	}
+	if bccspConfig != nil && bccspConfig.RemoteOpts != nil {
+		// The TLS material of the remote signing service is relative to core.yaml
+		configDir := filepath.Dir(viper.ConfigFileUsed())
+		remoteOpts := bccspConfig.RemoteOpts
+		for _, p := range []*string{&remoteOpts.RootCert, &remoteOpts.ClientCert, &remoteOpts.ClientKey} {
+			if *p != "" {
+				config.TranslatePathInPlace(configDir, p)
+			}
+		}
+	}
 
 	err = mspmgmt.LoadLocalMsp(mspMgrConfigDir, bccspConfig, localMSPID)
 	if err != nil {
@@ -131,6 +143,16 @@ func GetAdminClient() (pb.AdminClient, error) {
 	return adminClient, nil
 }
 
+// GetDiscoveryClient returns a new discovery client connection for this peer
+func GetDiscoveryClient() (discprotos.DiscoveryClient, error) {
+	clientConn, err := peer.NewPeerClientConnection()
+	if err != nil {
+		err = errors.ErrorWithCallstack("PER", "404", "Error trying to connect to local peer").WrapError(err)
+		return nil, err
+	}
+	return discprotos.NewDiscoveryClient(clientConn), nil
+}
+
 // GetDefaultSigner return a default Signer(Default/PERR) for cli
 func GetDefaultSigner() (msp.SigningIdentity, error) {
 	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
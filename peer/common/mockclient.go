@@ -17,7 +17,10 @@ limitations under the License.
 package common
 
 import (
+	"io"
+
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	context "golang.org/x/net/context"
@@ -94,3 +97,59 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, in *pb.LogLevel
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetChaincodeLogs(ctx context.Context, in *pb.ChaincodeLogsRequest, opts ...grpc.CallOption) (pb.Admin_GetChaincodeLogsClient, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &mockChaincodeLogsClient{lines: []string{in.ChaincodeName + " output"}}, nil
+}
+
+func (m *mockAdminClient) GetGossipMembers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*pb.GossipMembersResponse, error) {
+	response := &pb.GossipMembersResponse{
+		Self:    &pb.GossipMember{Endpoint: "peer0:7051", Org: "Org1MSP", Alive: true},
+		Members: []*pb.GossipMember{{Endpoint: "peer1:7051", Org: "Org1MSP", Alive: true}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) GetGossipChannel(ctx context.Context, in *pb.GossipChannelRequest, opts ...grpc.CallOption) (*pb.GossipChannelResponse, error) {
+	response := &pb.GossipChannelResponse{
+		Channel: in.Channel,
+		Members: []*pb.GossipMember{{Endpoint: "peer1:7051", Org: "Org1MSP", Alive: true, LedgerHeight: 10}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) GetGossipLeader(ctx context.Context, in *pb.GossipChannelRequest, opts ...grpc.CallOption) (*pb.GossipLeaderResponse, error) {
+	response := &pb.GossipLeaderResponse{
+		Channel:  in.Channel,
+		Election: true,
+		Leader:   &pb.GossipMember{Endpoint: "peer1:7051", Org: "Org1MSP", Alive: true, Leader: true},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) GetCertificateExpirations(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*pb.CertificateExpirationsResponse, error) {
+	response := &pb.CertificateExpirationsResponse{
+		Certificates: []*pb.CertificateExpiration{
+			{Source: "local", Kind: "enrollment", MspId: "Org1MSP", Subject: "peer0", NotAfter: &timestamp.Timestamp{Seconds: 1893456000}},
+		},
+	}
+	return response, m.err
+}
+
+// mockChaincodeLogsClient sends its lines in a single response
+type mockChaincodeLogsClient struct {
+	grpc.ClientStream
+	lines []string
+}
+
+func (m *mockChaincodeLogsClient) Recv() (*pb.ChaincodeLogsResponse, error) {
+	if m.lines == nil {
+		return nil, io.EOF
+	}
+	resp := &pb.ChaincodeLogsResponse{Lines: m.lines}
+	m.lines = nil
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discover
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const (
+	discoverFuncName = "discover"
+	shortDes         = "Discover the endorsers of a chaincode."
+	longDes          = "Discover the peers able to endorse proposals for a chaincode on a channel, and the ways of picking them to satisfy its endorsement policy."
+)
+
+var (
+	channelID     string
+	chaincodeName string
+)
+
+// DiscoverCmdFactory holds the clients used by DiscoverCmd
+type DiscoverCmdFactory struct {
+	DiscoveryClient discprotos.DiscoveryClient
+	Signer          msp.SigningIdentity
+}
+
+// InitCmdFactory init the DiscoverCmdFactory with default clients
+func InitCmdFactory() (*DiscoverCmdFactory, error) {
+	discoveryClient, err := common.GetDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, err
+	}
+	return &DiscoverCmdFactory{
+		DiscoveryClient: discoveryClient,
+		Signer:          signer,
+	}, nil
+}
+
+// Cmd returns the cobra command for Discover
+func Cmd(cf *DiscoverCmdFactory) *cobra.Command {
+	discoverCmd := &cobra.Command{
+		Use:   discoverFuncName,
+		Short: shortDes,
+		Long:  longDes,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discover(cf)
+		},
+	}
+	flags := discoverCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "C", common.UndefinedParamValue, "The channel on which to discover the endorsers")
+	flags.StringVarP(&chaincodeName, "name", "n", common.UndefinedParamValue, "Name of the chaincode")
+
+	return discoverCmd
+}
+
+func discover(cf *DiscoverCmdFactory) error {
+	if channelID == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply channel ID")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply chaincode name")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity: %s", err)
+	}
+	payload, err := proto.Marshal(&discprotos.Request{Creator: creator, Channel: channelID, Chaincode: chaincodeName})
+	if err != nil {
+		return fmt.Errorf("Error marshalling request: %s", err)
+	}
+	signature, err := cf.Signer.Sign(payload)
+	if err != nil {
+		return fmt.Errorf("Error signing request: %s", err)
+	}
+
+	resp, err := cf.DiscoveryClient.Discover(context.Background(), &discprotos.SignedRequest{Payload: payload, Signature: signature})
+	if err != nil {
+		return fmt.Errorf("Error discovering the endorsers of chaincode %s: %s", chaincodeName, err)
+	}
+	// Identities are left out as they are of little use on the command line
+	for _, peers := range resp.PeersByOrg {
+		for _, peer := range peers.Peers {
+			peer.Identity = nil
+		}
+	}
+	out, err := (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(resp)
+	if err != nil {
+		return fmt.Errorf("Error marshalling response: %s", err)
+	}
+	fmt.Println(out)
+	return nil
+}
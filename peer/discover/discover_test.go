@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discover
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type mockSigner struct {
+	msp.SigningIdentity
+}
+
+func (*mockSigner) Serialize() ([]byte, error) {
+	return []byte("creator"), nil
+}
+
+func (*mockSigner) Sign(msg []byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+type mockDiscoveryClient struct {
+	req  *discprotos.Request
+	resp *discprotos.Response
+	err  error
+}
+
+func (c *mockDiscoveryClient) Discover(ctx context.Context, in *discprotos.SignedRequest, opts ...grpc.CallOption) (*discprotos.Response, error) {
+	c.req = &discprotos.Request{}
+	if err := proto.Unmarshal(in.Payload, c.req); err != nil {
+		return nil, err
+	}
+	return c.resp, c.err
+}
+
+func TestDiscover(t *testing.T) {
+	client := &mockDiscoveryClient{resp: &discprotos.Response{
+		PeersByOrg: map[string]*discprotos.Peers{
+			"A": {Peers: []*discprotos.Peer{{Endpoint: "p0.a:7051", MspId: "A", Identity: []byte("id"), LedgerHeight: 3}}},
+		},
+		Layouts: []*discprotos.Layout{{QuantitiesByOrg: map[string]uint32{"A": 1}}},
+	}}
+	cf := &DiscoverCmdFactory{DiscoveryClient: client, Signer: &mockSigner{}}
+
+	cmd := Cmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.Error(t, cmd.Execute(), "Missing chaincode name")
+
+	cmd = Cmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, &discprotos.Request{Creator: []byte("creator"), Channel: "mychannel", Chaincode: "mycc"}, client.req)
+
+	client.err = errors.New("access denied")
+	assert.Error(t, cmd.Execute())
+}
@@ -18,8 +18,11 @@ package gossip
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
@@ -74,6 +77,31 @@ func (s *mspMessageCryptoService) ValidateIdentity(peerIdentity api.PeerIdentity
 	return err
 }
 
+// Expiration returns the time at which the identity of a remote peer expires,
+// which is the end of the validity period of its enrollment certificate.
+func (s *mspMessageCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	// Validate arguments
+	if len(peerIdentity) == 0 {
+		return time.Time{}, errors.New("Invalid Peer Identity. It must be different from nil.")
+	}
+
+	sid, err := s.deserializer.Deserialize(peerIdentity)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed deserializing peer identity [% x]: [%s]", peerIdentity, err)
+	}
+
+	pemBlock, _ := pem.Decode(sid.IdBytes)
+	if pemBlock == nil {
+		return time.Time{}, fmt.Errorf("Failed decoding PEM certificate of peer identity [% x]", peerIdentity)
+	}
+	cert, err := x509.ParseCertificate(pemBlock.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed parsing certificate of peer identity [% x]: [%s]", peerIdentity, err)
+	}
+
+	return cert.NotAfter, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 // If any error occurs, the method return nil
 // The PKid of a peer is computed as the SHA2-256 of peerIdentity which
@@ -17,8 +17,16 @@ limitations under the License.
 package gossip
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
@@ -100,6 +108,40 @@ func TestValidateIdentity(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExpiration(t *testing.T) {
+	msgCryptoService := NewMCS(
+		&mocks.ChannelPolicyManagerGetter{},
+		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
+		&mocks.DeserializersManager{},
+	)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "peer0"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	peerIdentity := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	expiration, err := msgCryptoService.Expiration(peerIdentity)
+	assert.NoError(t, err)
+	assert.True(t, notAfter.Equal(expiration))
+
+	_, err = msgCryptoService.Expiration([]byte("Alice"))
+	assert.Error(t, err)
+
+	_, err = msgCryptoService.Expiration(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("Alice")}))
+	assert.Error(t, err)
+
+	_, err = msgCryptoService.Expiration(nil)
+	assert.Error(t, err)
+}
+
 func TestSign(t *testing.T) {
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetter{},
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func certificatesCmd() *cobra.Command {
+	return nodeCertificatesCmd
+}
+
+var nodeCertificatesCmd = &cobra.Command{
+	Use:   "certificates",
+	Short: "Lists the expiry dates of the certificates of the node.",
+	Long:  `Lists the expiry dates of the enrollment and TLS certificates of the running node, and of the admin and CA certificates of the channels it has joined.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		adminClient, err := common.GetAdminClient()
+		if err != nil {
+			return err
+		}
+		return certificates(adminClient)
+	},
+}
+
+func certificates(adminClient pb.AdminClient) error {
+	resp, err := adminClient.GetCertificateExpirations(context.Background(), &empty.Empty{})
+	if err != nil {
+		return fmt.Errorf("Error trying to get the certificate expirations from local peer: %s", err)
+	}
+	out, err := (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(resp)
+	if err != nil {
+		return fmt.Errorf("Error marshalling response: %s", err)
+	}
+	fmt.Println(out)
+	return nil
+}
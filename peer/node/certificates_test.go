@@ -0,0 +1,23 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificates(t *testing.T) {
+	assert.NoError(t, certificates(common.GetMockAdminClient(nil)))
+
+	err := certificates(common.GetMockAdminClient(errors.New("connection refused")))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
@@ -25,8 +25,8 @@ import (
 
 const (
 	nodeFuncName = "node"
-	shortDes     = "Operate a peer node: start|status."
-	longDes      = "Operate a peer node: start|status."
+	shortDes     = "Operate a peer node: start|status|certificates."
+	longDes      = "Operate a peer node: start|status|certificates."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -35,6 +35,7 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(certificatesCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,424 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bccsp/remote.proto
+
+/*
+Package bccsp is a generated protocol buffer package.
+
+It is generated from these files:
+	bccsp/remote.proto
+
+It has these top-level messages:
+	KeyGenRequest
+	GetKeyRequest
+	KeyResponse
+	SignRequest
+	SignResponse
+	DecryptRequest
+	DecryptResponse
+*/
+package bccsp
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// KeyGenRequest asks for a key pair of the given algorithm
+type KeyGenRequest struct {
+	// algorithm is the identifier of the key generation algorithm,
+	// as returned by bccsp.KeyGenOpts.Algorithm
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm" json:"algorithm,omitempty"`
+	// ephemeral is true if the key must not be persisted by the service
+	Ephemeral bool `protobuf:"varint,2,opt,name=ephemeral" json:"ephemeral,omitempty"`
+}
+
+func (m *KeyGenRequest) Reset()                    { *m = KeyGenRequest{} }
+func (m *KeyGenRequest) String() string            { return proto.CompactTextString(m) }
+func (*KeyGenRequest) ProtoMessage()               {}
+func (*KeyGenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *KeyGenRequest) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *KeyGenRequest) GetEphemeral() bool {
+	if m != nil {
+		return m.Ephemeral
+	}
+	return false
+}
+
+// GetKeyRequest asks for the key with the given subject key identifier
+type GetKeyRequest struct {
+	Ski []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+}
+
+func (m *GetKeyRequest) Reset()                    { *m = GetKeyRequest{} }
+func (m *GetKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetKeyRequest) ProtoMessage()               {}
+func (*GetKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *GetKeyRequest) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+// KeyResponse describes a key held by the service
+type KeyResponse struct {
+	// ski is the subject key identifier of the key
+	Ski []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+	// public_key is the PKIX encoding of the public part of the key
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// private is true if the service holds the private part of the key
+	Private bool `protobuf:"varint,3,opt,name=private" json:"private,omitempty"`
+}
+
+func (m *KeyResponse) Reset()                    { *m = KeyResponse{} }
+func (m *KeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*KeyResponse) ProtoMessage()               {}
+func (*KeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *KeyResponse) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+func (m *KeyResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *KeyResponse) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+// SignRequest asks for the signature of a digest
+type SignRequest struct {
+	Ski    []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+	Digest []byte `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (m *SignRequest) Reset()                    { *m = SignRequest{} }
+func (m *SignRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()               {}
+func (*SignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SignRequest) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+func (m *SignRequest) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+// SignResponse carries the signature of a digest
+type SignResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignResponse) Reset()                    { *m = SignResponse{} }
+func (m *SignResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()               {}
+func (*SignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// DecryptRequest asks for the decryption of a ciphertext
+type DecryptRequest struct {
+	Ski        []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+	Ciphertext []byte `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (m *DecryptRequest) Reset()                    { *m = DecryptRequest{} }
+func (m *DecryptRequest) String() string            { return proto.CompactTextString(m) }
+func (*DecryptRequest) ProtoMessage()               {}
+func (*DecryptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *DecryptRequest) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+func (m *DecryptRequest) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+// DecryptResponse carries the plaintext of a ciphertext
+type DecryptResponse struct {
+	Plaintext []byte `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+}
+
+func (m *DecryptResponse) Reset()                    { *m = DecryptResponse{} }
+func (m *DecryptResponse) String() string            { return proto.CompactTextString(m) }
+func (*DecryptResponse) ProtoMessage()               {}
+func (*DecryptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DecryptResponse) GetPlaintext() []byte {
+	if m != nil {
+		return m.Plaintext
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*KeyGenRequest)(nil), "bccsp.KeyGenRequest")
+	proto.RegisterType((*GetKeyRequest)(nil), "bccsp.GetKeyRequest")
+	proto.RegisterType((*KeyResponse)(nil), "bccsp.KeyResponse")
+	proto.RegisterType((*SignRequest)(nil), "bccsp.SignRequest")
+	proto.RegisterType((*SignResponse)(nil), "bccsp.SignResponse")
+	proto.RegisterType((*DecryptRequest)(nil), "bccsp.DecryptRequest")
+	proto.RegisterType((*DecryptResponse)(nil), "bccsp.DecryptResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for RemoteSigner service
+
+type RemoteSignerClient interface {
+	// KeyGen generates a key pair and returns its public part
+	KeyGen(ctx context.Context, in *KeyGenRequest, opts ...grpc.CallOption) (*KeyResponse, error)
+	// GetKey returns the public part of the key with the given SKI
+	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*KeyResponse, error)
+	// Sign signs a digest with the private key with the given SKI
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// Decrypt decrypts a ciphertext with the private key with the given SKI
+	Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRemoteSignerClient(cc *grpc.ClientConn) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) KeyGen(ctx context.Context, in *KeyGenRequest, opts ...grpc.CallOption) (*KeyResponse, error) {
+	out := new(KeyResponse)
+	err := grpc.Invoke(ctx, "/bccsp.RemoteSigner/KeyGen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*KeyResponse, error) {
+	out := new(KeyResponse)
+	err := grpc.Invoke(ctx, "/bccsp.RemoteSigner/GetKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := grpc.Invoke(ctx, "/bccsp.RemoteSigner/Sign", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error) {
+	out := new(DecryptResponse)
+	err := grpc.Invoke(ctx, "/bccsp.RemoteSigner/Decrypt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RemoteSigner service
+
+type RemoteSignerServer interface {
+	// KeyGen generates a key pair and returns its public part
+	KeyGen(context.Context, *KeyGenRequest) (*KeyResponse, error)
+	// GetKey returns the public part of the key with the given SKI
+	GetKey(context.Context, *GetKeyRequest) (*KeyResponse, error)
+	// Sign signs a digest with the private key with the given SKI
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	// Decrypt decrypts a ciphertext with the private key with the given SKI
+	Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error)
+}
+
+func RegisterRemoteSignerServer(s *grpc.Server, srv RemoteSignerServer) {
+	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
+}
+
+func _RemoteSigner_KeyGen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyGenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).KeyGen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bccsp.RemoteSigner/KeyGen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).KeyGen(ctx, req.(*KeyGenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bccsp.RemoteSigner/GetKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).GetKey(ctx, req.(*GetKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bccsp.RemoteSigner/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_Decrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).Decrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bccsp.RemoteSigner/Decrypt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).Decrypt(ctx, req.(*DecryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "bccsp.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "KeyGen",
+			Handler:    _RemoteSigner_KeyGen_Handler,
+		},
+		{
+			MethodName: "GetKey",
+			Handler:    _RemoteSigner_GetKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _RemoteSigner_Sign_Handler,
+		},
+		{
+			MethodName: "Decrypt",
+			Handler:    _RemoteSigner_Decrypt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bccsp/remote.proto",
+}
+
+func init() { proto.RegisterFile("bccsp/remote.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 389 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x92, 0xcb, 0xcb, 0xd3, 0x40,
+	0x14, 0xc5, 0x8d, 0xd5, 0xd6, 0xdc, 0xa6, 0x2a, 0xa3, 0x96, 0x50, 0xaa, 0xd4, 0x71, 0x53, 0x44,
+	0x12, 0x7c, 0x80, 0xe0, 0xb2, 0x08, 0x5d, 0x64, 0x65, 0xdc, 0x88, 0x1b, 0x49, 0xd2, 0x6b, 0x32,
+	0x34, 0x8f, 0x71, 0x66, 0x22, 0xe6, 0x4f, 0xf6, 0xbf, 0xf8, 0xc8, 0x4c, 0x1e, 0xcd, 0xf7, 0xd1,
+	0x5d, 0xe7, 0x77, 0xcf, 0xb9, 0x67, 0x3a, 0x27, 0x40, 0xe2, 0x24, 0x91, 0xdc, 0x17, 0x58, 0x54,
+	0x0a, 0x3d, 0x2e, 0x2a, 0x55, 0x91, 0x87, 0x9a, 0xd1, 0x00, 0x56, 0x01, 0x36, 0x47, 0x2c, 0x43,
+	0xfc, 0x53, 0xa3, 0x54, 0x64, 0x0b, 0x76, 0x94, 0xa7, 0x95, 0x60, 0x2a, 0x2b, 0x5c, 0x6b, 0x67,
+	0xed, 0xed, 0x70, 0x04, 0xed, 0x14, 0x79, 0x86, 0x05, 0x8a, 0x28, 0x77, 0xef, 0xef, 0xac, 0xfd,
+	0xa3, 0x70, 0x04, 0xf4, 0x35, 0xac, 0x8e, 0xa8, 0x02, 0x6c, 0xfa, 0x65, 0x4f, 0x61, 0x26, 0xcf,
+	0x4c, 0xaf, 0x71, 0xc2, 0xf6, 0x27, 0xfd, 0x01, 0x4b, 0x3d, 0x97, 0xbc, 0x2a, 0x25, 0xde, 0x15,
+	0x90, 0x97, 0x00, 0xbc, 0x8e, 0x73, 0x96, 0xfc, 0x3a, 0x63, 0xa3, 0x23, 0x9c, 0xd0, 0x36, 0x24,
+	0xc0, 0x86, 0xb8, 0xb0, 0xe0, 0x82, 0xfd, 0x8d, 0x14, 0xba, 0x33, 0x1d, 0xdf, 0x1f, 0xe9, 0x67,
+	0x58, 0x7e, 0x67, 0x69, 0x79, 0x35, 0x9a, 0xac, 0x61, 0x7e, 0x62, 0x29, 0x4a, 0xd5, 0x6d, 0xed,
+	0x4e, 0xf4, 0x1d, 0x38, 0xc6, 0xd8, 0xdd, 0x69, 0x0b, 0xb6, 0x64, 0x69, 0x19, 0xa9, 0x5a, 0x60,
+	0xe7, 0x1f, 0x01, 0x3d, 0xc0, 0xe3, 0xaf, 0x98, 0x88, 0x86, 0xab, 0xeb, 0x49, 0xaf, 0x00, 0x12,
+	0xc6, 0x33, 0x14, 0x0a, 0xff, 0xf5, 0x69, 0x17, 0x84, 0xfa, 0xf0, 0x64, 0xd8, 0x31, 0x86, 0xf2,
+	0x3c, 0x62, 0xa5, 0x76, 0x74, 0xa1, 0x03, 0xf8, 0xf0, 0xdf, 0x02, 0x27, 0xd4, 0xed, 0xb5, 0x37,
+	0x45, 0x41, 0x3e, 0xc1, 0xdc, 0xd4, 0x46, 0x9e, 0x7b, 0xba, 0x48, 0x6f, 0xd2, 0xe2, 0x86, 0x8c,
+	0xb4, 0x8f, 0xa0, 0xf7, 0x5a, 0x97, 0xe9, 0x67, 0x70, 0x4d, 0xea, 0xba, 0xe2, 0x7a, 0x0f, 0x0f,
+	0xda, 0x54, 0xd2, 0x4f, 0x2f, 0x5e, 0x79, 0xf3, 0x6c, 0xc2, 0x06, 0xcb, 0x17, 0x58, 0x74, 0x7f,
+	0x90, 0xbc, 0xe8, 0x14, 0xd3, 0x47, 0xdb, 0xac, 0x6f, 0xe3, 0xde, 0x7b, 0xf8, 0x06, 0x6f, 0x2a,
+	0x91, 0x7a, 0x59, 0xc3, 0x51, 0xe4, 0x78, 0x4a, 0x51, 0x78, 0xbf, 0xa3, 0x58, 0xb0, 0xc4, 0x7c,
+	0xb8, 0xd2, 0x18, 0x7f, 0xbe, 0x4d, 0x99, 0xca, 0xea, 0xd8, 0x4b, 0xaa, 0xc2, 0xbf, 0xd0, 0xfa,
+	0x46, 0xeb, 0x1b, 0xad, 0xaf, 0xb5, 0xf1, 0x5c, 0x9f, 0x3e, 0xde, 0x0c, 0x00, 0xc0, 0x7d, 0xd0,
+	0x41, 0x08, 0x03, 0x00, 0x00,
+}
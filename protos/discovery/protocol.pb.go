@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: discovery/protocol.proto
+
+/*
+Package discovery is a generated protocol buffer package.
+
+It is generated from these files:
+	discovery/protocol.proto
+
+It has these top-level messages:
+	SignedRequest
+	Request
+	Response
+	Peers
+	Peer
+	Layout
+*/
+package discovery
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// SignedRequest is a Request signed by the client that created it
+type SignedRequest struct {
+	// payload is a marshalled Request
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// signature of the payload by the creator of the Request
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedRequest) Reset()                    { *m = SignedRequest{} }
+func (m *SignedRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignedRequest) ProtoMessage()               {}
+func (*SignedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *SignedRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SignedRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Request asks for the endorsers of a chaincode on a channel
+type Request struct {
+	// creator is the serialized identity of the client, it must satisfy the
+	// readers policy of the channel
+	Creator   []byte `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	Channel   string `protobuf:"bytes,2,opt,name=channel" json:"channel,omitempty"`
+	Chaincode string `protobuf:"bytes,3,opt,name=chaincode" json:"chaincode,omitempty"`
+}
+
+func (m *Request) Reset()                    { *m = Request{} }
+func (m *Request) String() string            { return proto.CompactTextString(m) }
+func (*Request) ProtoMessage()               {}
+func (*Request) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Request) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
+func (m *Request) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Request) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+// Response describes the peers able to endorse proposals for a chaincode
+// and the ways to satisfy its endorsement policy
+type Response struct {
+	// peers_by_org holds the peers of the channel, keyed by the MSP ID of
+	// their organization
+	PeersByOrg map[string]*Peers `protobuf:"bytes,1,rep,name=peers_by_org,json=peersByOrg" json:"peers_by_org,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// layouts are the minimal ways to satisfy the endorsement policy of the
+	// chaincode with the peers of the channel
+	Layouts []*Layout `protobuf:"bytes,2,rep,name=layouts" json:"layouts,omitempty"`
+}
+
+func (m *Response) Reset()                    { *m = Response{} }
+func (m *Response) String() string            { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()               {}
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Response) GetPeersByOrg() map[string]*Peers {
+	if m != nil {
+		return m.PeersByOrg
+	}
+	return nil
+}
+
+func (m *Response) GetLayouts() []*Layout {
+	if m != nil {
+		return m.Layouts
+	}
+	return nil
+}
+
+// Peers is a list of peers
+type Peers struct {
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *Peers) Reset()                    { *m = Peers{} }
+func (m *Peers) String() string            { return proto.CompactTextString(m) }
+func (*Peers) ProtoMessage()               {}
+func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Peers) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// Peer describes a peer of the channel
+type Peer struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	MspId    string `protobuf:"bytes,2,opt,name=msp_id,json=mspId" json:"msp_id,omitempty"`
+	// identity is the serialized identity of the peer
+	Identity     []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	LedgerHeight uint64 `protobuf:"varint,4,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Peer) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Peer) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *Peer) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *Peer) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+// Layout tells how many peers of each organization endorsements need to be
+// collected from
+type Layout struct {
+	QuantitiesByOrg map[string]uint32 `protobuf:"bytes,1,rep,name=quantities_by_org,json=quantitiesByOrg" json:"quantities_by_org,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *Layout) Reset()                    { *m = Layout{} }
+func (m *Layout) String() string            { return proto.CompactTextString(m) }
+func (*Layout) ProtoMessage()               {}
+func (*Layout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Layout) GetQuantitiesByOrg() map[string]uint32 {
+	if m != nil {
+		return m.QuantitiesByOrg
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SignedRequest)(nil), "discovery.SignedRequest")
+	proto.RegisterType((*Request)(nil), "discovery.Request")
+	proto.RegisterType((*Response)(nil), "discovery.Response")
+	proto.RegisterType((*Peers)(nil), "discovery.Peers")
+	proto.RegisterType((*Peer)(nil), "discovery.Peer")
+	proto.RegisterType((*Layout)(nil), "discovery.Layout")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Discovery service
+
+type DiscoveryClient interface {
+	// Discover returns the peers of a channel able to endorse proposals for
+	// a chaincode, and the ways of picking them to satisfy the endorsement
+	// policy of the chaincode
+	Discover(ctx context.Context, in *SignedRequest, opts ...grpc.CallOption) (*Response, error)
+}
+
+type discoveryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDiscoveryClient(cc *grpc.ClientConn) DiscoveryClient {
+	return &discoveryClient{cc}
+}
+
+func (c *discoveryClient) Discover(ctx context.Context, in *SignedRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/discovery.Discovery/Discover", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Discovery service
+
+type DiscoveryServer interface {
+	// Discover returns the peers of a channel able to endorse proposals for
+	// a chaincode, and the ways of picking them to satisfy the endorsement
+	// policy of the chaincode
+	Discover(context.Context, *SignedRequest) (*Response, error)
+}
+
+func RegisterDiscoveryServer(s *grpc.Server, srv DiscoveryServer) {
+	s.RegisterService(&_Discovery_serviceDesc, srv)
+}
+
+func _Discovery_Discover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscoveryServer).Discover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discovery.Discovery/Discover",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscoveryServer).Discover(ctx, req.(*SignedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Discovery_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "discovery.Discovery",
+	HandlerType: (*DiscoveryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Discover",
+			Handler:    _Discovery_Discover_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "discovery/protocol.proto",
+}
+
+func init() { proto.RegisterFile("discovery/protocol.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 476 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x53, 0x4d, 0x6f, 0xd3, 0x30,
+	0x18, 0x26, 0xeb, 0x67, 0xde, 0xb5, 0xea, 0x66, 0x86, 0x14, 0x55, 0x1c, 0xaa, 0x4c, 0x8c, 0x4a,
+	0x48, 0xa9, 0x54, 0x2e, 0x08, 0x6e, 0x15, 0x13, 0x43, 0x42, 0x1a, 0x98, 0x13, 0x70, 0xa8, 0xdc,
+	0xe4, 0x25, 0xb1, 0x48, 0xed, 0xd4, 0x76, 0x26, 0x85, 0x5f, 0xc3, 0x3f, 0xe2, 0x2f, 0xa1, 0x38,
+	0x4d, 0x9a, 0x95, 0xde, 0xf2, 0x7c, 0xbc, 0x4f, 0xec, 0xc7, 0x36, 0x78, 0x11, 0xd7, 0xa1, 0x7c,
+	0x40, 0x55, 0x2c, 0x32, 0x25, 0x8d, 0x0c, 0x65, 0x1a, 0xd8, 0x0f, 0xe2, 0x36, 0x8a, 0xff, 0x01,
+	0xc6, 0x5f, 0x79, 0x2c, 0x30, 0xa2, 0xb8, 0xcb, 0x51, 0x1b, 0xe2, 0xc1, 0x20, 0x63, 0x45, 0x2a,
+	0x59, 0xe4, 0x39, 0x33, 0x67, 0x3e, 0xa2, 0x35, 0x24, 0xcf, 0xc1, 0xd5, 0x3c, 0x16, 0xcc, 0xe4,
+	0x0a, 0xbd, 0x33, 0xab, 0x1d, 0x08, 0xff, 0x07, 0x0c, 0x5a, 0x11, 0xa1, 0x42, 0x66, 0xa4, 0xaa,
+	0x23, 0xf6, 0xd0, 0x2a, 0x09, 0x13, 0x02, 0x53, 0x1b, 0xe0, 0xd2, 0x1a, 0x96, 0xe1, 0x61, 0xc2,
+	0xb8, 0x08, 0x65, 0x84, 0x5e, 0xc7, 0x6a, 0x07, 0xc2, 0xff, 0xeb, 0xc0, 0x90, 0xa2, 0xce, 0xa4,
+	0xd0, 0x48, 0x6e, 0x61, 0x94, 0x21, 0x2a, 0xbd, 0xde, 0x14, 0x6b, 0xa9, 0x62, 0xcf, 0x99, 0x75,
+	0xe6, 0xe7, 0xcb, 0xeb, 0xa0, 0xd9, 0x54, 0x50, 0x5b, 0x83, 0xcf, 0xa5, 0x6f, 0x55, 0xdc, 0xab,
+	0xf8, 0x56, 0x18, 0x55, 0x50, 0xc8, 0x1a, 0x82, 0xbc, 0x82, 0x41, 0xca, 0x0a, 0x99, 0x1b, 0xed,
+	0x9d, 0xd9, 0x84, 0xcb, 0x56, 0xc2, 0x27, 0xab, 0xd0, 0xda, 0x31, 0xbd, 0x87, 0xc9, 0x51, 0x16,
+	0xb9, 0x80, 0xce, 0x2f, 0x2c, 0xec, 0x0e, 0x5d, 0x5a, 0x7e, 0x92, 0x1b, 0xe8, 0x3d, 0xb0, 0x34,
+	0xaf, 0xca, 0x39, 0x5f, 0x5e, 0xb4, 0xf2, 0xec, 0x30, 0xad, 0xe4, 0xb7, 0x67, 0x6f, 0x1c, 0x3f,
+	0x80, 0x9e, 0xe5, 0xc8, 0x0b, 0xe8, 0xd9, 0x45, 0xed, 0xb7, 0x31, 0x39, 0x1a, 0xa2, 0x95, 0xea,
+	0xff, 0x86, 0x6e, 0x09, 0xc9, 0x14, 0x86, 0x28, 0xa2, 0x4c, 0x72, 0x61, 0xf6, 0xbf, 0x6e, 0x30,
+	0x79, 0x06, 0xfd, 0xad, 0xce, 0xd6, 0x3c, 0xda, 0x97, 0xdb, 0xdb, 0xea, 0xec, 0x63, 0x54, 0x8e,
+	0xf0, 0x08, 0x85, 0xe1, 0xa6, 0xb0, 0xcd, 0x8e, 0x68, 0x83, 0xc9, 0x35, 0x8c, 0x53, 0x8c, 0x62,
+	0x54, 0xeb, 0x04, 0x79, 0x9c, 0x18, 0xaf, 0x3b, 0x73, 0xe6, 0x5d, 0x3a, 0xaa, 0xc8, 0x3b, 0xcb,
+	0xf9, 0x7f, 0x1c, 0xe8, 0x57, 0x85, 0x10, 0x0a, 0x97, 0xbb, 0x9c, 0x95, 0xb3, 0x1c, 0x8f, 0x0e,
+	0xe0, 0xe6, 0xbf, 0xfa, 0x82, 0x2f, 0x8d, 0xb5, 0x75, 0x06, 0x93, 0xdd, 0x63, 0x76, 0xba, 0x82,
+	0xab, 0x53, 0xc6, 0x13, 0x05, 0x5f, 0xb5, 0x0b, 0x1e, 0xb7, 0xea, 0x5c, 0xde, 0x81, 0xfb, 0xbe,
+	0xfe, 0x3b, 0x79, 0x07, 0xc3, 0x1a, 0x10, 0xaf, 0xb5, 0xaa, 0x47, 0x17, 0x7d, 0xfa, 0xf4, 0xc4,
+	0x85, 0xf1, 0x9f, 0xac, 0xbe, 0xc1, 0x4b, 0xa9, 0xe2, 0x20, 0x29, 0x32, 0x54, 0x55, 0x0b, 0xc1,
+	0x4f, 0xb6, 0x51, 0x3c, 0xac, 0xde, 0x8e, 0x3e, 0x4c, 0x7d, 0x0f, 0x62, 0x6e, 0x92, 0x7c, 0x13,
+	0x84, 0x72, 0xbb, 0x68, 0xf9, 0x17, 0x95, 0xbf, 0x7a, 0x74, 0x7a, 0xd1, 0xf8, 0x37, 0x7d, 0xcb,
+	0xbc, 0xfe, 0x37, 0x00, 0x0e, 0xf9, 0xa2, 0xcf, 0x99, 0x03, 0x00, 0x00,
+}
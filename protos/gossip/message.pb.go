@@ -13,6 +13,8 @@ It has these top-level messages:
 	Secret
 	GossipMessage
 	StateInfo
+	Properties
+	Chaincode
 	StateInfoSnapshot
 	StateInfoPullRequest
 	ConnEstablish
@@ -893,7 +895,8 @@ type StateInfo struct {
 	// channel_MAC is an authentication code that proves
 	// that the peer that sent this message knows
 	// the name of the channel.
-	Channel_MAC []byte `protobuf:"bytes,4,opt,name=channel_MAC,json=channelMAC,proto3" json:"channel_MAC,omitempty"`
+	Channel_MAC []byte      `protobuf:"bytes,4,opt,name=channel_MAC,json=channelMAC,proto3" json:"channel_MAC,omitempty"`
+	Properties  *Properties `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
 }
 
 func (m *StateInfo) Reset()                    { *m = StateInfo{} }
@@ -929,6 +932,71 @@ func (m *StateInfo) GetChannel_MAC() []byte {
 	return nil
 }
 
+func (m *StateInfo) GetProperties() *Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// Properties describes the state of a peer in a channel
+type Properties struct {
+	LedgerHeight uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	Leader       bool         `protobuf:"varint,2,opt,name=leader" json:"leader,omitempty"`
+	Chaincodes   []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *Properties) Reset()                    { *m = Properties{} }
+func (m *Properties) String() string            { return proto.CompactTextString(m) }
+func (*Properties) ProtoMessage()               {}
+func (*Properties) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Properties) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *Properties) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+func (m *Properties) GetChaincodes() []*Chaincode {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+// Chaincode represents a chaincode installed on a peer
+type Chaincode struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *Chaincode) Reset()                    { *m = Chaincode{} }
+func (m *Chaincode) String() string            { return proto.CompactTextString(m) }
+func (*Chaincode) ProtoMessage()               {}
+func (*Chaincode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -937,7 +1005,7 @@ type StateInfoSnapshot struct {
 func (m *StateInfoSnapshot) Reset()                    { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()               {}
-func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *StateInfoSnapshot) GetElements() []*Envelope {
 	if m != nil {
@@ -958,7 +1026,7 @@ type StateInfoPullRequest struct {
 func (m *StateInfoPullRequest) Reset()                    { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()               {}
-func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *StateInfoPullRequest) GetChannel_MAC() []byte {
 	if m != nil {
@@ -979,7 +1047,7 @@ type ConnEstablish struct {
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string            { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()               {}
-func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ConnEstablish) GetPkiId() []byte {
 	if m != nil {
@@ -1014,7 +1082,7 @@ type PeerIdentity struct {
 func (m *PeerIdentity) Reset()                    { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string            { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()               {}
-func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PeerIdentity) GetPkiId() []byte {
 	if m != nil {
@@ -1048,7 +1116,7 @@ type DataRequest struct {
 func (m *DataRequest) Reset()                    { *m = DataRequest{} }
 func (m *DataRequest) String() string            { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()               {}
-func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *DataRequest) GetNonce() uint64 {
 	if m != nil {
@@ -1082,7 +1150,7 @@ type GossipHello struct {
 func (m *GossipHello) Reset()                    { *m = GossipHello{} }
 func (m *GossipHello) String() string            { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()               {}
-func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *GossipHello) GetNonce() uint64 {
 	if m != nil {
@@ -1116,7 +1184,7 @@ type DataUpdate struct {
 func (m *DataUpdate) Reset()                    { *m = DataUpdate{} }
 func (m *DataUpdate) String() string            { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()               {}
-func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *DataUpdate) GetNonce() uint64 {
 	if m != nil {
@@ -1150,7 +1218,7 @@ type DataDigest struct {
 func (m *DataDigest) Reset()                    { *m = DataDigest{} }
 func (m *DataDigest) String() string            { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()               {}
-func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *DataDigest) GetNonce() uint64 {
 	if m != nil {
@@ -1181,7 +1249,7 @@ type DataMessage struct {
 func (m *DataMessage) Reset()                    { *m = DataMessage{} }
 func (m *DataMessage) String() string            { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()               {}
-func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *DataMessage) GetPayload() *Payload {
 	if m != nil {
@@ -1199,7 +1267,7 @@ type Payload struct {
 func (m *Payload) Reset()                    { *m = Payload{} }
 func (m *Payload) String() string            { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()               {}
-func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *Payload) GetSeqNum() uint64 {
 	if m != nil {
@@ -1226,7 +1294,7 @@ type AliveMessage struct {
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
 func (m *AliveMessage) String() string            { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()               {}
-func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *AliveMessage) GetMembership() *Member {
 	if m != nil {
@@ -1255,12 +1323,15 @@ type LeadershipMessage struct {
 	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration" json:"is_declaration,omitempty"`
+	// weight is the leadership weight of the sender,
+	// peers with higher weights are preferred as leaders
+	Weight uint32 `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
 }
 
 func (m *LeadershipMessage) Reset()                    { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string            { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()               {}
-func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *LeadershipMessage) GetPkiId() []byte {
 	if m != nil {
@@ -1283,6 +1354,13 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum uint64 `protobuf:"varint,1,opt,name=inc_num,json=incNum" json:"inc_num,omitempty"`
@@ -1292,7 +1370,7 @@ type PeerTime struct {
 func (m *PeerTime) Reset()                    { *m = PeerTime{} }
 func (m *PeerTime) String() string            { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()               {}
-func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *PeerTime) GetIncNum() uint64 {
 	if m != nil {
@@ -1318,7 +1396,7 @@ type MembershipRequest struct {
 func (m *MembershipRequest) Reset()                    { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string            { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()               {}
-func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *MembershipRequest) GetSelfInformation() *Envelope {
 	if m != nil {
@@ -1343,7 +1421,7 @@ type MembershipResponse struct {
 func (m *MembershipResponse) Reset()                    { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string            { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()               {}
-func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *MembershipResponse) GetAlive() []*Envelope {
 	if m != nil {
@@ -1370,7 +1448,7 @@ type Member struct {
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Member) GetEndpoint() string {
 	if m != nil {
@@ -1400,7 +1478,7 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 // RemoteStateRequest is used to ask a set of blocks
 // from a remote peer
@@ -1412,7 +1490,7 @@ type RemoteStateRequest struct {
 func (m *RemoteStateRequest) Reset()                    { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()               {}
-func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *RemoteStateRequest) GetStartSeqNum() uint64 {
 	if m != nil {
@@ -1437,7 +1515,7 @@ type RemoteStateResponse struct {
 func (m *RemoteStateResponse) Reset()                    { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()               {}
-func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	if m != nil {
@@ -1452,6 +1530,8 @@ func init() {
 	proto.RegisterType((*Secret)(nil), "gossip.Secret")
 	proto.RegisterType((*GossipMessage)(nil), "gossip.GossipMessage")
 	proto.RegisterType((*StateInfo)(nil), "gossip.StateInfo")
+	proto.RegisterType((*Properties)(nil), "gossip.Properties")
+	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
 	proto.RegisterType((*StateInfoSnapshot)(nil), "gossip.StateInfoSnapshot")
 	proto.RegisterType((*StateInfoPullRequest)(nil), "gossip.StateInfoPullRequest")
 	proto.RegisterType((*ConnEstablish)(nil), "gossip.ConnEstablish")
@@ -1620,91 +1700,98 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1366 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6f, 0xdc, 0x44,
-	0x10, 0x3f, 0x27, 0xf7, 0x39, 0xf7, 0x91, 0xcb, 0x26, 0x05, 0x13, 0x2a, 0x88, 0x2c, 0x5a, 0x05,
-	0x52, 0x2e, 0x55, 0xca, 0x47, 0xa5, 0x82, 0xd0, 0x25, 0x77, 0xe4, 0x22, 0x7a, 0x49, 0xe4, 0xa4,
-	0x82, 0xf2, 0x62, 0x6d, 0xce, 0x13, 0x9f, 0xa9, 0xbd, 0x76, 0xbc, 0x7b, 0x85, 0x3c, 0x22, 0xde,
-	0x78, 0xe1, 0x95, 0x3f, 0x17, 0x79, 0xd7, 0xf6, 0xd9, 0x75, 0x52, 0xa9, 0x95, 0x78, 0xf3, 0x7c,
-	0xfc, 0x66, 0x66, 0x67, 0xe7, 0x63, 0x0d, 0x9b, 0x4e, 0xc0, 0xb9, 0x1b, 0xee, 0xf9, 0xc8, 0x39,
-	0x75, 0x70, 0x10, 0x46, 0x81, 0x08, 0x48, 0x5d, 0x71, 0x8d, 0xbf, 0x34, 0x68, 0x8e, 0xd9, 0x6b,
-	0xf4, 0x82, 0x10, 0x89, 0x0e, 0x8d, 0x90, 0xde, 0x78, 0x01, 0xb5, 0x75, 0x6d, 0x5b, 0xdb, 0xe9,
-	0x98, 0x29, 0x49, 0xee, 0x43, 0x8b, 0xbb, 0x0e, 0xa3, 0x62, 0x11, 0xa1, 0xbe, 0x22, 0x65, 0x4b,
-	0x06, 0xf9, 0x01, 0xd6, 0x38, 0xce, 0x22, 0x14, 0x16, 0x26, 0xa6, 0xf4, 0xd5, 0x6d, 0x6d, 0xa7,
-	0xbd, 0xff, 0xc1, 0x40, 0xb9, 0x19, 0x9c, 0x4b, 0x71, 0xea, 0xc8, 0xec, 0xf1, 0x02, 0x6d, 0x4c,
-	0xa0, 0x57, 0xd4, 0x78, 0xdf, 0x50, 0x8c, 0x21, 0xd4, 0x95, 0x25, 0xf2, 0x08, 0xfa, 0x2e, 0x13,
-	0x18, 0x31, 0xea, 0x8d, 0x99, 0x1d, 0x06, 0x2e, 0x13, 0xd2, 0x54, 0x6b, 0x52, 0x31, 0x4b, 0x92,
-	0x83, 0x16, 0x34, 0x66, 0x01, 0x13, 0xc8, 0x84, 0xf1, 0x6f, 0x0b, 0xba, 0x47, 0x32, 0xec, 0xa9,
-	0x4a, 0x19, 0xd9, 0x84, 0x1a, 0x0b, 0xd8, 0x0c, 0x25, 0xbe, 0x6a, 0x2a, 0x22, 0x0e, 0x71, 0x36,
-	0xa7, 0x8c, 0xa1, 0x97, 0x84, 0x91, 0x92, 0x64, 0x17, 0x56, 0x05, 0x75, 0x64, 0x0e, 0x7a, 0xfb,
-	0x1f, 0xa5, 0x39, 0x28, 0xd8, 0x1c, 0x5c, 0x50, 0xc7, 0x8c, 0xb5, 0xc8, 0x13, 0x68, 0x51, 0xcf,
-	0x7d, 0x8d, 0x96, 0xcf, 0x1d, 0xbd, 0x26, 0xd3, 0xb6, 0x99, 0x42, 0x86, 0xb1, 0x20, 0x41, 0x4c,
-	0x2a, 0x66, 0x53, 0x2a, 0x4e, 0xb9, 0x43, 0xbe, 0x82, 0x86, 0x8f, 0xbe, 0x15, 0xe1, 0xb5, 0x5e,
-	0x97, 0x90, 0xcc, 0xcb, 0x14, 0xfd, 0x4b, 0x8c, 0xf8, 0xdc, 0x0d, 0x4d, 0xbc, 0x5e, 0x20, 0x17,
-	0x93, 0x8a, 0x59, 0xf7, 0xd1, 0x37, 0xf1, 0x9a, 0x7c, 0x9d, 0xa2, 0xb8, 0xde, 0x90, 0xa8, 0xad,
-	0xdb, 0x50, 0x3c, 0x0c, 0x18, 0xc7, 0x0c, 0xc6, 0xc9, 0x63, 0x68, 0xda, 0x54, 0x50, 0x19, 0x60,
-	0x53, 0xe2, 0x36, 0x52, 0xdc, 0x88, 0x0a, 0xba, 0x8c, 0xaf, 0x11, 0xab, 0xc5, 0xe1, 0xed, 0x42,
-	0x6d, 0x8e, 0x9e, 0x17, 0xe8, 0xad, 0xa2, 0xba, 0x4a, 0xc1, 0x24, 0x16, 0x4d, 0x2a, 0xa6, 0xd2,
-	0x21, 0x7b, 0x89, 0x79, 0xdb, 0x75, 0x74, 0x90, 0xfa, 0x24, 0x6f, 0x7e, 0xe4, 0x3a, 0xea, 0x14,
-	0xd2, 0xfa, 0xc8, 0x75, 0xb2, 0x78, 0xe2, 0xd3, 0xb7, 0xcb, 0xf1, 0x2c, 0xcf, 0x2d, 0x11, 0xea,
-	0xe0, 0x6d, 0x89, 0x58, 0x84, 0x36, 0x15, 0xa8, 0x77, 0xca, 0x5e, 0x5e, 0x48, 0xc9, 0xa4, 0x62,
-	0x82, 0x9d, 0x51, 0xe4, 0x01, 0xd4, 0xd0, 0x0f, 0xc5, 0x8d, 0xde, 0x95, 0x80, 0x6e, 0x0a, 0x18,
-	0xc7, 0xcc, 0xf8, 0x00, 0x52, 0x4a, 0x76, 0xa1, 0x3a, 0x0b, 0x18, 0xd3, 0x7b, 0x52, 0xeb, 0x5e,
-	0xaa, 0x75, 0x18, 0x30, 0x36, 0xe6, 0x82, 0x5e, 0x7a, 0x2e, 0x9f, 0x4f, 0x2a, 0xa6, 0x54, 0x22,
-	0xfb, 0x00, 0x5c, 0x50, 0x81, 0x96, 0xcb, 0xae, 0x02, 0x7d, 0x4d, 0x42, 0xd6, 0xb3, 0x36, 0x89,
-	0x25, 0xc7, 0xec, 0x2a, 0xce, 0x4e, 0x8b, 0xa7, 0x04, 0x39, 0x80, 0x9e, 0xc2, 0x70, 0x46, 0x43,
-	0x3e, 0x0f, 0x84, 0xde, 0x2f, 0x5e, 0x7a, 0x86, 0x3b, 0x4f, 0x14, 0x26, 0x15, 0xb3, 0x2b, 0x21,
-	0x29, 0x83, 0x4c, 0x61, 0x63, 0xe9, 0xd7, 0x0a, 0x17, 0x9e, 0x27, 0xf3, 0xb7, 0x2e, 0x0d, 0xdd,
-	0x2f, 0x19, 0x3a, 0x5b, 0x78, 0xde, 0x32, 0x91, 0x7d, 0xfe, 0x06, 0x9f, 0x0c, 0x41, 0xd9, 0x8f,
-	0x8d, 0xc4, 0x4a, 0x3a, 0x29, 0x16, 0x94, 0x89, 0x7e, 0x20, 0x50, 0x9a, 0x5b, 0x9a, 0xe9, 0xf0,
-	0x1c, 0x4d, 0x46, 0xe9, 0xa9, 0xa2, 0xa4, 0xe4, 0xf4, 0x0d, 0x69, 0xe3, 0xe3, 0x5b, 0x6d, 0x64,
-	0x55, 0xd9, 0xe5, 0x79, 0x46, 0x9c, 0x1b, 0x0f, 0xa9, 0xad, 0x8a, 0x57, 0x96, 0xe8, 0x66, 0x31,
-	0x37, 0xcf, 0x33, 0xe9, 0xb2, 0x50, 0xbb, 0x4b, 0x48, 0x5c, 0xae, 0xcf, 0xa0, 0x1b, 0x22, 0x46,
-	0x96, 0x6b, 0x23, 0x13, 0xae, 0xb8, 0xd1, 0xef, 0x15, 0xdb, 0xf0, 0x0c, 0x31, 0x3a, 0x4e, 0x64,
-	0xf1, 0x31, 0xc2, 0x1c, 0x6d, 0x58, 0xb0, 0x7a, 0x41, 0x1d, 0xd2, 0x85, 0xd6, 0x8b, 0x93, 0xd1,
-	0xf8, 0xc7, 0xe3, 0x93, 0xf1, 0xa8, 0x5f, 0x21, 0x2d, 0xa8, 0x8d, 0xa7, 0x67, 0x17, 0x2f, 0xfb,
-	0x1a, 0xe9, 0x40, 0xf3, 0xd4, 0x3c, 0xb2, 0x4e, 0x4f, 0x9e, 0xbf, 0xec, 0xaf, 0xc4, 0x7a, 0x87,
-	0x93, 0xe1, 0x89, 0x22, 0x57, 0x49, 0x1f, 0x3a, 0x92, 0x1c, 0x9e, 0x8c, 0xac, 0x53, 0xf3, 0xa8,
-	0x5f, 0x25, 0x6b, 0xd0, 0x56, 0x0a, 0xa6, 0x64, 0xd4, 0xf2, 0xa3, 0xe9, 0x1f, 0x0d, 0x5a, 0xd9,
-	0x15, 0x91, 0x2d, 0x68, 0xfa, 0x28, 0x68, 0x5c, 0xb0, 0xc9, 0x90, 0xcc, 0x68, 0x32, 0x80, 0x96,
-	0x70, 0x7d, 0xe4, 0x82, 0xfa, 0xa1, 0x1c, 0x4f, 0xed, 0xfd, 0x7e, 0xfe, 0x38, 0x17, 0xae, 0x8f,
-	0xe6, 0x52, 0x85, 0xdc, 0x83, 0x7a, 0xf8, 0xca, 0xb5, 0x5c, 0x5b, 0x4e, 0xad, 0x8e, 0x59, 0x0b,
-	0x5f, 0xb9, 0xc7, 0x36, 0xf9, 0x14, 0xda, 0xc9, 0x50, 0xb3, 0xa6, 0xc3, 0x43, 0xbd, 0x2a, 0x65,
-	0x90, 0xb0, 0xa6, 0xc3, 0x43, 0x63, 0x08, 0xeb, 0xa5, 0xe2, 0x23, 0x8f, 0xa0, 0x89, 0x1e, 0xfa,
-	0xc8, 0x04, 0xd7, 0xb5, 0xed, 0xd5, 0xbc, 0xef, 0x6c, 0x05, 0x64, 0x1a, 0xc6, 0xb7, 0xb0, 0x79,
-	0x5b, 0xd9, 0xbd, 0xe9, 0x5b, 0x2b, 0xf9, 0xbe, 0x82, 0x6e, 0xa1, 0xc7, 0x72, 0x87, 0xd0, 0xf2,
-	0x87, 0xd8, 0x82, 0x66, 0x76, 0xb3, 0x6a, 0x52, 0x67, 0x34, 0x31, 0xa0, 0x2b, 0x3c, 0x6e, 0xcd,
-	0x30, 0x12, 0xd6, 0x9c, 0xf2, 0x79, 0x72, 0xfc, 0xb6, 0xf0, 0xf8, 0x21, 0x46, 0x62, 0x42, 0xf9,
-	0xdc, 0x78, 0x01, 0x9d, 0x7c, 0x05, 0xdc, 0xe5, 0x86, 0x40, 0x35, 0x36, 0x93, 0xb8, 0x90, 0xdf,
-	0x85, 0x2b, 0x5a, 0x2d, 0x5e, 0x91, 0xe1, 0x43, 0x3b, 0x37, 0xae, 0xee, 0x5e, 0x32, 0xb6, 0x1c,
-	0x80, 0x5c, 0x5f, 0xd9, 0x5e, 0xdd, 0x69, 0x99, 0x29, 0x49, 0x06, 0xd0, 0xf4, 0xb9, 0x63, 0x89,
-	0x9b, 0x64, 0xdb, 0xf6, 0x96, 0x53, 0x30, 0xce, 0xe2, 0x94, 0x3b, 0x17, 0x37, 0x21, 0x9a, 0x0d,
-	0x5f, 0x7d, 0x18, 0x01, 0xb4, 0x73, 0xe3, 0xf7, 0x0e, 0x77, 0xf9, 0x78, 0x57, 0x4a, 0x25, 0xf5,
-	0x6e, 0x0e, 0xff, 0x00, 0x58, 0x4e, 0xd6, 0x3b, 0xfc, 0x7d, 0x06, 0xd5, 0xc4, 0xd7, 0xed, 0x55,
-	0x52, 0x7d, 0x2f, 0xcf, 0x9e, 0xf2, 0xac, 0x36, 0xc7, 0xff, 0x9e, 0xd8, 0xa7, 0xea, 0x1e, 0xd3,
-	0xc7, 0xc2, 0xe7, 0xc5, 0x97, 0x4b, 0x7b, 0x7f, 0x2d, 0x43, 0x2b, 0x76, 0xf6, 0x94, 0x31, 0xbe,
-	0x81, 0x46, 0xc2, 0x23, 0x1f, 0x42, 0x83, 0xe3, 0xb5, 0xc5, 0x16, 0x7e, 0x12, 0x66, 0x9d, 0xe3,
-	0xf5, 0xc9, 0xc2, 0x8f, 0xab, 0x2a, 0x77, 0x1b, 0xf2, 0xdb, 0xf8, 0x5b, 0x83, 0x4e, 0xfe, 0x69,
-	0x40, 0x06, 0x00, 0x7e, 0xb6, 0xc1, 0x13, 0xb7, 0xbd, 0xe2, 0x6e, 0x37, 0x73, 0x1a, 0xef, 0x3c,
-	0x1d, 0xf2, 0x1d, 0x54, 0x2d, 0x76, 0x90, 0xf1, 0xa7, 0x06, 0xeb, 0xa5, 0x19, 0x7b, 0x57, 0x8f,
-	0xbc, 0xab, 0xe3, 0x07, 0xd0, 0x73, 0xb9, 0x65, 0xe3, 0xcc, 0xa3, 0x11, 0x15, 0x6e, 0xc0, 0xe4,
-	0x8d, 0x34, 0xcd, 0xae, 0xcb, 0x47, 0x4b, 0xa6, 0xf1, 0x1d, 0x34, 0x53, 0x74, 0x9c, 0x49, 0x97,
-	0xcd, 0xf2, 0x99, 0x74, 0xd9, 0x2c, 0xce, 0x64, 0x2e, 0xc5, 0x2b, 0xf9, 0x14, 0x1b, 0x57, 0xb0,
-	0x5e, 0x7a, 0x35, 0x91, 0x67, 0xd0, 0xe7, 0xe8, 0x5d, 0xc9, 0x75, 0x19, 0xf9, 0xca, 0xb7, 0x56,
-	0x0c, 0x38, 0xab, 0xd2, 0xb5, 0x58, 0xf3, 0x78, 0xa9, 0x18, 0x97, 0xdc, 0x2b, 0x16, 0xfc, 0xce,
-	0x64, 0x69, 0x75, 0x4c, 0x45, 0x18, 0x97, 0x40, 0xca, 0xef, 0x2c, 0xf2, 0x10, 0x6a, 0xf2, 0x59,
-	0x77, 0xe7, 0xa4, 0x54, 0x62, 0xd9, 0x2a, 0x48, 0xed, 0xb7, 0xb4, 0x0a, 0x52, 0xdb, 0xf8, 0x19,
-	0xea, 0xca, 0x47, 0x7c, 0x67, 0x58, 0x78, 0xf7, 0x9a, 0x19, 0xfd, 0xd6, 0x36, 0xbf, 0x7d, 0x13,
-	0x18, 0x0d, 0xa8, 0xc9, 0x67, 0x8f, 0xf1, 0x0b, 0x90, 0xf2, 0x72, 0x8f, 0xe7, 0x28, 0x17, 0x34,
-	0x12, 0x56, 0xb1, 0x8a, 0xdb, 0x92, 0x79, 0xae, 0x4a, 0xf9, 0x13, 0x68, 0x23, 0xb3, 0xad, 0xe2,
-	0x25, 0xb4, 0x90, 0xd9, 0x4a, 0x6e, 0x1c, 0xc0, 0xc6, 0x2d, 0x2b, 0x9f, 0xec, 0x42, 0x33, 0x69,
-	0x98, 0x74, 0x9b, 0x94, 0x3a, 0x2a, 0x53, 0xf8, 0xe2, 0x7b, 0x68, 0xe7, 0x9a, 0xf4, 0xcd, 0xad,
-	0xdc, 0x85, 0xd6, 0xc1, 0xf3, 0xd3, 0xc3, 0x9f, 0xac, 0xe9, 0xf9, 0x51, 0x5f, 0x8b, 0x97, 0xef,
-	0xf1, 0x68, 0x7c, 0x72, 0x71, 0x7c, 0xf1, 0x52, 0x72, 0x56, 0xf6, 0x7f, 0x83, 0xba, 0x1a, 0x92,
-	0xe4, 0x29, 0x74, 0xd4, 0xd7, 0xb9, 0x88, 0x90, 0xfa, 0xa4, 0x94, 0xf0, 0xad, 0x12, 0xc7, 0xa8,
-	0xec, 0x68, 0x8f, 0x35, 0xf2, 0x10, 0xaa, 0x67, 0x2e, 0x73, 0x48, 0xf1, 0xb9, 0xb8, 0x55, 0x24,
-	0x8d, 0xca, 0xc1, 0x97, 0xbf, 0xee, 0x3a, 0xae, 0x98, 0x2f, 0x2e, 0x07, 0xb3, 0xc0, 0xdf, 0x9b,
-	0xdf, 0x84, 0x18, 0x79, 0x68, 0x3b, 0x18, 0xed, 0x5d, 0xd1, 0xcb, 0xc8, 0x9d, 0xed, 0xc9, 0x3f,
-	0x35, 0xbe, 0xa7, 0x60, 0x97, 0x75, 0x49, 0x3e, 0xf9, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x74, 0x25,
-	0x05, 0xf1, 0xd0, 0x0d, 0x00, 0x00,
+	// 1478 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0x5b, 0x6f, 0xdb, 0xc6,
+	0x12, 0x16, 0xad, 0x2b, 0x47, 0x17, 0xcb, 0x6b, 0x27, 0x87, 0xc7, 0x27, 0x38, 0x35, 0xd8, 0x26,
+	0x48, 0xeb, 0x54, 0x4e, 0x9d, 0x5e, 0x52, 0xa4, 0x45, 0x21, 0x5b, 0xaa, 0x65, 0x34, 0xb2, 0x0d,
+	0xda, 0x41, 0x9b, 0xbe, 0x10, 0x6b, 0x71, 0x4d, 0xb1, 0x21, 0x97, 0x34, 0x77, 0x95, 0xd4, 0x40,
+	0xdf, 0xfa, 0xd6, 0x5f, 0xd0, 0xfe, 0x9e, 0xfe, 0xb1, 0x62, 0x77, 0x79, 0xb5, 0xec, 0x00, 0x09,
+	0xd0, 0x37, 0xce, 0x7d, 0xf6, 0xdb, 0x99, 0xd9, 0x21, 0x6c, 0xb8, 0x21, 0x63, 0x5e, 0xb4, 0x13,
+	0x10, 0xc6, 0xb0, 0x4b, 0x06, 0x51, 0x1c, 0xf2, 0x10, 0x35, 0x14, 0xd7, 0xfc, 0x5d, 0x83, 0xd6,
+	0x98, 0xbe, 0x26, 0x7e, 0x18, 0x11, 0x64, 0x40, 0x33, 0xc2, 0x57, 0x7e, 0x88, 0x1d, 0x43, 0xdb,
+	0xd2, 0x1e, 0x76, 0xac, 0x94, 0x44, 0xf7, 0x40, 0x67, 0x9e, 0x4b, 0x31, 0x5f, 0xc4, 0xc4, 0x58,
+	0x91, 0xb2, 0x9c, 0x81, 0xbe, 0x83, 0x55, 0x46, 0x66, 0x31, 0xe1, 0x36, 0x49, 0x5c, 0x19, 0xd5,
+	0x2d, 0xed, 0x61, 0x7b, 0xf7, 0xee, 0x40, 0x85, 0x19, 0x9c, 0x4a, 0x71, 0x1a, 0xc8, 0xea, 0xb1,
+	0x12, 0x6d, 0x4e, 0xa0, 0x57, 0xd6, 0x78, 0xdf, 0x54, 0xcc, 0x21, 0x34, 0x94, 0x27, 0xf4, 0x08,
+	0xfa, 0x1e, 0xe5, 0x24, 0xa6, 0xd8, 0x1f, 0x53, 0x27, 0x0a, 0x3d, 0xca, 0xa5, 0x2b, 0x7d, 0x52,
+	0xb1, 0x96, 0x24, 0x7b, 0x3a, 0x34, 0x67, 0x21, 0xe5, 0x84, 0x72, 0xf3, 0x4f, 0x1d, 0xba, 0x07,
+	0x32, 0xed, 0xa9, 0x82, 0x0c, 0x6d, 0x40, 0x9d, 0x86, 0x74, 0x46, 0xa4, 0x7d, 0xcd, 0x52, 0x84,
+	0x48, 0x71, 0x36, 0xc7, 0x94, 0x12, 0x3f, 0x49, 0x23, 0x25, 0xd1, 0x36, 0x54, 0x39, 0x76, 0x25,
+	0x06, 0xbd, 0xdd, 0xff, 0xa6, 0x18, 0x94, 0x7c, 0x0e, 0xce, 0xb0, 0x6b, 0x09, 0x2d, 0xf4, 0x04,
+	0x74, 0xec, 0x7b, 0xaf, 0x89, 0x1d, 0x30, 0xd7, 0xa8, 0x4b, 0xd8, 0x36, 0x52, 0x93, 0xa1, 0x10,
+	0x24, 0x16, 0x93, 0x8a, 0xd5, 0x92, 0x8a, 0x53, 0xe6, 0xa2, 0xcf, 0xa1, 0x19, 0x90, 0xc0, 0x8e,
+	0xc9, 0xa5, 0xd1, 0x90, 0x26, 0x59, 0x94, 0x29, 0x09, 0xce, 0x49, 0xcc, 0xe6, 0x5e, 0x64, 0x91,
+	0xcb, 0x05, 0x61, 0x7c, 0x52, 0xb1, 0x1a, 0x01, 0x09, 0x2c, 0x72, 0x89, 0xbe, 0x48, 0xad, 0x98,
+	0xd1, 0x94, 0x56, 0x9b, 0x37, 0x59, 0xb1, 0x28, 0xa4, 0x8c, 0x64, 0x66, 0x0c, 0x3d, 0x86, 0x96,
+	0x83, 0x39, 0x96, 0x09, 0xb6, 0xa4, 0xdd, 0x7a, 0x6a, 0x37, 0xc2, 0x1c, 0xe7, 0xf9, 0x35, 0x85,
+	0x9a, 0x48, 0x6f, 0x1b, 0xea, 0x73, 0xe2, 0xfb, 0xa1, 0xa1, 0x97, 0xd5, 0x15, 0x04, 0x13, 0x21,
+	0x9a, 0x54, 0x2c, 0xa5, 0x83, 0x76, 0x12, 0xf7, 0x8e, 0xe7, 0x1a, 0x20, 0xf5, 0x51, 0xd1, 0xfd,
+	0xc8, 0x73, 0xd5, 0x29, 0xa4, 0xf7, 0x91, 0xe7, 0x66, 0xf9, 0x88, 0xd3, 0xb7, 0x97, 0xf3, 0xc9,
+	0xcf, 0x2d, 0x2d, 0xd4, 0xc1, 0xdb, 0xd2, 0x62, 0x11, 0x39, 0x98, 0x13, 0xa3, 0xb3, 0x1c, 0xe5,
+	0x85, 0x94, 0x4c, 0x2a, 0x16, 0x38, 0x19, 0x85, 0xee, 0x43, 0x9d, 0x04, 0x11, 0xbf, 0x32, 0xba,
+	0xd2, 0xa0, 0x9b, 0x1a, 0x8c, 0x05, 0x53, 0x1c, 0x40, 0x4a, 0xd1, 0x36, 0xd4, 0x66, 0x21, 0xa5,
+	0x46, 0x4f, 0x6a, 0xdd, 0x49, 0xb5, 0xf6, 0x43, 0x4a, 0xc7, 0x8c, 0xe3, 0x73, 0xdf, 0x63, 0xf3,
+	0x49, 0xc5, 0x92, 0x4a, 0x68, 0x17, 0x80, 0x71, 0xcc, 0x89, 0xed, 0xd1, 0x8b, 0xd0, 0x58, 0x95,
+	0x26, 0x6b, 0x59, 0x9b, 0x08, 0xc9, 0x21, 0xbd, 0x10, 0xe8, 0xe8, 0x2c, 0x25, 0xd0, 0x1e, 0xf4,
+	0x94, 0x0d, 0xa3, 0x38, 0x62, 0xf3, 0x90, 0x1b, 0xfd, 0xf2, 0xa5, 0x67, 0x76, 0xa7, 0x89, 0xc2,
+	0xa4, 0x62, 0x75, 0xa5, 0x49, 0xca, 0x40, 0x53, 0x58, 0xcf, 0xe3, 0xda, 0xd1, 0xc2, 0xf7, 0x25,
+	0x7e, 0x6b, 0xd2, 0xd1, 0xbd, 0x25, 0x47, 0x27, 0x0b, 0xdf, 0xcf, 0x81, 0xec, 0xb3, 0x6b, 0x7c,
+	0x34, 0x04, 0xe5, 0xdf, 0x8e, 0x95, 0x92, 0x81, 0xca, 0x05, 0x65, 0x91, 0x20, 0xe4, 0x44, 0xba,
+	0xcb, 0xdd, 0x74, 0x58, 0x81, 0x46, 0xa3, 0xf4, 0x54, 0x71, 0x52, 0x72, 0xc6, 0xba, 0xf4, 0xf1,
+	0xbf, 0x1b, 0x7d, 0x64, 0x55, 0xd9, 0x65, 0x45, 0x86, 0xc0, 0xc6, 0x27, 0xd8, 0x51, 0xc5, 0x2b,
+	0x4b, 0x74, 0xa3, 0x8c, 0xcd, 0xf3, 0x4c, 0x9a, 0x17, 0x6a, 0x37, 0x37, 0x11, 0xe5, 0xfa, 0x0c,
+	0xba, 0x11, 0x21, 0xb1, 0xed, 0x39, 0x84, 0x72, 0x8f, 0x5f, 0x19, 0x77, 0xca, 0x6d, 0x78, 0x42,
+	0x48, 0x7c, 0x98, 0xc8, 0xc4, 0x31, 0xa2, 0x02, 0x6d, 0xda, 0x50, 0x3d, 0xc3, 0x2e, 0xea, 0x82,
+	0xfe, 0xe2, 0x68, 0x34, 0xfe, 0xfe, 0xf0, 0x68, 0x3c, 0xea, 0x57, 0x90, 0x0e, 0xf5, 0xf1, 0xf4,
+	0xe4, 0xec, 0x65, 0x5f, 0x43, 0x1d, 0x68, 0x1d, 0x5b, 0x07, 0xf6, 0xf1, 0xd1, 0xf3, 0x97, 0xfd,
+	0x15, 0xa1, 0xb7, 0x3f, 0x19, 0x1e, 0x29, 0xb2, 0x8a, 0xfa, 0xd0, 0x91, 0xe4, 0xf0, 0x68, 0x64,
+	0x1f, 0x5b, 0x07, 0xfd, 0x1a, 0x5a, 0x85, 0xb6, 0x52, 0xb0, 0x24, 0xa3, 0x5e, 0x1c, 0x4d, 0x7f,
+	0x6b, 0xa0, 0x67, 0x57, 0x84, 0x36, 0xa1, 0x15, 0x10, 0x8e, 0x45, 0xc1, 0x26, 0x43, 0x32, 0xa3,
+	0xd1, 0x00, 0x74, 0xee, 0x05, 0x84, 0x71, 0x1c, 0x44, 0x72, 0x3c, 0xb5, 0x77, 0xfb, 0xc5, 0xe3,
+	0x9c, 0x79, 0x01, 0xb1, 0x72, 0x15, 0x74, 0x07, 0x1a, 0xd1, 0x2b, 0xcf, 0xf6, 0x1c, 0x39, 0xb5,
+	0x3a, 0x56, 0x3d, 0x7a, 0xe5, 0x1d, 0x3a, 0xe8, 0x03, 0x68, 0x27, 0x43, 0xcd, 0x9e, 0x0e, 0xf7,
+	0x8d, 0x9a, 0x94, 0x41, 0xc2, 0x9a, 0x0e, 0xf7, 0x45, 0x39, 0x47, 0x71, 0x18, 0x91, 0x98, 0x7b,
+	0x84, 0x19, 0xf5, 0x72, 0x63, 0x9d, 0x64, 0x12, 0xab, 0xa0, 0x65, 0xfe, 0x06, 0x90, 0x4b, 0xd0,
+	0x87, 0xd0, 0xf5, 0x89, 0xe3, 0x92, 0xd8, 0x9e, 0x13, 0xcf, 0x9d, 0xf3, 0x64, 0xc8, 0x76, 0x14,
+	0x73, 0x22, 0x79, 0xe8, 0x2e, 0x34, 0xd4, 0x95, 0xc9, 0xb3, 0xb4, 0xac, 0x84, 0x42, 0x9f, 0x81,
+	0x48, 0xc6, 0xa3, 0xb3, 0xd0, 0x21, 0xcc, 0xa8, 0x6e, 0x55, 0x8b, 0xdd, 0xb4, 0x9f, 0x4a, 0xac,
+	0x82, 0x92, 0xf9, 0x35, 0xe8, 0x99, 0x00, 0x21, 0xa8, 0x51, 0x1c, 0xa8, 0xc1, 0xae, 0x5b, 0xf2,
+	0x5b, 0xcc, 0xf5, 0xd7, 0x24, 0x66, 0x5e, 0x48, 0x65, 0x30, 0xdd, 0x4a, 0x49, 0x73, 0x08, 0x6b,
+	0x4b, 0x9d, 0x86, 0x1e, 0x41, 0x8b, 0xf8, 0x24, 0x20, 0x94, 0x33, 0x43, 0xdb, 0xaa, 0x16, 0x81,
+	0xce, 0xde, 0xbb, 0x4c, 0xc3, 0xfc, 0x0a, 0x36, 0x6e, 0xea, 0xb1, 0xeb, 0x40, 0x6b, 0xd7, 0x81,
+	0x36, 0x2f, 0xa0, 0x5b, 0x1a, 0x28, 0x85, 0x1b, 0xd3, 0x8a, 0x37, 0xb6, 0x09, 0xad, 0xac, 0x8c,
+	0xd5, 0xb3, 0x94, 0xd1, 0xc8, 0x84, 0x2e, 0xf7, 0x99, 0x3d, 0x23, 0x31, 0xb7, 0xe7, 0x98, 0xcd,
+	0x93, 0xbb, 0x6e, 0x73, 0x9f, 0xed, 0x93, 0x98, 0x4f, 0x30, 0x9b, 0x9b, 0x2f, 0xa0, 0x53, 0x2c,
+	0xf7, 0xdb, 0xc2, 0x20, 0xa8, 0x09, 0x37, 0x49, 0x08, 0xf9, 0x5d, 0xaa, 0xc7, 0x6a, 0xb9, 0x1e,
+	0xcd, 0x00, 0xda, 0x85, 0xd9, 0x7c, 0xfb, 0x8b, 0xea, 0xc8, 0x69, 0xcf, 0x8c, 0x95, 0xad, 0xaa,
+	0x40, 0x3e, 0x21, 0xd1, 0x00, 0x5a, 0x01, 0x73, 0x6d, 0x7e, 0x95, 0xac, 0x16, 0xbd, 0x7c, 0xe4,
+	0x0b, 0x14, 0xa7, 0xcc, 0x3d, 0xbb, 0x8a, 0x88, 0xd5, 0x0c, 0xd4, 0x87, 0x19, 0x42, 0xbb, 0xf0,
+	0xd6, 0xdc, 0x12, 0xae, 0x98, 0xef, 0xca, 0x52, 0xff, 0xbc, 0x5b, 0xc0, 0x5f, 0x01, 0xf2, 0x67,
+	0xe4, 0x96, 0x78, 0x1f, 0x41, 0x2d, 0x89, 0x75, 0x73, 0x95, 0xd4, 0xde, 0x2b, 0xb2, 0x0f, 0x90,
+	0x3f, 0x93, 0xff, 0x3a, 0xb0, 0x4f, 0xd5, 0x3d, 0xa6, 0x9b, 0xd1, 0xc7, 0xe5, 0x35, 0xad, 0xbd,
+	0xbb, 0x9a, 0x59, 0x2b, 0x76, 0xb6, 0xb7, 0x99, 0x5f, 0x42, 0x33, 0xe1, 0xa1, 0xff, 0x40, 0x93,
+	0x91, 0x4b, 0x9b, 0x2e, 0x82, 0x24, 0xcd, 0x06, 0x23, 0x97, 0x47, 0x8b, 0x40, 0x54, 0x55, 0xe1,
+	0x36, 0xe4, 0xb7, 0xf9, 0x87, 0x06, 0x9d, 0xe2, 0x1e, 0x84, 0x06, 0x00, 0x41, 0xb6, 0xae, 0x24,
+	0x61, 0x7b, 0xe5, 0x45, 0xc6, 0x2a, 0x68, 0xbc, 0xf3, 0x28, 0x2c, 0x76, 0x50, 0xad, 0xdc, 0x41,
+	0xe6, 0x5f, 0x1a, 0xac, 0x2d, 0x3d, 0x28, 0xb7, 0xf5, 0xc8, 0xbb, 0x06, 0xbe, 0x0f, 0x3d, 0x8f,
+	0xd9, 0x0e, 0x99, 0xf9, 0x38, 0xc6, 0x5c, 0xcc, 0x9f, 0xaa, 0x1c, 0x76, 0x5d, 0x8f, 0x8d, 0x72,
+	0xa6, 0x98, 0x85, 0x6f, 0xd4, 0xa4, 0x14, 0xd9, 0x75, 0xad, 0x84, 0x32, 0xbf, 0x81, 0x56, 0xea,
+	0x55, 0x20, 0xec, 0xd1, 0x59, 0x11, 0x61, 0x8f, 0xce, 0x04, 0xc2, 0x05, 0xe8, 0x57, 0x8a, 0xd0,
+	0x9b, 0x17, 0xb0, 0xb6, 0xb4, 0x3a, 0xa2, 0x67, 0xd0, 0x67, 0xc4, 0xbf, 0x90, 0x3b, 0x43, 0x1c,
+	0xa8, 0x9c, 0xb4, 0x2d, 0xed, 0xc6, 0xea, 0x5d, 0x15, 0x9a, 0x87, 0xb9, 0xa2, 0x28, 0xc5, 0x57,
+	0x34, 0x7c, 0x43, 0x65, 0xc9, 0x75, 0x2c, 0x45, 0x98, 0xe7, 0x80, 0x96, 0x97, 0x4d, 0xf4, 0x00,
+	0xea, 0x72, 0xb7, 0xbd, 0x75, 0x82, 0x2a, 0xb1, 0x6c, 0x21, 0x82, 0x9d, 0xb7, 0xb4, 0x10, 0xc1,
+	0x8e, 0xf9, 0x23, 0x34, 0x54, 0x0c, 0x71, 0x97, 0xa4, 0xb4, 0xfc, 0x5b, 0x19, 0xfd, 0xd6, 0xf6,
+	0xbf, 0xf9, 0x39, 0x34, 0x9b, 0x50, 0x97, 0xbb, 0x9f, 0xf9, 0x13, 0xa0, 0xe5, 0x0d, 0x47, 0xcc,
+	0x57, 0xc6, 0x71, 0xcc, 0xed, 0x72, 0x75, 0xb7, 0x25, 0xf3, 0x54, 0x95, 0xf8, 0xff, 0xa1, 0x4d,
+	0xa8, 0x63, 0x97, 0x2f, 0x41, 0x27, 0xd4, 0x51, 0x72, 0x73, 0x0f, 0xd6, 0x6f, 0xd8, 0x7b, 0xd0,
+	0x36, 0xb4, 0x92, 0x46, 0x4a, 0x5f, 0x99, 0xa5, 0x4e, 0xcb, 0x14, 0x3e, 0xf9, 0x16, 0xda, 0x85,
+	0xe6, 0xbd, 0xbe, 0x9a, 0x74, 0x41, 0xdf, 0x7b, 0x7e, 0xbc, 0xff, 0x83, 0x3d, 0x3d, 0x3d, 0xe8,
+	0x6b, 0x62, 0x03, 0x39, 0x1c, 0x8d, 0x8f, 0xce, 0x0e, 0xcf, 0x5e, 0x4a, 0xce, 0xca, 0xee, 0x2f,
+	0xd0, 0x50, 0xc3, 0x13, 0x3d, 0x85, 0x8e, 0xfa, 0x3a, 0xe5, 0x31, 0xc1, 0x01, 0x5a, 0x02, 0x7c,
+	0x73, 0x89, 0x63, 0x56, 0x1e, 0x6a, 0x8f, 0x35, 0xf4, 0x00, 0x6a, 0x27, 0x1e, 0x75, 0x51, 0x79,
+	0x67, 0xde, 0x2c, 0x93, 0x66, 0x65, 0xef, 0xd3, 0x9f, 0xb7, 0x5d, 0x8f, 0xcf, 0x17, 0xe7, 0x83,
+	0x59, 0x18, 0xec, 0xcc, 0xaf, 0x22, 0x12, 0xab, 0x87, 0x7f, 0xe7, 0x02, 0x9f, 0xc7, 0xde, 0x6c,
+	0x47, 0xfe, 0xae, 0xb2, 0x1d, 0x65, 0x76, 0xde, 0x90, 0xe4, 0x93, 0x7f, 0x06, 0x00, 0x09, 0x29,
+	0x0d, 0xc3, 0xd5, 0x0e, 0x00, 0x00,
 }
@@ -11,12 +11,16 @@ It is generated from these files:
 
 It has these top-level messages:
 	SerializedIdentity
+	SerializedIdemixIdentity
 	MSPConfig
 	FabricMSPConfig
 	FabricCryptoConfig
 	SigningIdentityInfo
 	KeyInfo
 	FabricOUIdentifier
+	FabricNodeOUs
+	IdemixMSPConfig
+	IdemixMSPSignerConfig
 	MSPPrincipal
 	OrganizationUnit
 	MSPRole
@@ -67,23 +71,89 @@ func (m *SerializedIdentity) GetIdBytes() []byte {
 	return nil
 }
 
+// This struct represents an Idemix Identity
+// to be used to serialize it and deserialize it.
+// The IdemixMSP will first serialize an idemix identity to bytes using
+// this proto, and then uses these bytes as id_bytes in SerializedIdentity
+type SerializedIdemixIdentity struct {
+	// nym is the serialized pseudonym of this identity
+	Nym []byte `protobuf:"bytes,1,opt,name=nym,proto3" json:"nym,omitempty"`
+	// ou contains the organizational unit of the idemix identity,
+	// if disclosed
+	Ou string `protobuf:"bytes,2,opt,name=ou" json:"ou,omitempty"`
+	// role contains the role of this identity (e.g., ADMIN or MEMBER),
+	// if disclosed
+	Role int32 `protobuf:"varint,3,opt,name=role" json:"role,omitempty"`
+	// disclosure tells, for each attribute of the credential,
+	// whether it is disclosed by this identity
+	Disclosure []byte `protobuf:"bytes,4,opt,name=disclosure,proto3" json:"disclosure,omitempty"`
+	// proof contains the cryptographic evidence that this identity is valid
+	Proof []byte `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *SerializedIdemixIdentity) Reset()                    { *m = SerializedIdemixIdentity{} }
+func (m *SerializedIdemixIdentity) String() string            { return proto.CompactTextString(m) }
+func (*SerializedIdemixIdentity) ProtoMessage()               {}
+func (*SerializedIdemixIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SerializedIdemixIdentity) GetNym() []byte {
+	if m != nil {
+		return m.Nym
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetOu() string {
+	if m != nil {
+		return m.Ou
+	}
+	return ""
+}
+
+func (m *SerializedIdemixIdentity) GetRole() int32 {
+	if m != nil {
+		return m.Role
+	}
+	return 0
+}
+
+func (m *SerializedIdemixIdentity) GetDisclosure() []byte {
+	if m != nil {
+		return m.Disclosure
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SerializedIdentity)(nil), "msp.SerializedIdentity")
+	proto.RegisterType((*SerializedIdemixIdentity)(nil), "msp.SerializedIdemixIdentity")
 }
 
 func init() { proto.RegisterFile("msp/identities.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 171 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0xcd, 0x3d, 0x0b, 0xc2, 0x30,
-	0x10, 0xc6, 0x71, 0xaa, 0xf8, 0x16, 0x9c, 0x42, 0x87, 0xba, 0x55, 0xa7, 0x4e, 0xc9, 0xe0, 0x37,
-	0x28, 0x38, 0x38, 0xb8, 0xd4, 0xcd, 0x45, 0x9a, 0xe6, 0x6c, 0x0f, 0x1a, 0x73, 0xe4, 0xe2, 0x50,
-	0x3f, 0xbd, 0x68, 0x16, 0xb7, 0xe7, 0x0f, 0x3f, 0x78, 0x44, 0xee, 0x98, 0x34, 0x5a, 0x78, 0x46,
-	0x8c, 0x08, 0xac, 0x28, 0xf8, 0xe8, 0xe5, 0xdc, 0x31, 0x1d, 0x4e, 0x42, 0x5e, 0x21, 0x60, 0x3b,
-	0xe2, 0x1b, 0xec, 0x39, 0x91, 0x49, 0xe6, 0x62, 0xe1, 0x98, 0xd0, 0x16, 0x59, 0x99, 0x55, 0x9b,
-	0x26, 0x85, 0xdc, 0x89, 0x35, 0xda, 0xbb, 0x99, 0x22, 0x70, 0x31, 0x2b, 0xb3, 0x6a, 0xdb, 0xac,
-	0xd0, 0xd6, 0xdf, 0xac, 0x2f, 0x62, 0xef, 0x43, 0xaf, 0x86, 0x89, 0x20, 0x8c, 0x60, 0x7b, 0x08,
-	0xea, 0xd1, 0x9a, 0x80, 0x5d, 0xfa, 0x62, 0xe5, 0x98, 0x6e, 0x55, 0x8f, 0x71, 0x78, 0x19, 0xd5,
-	0x79, 0xa7, 0xff, 0xa4, 0x4e, 0x52, 0x27, 0xa9, 0x1d, 0x93, 0x59, 0xfe, 0xf6, 0xf1, 0x13, 0x00,
-	0x00, 0xff, 0xff, 0xaf, 0xfd, 0x80, 0x9c, 0xb9, 0x00, 0x00, 0x00,
+	// 243 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x8f, 0xb1, 0x4e, 0xc3, 0x30,
+	0x10, 0x86, 0x95, 0xa4, 0x01, 0x7a, 0xaa, 0x10, 0xb2, 0x3a, 0x98, 0x05, 0x85, 0x4e, 0x99, 0x92,
+	0x81, 0x37, 0xa8, 0xc4, 0xc0, 0xc0, 0x12, 0x36, 0x16, 0xd4, 0xc4, 0xd7, 0xf4, 0xa4, 0xb8, 0x67,
+	0xf9, 0x12, 0x89, 0xf0, 0x00, 0x3c, 0x37, 0x8a, 0x2d, 0xa1, 0x76, 0xfb, 0x7f, 0xfb, 0xbb, 0x4f,
+	0x77, 0xb0, 0xb5, 0xe2, 0x6a, 0x32, 0x78, 0x1e, 0x69, 0x24, 0x94, 0xca, 0x79, 0x1e, 0x59, 0x65,
+	0x56, 0xdc, 0xee, 0x15, 0xd4, 0x07, 0x7a, 0x3a, 0x0c, 0xf4, 0x83, 0xe6, 0x2d, 0x22, 0xb3, 0xda,
+	0x42, 0x6e, 0xc5, 0x91, 0xd1, 0x49, 0x91, 0x94, 0xeb, 0x26, 0x16, 0xf5, 0x08, 0x77, 0x64, 0xbe,
+	0xda, 0x79, 0x44, 0xd1, 0x69, 0x91, 0x94, 0x9b, 0xe6, 0x96, 0xcc, 0x7e, 0xa9, 0xbb, 0xdf, 0x04,
+	0xf4, 0x95, 0xc7, 0xd2, 0xf7, 0xbf, 0xed, 0x01, 0xb2, 0xf3, 0x6c, 0x83, 0x6b, 0xd3, 0x2c, 0x51,
+	0xdd, 0x43, 0xca, 0x53, 0x70, 0xac, 0x9b, 0x94, 0x27, 0xa5, 0x60, 0xe5, 0x79, 0x40, 0x9d, 0x15,
+	0x49, 0x99, 0x37, 0x21, 0xab, 0x27, 0x00, 0x43, 0xd2, 0x0d, 0x2c, 0x93, 0x47, 0xbd, 0x0a, 0xc3,
+	0x17, 0x2f, 0xcb, 0x8e, 0xce, 0x33, 0x1f, 0x75, 0x1e, 0xbe, 0x62, 0xd9, 0xbf, 0xc3, 0x33, 0xfb,
+	0xbe, 0x3a, 0xcd, 0x0e, 0xfd, 0x80, 0xa6, 0x47, 0x5f, 0x1d, 0x0f, 0xad, 0xa7, 0x2e, 0x1e, 0x2d,
+	0x95, 0x15, 0xf7, 0x59, 0xf6, 0x34, 0x9e, 0xa6, 0xb6, 0xea, 0xd8, 0xd6, 0x17, 0x64, 0x1d, 0xc9,
+	0x3a, 0x92, 0xb5, 0x15, 0xd7, 0xde, 0x84, 0xfc, 0xf2, 0x37, 0x00, 0xd8, 0x7d, 0xe8, 0x19, 0x42,
+	0x01, 0x00, 0x00,
 }
@@ -91,6 +91,9 @@ type FabricMSPConfig struct {
 	// List of TLS intermediate certificates trusted by this MSP;
 	// They are returned by GetTLSIntermediateCerts.
 	TlsIntermediateCerts [][]byte `protobuf:"bytes,10,rep,name=tls_intermediate_certs,json=tlsIntermediateCerts,proto3" json:"tls_intermediate_certs,omitempty"`
+	// FabricNodeOUs contains the configuration to distinguish clients,
+	// peers, orderers and admins from one another based on their OUs.
+	FabricNodeOus *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus" json:"fabric_node_ous,omitempty"`
 }
 
 func (m *FabricMSPConfig) Reset()                    { *m = FabricMSPConfig{} }
@@ -168,6 +171,13 @@ func (m *FabricMSPConfig) GetTlsIntermediateCerts() [][]byte {
 	return nil
 }
 
+func (m *FabricMSPConfig) GetFabricNodeOus() *FabricNodeOUs {
+	if m != nil {
+		return m.FabricNodeOus
+	}
+	return nil
+}
+
 // FabricCryptoConfig contains configuration parameters
 // for the cryptographic algorithms used by the MSP
 // this configuration refers to
@@ -301,6 +311,175 @@ func (m *FabricOUIdentifier) GetOrganizationalUnitIdentifier() string {
 	return ""
 }
 
+// FabricNodeOUs contains configuration to tell apart clients, peers,
+// orderers and admins based on the OUs of their certificates.
+// If the certificate of an OU identifier is empty, then the OU is
+// honored whatever CA of the MSP issued the identity.
+type FabricNodeOUs struct {
+	// If true then an identity that does not contain exactly one of
+	// the specified OUs is considered invalid.
+	Enable bool `protobuf:"varint,1,opt,name=enable" json:"enable,omitempty"`
+	// OU Identifier of the clients
+	ClientOuIdentifier *FabricOUIdentifier `protobuf:"bytes,2,opt,name=client_ou_identifier,json=clientOuIdentifier" json:"client_ou_identifier,omitempty"`
+	// OU Identifier of the peers
+	PeerOuIdentifier *FabricOUIdentifier `protobuf:"bytes,3,opt,name=peer_ou_identifier,json=peerOuIdentifier" json:"peer_ou_identifier,omitempty"`
+	// OU Identifier of the orderers
+	OrdererOuIdentifier *FabricOUIdentifier `protobuf:"bytes,4,opt,name=orderer_ou_identifier,json=ordererOuIdentifier" json:"orderer_ou_identifier,omitempty"`
+	// OU Identifier of the admins
+	AdminOuIdentifier *FabricOUIdentifier `protobuf:"bytes,5,opt,name=admin_ou_identifier,json=adminOuIdentifier" json:"admin_ou_identifier,omitempty"`
+}
+
+func (m *FabricNodeOUs) Reset()                    { *m = FabricNodeOUs{} }
+func (m *FabricNodeOUs) String() string            { return proto.CompactTextString(m) }
+func (*FabricNodeOUs) ProtoMessage()               {}
+func (*FabricNodeOUs) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *FabricNodeOUs) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+func (m *FabricNodeOUs) GetClientOuIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.ClientOuIdentifier
+	}
+	return nil
+}
+
+func (m *FabricNodeOUs) GetPeerOuIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.PeerOuIdentifier
+	}
+	return nil
+}
+
+func (m *FabricNodeOUs) GetOrdererOuIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.OrdererOuIdentifier
+	}
+	return nil
+}
+
+func (m *FabricNodeOUs) GetAdminOuIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.AdminOuIdentifier
+	}
+	return nil
+}
+
+// IdemixMSPConfig collects all the configuration information for
+// an Idemix MSP.
+type IdemixMSPConfig struct {
+	// Name holds the identifier of the MSP
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// ipk represents the (serialized) issuer public key
+	Ipk []byte `protobuf:"bytes,2,opt,name=ipk,proto3" json:"ipk,omitempty"`
+	// signer may contain crypto material to configure a default signer
+	Signer *IdemixMSPSignerConfig `protobuf:"bytes,3,opt,name=signer" json:"signer,omitempty"`
+}
+
+func (m *IdemixMSPConfig) Reset()                    { *m = IdemixMSPConfig{} }
+func (m *IdemixMSPConfig) String() string            { return proto.CompactTextString(m) }
+func (*IdemixMSPConfig) ProtoMessage()               {}
+func (*IdemixMSPConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *IdemixMSPConfig) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IdemixMSPConfig) GetIpk() []byte {
+	if m != nil {
+		return m.Ipk
+	}
+	return nil
+}
+
+func (m *IdemixMSPConfig) GetSigner() *IdemixMSPSignerConfig {
+	if m != nil {
+		return m.Signer
+	}
+	return nil
+}
+
+// IdemixMSPSignerConfig contains the crypto material to set up an idemix signing identity
+type IdemixMSPSignerConfig struct {
+	// cred represents the serialized idemix credential of the default signer
+	Cred []byte `protobuf:"bytes,1,opt,name=cred,proto3" json:"cred,omitempty"`
+	// sk is the secret key of the default signer, corresponding to credential Cred
+	Sk []byte `protobuf:"bytes,2,opt,name=sk,proto3" json:"sk,omitempty"`
+	// organizational_unit_identifier defines the organizational unit the default signer is in
+	OrganizationalUnitIdentifier string `protobuf:"bytes,3,opt,name=organizational_unit_identifier,json=organizationalUnitIdentifier" json:"organizational_unit_identifier,omitempty"`
+	// role defines the MSPRoleType of the default signer
+	Role int32 `protobuf:"varint,4,opt,name=role" json:"role,omitempty"`
+	// enrollment_id contains the enrollment id of this signer
+	EnrollmentId string `protobuf:"bytes,5,opt,name=enrollment_id,json=enrollmentId" json:"enrollment_id,omitempty"`
+	// disclose_ou tells whether signatures of the default signer
+	// disclose its organizational unit
+	DiscloseOu bool `protobuf:"varint,6,opt,name=disclose_ou,json=discloseOu" json:"disclose_ou,omitempty"`
+	// disclose_role tells whether signatures of the default signer
+	// disclose its role
+	DiscloseRole bool `protobuf:"varint,7,opt,name=disclose_role,json=discloseRole" json:"disclose_role,omitempty"`
+}
+
+func (m *IdemixMSPSignerConfig) Reset()                    { *m = IdemixMSPSignerConfig{} }
+func (m *IdemixMSPSignerConfig) String() string            { return proto.CompactTextString(m) }
+func (*IdemixMSPSignerConfig) ProtoMessage()               {}
+func (*IdemixMSPSignerConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+func (m *IdemixMSPSignerConfig) GetCred() []byte {
+	if m != nil {
+		return m.Cred
+	}
+	return nil
+}
+
+func (m *IdemixMSPSignerConfig) GetSk() []byte {
+	if m != nil {
+		return m.Sk
+	}
+	return nil
+}
+
+func (m *IdemixMSPSignerConfig) GetOrganizationalUnitIdentifier() string {
+	if m != nil {
+		return m.OrganizationalUnitIdentifier
+	}
+	return ""
+}
+
+func (m *IdemixMSPSignerConfig) GetRole() int32 {
+	if m != nil {
+		return m.Role
+	}
+	return 0
+}
+
+func (m *IdemixMSPSignerConfig) GetEnrollmentId() string {
+	if m != nil {
+		return m.EnrollmentId
+	}
+	return ""
+}
+
+func (m *IdemixMSPSignerConfig) GetDiscloseOu() bool {
+	if m != nil {
+		return m.DiscloseOu
+	}
+	return false
+}
+
+func (m *IdemixMSPSignerConfig) GetDiscloseRole() bool {
+	if m != nil {
+		return m.DiscloseRole
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*MSPConfig)(nil), "msp.MSPConfig")
 	proto.RegisterType((*FabricMSPConfig)(nil), "msp.FabricMSPConfig")
@@ -308,48 +487,66 @@ func init() {
 	proto.RegisterType((*SigningIdentityInfo)(nil), "msp.SigningIdentityInfo")
 	proto.RegisterType((*KeyInfo)(nil), "msp.KeyInfo")
 	proto.RegisterType((*FabricOUIdentifier)(nil), "msp.FabricOUIdentifier")
+	proto.RegisterType((*FabricNodeOUs)(nil), "msp.FabricNodeOUs")
+	proto.RegisterType((*IdemixMSPConfig)(nil), "msp.IdemixMSPConfig")
+	proto.RegisterType((*IdemixMSPSignerConfig)(nil), "msp.IdemixMSPSignerConfig")
 }
 
 func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 599 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x54, 0xdf, 0x6e, 0xd3, 0x3c,
-	0x14, 0x57, 0xd7, 0xad, 0xfb, 0x7a, 0x9a, 0x76, 0xfb, 0xbc, 0x31, 0x72, 0xc1, 0x46, 0x16, 0x40,
-	0xe4, 0x86, 0x54, 0xda, 0x90, 0xb8, 0xe1, 0x6a, 0x45, 0x13, 0x15, 0x54, 0x4c, 0xa9, 0x76, 0xc3,
-	0x4d, 0xe4, 0xa6, 0x4e, 0x6a, 0x35, 0xb1, 0x23, 0xdb, 0x9d, 0x14, 0xc4, 0x5b, 0xf0, 0xaa, 0x3c,
-	0x00, 0x8a, 0xed, 0xb6, 0x29, 0x4c, 0xbb, 0xb3, 0x7f, 0x7f, 0x4e, 0x8f, 0x7f, 0xe7, 0x34, 0x70,
-	0x5a, 0xc8, 0x72, 0x58, 0xc8, 0x32, 0x4e, 0x38, 0x4b, 0x69, 0x16, 0x96, 0x82, 0x2b, 0x8e, 0xda,
-	0x85, 0x2c, 0xfd, 0x0f, 0xd0, 0x9d, 0x4c, 0xef, 0x46, 0x1a, 0x47, 0x08, 0xf6, 0x55, 0x55, 0x12,
-	0xb7, 0xe5, 0xb5, 0x82, 0x83, 0x48, 0x9f, 0xd1, 0x19, 0x74, 0x8c, 0xcb, 0xdd, 0xf3, 0x5a, 0x81,
-	0x13, 0xd9, 0x9b, 0xff, 0xbb, 0x0d, 0x47, 0xb7, 0x78, 0x26, 0x68, 0xb2, 0xe3, 0x67, 0xb8, 0x30,
-	0xfe, 0x6e, 0xa4, 0xcf, 0xe8, 0x1c, 0x40, 0x70, 0xae, 0xe2, 0x84, 0x08, 0x25, 0xdd, 0x3d, 0xaf,
-	0x1d, 0x38, 0x51, 0xb7, 0x46, 0x46, 0x35, 0x80, 0xde, 0x01, 0xa2, 0x4c, 0x11, 0x51, 0x90, 0x39,
-	0xc5, 0x8a, 0x58, 0x59, 0x5b, 0xcb, 0xfe, 0x6f, 0x32, 0x46, 0x7e, 0x06, 0x1d, 0x3c, 0x2f, 0x28,
-	0x93, 0xee, 0xbe, 0x96, 0xd8, 0x1b, 0x7a, 0x0b, 0x47, 0x82, 0x3c, 0xf0, 0x04, 0x2b, 0xca, 0x59,
-	0x9c, 0x53, 0xa9, 0xdc, 0x03, 0x2d, 0x18, 0x6c, 0xe1, 0xaf, 0x54, 0x2a, 0x34, 0x82, 0x63, 0x49,
-	0x33, 0x46, 0x59, 0x16, 0xd3, 0x39, 0x61, 0x8a, 0xaa, 0xca, 0xed, 0x78, 0xad, 0xa0, 0x77, 0xe5,
-	0x86, 0x85, 0x2c, 0xc3, 0xa9, 0x21, 0xc7, 0x96, 0x1b, 0xb3, 0x94, 0x47, 0x47, 0x72, 0x17, 0x44,
-	0x31, 0xbc, 0xe4, 0x22, 0xc3, 0x8c, 0xfe, 0xd0, 0x85, 0x71, 0x1e, 0xaf, 0x18, 0x55, 0xb6, 0x60,
-	0x4a, 0x89, 0x90, 0xee, 0xa1, 0xd7, 0x0e, 0x7a, 0x57, 0xcf, 0x75, 0x4d, 0x13, 0xd3, 0xb7, 0xfb,
-	0xf1, 0x86, 0x8f, 0xce, 0x77, 0xfd, 0xf7, 0x8c, 0xaa, 0x2d, 0x2b, 0xd1, 0x47, 0xe8, 0x27, 0xa2,
-	0x2a, 0x15, 0xb7, 0x13, 0x73, 0xff, 0xd3, 0x2d, 0x36, 0xcb, 0x8d, 0x34, 0x6f, 0x82, 0x8f, 0x9c,
-	0xa4, 0x71, 0x43, 0xaf, 0x61, 0xa0, 0x72, 0x19, 0x37, 0x62, 0xef, 0xea, 0x2c, 0x1c, 0x95, 0xcb,
-	0x68, 0x93, 0xfc, 0x7b, 0x38, 0xab, 0x55, 0x8f, 0xa4, 0x0f, 0x5a, 0x7d, 0xaa, 0x72, 0x39, 0xfe,
-	0x7b, 0x00, 0xfe, 0xaf, 0x16, 0xa0, 0x7f, 0x1b, 0x40, 0x57, 0xf0, 0xac, 0x0e, 0x09, 0xab, 0x95,
-	0x20, 0xf1, 0x02, 0xcb, 0x45, 0x9c, 0xe2, 0x82, 0xe6, 0x95, 0x5d, 0x85, 0x93, 0x0d, 0xf9, 0x19,
-	0xcb, 0xc5, 0xad, 0xa6, 0xd0, 0x18, 0x2e, 0xd7, 0x23, 0x68, 0x44, 0x67, 0xdd, 0x2b, 0x96, 0xd4,
-	0xd1, 0xe8, 0xa5, 0xeb, 0x46, 0x17, 0x6b, 0xe1, 0x36, 0x24, 0x5d, 0xc8, 0xaa, 0x7c, 0x0e, 0x27,
-	0x8f, 0x0c, 0x0e, 0xbd, 0x82, 0x7e, 0xb9, 0x9a, 0xe5, 0x34, 0x89, 0xeb, 0xdf, 0x27, 0x42, 0x77,
-	0xe3, 0x44, 0x8e, 0x01, 0xa7, 0x1a, 0x43, 0xd7, 0x30, 0x28, 0x05, 0x7d, 0xa8, 0x9f, 0x6f, 0x55,
-	0x7b, 0x3a, 0x6c, 0x47, 0x87, 0xfd, 0x85, 0x98, 0x1d, 0xe8, 0x5b, 0x8d, 0x31, 0xf9, 0x53, 0x38,
-	0xb4, 0x0c, 0x7a, 0x03, 0x83, 0x25, 0x69, 0xbe, 0xc0, 0xbe, 0xb9, 0xbf, 0x24, 0x8d, 0x76, 0xd1,
-	0x25, 0x38, 0xb5, 0xac, 0xc0, 0x8a, 0x08, 0x8a, 0x73, 0xfb, 0x6f, 0xea, 0x2d, 0x49, 0x35, 0xb1,
-	0x90, 0xff, 0x73, 0x1d, 0x6d, 0x73, 0x55, 0x90, 0x07, 0xbd, 0x7a, 0x2c, 0x34, 0xa5, 0x09, 0x56,
-	0xc4, 0x3e, 0xa1, 0x09, 0xa1, 0x4f, 0x70, 0xf1, 0xf4, 0x3a, 0xda, 0x14, 0x5f, 0x3c, 0xb5, 0x74,
-	0x37, 0x31, 0x5c, 0x72, 0x91, 0x85, 0x8b, 0xaa, 0x24, 0x22, 0x27, 0xf3, 0x8c, 0x88, 0x30, 0xd5,
-	0xdd, 0x98, 0xcf, 0x85, 0xac, 0xe3, 0xb8, 0x39, 0x9e, 0xc8, 0xd2, 0x8c, 0xfc, 0x0e, 0x27, 0x4b,
-	0x9c, 0x91, 0xef, 0x41, 0x46, 0xd5, 0x62, 0x35, 0x0b, 0x13, 0x5e, 0x0c, 0x1b, 0xde, 0xa1, 0xf1,
-	0x0e, 0x8d, 0xb7, 0xfe, 0xf8, 0xcc, 0x3a, 0xfa, 0x7c, 0xfd, 0x27, 0x00, 0x00, 0xff, 0xff, 0x2e,
-	0xb1, 0x83, 0xaa, 0x8e, 0x04, 0x00, 0x00,
+	// 844 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x6e, 0x23, 0x35,
+	0x14, 0x56, 0x92, 0x36, 0x6d, 0x4e, 0x26, 0x49, 0xd7, 0xfd, 0x61, 0x84, 0xd8, 0xdd, 0x74, 0x00,
+	0x91, 0x1b, 0x52, 0xa9, 0x8b, 0x84, 0x84, 0xb8, 0xda, 0xc2, 0x42, 0xb4, 0x94, 0xae, 0xa6, 0xea,
+	0x0d, 0x37, 0x23, 0x67, 0xc6, 0x99, 0x58, 0xf1, 0xd8, 0x23, 0xdb, 0x53, 0x11, 0xc4, 0x05, 0xef,
+	0xc0, 0x8b, 0xf0, 0x26, 0xbc, 0x12, 0xf2, 0x4f, 0x92, 0x49, 0x53, 0x85, 0xbd, 0xb3, 0xcf, 0xf9,
+	0xce, 0x77, 0x3c, 0x9f, 0x3f, 0x9f, 0x81, 0xb3, 0x42, 0x95, 0x57, 0x85, 0x2a, 0x93, 0x54, 0xf0,
+	0x19, 0xcd, 0xc7, 0xa5, 0x14, 0x5a, 0xa0, 0x56, 0xa1, 0xca, 0xe8, 0x5b, 0xe8, 0xdc, 0xde, 0x7f,
+	0xb8, 0xb1, 0x71, 0x84, 0xe0, 0x40, 0x2f, 0x4b, 0x12, 0x36, 0x86, 0x8d, 0xd1, 0x61, 0x6c, 0xd7,
+	0xe8, 0x02, 0xda, 0xae, 0x2a, 0x6c, 0x0e, 0x1b, 0xa3, 0x20, 0xf6, 0xbb, 0xe8, 0x9f, 0x03, 0x18,
+	0xbc, 0xc3, 0x53, 0x49, 0xd3, 0xad, 0x7a, 0x8e, 0x0b, 0x57, 0xdf, 0x89, 0xed, 0x1a, 0xbd, 0x04,
+	0x90, 0x42, 0xe8, 0x24, 0x25, 0x52, 0xab, 0xb0, 0x39, 0x6c, 0x8d, 0x82, 0xb8, 0x63, 0x22, 0x37,
+	0x26, 0x80, 0xbe, 0x06, 0x44, 0xb9, 0x26, 0xb2, 0x20, 0x19, 0xc5, 0x9a, 0x78, 0x58, 0xcb, 0xc2,
+	0x5e, 0xd4, 0x33, 0x0e, 0x7e, 0x01, 0x6d, 0x9c, 0x15, 0x94, 0xab, 0xf0, 0xc0, 0x42, 0xfc, 0x0e,
+	0x7d, 0x05, 0x03, 0x49, 0x1e, 0x45, 0x8a, 0x35, 0x15, 0x3c, 0x61, 0x54, 0xe9, 0xf0, 0xd0, 0x02,
+	0xfa, 0x9b, 0xf0, 0x2f, 0x54, 0x69, 0x74, 0x03, 0x27, 0x8a, 0xe6, 0x9c, 0xf2, 0x3c, 0xa1, 0x19,
+	0xe1, 0x9a, 0xea, 0x65, 0xd8, 0x1e, 0x36, 0x46, 0xdd, 0xeb, 0x70, 0x5c, 0xa8, 0x72, 0x7c, 0xef,
+	0x92, 0x13, 0x9f, 0x9b, 0xf0, 0x99, 0x88, 0x07, 0x6a, 0x3b, 0x88, 0x12, 0x78, 0x2d, 0x64, 0x8e,
+	0x39, 0xfd, 0xc3, 0x12, 0x63, 0x96, 0x54, 0x9c, 0x6a, 0x4f, 0x38, 0xa3, 0x44, 0xaa, 0xf0, 0x68,
+	0xd8, 0x1a, 0x75, 0xaf, 0x3f, 0xb1, 0x9c, 0x4e, 0xa6, 0xbb, 0x87, 0xc9, 0x3a, 0x1f, 0xbf, 0xdc,
+	0xae, 0x7f, 0xe0, 0x54, 0x6f, 0xb2, 0x0a, 0x7d, 0x0f, 0xbd, 0x54, 0x2e, 0x4b, 0x2d, 0xfc, 0x8d,
+	0x85, 0xc7, 0xc3, 0xc6, 0x13, 0xba, 0x1b, 0x9b, 0x77, 0xc2, 0xc7, 0x41, 0x5a, 0xdb, 0xa1, 0x2f,
+	0xa0, 0xaf, 0x99, 0x4a, 0x6a, 0xb2, 0x77, 0xac, 0x16, 0x81, 0x66, 0x2a, 0x5e, 0x2b, 0xff, 0x0d,
+	0x5c, 0x18, 0xd4, 0x33, 0xea, 0x83, 0x45, 0x9f, 0x69, 0xa6, 0x26, 0x3b, 0x17, 0xf0, 0x1d, 0x0c,
+	0x66, 0xb6, 0x7f, 0xc2, 0x45, 0x46, 0x12, 0x51, 0xa9, 0xb0, 0x6b, 0xcf, 0x86, 0x6a, 0x67, 0xfb,
+	0x55, 0x64, 0xe4, 0xee, 0x41, 0xc5, 0xbd, 0xd9, 0x66, 0x5b, 0xa9, 0xe8, 0xef, 0x06, 0xa0, 0xdd,
+	0xc3, 0xa3, 0x6b, 0x38, 0x37, 0x02, 0x63, 0x5d, 0x49, 0x92, 0xcc, 0xb1, 0x9a, 0x27, 0x33, 0x5c,
+	0x50, 0xb6, 0xf4, 0x36, 0x3a, 0x5d, 0x27, 0x7f, 0xc6, 0x6a, 0xfe, 0xce, 0xa6, 0xd0, 0x04, 0x2e,
+	0x57, 0xd7, 0x57, 0x93, 0xdd, 0x57, 0x57, 0x3c, 0x35, 0xb2, 0x5a, 0xc3, 0x76, 0xe2, 0x57, 0x2b,
+	0xe0, 0x46, 0x60, 0x4b, 0xe4, 0x51, 0x91, 0x80, 0xd3, 0x67, 0x2e, 0x1d, 0x7d, 0x0e, 0xbd, 0xb2,
+	0x9a, 0x32, 0x9a, 0x26, 0xa6, 0x3f, 0x91, 0xf6, 0x34, 0x41, 0x1c, 0xb8, 0xe0, 0xbd, 0x8d, 0xa1,
+	0x37, 0xd0, 0x2f, 0x25, 0x7d, 0x34, 0xd2, 0x79, 0x54, 0xd3, 0x8a, 0x11, 0x58, 0x31, 0xde, 0x13,
+	0xe7, 0x9f, 0x9e, 0xc7, 0xb8, 0xa2, 0xe8, 0x1e, 0x8e, 0x7c, 0x06, 0x7d, 0x09, 0xfd, 0x05, 0xa9,
+	0x7f, 0x81, 0xff, 0xe6, 0xde, 0x82, 0xd4, 0x8e, 0x8b, 0x2e, 0x21, 0x30, 0xb0, 0x02, 0x6b, 0x22,
+	0x29, 0x66, 0xfe, 0x25, 0x76, 0x17, 0x64, 0x79, 0xeb, 0x43, 0xd1, 0x9f, 0x80, 0x76, 0x6d, 0x86,
+	0x86, 0xd0, 0x35, 0x57, 0x4a, 0x67, 0x34, 0xc5, 0x9a, 0xf8, 0x4f, 0xa8, 0x87, 0xd0, 0x0f, 0xf0,
+	0x6a, 0xbf, 0x95, 0xbd, 0x8a, 0x9f, 0xed, 0x33, 0x6c, 0xf4, 0x6f, 0x13, 0x7a, 0x5b, 0x57, 0x6f,
+	0x1e, 0x2a, 0xe1, 0x78, 0xca, 0x5c, 0xd3, 0xe3, 0xd8, 0xef, 0xd0, 0x04, 0xce, 0x52, 0x46, 0x09,
+	0xd7, 0x89, 0xa8, 0x9e, 0x76, 0xd9, 0xf3, 0x5e, 0x90, 0x2b, 0xba, 0xab, 0x6a, 0x1f, 0xf7, 0x23,
+	0xa0, 0x92, 0x10, 0xf9, 0x84, 0xa8, 0xb5, 0x9f, 0xe8, 0xc4, 0x94, 0x6c, 0xd1, 0xbc, 0x87, 0x73,
+	0x21, 0x33, 0x22, 0x77, 0x98, 0x0e, 0xf6, 0x33, 0x9d, 0xfa, 0xaa, 0x2d, 0xb2, 0x9f, 0xe0, 0xd4,
+	0x4e, 0xa4, 0x27, 0x54, 0x87, 0xfb, 0xa9, 0x5e, 0xd8, 0x9a, 0x3a, 0x51, 0xb4, 0x80, 0xc1, 0x24,
+	0x23, 0x05, 0xfd, 0x7d, 0xff, 0x74, 0x3d, 0x81, 0x16, 0x2d, 0x17, 0xde, 0x10, 0x66, 0x89, 0xae,
+	0xa1, 0xed, 0xad, 0xe8, 0x94, 0xf8, 0xd4, 0x36, 0x5d, 0x73, 0x39, 0x0f, 0xfa, 0xb1, 0xe1, 0x91,
+	0xd1, 0x5f, 0x4d, 0x38, 0x7f, 0x16, 0x61, 0x7a, 0xa6, 0x92, 0x64, 0xde, 0x39, 0x76, 0x8d, 0xfa,
+	0xd0, 0x54, 0xab, 0x96, 0x4d, 0xb5, 0xf8, 0x08, 0x0b, 0xb5, 0xfe, 0xdf, 0x42, 0xa6, 0x93, 0x14,
+	0x8c, 0x58, 0xd5, 0x0f, 0x63, 0xbb, 0x36, 0x6f, 0x90, 0x70, 0x29, 0x18, 0x2b, 0x8c, 0x61, 0x68,
+	0x66, 0x75, 0xec, 0xc4, 0xc1, 0x26, 0x38, 0xc9, 0xd0, 0x6b, 0xe8, 0x66, 0x54, 0xa5, 0x4c, 0x28,
+	0x33, 0x8e, 0xec, 0x30, 0x3f, 0x8e, 0x61, 0x15, 0xba, 0xab, 0x0c, 0xcb, 0x1a, 0x60, 0x5b, 0x1c,
+	0x59, 0x48, 0xb0, 0x0a, 0xc6, 0x82, 0x91, 0xb7, 0x09, 0x5c, 0x0a, 0x99, 0x8f, 0xe7, 0xcb, 0x92,
+	0x48, 0x46, 0xb2, 0x9c, 0xc8, 0xb1, 0x1b, 0x5e, 0xee, 0x67, 0xa9, 0x8c, 0x8a, 0x6f, 0x4f, 0x6e,
+	0x55, 0xe9, 0x84, 0xf9, 0x80, 0xd3, 0x05, 0xce, 0xc9, 0x6f, 0xa3, 0x9c, 0xea, 0x79, 0x35, 0x1d,
+	0xa7, 0xa2, 0xb8, 0xaa, 0xd5, 0x5e, 0xb9, 0xda, 0x2b, 0x57, 0x6b, 0x7e, 0xbd, 0xd3, 0xb6, 0x5d,
+	0xbf, 0xf9, 0x6f, 0x00, 0x71, 0x04, 0xbb, 0x98, 0x8c, 0x07, 0x00, 0x00,
 }
@@ -22,6 +22,15 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	ChaincodeLogsRequest
+	ChaincodeLogsResponse
+	GossipMember
+	GossipMembersResponse
+	GossipChannelRequest
+	GossipChannelResponse
+	GossipLeaderResponse
+	CertificateExpiration
+	CertificateExpirationsResponse
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -30,6 +39,9 @@ It has these top-level messages:
 	ChaincodeEvent
 	ChaincodeMessage
 	PutStateInfo
+	GetStateMultiple
+	GetStateMultipleResponse
+	PutStateMultiple
 	GetStateByRange
 	GetQueryResult
 	GetHistoryForKey
@@ -40,12 +52,16 @@ It has these top-level messages:
 	AnchorPeers
 	AnchorPeer
 	ChaincodeReg
+	TxStatusReg
 	Interest
 	Register
 	Rejection
+	TxStatus
 	Unregister
 	SignedEvent
 	Event
+	BlockChaincodeEvents
+	DeliverResponse
 	PeerID
 	PeerEndpoint
 	SignedProposal
@@ -75,6 +91,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -189,10 +206,335 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+type ChaincodeLogsRequest struct {
+	ChaincodeName string `protobuf:"bytes,1,opt,name=chaincode_name,json=chaincodeName" json:"chaincode_name,omitempty"`
+	Follow        bool   `protobuf:"varint,2,opt,name=follow" json:"follow,omitempty"`
+}
+
+func (m *ChaincodeLogsRequest) Reset()                    { *m = ChaincodeLogsRequest{} }
+func (m *ChaincodeLogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeLogsRequest) ProtoMessage()               {}
+func (*ChaincodeLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ChaincodeLogsRequest) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *ChaincodeLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type ChaincodeLogsResponse struct {
+	Lines []string `protobuf:"bytes,1,rep,name=lines" json:"lines,omitempty"`
+}
+
+func (m *ChaincodeLogsResponse) Reset()                    { *m = ChaincodeLogsResponse{} }
+func (m *ChaincodeLogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeLogsResponse) ProtoMessage()               {}
+func (*ChaincodeLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ChaincodeLogsResponse) GetLines() []string {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+// GossipMember describes a member known to the gossip component of a peer
+type GossipMember struct {
+	Endpoint         string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	InternalEndpoint string `protobuf:"bytes,2,opt,name=internal_endpoint,json=internalEndpoint" json:"internal_endpoint,omitempty"`
+	PkiId            []byte `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	// MSP ID of the organization of the member, empty if unknown
+	Org   string `protobuf:"bytes,4,opt,name=org" json:"org,omitempty"`
+	Alive bool   `protobuf:"varint,5,opt,name=alive" json:"alive,omitempty"`
+	// Last time the member was seen alive
+	LastSeen *google_protobuf1.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen" json:"last_seen,omitempty"`
+	// Ledger height and leadership the member advertises in a channel,
+	// only set for the members of a channel
+	LedgerHeight uint64 `protobuf:"varint,7,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	Leader       bool   `protobuf:"varint,8,opt,name=leader" json:"leader,omitempty"`
+}
+
+func (m *GossipMember) Reset()                    { *m = GossipMember{} }
+func (m *GossipMember) String() string            { return proto.CompactTextString(m) }
+func (*GossipMember) ProtoMessage()               {}
+func (*GossipMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *GossipMember) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetInternalEndpoint() string {
+	if m != nil {
+		return m.InternalEndpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *GossipMember) GetOrg() string {
+	if m != nil {
+		return m.Org
+	}
+	return ""
+}
+
+func (m *GossipMember) GetAlive() bool {
+	if m != nil {
+		return m.Alive
+	}
+	return false
+}
+
+func (m *GossipMember) GetLastSeen() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+func (m *GossipMember) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *GossipMember) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+type GossipMembersResponse struct {
+	Self    *GossipMember   `protobuf:"bytes,1,opt,name=self" json:"self,omitempty"`
+	Members []*GossipMember `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	// Amount of messages held by the message stores of the gossip component
+	MessageStoreSizes map[string]int32 `protobuf:"bytes,3,rep,name=message_store_sizes,json=messageStoreSizes" json:"message_store_sizes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *GossipMembersResponse) Reset()                    { *m = GossipMembersResponse{} }
+func (m *GossipMembersResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipMembersResponse) ProtoMessage()               {}
+func (*GossipMembersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *GossipMembersResponse) GetSelf() *GossipMember {
+	if m != nil {
+		return m.Self
+	}
+	return nil
+}
+
+func (m *GossipMembersResponse) GetMembers() []*GossipMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *GossipMembersResponse) GetMessageStoreSizes() map[string]int32 {
+	if m != nil {
+		return m.MessageStoreSizes
+	}
+	return nil
+}
+
+type GossipChannelRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+}
+
+func (m *GossipChannelRequest) Reset()                    { *m = GossipChannelRequest{} }
+func (m *GossipChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*GossipChannelRequest) ProtoMessage()               {}
+func (*GossipChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *GossipChannelRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+type GossipChannelResponse struct {
+	Channel string          `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Members []*GossipMember `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	// Amount of messages held by the message stores of the channel
+	MessageStoreSizes map[string]int32 `protobuf:"bytes,3,rep,name=message_store_sizes,json=messageStoreSizes" json:"message_store_sizes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *GossipChannelResponse) Reset()                    { *m = GossipChannelResponse{} }
+func (m *GossipChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipChannelResponse) ProtoMessage()               {}
+func (*GossipChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *GossipChannelResponse) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *GossipChannelResponse) GetMembers() []*GossipMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *GossipChannelResponse) GetMessageStoreSizes() map[string]int32 {
+	if m != nil {
+		return m.MessageStoreSizes
+	}
+	return nil
+}
+
+type GossipLeaderResponse struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	// Whether leader election is used in the channel, as opposed
+	// to statically configured leaders
+	Election bool `protobuf:"varint,2,opt,name=election" json:"election,omitempty"`
+	// The leader, unset if no leader is known
+	Leader *GossipMember `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
+	// Whether the peer is the leader
+	Self bool `protobuf:"varint,4,opt,name=self" json:"self,omitempty"`
+}
+
+func (m *GossipLeaderResponse) Reset()                    { *m = GossipLeaderResponse{} }
+func (m *GossipLeaderResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipLeaderResponse) ProtoMessage()               {}
+func (*GossipLeaderResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *GossipLeaderResponse) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *GossipLeaderResponse) GetElection() bool {
+	if m != nil {
+		return m.Election
+	}
+	return false
+}
+
+func (m *GossipLeaderResponse) GetLeader() *GossipMember {
+	if m != nil {
+		return m.Leader
+	}
+	return nil
+}
+
+func (m *GossipLeaderResponse) GetSelf() bool {
+	if m != nil {
+		return m.Self
+	}
+	return false
+}
+
+// CertificateExpiration describes when a certificate the peer relies on expires
+type CertificateExpiration struct {
+	// "local" for the certificates of the peer, or the channel whose
+	// configuration carries the certificate
+	Source string `protobuf:"bytes,1,opt,name=source" json:"source,omitempty"`
+	// enrollment, tls, admin, root CA, intermediate CA, TLS root CA or
+	// TLS intermediate CA
+	Kind string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	// MSP ID of the owner of the certificate, empty for TLS certificates
+	MspId    string                      `protobuf:"bytes,3,opt,name=msp_id,json=mspId" json:"msp_id,omitempty"`
+	Subject  string                      `protobuf:"bytes,4,opt,name=subject" json:"subject,omitempty"`
+	NotAfter *google_protobuf1.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+}
+
+func (m *CertificateExpiration) Reset()                    { *m = CertificateExpiration{} }
+func (m *CertificateExpiration) String() string            { return proto.CompactTextString(m) }
+func (*CertificateExpiration) ProtoMessage()               {}
+func (*CertificateExpiration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *CertificateExpiration) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CertificateExpiration) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *CertificateExpiration) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *CertificateExpiration) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *CertificateExpiration) GetNotAfter() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+type CertificateExpirationsResponse struct {
+	// Certificates ordered by expiry date, those expiring first coming first
+	Certificates []*CertificateExpiration `protobuf:"bytes,1,rep,name=certificates" json:"certificates,omitempty"`
+}
+
+func (m *CertificateExpirationsResponse) Reset()         { *m = CertificateExpirationsResponse{} }
+func (m *CertificateExpirationsResponse) String() string { return proto.CompactTextString(m) }
+func (*CertificateExpirationsResponse) ProtoMessage()    {}
+func (*CertificateExpirationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{11}
+}
+
+func (m *CertificateExpirationsResponse) GetCertificates() []*CertificateExpiration {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*ChaincodeLogsRequest)(nil), "protos.ChaincodeLogsRequest")
+	proto.RegisterType((*ChaincodeLogsResponse)(nil), "protos.ChaincodeLogsResponse")
+	proto.RegisterType((*GossipMember)(nil), "protos.GossipMember")
+	proto.RegisterType((*GossipMembersResponse)(nil), "protos.GossipMembersResponse")
+	proto.RegisterType((*GossipChannelRequest)(nil), "protos.GossipChannelRequest")
+	proto.RegisterType((*GossipChannelResponse)(nil), "protos.GossipChannelResponse")
+	proto.RegisterType((*GossipLeaderResponse)(nil), "protos.GossipLeaderResponse")
+	proto.RegisterType((*CertificateExpiration)(nil), "protos.CertificateExpiration")
+	proto.RegisterType((*CertificateExpirationsResponse)(nil), "protos.CertificateExpirationsResponse")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -213,6 +555,18 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// Return the captured output of a chaincode, and the output still to
+	// come if follow is set.
+	GetChaincodeLogs(ctx context.Context, in *ChaincodeLogsRequest, opts ...grpc.CallOption) (Admin_GetChaincodeLogsClient, error)
+	// Return the members known to the gossip component of the peer.
+	GetGossipMembers(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*GossipMembersResponse, error)
+	// Return the members of a channel as seen by the gossip component of the peer.
+	GetGossipChannel(ctx context.Context, in *GossipChannelRequest, opts ...grpc.CallOption) (*GossipChannelResponse, error)
+	// Return the leader of the organization of the peer in a channel.
+	GetGossipLeader(ctx context.Context, in *GossipChannelRequest, opts ...grpc.CallOption) (*GossipLeaderResponse, error)
+	// Return the expiry dates of the certificates of the peer and of the
+	// MSPs of the channels it has joined.
+	GetCertificateExpirations(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*CertificateExpirationsResponse, error)
 }
 
 type adminClient struct {
@@ -268,6 +622,74 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *google_protobuf.E
 	return out, nil
 }
 
+func (c *adminClient) GetChaincodeLogs(ctx context.Context, in *ChaincodeLogsRequest, opts ...grpc.CallOption) (Admin_GetChaincodeLogsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Admin_serviceDesc.Streams[0], c.cc, "/protos.Admin/GetChaincodeLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminGetChaincodeLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_GetChaincodeLogsClient interface {
+	Recv() (*ChaincodeLogsResponse, error)
+	grpc.ClientStream
+}
+
+type adminGetChaincodeLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminGetChaincodeLogsClient) Recv() (*ChaincodeLogsResponse, error) {
+	m := new(ChaincodeLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) GetGossipMembers(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*GossipMembersResponse, error) {
+	out := new(GossipMembersResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipMembers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipChannel(ctx context.Context, in *GossipChannelRequest, opts ...grpc.CallOption) (*GossipChannelResponse, error) {
+	out := new(GossipChannelResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipLeader(ctx context.Context, in *GossipChannelRequest, opts ...grpc.CallOption) (*GossipLeaderResponse, error) {
+	out := new(GossipLeaderResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipLeader", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetCertificateExpirations(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*CertificateExpirationsResponse, error) {
+	out := new(CertificateExpirationsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetCertificateExpirations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -277,6 +699,18 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *google_protobuf.Empty) (*google_protobuf.Empty, error)
+	// Return the captured output of a chaincode, and the output still to
+	// come if follow is set.
+	GetChaincodeLogs(*ChaincodeLogsRequest, Admin_GetChaincodeLogsServer) error
+	// Return the members known to the gossip component of the peer.
+	GetGossipMembers(context.Context, *google_protobuf.Empty) (*GossipMembersResponse, error)
+	// Return the members of a channel as seen by the gossip component of the peer.
+	GetGossipChannel(context.Context, *GossipChannelRequest) (*GossipChannelResponse, error)
+	// Return the leader of the organization of the peer in a channel.
+	GetGossipLeader(context.Context, *GossipChannelRequest) (*GossipLeaderResponse, error)
+	// Return the expiry dates of the certificates of the peer and of the
+	// MSPs of the channels it has joined.
+	GetCertificateExpirations(context.Context, *google_protobuf.Empty) (*CertificateExpirationsResponse, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -373,6 +807,99 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetChaincodeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChaincodeLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).GetChaincodeLogs(m, &adminGetChaincodeLogsServer{stream})
+}
+
+type Admin_GetChaincodeLogsServer interface {
+	Send(*ChaincodeLogsResponse) error
+	grpc.ServerStream
+}
+
+type adminGetChaincodeLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminGetChaincodeLogsServer) Send(m *ChaincodeLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_GetGossipMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipMembers(ctx, req.(*google_protobuf.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetGossipChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipChannel(ctx, req.(*GossipChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetGossipLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipLeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipLeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipLeader(ctx, req.(*GossipChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetCertificateExpirations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetCertificateExpirations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetCertificateExpirations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetCertificateExpirations(ctx, req.(*google_protobuf.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -397,40 +924,100 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetGossipMembers",
+			Handler:    _Admin_GetGossipMembers_Handler,
+		},
+		{
+			MethodName: "GetGossipChannel",
+			Handler:    _Admin_GetGossipChannel_Handler,
+		},
+		{
+			MethodName: "GetGossipLeader",
+			Handler:    _Admin_GetGossipLeader_Handler,
+		},
+		{
+			MethodName: "GetCertificateExpirations",
+			Handler:    _Admin_GetCertificateExpirations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetChaincodeLogs",
+			Handler:       _Admin_GetChaincodeLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x9b, 0x42, 0x0b, 0x79, 0x1b, 0xcc, 0x58, 0x08, 0xaa, 0x4e, 0x08, 0x94, 0x13, 0x5c,
-	0x1c, 0x69, 0x1c, 0x38, 0x20, 0x0e, 0xdd, 0x12, 0x06, 0x62, 0x4b, 0x23, 0x67, 0x15, 0x02, 0x09,
-	0x4d, 0x49, 0xf3, 0xe6, 0x55, 0x38, 0x73, 0xb0, 0x9d, 0x4a, 0xfb, 0x3a, 0x7c, 0x2e, 0x3e, 0x0c,
-	0x4a, 0xdc, 0x68, 0x13, 0xb0, 0x03, 0x82, 0x93, 0xf3, 0xde, 0xfb, 0xff, 0xff, 0x71, 0x7e, 0xb1,
-	0x81, 0xd4, 0x88, 0x3a, 0xcc, 0xcb, 0x6a, 0x75, 0xc1, 0x6a, 0xad, 0xac, 0xa2, 0xe3, 0x6e, 0x31,
-	0xd3, 0x5d, 0xa1, 0x94, 0x90, 0x18, 0x76, 0x65, 0xd1, 0x9c, 0x85, 0x58, 0xd5, 0xf6, 0xd2, 0x89,
-	0x82, 0xef, 0x1e, 0x6c, 0x67, 0xa8, 0xd7, 0xa8, 0x33, 0x9b, 0xdb, 0xc6, 0xd0, 0x57, 0x30, 0x36,
-	0xdd, 0xd3, 0xc4, 0x7b, 0xe6, 0x3d, 0xbf, 0xbf, 0xf7, 0xd4, 0x09, 0x0d, 0xbb, 0xae, 0x62, 0x6e,
-	0x39, 0x50, 0x25, 0xf2, 0x8d, 0x3c, 0xf8, 0x04, 0x70, 0xd5, 0xa5, 0xf7, 0xc0, 0x5f, 0x24, 0x51,
-	0xfc, 0xf6, 0x7d, 0x12, 0x47, 0x64, 0x40, 0xb7, 0xe0, 0x4e, 0x76, 0x32, 0xe3, 0x27, 0x71, 0x44,
-	0x3c, 0x57, 0xcc, 0xd3, 0x34, 0x8e, 0xc8, 0x90, 0x02, 0x8c, 0xd3, 0xd9, 0x22, 0x8b, 0x23, 0x72,
-	0x8b, 0xfa, 0x30, 0x8a, 0x39, 0x9f, 0x73, 0x72, 0xbb, 0xd5, 0x2c, 0x92, 0x0f, 0xc9, 0xfc, 0x63,
-	0x42, 0x46, 0xc1, 0x31, 0xec, 0x1c, 0x29, 0x71, 0x84, 0x6b, 0x94, 0x1c, 0xbf, 0x35, 0x68, 0x2c,
-	0x7d, 0x02, 0x20, 0x95, 0x38, 0xad, 0x54, 0xd9, 0x48, 0xec, 0xb6, 0xea, 0x73, 0x5f, 0x2a, 0x71,
-	0xdc, 0x35, 0xe8, 0x2e, 0xb4, 0xc5, 0xa9, 0x6c, 0x2d, 0x93, 0x61, 0x37, 0xbd, 0x2b, 0x37, 0x11,
-	0x41, 0x02, 0xe4, 0x2a, 0xce, 0xd4, 0xea, 0xc2, 0xe0, 0xbf, 0xe4, 0xed, 0xfd, 0x18, 0xc2, 0x68,
-	0xd6, 0x82, 0xa7, 0xaf, 0xc1, 0x3f, 0x44, 0xbb, 0x21, 0xf9, 0x88, 0x39, 0xf0, 0xac, 0x07, 0xcf,
-	0xe2, 0x16, 0xfc, 0xf4, 0xe1, 0x9f, 0x88, 0x06, 0x03, 0xfa, 0x06, 0xb6, 0x32, 0x9b, 0x6b, 0xeb,
-	0xda, 0x7f, 0x6d, 0x7f, 0x07, 0x0f, 0x0e, 0xd1, 0xba, 0xfd, 0xf6, 0x9f, 0x47, 0x1f, 0xf7, 0xe2,
-	0x5f, 0xf8, 0x4d, 0x27, 0xbf, 0x0f, 0x1c, 0x09, 0x97, 0x94, 0xfd, 0x9f, 0xa4, 0x03, 0xd8, 0xe1,
-	0xb8, 0x46, 0x6d, 0xfb, 0xd9, 0xcd, 0x54, 0x6e, 0xe8, 0x07, 0x83, 0xfd, 0x2f, 0x10, 0x28, 0x2d,
-	0xd8, 0xf9, 0x65, 0x8d, 0x5a, 0x62, 0x29, 0x50, 0xb3, 0xb3, 0xbc, 0xd0, 0xab, 0x65, 0xff, 0xe2,
-	0xf6, 0xe4, 0xef, 0x6f, 0x77, 0x7f, 0x20, 0xcd, 0x97, 0x5f, 0x73, 0x81, 0x9f, 0x5f, 0x88, 0x95,
-	0x3d, 0x6f, 0x0a, 0xb6, 0x54, 0x55, 0x78, 0xcd, 0x18, 0x3a, 0xa3, 0xbb, 0x0a, 0x26, 0x6c, 0x8d,
-	0x85, 0xbb, 0x26, 0x2f, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xe6, 0xef, 0xb7, 0x41, 0x03,
-	0x00, 0x00,
+	// 1030 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xbc, 0x56, 0xdf, 0x6f, 0xe3, 0x44,
+	0x10, 0xae, 0x93, 0x26, 0x6d, 0xa6, 0xe9, 0xd5, 0x5d, 0xda, 0x62, 0x72, 0x94, 0xab, 0x8c, 0x40,
+	0x41, 0x80, 0x73, 0x2a, 0x48, 0x87, 0x40, 0x3c, 0xf4, 0x9a, 0xd0, 0xab, 0xae, 0x4d, 0x8b, 0xd3,
+	0x0a, 0x81, 0x74, 0x8a, 0x1c, 0x7b, 0xe2, 0x98, 0xda, 0x5e, 0xe3, 0xdd, 0x04, 0xca, 0x1b, 0xef,
+	0xc0, 0x7f, 0xc0, 0x0b, 0xef, 0xfc, 0x8f, 0x68, 0x77, 0x6d, 0x27, 0xcd, 0x25, 0x3d, 0x10, 0x3f,
+	0x9e, 0xb2, 0x33, 0xfb, 0xcd, 0x97, 0xd9, 0x6f, 0x67, 0x76, 0x0c, 0x7a, 0x82, 0x98, 0xb6, 0x1c,
+	0x2f, 0x0a, 0x62, 0x2b, 0x49, 0x29, 0xa7, 0xa4, 0x2a, 0x7f, 0x58, 0xe3, 0xa1, 0x4f, 0xa9, 0x1f,
+	0x62, 0x4b, 0x9a, 0x83, 0xf1, 0xb0, 0x85, 0x51, 0xc2, 0x6f, 0x15, 0xa8, 0xf1, 0x68, 0x7e, 0x93,
+	0x07, 0x11, 0x32, 0xee, 0x44, 0x89, 0x02, 0x98, 0xbf, 0x6b, 0x50, 0xef, 0x61, 0x3a, 0xc1, 0xb4,
+	0xc7, 0x1d, 0x3e, 0x66, 0xe4, 0x09, 0x54, 0x99, 0x5c, 0x19, 0xda, 0x81, 0xd6, 0x7c, 0x70, 0xf8,
+	0x48, 0x01, 0x99, 0x35, 0x8b, 0xb2, 0xd4, 0xcf, 0x31, 0xf5, 0xd0, 0xce, 0xe0, 0xe6, 0xd7, 0x00,
+	0x53, 0x2f, 0xd9, 0x84, 0xda, 0x75, 0xb7, 0xdd, 0xf9, 0xe2, 0xb4, 0xdb, 0x69, 0xeb, 0x2b, 0x64,
+	0x03, 0xd6, 0x7a, 0x57, 0x47, 0xf6, 0x55, 0xa7, 0xad, 0x6b, 0xca, 0xb8, 0xb8, 0xbc, 0xec, 0xb4,
+	0xf5, 0x12, 0x01, 0xa8, 0x5e, 0x1e, 0x5d, 0xf7, 0x3a, 0x6d, 0xbd, 0x4c, 0x6a, 0x50, 0xe9, 0xd8,
+	0xf6, 0x85, 0xad, 0xaf, 0x0a, 0xcc, 0x75, 0xf7, 0x79, 0xf7, 0xe2, 0xab, 0xae, 0x5e, 0x31, 0xcf,
+	0x61, 0xeb, 0x8c, 0xfa, 0x67, 0x38, 0xc1, 0xd0, 0xc6, 0xef, 0xc6, 0xc8, 0x38, 0xd9, 0x07, 0x08,
+	0xa9, 0xdf, 0x8f, 0xa8, 0x37, 0x0e, 0x51, 0xa6, 0x5a, 0xb3, 0x6b, 0x21, 0xf5, 0xcf, 0xa5, 0x83,
+	0x3c, 0x04, 0x61, 0xf4, 0x43, 0x11, 0x62, 0x94, 0xe4, 0xee, 0x7a, 0x98, 0x51, 0x98, 0x5d, 0xd0,
+	0xa7, 0x74, 0x2c, 0xa1, 0x31, 0xc3, 0x7f, 0xc4, 0x77, 0x0d, 0x3b, 0xc7, 0x23, 0x27, 0x88, 0x5d,
+	0xea, 0xe1, 0x19, 0xf5, 0x59, 0x9e, 0xe3, 0x3b, 0xf0, 0xc0, 0xcd, 0xfd, 0xfd, 0xd8, 0x89, 0x72,
+	0xde, 0xcd, 0xc2, 0xdb, 0x75, 0x22, 0x24, 0x7b, 0x50, 0x1d, 0xd2, 0x30, 0xa4, 0xdf, 0x4b, 0xe2,
+	0x75, 0x3b, 0xb3, 0xcc, 0x0f, 0x61, 0x77, 0x8e, 0x36, 0xcb, 0x75, 0x07, 0x2a, 0x61, 0x10, 0xa3,
+	0xb8, 0xa1, 0x72, 0xb3, 0x66, 0x2b, 0xc3, 0xfc, 0xb9, 0x04, 0xf5, 0x13, 0xca, 0x58, 0x90, 0x9c,
+	0x63, 0x34, 0xc0, 0x94, 0x34, 0x60, 0x1d, 0x63, 0x2f, 0xa1, 0x41, 0xcc, 0xb3, 0x3f, 0x2e, 0x6c,
+	0xf2, 0x3e, 0x6c, 0x07, 0x31, 0xc7, 0x34, 0x76, 0xc2, 0x7e, 0x01, 0x52, 0xe7, 0xd2, 0xf3, 0x8d,
+	0x4e, 0x0e, 0xde, 0x85, 0x6a, 0x72, 0x13, 0xf4, 0x03, 0xcf, 0x28, 0x1f, 0x68, 0xcd, 0xba, 0x5d,
+	0x49, 0x6e, 0x82, 0x53, 0x8f, 0xe8, 0x50, 0xa6, 0xa9, 0x6f, 0xac, 0xca, 0x28, 0xb1, 0x14, 0x89,
+	0x39, 0x61, 0x30, 0x41, 0xa3, 0x22, 0x0f, 0xa2, 0x0c, 0xf2, 0x04, 0x6a, 0xa1, 0xc3, 0x78, 0x9f,
+	0x21, 0xc6, 0x46, 0xf5, 0x40, 0x6b, 0x6e, 0x1c, 0x36, 0x2c, 0x55, 0x97, 0x56, 0x5e, 0x97, 0xd6,
+	0x55, 0x5e, 0x97, 0xf6, 0xba, 0x00, 0xf7, 0x10, 0x63, 0xf2, 0x36, 0x6c, 0x86, 0xe8, 0xf9, 0x98,
+	0xf6, 0x47, 0x18, 0xf8, 0x23, 0x6e, 0xac, 0x1d, 0x68, 0xcd, 0x55, 0xbb, 0xae, 0x9c, 0xcf, 0xa4,
+	0x4f, 0xa8, 0x17, 0xa2, 0xe3, 0x61, 0x6a, 0xac, 0x2b, 0xf5, 0x94, 0x65, 0xfe, 0x56, 0x82, 0xdd,
+	0x59, 0x39, 0xa6, 0xf2, 0x35, 0x61, 0x95, 0x61, 0x38, 0x94, 0x9a, 0x6c, 0x1c, 0xee, 0xe4, 0xf5,
+	0x3d, 0x0b, 0xb6, 0x25, 0x82, 0x58, 0xb0, 0x16, 0xa9, 0x60, 0xa3, 0x74, 0x50, 0x5e, 0x0a, 0xce,
+	0x41, 0xc4, 0x83, 0xd7, 0x22, 0x64, 0xcc, 0xf1, 0xb1, 0xcf, 0x38, 0x4d, 0xb1, 0xcf, 0x82, 0x1f,
+	0x91, 0x19, 0x65, 0x19, 0xfb, 0xf1, 0xa2, 0xd8, 0x22, 0x2b, 0xeb, 0x5c, 0x05, 0xf6, 0x44, 0x5c,
+	0x4f, 0x84, 0x75, 0x62, 0x9e, 0xde, 0xda, 0xdb, 0xd1, 0xbc, 0xbf, 0xd1, 0x86, 0xbd, 0xc5, 0x60,
+	0x71, 0x23, 0x37, 0x78, 0x9b, 0x5d, 0xb6, 0x58, 0x8a, 0x1b, 0x99, 0x38, 0xe1, 0x18, 0xe5, 0xdd,
+	0x56, 0x6c, 0x65, 0x7c, 0x5a, 0xfa, 0x44, 0x33, 0x1f, 0xc3, 0x8e, 0x4a, 0xe4, 0x78, 0xe4, 0xc4,
+	0xf1, 0xb4, 0xb1, 0x0c, 0x58, 0x73, 0x95, 0x27, 0xe3, 0xc9, 0x4d, 0xf3, 0x97, 0x42, 0xd1, 0x22,
+	0x24, 0x53, 0x74, 0x69, 0xcc, 0x7f, 0xaa, 0xe0, 0x5c, 0x16, 0xff, 0xbb, 0x82, 0xbf, 0x6a, 0xb9,
+	0x84, 0x67, 0xb2, 0xe4, 0xfe, 0x82, 0x1c, 0xa2, 0x25, 0x43, 0x74, 0x79, 0x40, 0xe3, 0xac, 0xd9,
+	0x0b, 0x9b, 0x7c, 0x50, 0x14, 0x72, 0xf9, 0x9e, 0xc2, 0xcc, 0x30, 0x84, 0x64, 0x45, 0xbc, 0x2a,
+	0x59, 0xe4, 0xda, 0xfc, 0x43, 0x83, 0xdd, 0x63, 0x4c, 0x79, 0x30, 0x0c, 0x5c, 0x87, 0x63, 0xe7,
+	0x87, 0x24, 0x48, 0x1d, 0xc9, 0xbd, 0x07, 0x55, 0x46, 0xc7, 0xa9, 0x9b, 0xbf, 0x40, 0x99, 0x25,
+	0x58, 0x6e, 0x82, 0xd8, 0xcb, 0x3a, 0x5f, 0xae, 0x45, 0xb7, 0x47, 0x2c, 0xc9, 0xbb, 0xbd, 0x66,
+	0x57, 0x22, 0x96, 0x9c, 0x7a, 0xe2, 0x50, 0x6c, 0x3c, 0xf8, 0x16, 0x5d, 0x9e, 0x75, 0x7c, 0x6e,
+	0x8a, 0xfe, 0x8e, 0x29, 0xef, 0x3b, 0x43, 0x8e, 0xa9, 0x51, 0x79, 0x75, 0x7f, 0xc7, 0x94, 0x1f,
+	0x09, 0xac, 0xe9, 0xc2, 0x5b, 0x0b, 0xd3, 0x9d, 0xb6, 0xea, 0x11, 0xd4, 0xdd, 0x29, 0x42, 0x3d,
+	0x78, 0x1b, 0x87, 0xfb, 0xb9, 0x32, 0x0b, 0xa3, 0xed, 0x3b, 0x21, 0x87, 0x3f, 0x55, 0xa1, 0x72,
+	0x24, 0xc6, 0x26, 0xf9, 0x0c, 0x6a, 0x27, 0xc8, 0xb3, 0x31, 0xb7, 0xf7, 0x52, 0x86, 0x1d, 0x31,
+	0x36, 0x1b, 0x3b, 0x8b, 0xc6, 0x9d, 0xb9, 0x42, 0x3e, 0x87, 0x8d, 0x1e, 0x77, 0x52, 0xae, 0xdc,
+	0x7f, 0x3b, 0xfc, 0x19, 0x6c, 0x9f, 0x20, 0x57, 0xc3, 0x24, 0x9f, 0x3d, 0xe4, 0xf5, 0x1c, 0x3c,
+	0x37, 0xdc, 0x1a, 0xc6, 0xcb, 0x1b, 0x4a, 0x10, 0xc5, 0xd4, 0xfb, 0x77, 0x98, 0x8e, 0x61, 0xcb,
+	0xc6, 0x09, 0xa6, 0x3c, 0xdf, 0x5b, 0xae, 0xca, 0x12, 0xbf, 0xb9, 0x42, 0x7a, 0xa0, 0x9f, 0x20,
+	0xbf, 0x33, 0xa7, 0xc8, 0x9b, 0xc5, 0xfd, 0x2c, 0x98, 0x8a, 0x8d, 0xfd, 0x25, 0xbb, 0x79, 0x5e,
+	0x8f, 0x35, 0xf2, 0x5c, 0x92, 0xde, 0x79, 0x27, 0x97, 0xa6, 0xb6, 0x7f, 0xef, 0xb3, 0x6a, 0xae,
+	0x90, 0x2f, 0x67, 0xc8, 0xb2, 0x27, 0x63, 0x9a, 0xe1, 0xa2, 0x27, 0xb0, 0xb1, 0xbf, 0x64, 0xb7,
+	0xa0, 0xbc, 0x80, 0xad, 0x82, 0x52, 0xf5, 0xfe, 0x2b, 0x18, 0xe7, 0x76, 0xef, 0xbe, 0x17, 0xe6,
+	0x0a, 0x79, 0x01, 0x6f, 0x08, 0x15, 0x17, 0x36, 0xc3, 0xd2, 0x93, 0xbf, 0x7b, 0x6f, 0x1b, 0xcc,
+	0x48, 0xf0, 0xf4, 0x05, 0x98, 0x34, 0xf5, 0xad, 0xd1, 0x6d, 0x82, 0xa9, 0x1a, 0x9e, 0xd6, 0xd0,
+	0x19, 0xa4, 0x81, 0x9b, 0x33, 0x24, 0x88, 0xe9, 0xd3, 0xba, 0x6c, 0x93, 0x4b, 0xc7, 0xbd, 0x71,
+	0x7c, 0xfc, 0xe6, 0x3d, 0x3f, 0xe0, 0xa3, 0xf1, 0xc0, 0x72, 0x69, 0xd4, 0x9a, 0x09, 0x6c, 0xa9,
+	0x40, 0xf5, 0x41, 0xc9, 0x5a, 0x22, 0x70, 0xa0, 0xbe, 0x44, 0x3f, 0xfa, 0x73, 0x00, 0xdd, 0xd8,
+	0x3c, 0xb1, 0xa4, 0x0a, 0x00, 0x00,
 }
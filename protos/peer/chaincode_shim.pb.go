@@ -21,25 +21,28 @@ var _ = math.Inf
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED           ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER            ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED          ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY               ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION         ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED           ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR               ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE           ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE           ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE           ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE    ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE            ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE  ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT    ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT    ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_UNDEFINED                  ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER                   ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED                 ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                       ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                      ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION                ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED                  ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                      ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE                  ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE                  ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE                  ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE           ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE                   ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE         ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT           ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT           ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE          ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE                  ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY        ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_MULTIPLE         ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_MULTIPLE         ChaincodeMessage_Type = 21
+	ChaincodeMessage_INVOKE_CHAINCODE_READ_ONLY ChaincodeMessage_Type = 22
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,27 +65,33 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "GET_STATE_MULTIPLE",
+	21: "PUT_STATE_MULTIPLE",
+	22: "INVOKE_CHAINCODE_READ_ONLY",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":           0,
-	"REGISTER":            1,
-	"REGISTERED":          2,
-	"INIT":                3,
-	"READY":               4,
-	"TRANSACTION":         5,
-	"COMPLETED":           6,
-	"ERROR":               7,
-	"GET_STATE":           8,
-	"PUT_STATE":           9,
-	"DEL_STATE":           10,
-	"INVOKE_CHAINCODE":    11,
-	"RESPONSE":            13,
-	"GET_STATE_BY_RANGE":  14,
-	"GET_QUERY_RESULT":    15,
-	"QUERY_STATE_NEXT":    16,
-	"QUERY_STATE_CLOSE":   17,
-	"KEEPALIVE":           18,
-	"GET_HISTORY_FOR_KEY": 19,
+	"UNDEFINED":                  0,
+	"REGISTER":                   1,
+	"REGISTERED":                 2,
+	"INIT":                       3,
+	"READY":                      4,
+	"TRANSACTION":                5,
+	"COMPLETED":                  6,
+	"ERROR":                      7,
+	"GET_STATE":                  8,
+	"PUT_STATE":                  9,
+	"DEL_STATE":                  10,
+	"INVOKE_CHAINCODE":           11,
+	"RESPONSE":                   13,
+	"GET_STATE_BY_RANGE":         14,
+	"GET_QUERY_RESULT":           15,
+	"QUERY_STATE_NEXT":           16,
+	"QUERY_STATE_CLOSE":          17,
+	"KEEPALIVE":                  18,
+	"GET_HISTORY_FOR_KEY":        19,
+	"GET_STATE_MULTIPLE":         20,
+	"PUT_STATE_MULTIPLE":         21,
+	"INVOKE_CHAINCODE_READ_ONLY": 22,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -173,6 +182,70 @@ func (m *PutStateInfo) GetValue() []byte {
 	return nil
 }
 
+// GetStateMultiple is the payload of a GET_STATE_MULTIPLE message, it
+// requests the values of several keys in a single round trip
+type GetStateMultiple struct {
+	Keys []string `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (m *GetStateMultiple) Reset()                    { *m = GetStateMultiple{} }
+func (m *GetStateMultiple) String() string            { return proto.CompactTextString(m) }
+func (*GetStateMultiple) ProtoMessage()               {}
+func (*GetStateMultiple) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *GetStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+// GetStateMultipleResponse is the payload of the RESPONSE to a
+// GET_STATE_MULTIPLE message. values[i] is the value of keys[i] in the
+// request, empty if the key does not exist
+type GetStateMultipleResponse struct {
+	Values [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (m *GetStateMultipleResponse) Reset()                    { *m = GetStateMultipleResponse{} }
+func (m *GetStateMultipleResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStateMultipleResponse) ProtoMessage()               {}
+func (*GetStateMultipleResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+func (m *GetStateMultipleResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// PutStateMultiple is the payload of a PUT_STATE_MULTIPLE message, it carries
+// the writes buffered by the chaincode in a single round trip. A key appears
+// at most once, either in puts or in deletes
+type PutStateMultiple struct {
+	Puts    []*PutStateInfo `protobuf:"bytes,1,rep,name=puts" json:"puts,omitempty"`
+	Deletes []string        `protobuf:"bytes,2,rep,name=deletes" json:"deletes,omitempty"`
+}
+
+func (m *PutStateMultiple) Reset()                    { *m = PutStateMultiple{} }
+func (m *PutStateMultiple) String() string            { return proto.CompactTextString(m) }
+func (*PutStateMultiple) ProtoMessage()               {}
+func (*PutStateMultiple) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+
+func (m *PutStateMultiple) GetPuts() []*PutStateInfo {
+	if m != nil {
+		return m.Puts
+	}
+	return nil
+}
+
+func (m *PutStateMultiple) GetDeletes() []string {
+	if m != nil {
+		return m.Deletes
+	}
+	return nil
+}
+
 type GetStateByRange struct {
 	StartKey string `protobuf:"bytes,1,opt,name=startKey" json:"startKey,omitempty"`
 	EndKey   string `protobuf:"bytes,2,opt,name=endKey" json:"endKey,omitempty"`
@@ -181,7 +254,7 @@ type GetStateByRange struct {
 func (m *GetStateByRange) Reset()                    { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string            { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()               {}
-func (*GetStateByRange) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*GetStateByRange) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
 
 func (m *GetStateByRange) GetStartKey() string {
 	if m != nil {
@@ -204,7 +277,7 @@ type GetQueryResult struct {
 func (m *GetQueryResult) Reset()                    { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string            { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()               {}
-func (*GetQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+func (*GetQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
 
 func (m *GetQueryResult) GetQuery() string {
 	if m != nil {
@@ -220,7 +293,7 @@ type GetHistoryForKey struct {
 func (m *GetHistoryForKey) Reset()                    { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string            { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()               {}
-func (*GetHistoryForKey) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+func (*GetHistoryForKey) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
 
 func (m *GetHistoryForKey) GetKey() string {
 	if m != nil {
@@ -236,7 +309,7 @@ type QueryStateNext struct {
 func (m *QueryStateNext) Reset()                    { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()               {}
-func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
 
 func (m *QueryStateNext) GetId() string {
 	if m != nil {
@@ -252,7 +325,7 @@ type QueryStateClose struct {
 func (m *QueryStateClose) Reset()                    { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()               {}
-func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
 
 func (m *QueryStateClose) GetId() string {
 	if m != nil {
@@ -268,7 +341,7 @@ type QueryResultBytes struct {
 func (m *QueryResultBytes) Reset()                    { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string            { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()               {}
-func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
 
 func (m *QueryResultBytes) GetResultBytes() []byte {
 	if m != nil {
@@ -286,7 +359,7 @@ type QueryResponse struct {
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
 func (m *QueryResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()               {}
-func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
 
 func (m *QueryResponse) GetResults() []*QueryResultBytes {
 	if m != nil {
@@ -312,6 +385,9 @@ func (m *QueryResponse) GetId() string {
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*PutStateInfo)(nil), "protos.PutStateInfo")
+	proto.RegisterType((*GetStateMultiple)(nil), "protos.GetStateMultiple")
+	proto.RegisterType((*GetStateMultipleResponse)(nil), "protos.GetStateMultipleResponse")
+	proto.RegisterType((*PutStateMultiple)(nil), "protos.PutStateMultiple")
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
@@ -429,54 +505,60 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x94, 0x6f, 0x6f, 0xe2, 0x46,
-	0x10, 0xc6, 0x8f, 0x3f, 0x49, 0x60, 0x20, 0xb0, 0xb7, 0xb9, 0xa6, 0x3e, 0xa4, 0xaa, 0xd4, 0xaa,
-	0x2a, 0xfa, 0xc6, 0xb4, 0xb4, 0xaa, 0xfa, 0xae, 0x22, 0xb0, 0x21, 0x56, 0x88, 0xcd, 0xad, 0x9d,
-	0xd3, 0xd1, 0x37, 0x96, 0x03, 0x1b, 0x63, 0x15, 0x58, 0x77, 0x77, 0x39, 0x9d, 0x3f, 0x61, 0xbf,
-	0x4c, 0x3f, 0xc4, 0x69, 0x6d, 0x4c, 0xb8, 0x9c, 0xf2, 0xca, 0x7e, 0x66, 0x7e, 0xf3, 0xcc, 0xec,
-	0x6a, 0x35, 0xf0, 0x36, 0x61, 0x4c, 0xf4, 0x17, 0xab, 0x30, 0xde, 0x2e, 0xf8, 0x92, 0x05, 0x72,
-	0x15, 0x6f, 0xac, 0x44, 0x70, 0xc5, 0xf1, 0x69, 0xf6, 0x91, 0x9d, 0xce, 0x33, 0x84, 0x7d, 0x64,
-	0x5b, 0x95, 0x33, 0x9d, 0x8b, 0x2c, 0x97, 0x08, 0x9e, 0x70, 0x19, 0xae, 0xf7, 0xc1, 0xef, 0x23,
-	0xce, 0xa3, 0x35, 0xeb, 0x67, 0xea, 0x61, 0xf7, 0xd8, 0x57, 0xf1, 0x86, 0x49, 0x15, 0x6e, 0x92,
-	0x1c, 0x30, 0xff, 0xaf, 0x02, 0x1a, 0x15, 0x7e, 0x77, 0x4c, 0xca, 0x30, 0x62, 0xf8, 0x57, 0xa8,
-	0xaa, 0x34, 0x61, 0x46, 0xa9, 0x5b, 0xea, 0xb5, 0x06, 0xdf, 0xe5, 0xa8, 0xb4, 0x9e, 0x73, 0x96,
-	0x9f, 0x26, 0x8c, 0x66, 0x28, 0xfe, 0x13, 0xea, 0x07, 0x6b, 0xa3, 0xdc, 0x2d, 0xf5, 0x1a, 0x83,
-	0x8e, 0x95, 0x37, 0xb7, 0x8a, 0xe6, 0x96, 0x5f, 0x10, 0xf4, 0x09, 0xc6, 0x06, 0x9c, 0x25, 0x61,
-	0xba, 0xe6, 0xe1, 0xd2, 0xa8, 0x74, 0x4b, 0xbd, 0x26, 0x2d, 0x24, 0xc6, 0x50, 0x55, 0x9f, 0xe2,
-	0xa5, 0x51, 0xed, 0x96, 0x7a, 0x75, 0x9a, 0xfd, 0xe3, 0x01, 0xd4, 0x8a, 0x23, 0x1a, 0x27, 0x59,
-	0x9b, 0xcb, 0x62, 0x3c, 0x2f, 0x8e, 0xb6, 0x6c, 0x39, 0xdb, 0x67, 0xe9, 0x81, 0xc3, 0x7f, 0x41,
-	0xfb, 0xd9, 0x95, 0x19, 0xa7, 0x5f, 0x96, 0x1e, 0x4e, 0x46, 0x74, 0x96, 0xb6, 0x16, 0x5f, 0x68,
-	0xf3, 0xbf, 0x32, 0x54, 0xf5, 0x59, 0xf1, 0x39, 0xd4, 0xef, 0x9d, 0x31, 0xb9, 0xb6, 0x1d, 0x32,
-	0x46, 0xaf, 0x70, 0x13, 0x6a, 0x94, 0x4c, 0x6c, 0xcf, 0x27, 0x14, 0x95, 0x70, 0x0b, 0xa0, 0x50,
-	0x64, 0x8c, 0xca, 0xb8, 0x06, 0x55, 0xdb, 0xb1, 0x7d, 0x54, 0xc1, 0x75, 0x38, 0xa1, 0x64, 0x38,
-	0x9e, 0xa3, 0x2a, 0x6e, 0x43, 0xc3, 0xa7, 0x43, 0xc7, 0x1b, 0x8e, 0x7c, 0xdb, 0x75, 0xd0, 0x89,
-	0xb6, 0x1c, 0xb9, 0x77, 0xb3, 0x29, 0xf1, 0xc9, 0x18, 0x9d, 0x6a, 0x94, 0x50, 0xea, 0x52, 0x74,
-	0xa6, 0x33, 0x13, 0xe2, 0x07, 0x9e, 0x3f, 0xf4, 0x09, 0xaa, 0x69, 0x39, 0xbb, 0x2f, 0x64, 0x5d,
-	0xcb, 0x31, 0x99, 0xee, 0x25, 0xe0, 0x37, 0x80, 0x6c, 0xe7, 0xbd, 0x7b, 0x4b, 0x82, 0xd1, 0xcd,
-	0xd0, 0x76, 0x46, 0xee, 0x98, 0xa0, 0x46, 0x3e, 0xa0, 0x37, 0x73, 0x1d, 0x8f, 0xa0, 0x73, 0x7c,
-	0x09, 0xf8, 0x60, 0x18, 0x5c, 0xcd, 0x03, 0x3a, 0x74, 0x26, 0x04, 0xb5, 0x74, 0xad, 0x8e, 0xbf,
-	0xbb, 0x27, 0x74, 0x1e, 0x50, 0xe2, 0xdd, 0x4f, 0x7d, 0xd4, 0xd6, 0xd1, 0x3c, 0x92, 0xf3, 0x0e,
-	0xf9, 0xe0, 0x23, 0x84, 0xbf, 0x81, 0xd7, 0xc7, 0xd1, 0xd1, 0xd4, 0xf5, 0x08, 0x7a, 0xad, 0xa7,
-	0xb9, 0x25, 0x64, 0x36, 0x9c, 0xda, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8, 0xd0, 0x8e, 0x37, 0xb6,
-	0xe7, 0xbb, 0x74, 0x1e, 0x5c, 0xbb, 0x34, 0xb8, 0x25, 0x73, 0x74, 0x61, 0xfe, 0x01, 0xcd, 0xd9,
-	0x4e, 0x79, 0x2a, 0x54, 0xcc, 0xde, 0x3e, 0x72, 0x8c, 0xa0, 0xf2, 0x0f, 0x4b, 0xb3, 0x87, 0x56,
-	0xa7, 0xfa, 0x17, 0xbf, 0x81, 0x93, 0x8f, 0xe1, 0x7a, 0xc7, 0xb2, 0x47, 0xd4, 0xa4, 0xb9, 0x30,
-	0x09, 0xb4, 0x27, 0x2c, 0xaf, 0xbb, 0x4a, 0x69, 0xb8, 0x8d, 0x18, 0xee, 0x40, 0x4d, 0xaa, 0x50,
-	0xa8, 0xdb, 0x43, 0xfd, 0x41, 0xe3, 0x4b, 0x38, 0x65, 0xdb, 0xa5, 0xce, 0x94, 0xb3, 0xcc, 0x5e,
-	0x99, 0x3f, 0x41, 0x6b, 0xc2, 0xd4, 0xbb, 0x1d, 0x13, 0x29, 0x65, 0x72, 0xb7, 0x56, 0xba, 0xdd,
-	0xbf, 0x5a, 0xee, 0x2d, 0x72, 0x61, 0xfe, 0x08, 0x68, 0xc2, 0xd4, 0x4d, 0x2c, 0x15, 0x17, 0xe9,
-	0x35, 0x17, 0xda, 0xf3, 0xab, 0x51, 0xcd, 0x2e, 0xb4, 0x32, 0xab, 0x6c, 0x2c, 0x87, 0x7d, 0x52,
-	0xb8, 0x05, 0xe5, 0x78, 0xb9, 0x47, 0xca, 0xf1, 0xd2, 0xfc, 0x01, 0xda, 0x4f, 0xc4, 0x68, 0xcd,
-	0x25, 0xfb, 0x0a, 0xf9, 0x1d, 0xd0, 0xd1, 0x3c, 0x57, 0xa9, 0x62, 0x12, 0x77, 0xa1, 0x21, 0x9e,
-	0x64, 0x06, 0x37, 0xe9, 0x71, 0xc8, 0xdc, 0xc2, 0x79, 0x51, 0x95, 0xf0, 0xad, 0x64, 0x78, 0x00,
-	0x67, 0x79, 0x5e, 0xe3, 0x95, 0x5e, 0x63, 0x60, 0x14, 0x6f, 0xfb, 0xb9, 0x3b, 0x2d, 0x40, 0xfc,
-	0x16, 0x6a, 0xab, 0x50, 0x06, 0x1b, 0x2e, 0xf2, 0xdb, 0xae, 0xd1, 0xb3, 0x55, 0x28, 0xef, 0xb8,
-	0x28, 0xa6, 0xac, 0x14, 0x53, 0x0e, 0x3e, 0x1c, 0x6d, 0x09, 0x6f, 0x97, 0x24, 0x5c, 0x28, 0x3c,
-	0x86, 0x1a, 0x65, 0x51, 0x2c, 0x15, 0x13, 0xd8, 0x78, 0x69, 0x47, 0x74, 0x5e, 0xcc, 0x98, 0xaf,
-	0x7a, 0xa5, 0x5f, 0x4a, 0x57, 0x2e, 0x98, 0x5c, 0x44, 0xd6, 0x2a, 0x4d, 0x98, 0x58, 0xb3, 0x65,
-	0xc4, 0x84, 0xf5, 0x18, 0x3e, 0x88, 0x78, 0x51, 0xd4, 0xe9, 0xb5, 0xf6, 0xf7, 0xcf, 0x51, 0xac,
-	0x56, 0xbb, 0x07, 0x6b, 0xc1, 0x37, 0xfd, 0x23, 0xb4, 0x9f, 0xa3, 0xf9, 0x7a, 0x93, 0x7d, 0x8d,
-	0x3e, 0xe4, 0xbb, 0xf2, 0xb7, 0xcf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xad, 0x9f, 0x80, 0x68, 0x4f,
-	0x05, 0x00, 0x00,
+	// 872 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x95, 0x5f, 0x6f, 0xe2, 0x46,
+	0x17, 0xc6, 0x97, 0x7f, 0x09, 0x1c, 0x08, 0xcc, 0x4e, 0xb2, 0x79, 0xbd, 0x48, 0x6f, 0x4b, 0xad,
+	0x6a, 0x45, 0x6f, 0xa0, 0xa5, 0x55, 0xd5, 0xbb, 0x8a, 0xc0, 0x84, 0x58, 0x01, 0x9b, 0x1d, 0x9b,
+	0x68, 0xe9, 0x8d, 0xe5, 0xc0, 0xc4, 0x58, 0x6b, 0xb0, 0xeb, 0x19, 0xaf, 0xd6, 0x9f, 0xa4, 0x5f,
+	0xa6, 0x1f, 0xae, 0x1a, 0x1b, 0x13, 0x42, 0xb4, 0x57, 0xf8, 0x39, 0xe7, 0x77, 0x8e, 0x9f, 0x39,
+	0x1c, 0x06, 0x78, 0x1f, 0x32, 0x16, 0xf5, 0x57, 0x1b, 0xc7, 0xdb, 0xad, 0x82, 0x35, 0xb3, 0xf9,
+	0xc6, 0xdb, 0xf6, 0xc2, 0x28, 0x10, 0x01, 0x3e, 0x4b, 0x3f, 0x78, 0xbb, 0x7d, 0x82, 0xb0, 0x2f,
+	0x6c, 0x27, 0x32, 0xa6, 0x7d, 0x99, 0xe6, 0xc2, 0x28, 0x08, 0x03, 0xee, 0xf8, 0xfb, 0xe0, 0xf7,
+	0x6e, 0x10, 0xb8, 0x3e, 0xeb, 0xa7, 0xea, 0x31, 0x7e, 0xea, 0x0b, 0x6f, 0xcb, 0xb8, 0x70, 0xb6,
+	0x61, 0x06, 0xa8, 0xff, 0x56, 0x00, 0x8d, 0xf2, 0x7e, 0x33, 0xc6, 0xb9, 0xe3, 0x32, 0xfc, 0x0b,
+	0x94, 0x45, 0x12, 0x32, 0xa5, 0xd0, 0x29, 0x74, 0x9b, 0x83, 0xff, 0x67, 0x28, 0xef, 0x9d, 0x72,
+	0x3d, 0x2b, 0x09, 0x19, 0x4d, 0x51, 0xfc, 0x07, 0xd4, 0x0e, 0xad, 0x95, 0x62, 0xa7, 0xd0, 0xad,
+	0x0f, 0xda, 0xbd, 0xec, 0xe5, 0xbd, 0xfc, 0xe5, 0x3d, 0x2b, 0x27, 0xe8, 0x33, 0x8c, 0x15, 0x38,
+	0x0f, 0x9d, 0xc4, 0x0f, 0x9c, 0xb5, 0x52, 0xea, 0x14, 0xba, 0x0d, 0x9a, 0x4b, 0x8c, 0xa1, 0x2c,
+	0xbe, 0x7a, 0x6b, 0xa5, 0xdc, 0x29, 0x74, 0x6b, 0x34, 0x7d, 0xc6, 0x03, 0xa8, 0xe6, 0x47, 0x54,
+	0x2a, 0xe9, 0x6b, 0xae, 0x73, 0x7b, 0xa6, 0xe7, 0xee, 0xd8, 0x7a, 0xbe, 0xcf, 0xd2, 0x03, 0x87,
+	0xff, 0x84, 0xd6, 0xc9, 0xc8, 0x94, 0xb3, 0x97, 0xa5, 0x87, 0x93, 0x11, 0x99, 0xa5, 0xcd, 0xd5,
+	0x0b, 0xad, 0xfe, 0x53, 0x82, 0xb2, 0x3c, 0x2b, 0xbe, 0x80, 0xda, 0x42, 0x1f, 0x93, 0x5b, 0x4d,
+	0x27, 0x63, 0xf4, 0x06, 0x37, 0xa0, 0x4a, 0xc9, 0x44, 0x33, 0x2d, 0x42, 0x51, 0x01, 0x37, 0x01,
+	0x72, 0x45, 0xc6, 0xa8, 0x88, 0xab, 0x50, 0xd6, 0x74, 0xcd, 0x42, 0x25, 0x5c, 0x83, 0x0a, 0x25,
+	0xc3, 0xf1, 0x12, 0x95, 0x71, 0x0b, 0xea, 0x16, 0x1d, 0xea, 0xe6, 0x70, 0x64, 0x69, 0x86, 0x8e,
+	0x2a, 0xb2, 0xe5, 0xc8, 0x98, 0xcd, 0xa7, 0xc4, 0x22, 0x63, 0x74, 0x26, 0x51, 0x42, 0xa9, 0x41,
+	0xd1, 0xb9, 0xcc, 0x4c, 0x88, 0x65, 0x9b, 0xd6, 0xd0, 0x22, 0xa8, 0x2a, 0xe5, 0x7c, 0x91, 0xcb,
+	0x9a, 0x94, 0x63, 0x32, 0xdd, 0x4b, 0xc0, 0x57, 0x80, 0x34, 0xfd, 0xc1, 0xb8, 0x27, 0xf6, 0xe8,
+	0x6e, 0xa8, 0xe9, 0x23, 0x63, 0x4c, 0x50, 0x3d, 0x33, 0x68, 0xce, 0x0d, 0xdd, 0x24, 0xe8, 0x02,
+	0x5f, 0x03, 0x3e, 0x34, 0xb4, 0x6f, 0x96, 0x36, 0x1d, 0xea, 0x13, 0x82, 0x9a, 0xb2, 0x56, 0xc6,
+	0x3f, 0x2e, 0x08, 0x5d, 0xda, 0x94, 0x98, 0x8b, 0xa9, 0x85, 0x5a, 0x32, 0x9a, 0x45, 0x32, 0x5e,
+	0x27, 0x9f, 0x2c, 0x84, 0xf0, 0x3b, 0x78, 0x7b, 0x1c, 0x1d, 0x4d, 0x0d, 0x93, 0xa0, 0xb7, 0xd2,
+	0xcd, 0x3d, 0x21, 0xf3, 0xe1, 0x54, 0x7b, 0x20, 0x08, 0xe3, 0xff, 0xc1, 0xa5, 0xec, 0x78, 0xa7,
+	0x99, 0x96, 0x41, 0x97, 0xf6, 0xad, 0x41, 0xed, 0x7b, 0xb2, 0x44, 0x97, 0x2f, 0x2d, 0xcc, 0x16,
+	0x53, 0x4b, 0x9b, 0x4f, 0x09, 0xba, 0x92, 0xf1, 0xf9, 0xe2, 0x55, 0xfc, 0x1d, 0xfe, 0x0e, 0xda,
+	0xa7, 0xc7, 0xb2, 0xe5, 0x28, 0x6d, 0x43, 0x9f, 0x2e, 0xd1, 0xb5, 0xfa, 0x3b, 0x34, 0xe6, 0xb1,
+	0x30, 0x85, 0x23, 0x98, 0xb6, 0x7b, 0x0a, 0x30, 0x82, 0xd2, 0x67, 0x96, 0xa4, 0x8b, 0x5b, 0xa3,
+	0xf2, 0x11, 0x5f, 0x41, 0xe5, 0x8b, 0xe3, 0xc7, 0x2c, 0x5d, 0xca, 0x06, 0xcd, 0x84, 0xfa, 0x01,
+	0xd0, 0x84, 0x65, 0x75, 0xb3, 0xd8, 0x17, 0x5e, 0xe8, 0x33, 0xb9, 0x6e, 0x9f, 0x59, 0xc2, 0x95,
+	0x42, 0xa7, 0x24, 0xd7, 0x4d, 0x3e, 0xab, 0x03, 0x50, 0x4e, 0x39, 0xca, 0x78, 0x18, 0xec, 0x38,
+	0xc3, 0xd7, 0x70, 0x96, 0x36, 0xcb, 0x2a, 0x1a, 0x74, 0xaf, 0xd4, 0x07, 0x40, 0xf3, 0xf8, 0x65,
+	0x0d, 0xee, 0x42, 0x39, 0x8c, 0x45, 0x46, 0xd6, 0x07, 0x57, 0xf9, 0xde, 0x1d, 0x7b, 0xa7, 0x29,
+	0x21, 0x7f, 0x0e, 0x6b, 0xe6, 0x33, 0xc1, 0xb8, 0x52, 0x4c, 0x8d, 0xe4, 0x52, 0x25, 0xd0, 0xca,
+	0xbd, 0xdc, 0x24, 0xd4, 0xd9, 0xb9, 0x0c, 0xb7, 0xa1, 0xca, 0x85, 0x13, 0x89, 0xfb, 0xc3, 0x99,
+	0x0f, 0x5a, 0xda, 0x63, 0xbb, 0xb5, 0xcc, 0x14, 0xd3, 0xcc, 0x5e, 0xa9, 0x1f, 0xa0, 0x39, 0x61,
+	0xe2, 0x63, 0xcc, 0xa2, 0x84, 0x32, 0x1e, 0xfb, 0x42, 0x8e, 0xe8, 0x6f, 0x29, 0xf7, 0x2d, 0x32,
+	0xa1, 0xfe, 0x98, 0x8e, 0xe8, 0xce, 0xe3, 0x22, 0x88, 0x92, 0xdb, 0x20, 0x92, 0x3d, 0x5f, 0x8d,
+	0x57, 0xed, 0x40, 0x33, 0x6d, 0x95, 0xda, 0xd2, 0xd9, 0x57, 0x81, 0x9b, 0x50, 0xf4, 0xd6, 0x7b,
+	0xa4, 0xe8, 0xad, 0xd5, 0x1f, 0xa0, 0xf5, 0x4c, 0x8c, 0xfc, 0x80, 0xb3, 0x57, 0xc8, 0x6f, 0x80,
+	0x8e, 0xfc, 0xdc, 0x24, 0x82, 0x71, 0xdc, 0x81, 0x7a, 0xf4, 0x2c, 0x53, 0xb8, 0x41, 0x8f, 0x43,
+	0xea, 0x0e, 0x2e, 0xf2, 0xaa, 0xec, 0x0b, 0x19, 0xc0, 0x79, 0x96, 0xcf, 0xe7, 0xac, 0xe4, 0x73,
+	0x3e, 0xed, 0x4e, 0x73, 0x10, 0xbf, 0x87, 0xea, 0xc6, 0xe1, 0xf6, 0x36, 0x88, 0xb2, 0x0d, 0xa9,
+	0xd2, 0xf3, 0x8d, 0xc3, 0x67, 0x41, 0x94, 0xbb, 0x2c, 0xe5, 0x2e, 0x07, 0x9f, 0x8e, 0x6e, 0x4a,
+	0x33, 0x0e, 0xc3, 0x20, 0x12, 0x78, 0x0c, 0x55, 0xca, 0x5c, 0x8f, 0x0b, 0x16, 0x61, 0xe5, 0x5b,
+	0xf7, 0x64, 0xfb, 0x9b, 0x19, 0xf5, 0x4d, 0xb7, 0xf0, 0x73, 0xe1, 0xc6, 0x00, 0x35, 0x88, 0xdc,
+	0xde, 0x26, 0x09, 0x59, 0xe4, 0xb3, 0xb5, 0xcb, 0xa2, 0xde, 0x93, 0xf3, 0x18, 0x79, 0xab, 0xbc,
+	0x4e, 0x5e, 0xed, 0x7f, 0xfd, 0xe4, 0x7a, 0x62, 0x13, 0x3f, 0xf6, 0x56, 0xc1, 0xb6, 0x7f, 0x84,
+	0xf6, 0x33, 0x34, 0xbb, 0xe2, 0x79, 0x5f, 0xa2, 0x8f, 0xd9, 0xff, 0xc5, 0xaf, 0xff, 0x0d, 0x00,
+	0x21, 0xc3, 0x16, 0x5b, 0x53, 0x06, 0x00, 0x00,
 }
@@ -25,6 +25,7 @@ const (
 	EventType_BLOCK     EventType = 1
 	EventType_CHAINCODE EventType = 2
 	EventType_REJECTION EventType = 3
+	EventType_TX_STATUS EventType = 4
 )
 
 var EventType_name = map[int32]string{
@@ -32,12 +33,14 @@ var EventType_name = map[int32]string{
 	1: "BLOCK",
 	2: "CHAINCODE",
 	3: "REJECTION",
+	4: "TX_STATUS",
 }
 var EventType_value = map[string]int32{
 	"REGISTER":  0,
 	"BLOCK":     1,
 	"CHAINCODE": 2,
 	"REJECTION": 3,
+	"TX_STATUS": 4,
 }
 
 func (x EventType) String() string {
@@ -71,6 +74,35 @@ func (m *ChaincodeReg) GetEventName() string {
 	return ""
 }
 
+// TxStatusReg is used for registering transaction status Interests
+// when EventType is TX_STATUS, either for a given transaction, or
+// for all the transactions created by the registering identity
+// A registration for a given transaction is removed once its status
+// has been delivered
+type TxStatusReg struct {
+	TxId           string `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	MyTransactions bool   `protobuf:"varint,2,opt,name=my_transactions,json=myTransactions" json:"my_transactions,omitempty"`
+}
+
+func (m *TxStatusReg) Reset()                    { *m = TxStatusReg{} }
+func (m *TxStatusReg) String() string            { return proto.CompactTextString(m) }
+func (*TxStatusReg) ProtoMessage()               {}
+func (*TxStatusReg) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
+
+func (m *TxStatusReg) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxStatusReg) GetMyTransactions() bool {
+	if m != nil {
+		return m.MyTransactions
+	}
+	return false
+}
+
 type Interest struct {
 	EventType EventType `protobuf:"varint,1,opt,name=event_type,json=eventType,enum=protos.EventType" json:"event_type,omitempty"`
 	// Ideally we should just have the following oneof for different
@@ -80,6 +112,7 @@ type Interest struct {
 	//
 	// Types that are valid to be assigned to RegInfo:
 	//	*Interest_ChaincodeRegInfo
+	//	*Interest_TxStatusRegInfo
 	RegInfo isInterest_RegInfo `protobuf_oneof:"RegInfo"`
 	ChainID string             `protobuf:"bytes,3,opt,name=chainID" json:"chainID,omitempty"`
 }
@@ -87,7 +120,7 @@ type Interest struct {
 func (m *Interest) Reset()                    { *m = Interest{} }
 func (m *Interest) String() string            { return proto.CompactTextString(m) }
 func (*Interest) ProtoMessage()               {}
-func (*Interest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
+func (*Interest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{2} }
 
 type isInterest_RegInfo interface {
 	isInterest_RegInfo()
@@ -96,8 +129,12 @@ type isInterest_RegInfo interface {
 type Interest_ChaincodeRegInfo struct {
 	ChaincodeRegInfo *ChaincodeReg `protobuf:"bytes,2,opt,name=chaincode_reg_info,json=chaincodeRegInfo,oneof"`
 }
+type Interest_TxStatusRegInfo struct {
+	TxStatusRegInfo *TxStatusReg `protobuf:"bytes,4,opt,name=tx_status_reg_info,json=txStatusRegInfo,oneof"`
+}
 
 func (*Interest_ChaincodeRegInfo) isInterest_RegInfo() {}
+func (*Interest_TxStatusRegInfo) isInterest_RegInfo()  {}
 
 func (m *Interest) GetRegInfo() isInterest_RegInfo {
 	if m != nil {
@@ -120,6 +157,13 @@ func (m *Interest) GetChaincodeRegInfo() *ChaincodeReg {
 	return nil
 }
 
+func (m *Interest) GetTxStatusRegInfo() *TxStatusReg {
+	if x, ok := m.GetRegInfo().(*Interest_TxStatusRegInfo); ok {
+		return x.TxStatusRegInfo
+	}
+	return nil
+}
+
 func (m *Interest) GetChainID() string {
 	if m != nil {
 		return m.ChainID
@@ -131,6 +175,7 @@ func (m *Interest) GetChainID() string {
 func (*Interest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Interest_OneofMarshaler, _Interest_OneofUnmarshaler, _Interest_OneofSizer, []interface{}{
 		(*Interest_ChaincodeRegInfo)(nil),
+		(*Interest_TxStatusRegInfo)(nil),
 	}
 }
 
@@ -143,6 +188,11 @@ func _Interest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ChaincodeRegInfo); err != nil {
 			return err
 		}
+	case *Interest_TxStatusRegInfo:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TxStatusRegInfo); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Interest.RegInfo has unexpected type %T", x)
@@ -161,6 +211,14 @@ func _Interest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.RegInfo = &Interest_ChaincodeRegInfo{msg}
 		return true, err
+	case 4: // RegInfo.tx_status_reg_info
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TxStatusReg)
+		err := b.DecodeMessage(msg)
+		m.RegInfo = &Interest_TxStatusRegInfo{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -175,6 +233,11 @@ func _Interest_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Interest_TxStatusRegInfo:
+		s := proto.Size(x.TxStatusRegInfo)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -192,7 +255,7 @@ type Register struct {
 func (m *Register) Reset()                    { *m = Register{} }
 func (m *Register) String() string            { return proto.CompactTextString(m) }
 func (*Register) ProtoMessage()               {}
-func (*Register) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{2} }
+func (*Register) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{3} }
 
 func (m *Register) GetEvents() []*Interest {
 	if m != nil {
@@ -211,7 +274,7 @@ type Rejection struct {
 func (m *Rejection) Reset()                    { *m = Rejection{} }
 func (m *Rejection) String() string            { return proto.CompactTextString(m) }
 func (*Rejection) ProtoMessage()               {}
-func (*Rejection) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{3} }
+func (*Rejection) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{4} }
 
 func (m *Rejection) GetTx() *Transaction {
 	if m != nil {
@@ -227,6 +290,57 @@ func (m *Rejection) GetErrorMsg() string {
 	return ""
 }
 
+// TxStatus is sent by the producer when a transaction is committed
+// string type - "txstatus"
+type TxStatus struct {
+	TxId           string           `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	BlockNumber    uint64           `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TxIndex        uint32           `protobuf:"varint,3,opt,name=tx_index,json=txIndex" json:"tx_index,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,4,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+	// Creator of the transaction
+	Creator []byte `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+}
+
+func (m *TxStatus) Reset()                    { *m = TxStatus{} }
+func (m *TxStatus) String() string            { return proto.CompactTextString(m) }
+func (*TxStatus) ProtoMessage()               {}
+func (*TxStatus) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{5} }
+
+func (m *TxStatus) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxStatus) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TxStatus) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *TxStatus) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *TxStatus) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
 // ---------- producer events ---------
 type Unregister struct {
 	Events []*Interest `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
@@ -235,7 +349,7 @@ type Unregister struct {
 func (m *Unregister) Reset()                    { *m = Unregister{} }
 func (m *Unregister) String() string            { return proto.CompactTextString(m) }
 func (*Unregister) ProtoMessage()               {}
-func (*Unregister) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{4} }
+func (*Unregister) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
 
 func (m *Unregister) GetEvents() []*Interest {
 	if m != nil {
@@ -255,7 +369,7 @@ type SignedEvent struct {
 func (m *SignedEvent) Reset()                    { *m = SignedEvent{} }
 func (m *SignedEvent) String() string            { return proto.CompactTextString(m) }
 func (*SignedEvent) ProtoMessage()               {}
-func (*SignedEvent) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{5} }
+func (*SignedEvent) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{7} }
 
 func (m *SignedEvent) GetSignature() []byte {
 	if m != nil {
@@ -281,15 +395,18 @@ type Event struct {
 	//	*Event_ChaincodeEvent
 	//	*Event_Rejection
 	//	*Event_Unregister
+	//	*Event_TxStatus
 	Event isEvent_Event `protobuf_oneof:"Event"`
 	// Creator of the event, specified as a certificate chain
 	Creator []byte `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Channel the producer event belongs to
+	ChannelId string `protobuf:"bytes,7,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
 func (m *Event) String() string            { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()               {}
-func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{8} }
 
 type isEvent_Event interface {
 	isEvent_Event()
@@ -310,12 +427,16 @@ type Event_Rejection struct {
 type Event_Unregister struct {
 	Unregister *Unregister `protobuf:"bytes,5,opt,name=unregister,oneof"`
 }
+type Event_TxStatus struct {
+	TxStatus *TxStatus `protobuf:"bytes,8,opt,name=tx_status,json=txStatus,oneof"`
+}
 
 func (*Event_Register) isEvent_Event()       {}
 func (*Event_Block) isEvent_Event()          {}
 func (*Event_ChaincodeEvent) isEvent_Event() {}
 func (*Event_Rejection) isEvent_Event()      {}
 func (*Event_Unregister) isEvent_Event()     {}
+func (*Event_TxStatus) isEvent_Event()       {}
 
 func (m *Event) GetEvent() isEvent_Event {
 	if m != nil {
@@ -359,6 +480,13 @@ func (m *Event) GetUnregister() *Unregister {
 	return nil
 }
 
+func (m *Event) GetTxStatus() *TxStatus {
+	if x, ok := m.GetEvent().(*Event_TxStatus); ok {
+		return x.TxStatus
+	}
+	return nil
+}
+
 func (m *Event) GetCreator() []byte {
 	if m != nil {
 		return m.Creator
@@ -366,6 +494,13 @@ func (m *Event) GetCreator() []byte {
 	return nil
 }
 
+func (m *Event) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Event_OneofMarshaler, _Event_OneofUnmarshaler, _Event_OneofSizer, []interface{}{
@@ -374,6 +509,7 @@ func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Event_ChaincodeEvent)(nil),
 		(*Event_Rejection)(nil),
 		(*Event_Unregister)(nil),
+		(*Event_TxStatus)(nil),
 	}
 }
 
@@ -406,6 +542,11 @@ func _Event_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Unregister); err != nil {
 			return err
 		}
+	case *Event_TxStatus:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TxStatus); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Event.Event has unexpected type %T", x)
@@ -456,6 +597,14 @@ func _Event_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Event = &Event_Unregister{msg}
 		return true, err
+	case 8: // Event.tx_status
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TxStatus)
+		err := b.DecodeMessage(msg)
+		m.Event = &Event_TxStatus{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -490,6 +639,185 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Event_TxStatus:
+		s := proto.Size(x.TxStatus)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// BlockChaincodeEvents holds the chaincode events emitted by the
+// valid transactions of a block
+type BlockChaincodeEvents struct {
+	Number          uint64            `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	ChaincodeEvents []*ChaincodeEvent `protobuf:"bytes,2,rep,name=chaincode_events,json=chaincodeEvents" json:"chaincode_events,omitempty"`
+}
+
+func (m *BlockChaincodeEvents) Reset()                    { *m = BlockChaincodeEvents{} }
+func (m *BlockChaincodeEvents) String() string            { return proto.CompactTextString(m) }
+func (*BlockChaincodeEvents) ProtoMessage()               {}
+func (*BlockChaincodeEvents) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{9} }
+
+func (m *BlockChaincodeEvents) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockChaincodeEvents) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
+// DeliverResponse is sent by the Deliver service of the peer
+type DeliverResponse struct {
+	// Types that are valid to be assigned to Type:
+	//	*DeliverResponse_Status
+	//	*DeliverResponse_Block
+	//	*DeliverResponse_ChaincodeEvents
+	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
+}
+
+func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
+func (m *DeliverResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeliverResponse) ProtoMessage()               {}
+func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{10} }
+
+type isDeliverResponse_Type interface {
+	isDeliverResponse_Type()
+}
+
+type DeliverResponse_Status struct {
+	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status,oneof"`
+}
+type DeliverResponse_Block struct {
+	Block *common.Block `protobuf:"bytes,2,opt,name=block,oneof"`
+}
+type DeliverResponse_ChaincodeEvents struct {
+	ChaincodeEvents *BlockChaincodeEvents `protobuf:"bytes,3,opt,name=chaincode_events,json=chaincodeEvents,oneof"`
+}
+
+func (*DeliverResponse_Status) isDeliverResponse_Type()          {}
+func (*DeliverResponse_Block) isDeliverResponse_Type()           {}
+func (*DeliverResponse_ChaincodeEvents) isDeliverResponse_Type() {}
+
+func (m *DeliverResponse) GetType() isDeliverResponse_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *DeliverResponse) GetStatus() common.Status {
+	if x, ok := m.GetType().(*DeliverResponse_Status); ok {
+		return x.Status
+	}
+	return common.Status_UNKNOWN
+}
+
+func (m *DeliverResponse) GetBlock() *common.Block {
+	if x, ok := m.GetType().(*DeliverResponse_Block); ok {
+		return x.Block
+	}
+	return nil
+}
+
+func (m *DeliverResponse) GetChaincodeEvents() *BlockChaincodeEvents {
+	if x, ok := m.GetType().(*DeliverResponse_ChaincodeEvents); ok {
+		return x.ChaincodeEvents
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
+		(*DeliverResponse_Status)(nil),
+		(*DeliverResponse_Block)(nil),
+		(*DeliverResponse_ChaincodeEvents)(nil),
+	}
+}
+
+func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*DeliverResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *DeliverResponse_Status:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Status))
+	case *DeliverResponse_Block:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Block); err != nil {
+			return err
+		}
+	case *DeliverResponse_ChaincodeEvents:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeEvents); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*DeliverResponse)
+	switch tag {
+	case 1: // Type.status
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Type = &DeliverResponse_Status{common.Status(x)}
+		return true, err
+	case 2: // Type.block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common.Block)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_Block{msg}
+		return true, err
+	case 3: // Type.chaincode_events
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockChaincodeEvents)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_ChaincodeEvents{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*DeliverResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *DeliverResponse_Status:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Status))
+	case *DeliverResponse_Block:
+		s := proto.Size(x.Block)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *DeliverResponse_ChaincodeEvents:
+		s := proto.Size(x.ChaincodeEvents)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -499,12 +827,16 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
+	proto.RegisterType((*TxStatusReg)(nil), "protos.TxStatusReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
 	proto.RegisterType((*Register)(nil), "protos.Register")
 	proto.RegisterType((*Rejection)(nil), "protos.Rejection")
+	proto.RegisterType((*TxStatus)(nil), "protos.TxStatus")
 	proto.RegisterType((*Unregister)(nil), "protos.Unregister")
 	proto.RegisterType((*SignedEvent)(nil), "protos.SignedEvent")
 	proto.RegisterType((*Event)(nil), "protos.Event")
+	proto.RegisterType((*BlockChaincodeEvents)(nil), "protos.BlockChaincodeEvents")
+	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -614,47 +946,235 @@ var _Events_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/events.proto",
 }
 
+// Client API for Deliver service
+
+type DeliverClient interface {
+	// Deliver first requires an Envelope of type DELIVER_SEEK_INFO with Payload data as a marshaled
+	// orderer.SeekInfo message, then a stream of block replies is received.
+	Deliver(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverClient, error)
+	// DeliverChaincodeEvents is like Deliver, but a stream of chaincode events replies
+	// is received, one for each block
+	DeliverChaincodeEvents(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverChaincodeEventsClient, error)
+}
+
+type deliverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDeliverClient(cc *grpc.ClientConn) DeliverClient {
+	return &deliverClient{cc}
+}
+
+func (c *deliverClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deliver_serviceDesc.Streams[0], c.cc, "/protos.Deliver/Deliver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deliverClient) DeliverChaincodeEvents(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverChaincodeEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deliver_serviceDesc.Streams[1], c.cc, "/protos.Deliver/DeliverChaincodeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverChaincodeEventsClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverChaincodeEventsClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverChaincodeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverChaincodeEventsClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverChaincodeEventsClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Deliver service
+
+type DeliverServer interface {
+	// Deliver first requires an Envelope of type DELIVER_SEEK_INFO with Payload data as a marshaled
+	// orderer.SeekInfo message, then a stream of block replies is received.
+	Deliver(Deliver_DeliverServer) error
+	// DeliverChaincodeEvents is like Deliver, but a stream of chaincode events replies
+	// is received, one for each block
+	DeliverChaincodeEvents(Deliver_DeliverChaincodeEventsServer) error
+}
+
+func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
+	s.RegisterService(&_Deliver_serviceDesc, srv)
+}
+
+func _Deliver_Deliver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).Deliver(&deliverDeliverServer{stream})
+}
+
+type Deliver_DeliverServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Deliver_DeliverChaincodeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverChaincodeEvents(&deliverDeliverChaincodeEventsServer{stream})
+}
+
+type Deliver_DeliverChaincodeEventsServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverChaincodeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverChaincodeEventsServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverChaincodeEventsServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Deliver_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Deliver",
+	HandlerType: (*DeliverServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Deliver",
+			Handler:       _Deliver_Deliver_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DeliverChaincodeEvents",
+			Handler:       _Deliver_DeliverChaincodeEvents_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/events.proto",
+}
+
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 611 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x6f, 0x9b, 0x4e,
-	0x10, 0x05, 0x27, 0x76, 0xcc, 0xd8, 0xce, 0xcf, 0xd9, 0xfc, 0x14, 0x21, 0xf7, 0x8f, 0x52, 0xaa,
-	0x4a, 0x6e, 0x0f, 0x26, 0xa5, 0x51, 0x0f, 0xbd, 0x05, 0x82, 0x0a, 0x4d, 0xf3, 0x47, 0x1b, 0xf7,
-	0xd2, 0x43, 0x2d, 0x8c, 0x27, 0x98, 0x26, 0x06, 0x6b, 0xd9, 0x54, 0xf1, 0x27, 0xea, 0xa9, 0xdf,
-	0xb1, 0x62, 0x61, 0x81, 0xb4, 0xa7, 0x9e, 0xf0, 0xcc, 0xbc, 0x37, 0xfb, 0xf6, 0xcd, 0xac, 0x61,
-	0x6f, 0x8d, 0xc8, 0x4c, 0xfc, 0x81, 0x09, 0xcf, 0x26, 0x6b, 0x96, 0xf2, 0x94, 0x74, 0xc4, 0x27,
-	0x1b, 0xed, 0x87, 0xe9, 0x6a, 0x95, 0x26, 0x66, 0xf1, 0x29, 0x8a, 0xa3, 0x91, 0xc0, 0x87, 0xcb,
-	0x20, 0x4e, 0xc2, 0x74, 0x81, 0x33, 0xc1, 0x2c, 0x6b, 0x07, 0xa2, 0xc6, 0x59, 0x90, 0x64, 0x41,
-	0xc8, 0x63, 0xc9, 0x31, 0xae, 0xa0, 0xef, 0x48, 0x02, 0xc5, 0x88, 0xbc, 0x80, 0x7e, 0xdd, 0x20,
-	0x5e, 0xe8, 0xea, 0xa1, 0x3a, 0xd6, 0x68, 0xaf, 0xca, 0xf9, 0x0b, 0xf2, 0x0c, 0x40, 0x74, 0x9e,
-	0x25, 0xc1, 0x0a, 0xf5, 0x96, 0x00, 0x68, 0x22, 0x73, 0x11, 0xac, 0xd0, 0xf8, 0xa9, 0x42, 0xd7,
-	0x4f, 0x38, 0x32, 0xcc, 0x38, 0x39, 0x92, 0x58, 0xbe, 0x59, 0xa3, 0x68, 0xb6, 0x6b, 0xed, 0x15,
-	0x47, 0x67, 0x13, 0x37, 0xaf, 0x4c, 0x37, 0x6b, 0x2c, 0xe9, 0xf9, 0x4f, 0x72, 0x0a, 0xa4, 0x16,
-	0xc0, 0x30, 0x9a, 0xc5, 0xc9, 0x4d, 0x2a, 0x4e, 0xe9, 0x59, 0xff, 0x4b, 0x66, 0x53, 0xb2, 0xa7,
-	0xd0, 0x61, 0xd8, 0x88, 0xfd, 0xe4, 0x26, 0x25, 0x3a, 0xec, 0x88, 0x9c, 0x7f, 0xaa, 0x6f, 0x09,
-	0x81, 0x32, 0xb4, 0x35, 0xd8, 0x29, 0x41, 0xc6, 0x31, 0x74, 0x29, 0x46, 0x71, 0xc6, 0x91, 0x91,
-	0x31, 0x74, 0x0a, 0xa3, 0x75, 0xf5, 0x70, 0x6b, 0xdc, 0xb3, 0x86, 0xf2, 0x28, 0x79, 0x15, 0x5a,
-	0xd6, 0x8d, 0x73, 0xd0, 0x28, 0x7e, 0x47, 0x61, 0x22, 0x79, 0x09, 0x2d, 0xfe, 0x20, 0xee, 0xd5,
-	0xb3, 0xf6, 0x25, 0x65, 0x5a, 0xbb, 0x4c, 0x5b, 0xfc, 0x81, 0x3c, 0x01, 0x0d, 0x19, 0x4b, 0xd9,
-	0x6c, 0x95, 0x45, 0xa5, 0x5f, 0x5d, 0x91, 0x38, 0xcf, 0x22, 0xe3, 0x3d, 0xc0, 0x97, 0x84, 0xfd,
-	0xbb, 0x8c, 0x33, 0xe8, 0x5d, 0xc7, 0x51, 0x82, 0x0b, 0xe1, 0x22, 0x79, 0x0a, 0x5a, 0x16, 0x47,
-	0x49, 0xc0, 0xef, 0x59, 0xe1, 0x73, 0x9f, 0xd6, 0x09, 0xf2, 0xbc, 0x1c, 0x83, 0xbd, 0xe1, 0x98,
-	0x09, 0x09, 0x7d, 0xda, 0xc8, 0x18, 0xbf, 0x5a, 0xd0, 0x2e, 0xfa, 0x4c, 0xa0, 0x2b, 0xc5, 0x94,
-	0xd7, 0xaa, 0x24, 0x48, 0xaf, 0x3c, 0x85, 0x56, 0x18, 0xf2, 0x0a, 0xda, 0xf3, 0xbb, 0x34, 0xbc,
-	0x2d, 0x27, 0x34, 0x98, 0x94, 0x1b, 0x69, 0xe7, 0x49, 0x4f, 0xa1, 0x45, 0x95, 0x9c, 0xc0, 0x7f,
-	0x7f, 0xec, 0xa5, 0x98, 0x4b, 0xcf, 0x3a, 0xf8, 0x6b, 0xa4, 0x42, 0x87, 0xa7, 0xd0, 0xdd, 0xf0,
-	0x51, 0x86, 0xbc, 0x05, 0x8d, 0x49, 0xdf, 0xf5, 0x6d, 0x41, 0xde, 0xab, 0xa5, 0x95, 0x05, 0x4f,
-	0xa1, 0x35, 0x8a, 0x1c, 0x03, 0xdc, 0x57, 0xde, 0xea, 0x6d, 0xc1, 0x21, 0x92, 0x53, 0xbb, 0xee,
-	0x29, 0xb4, 0x81, 0x13, 0xbb, 0xc3, 0x30, 0xe0, 0x29, 0xd3, 0x3b, 0xc2, 0x29, 0x19, 0xda, 0x3b,
-	0xa5, 0x4b, 0x6f, 0x6c, 0xd0, 0xaa, 0xe5, 0x25, 0x7d, 0xe8, 0x52, 0xf7, 0xa3, 0x7f, 0x3d, 0x75,
-	0xe9, 0x50, 0x21, 0x1a, 0xb4, 0xed, 0xcf, 0x97, 0xce, 0xd9, 0x50, 0x25, 0x03, 0xd0, 0x1c, 0xef,
-	0xc4, 0xbf, 0x70, 0x2e, 0x4f, 0xdd, 0x61, 0x2b, 0x0f, 0xa9, 0xfb, 0xc9, 0x75, 0xa6, 0xfe, 0xe5,
-	0xc5, 0x70, 0xcb, 0xfa, 0x00, 0x1d, 0xd1, 0x23, 0x23, 0x47, 0xb0, 0xed, 0x2c, 0x03, 0x4e, 0xaa,
-	0x05, 0x6a, 0x0c, 0x76, 0x34, 0x78, 0xf4, 0x5a, 0x0c, 0x65, 0xac, 0x1e, 0xa9, 0xf6, 0x37, 0x30,
-	0x52, 0x16, 0x4d, 0x96, 0x9b, 0x35, 0xb2, 0x3b, 0x5c, 0x44, 0xc8, 0x26, 0x37, 0xc1, 0x9c, 0xc5,
-	0xa1, 0x04, 0xe7, 0xaf, 0xdd, 0x1e, 0x14, 0xfd, 0xaf, 0x82, 0xf0, 0x36, 0x88, 0xf0, 0xeb, 0xeb,
-	0x28, 0xe6, 0xcb, 0xfb, 0x79, 0x3e, 0x21, 0xb3, 0xc1, 0x34, 0x0b, 0xa6, 0x59, 0x30, 0xcd, 0x9c,
-	0x39, 0x2f, 0xfe, 0x66, 0xde, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x19, 0x83, 0x34, 0xd3, 0x82,
-	0x04, 0x00, 0x00,
+	// 921 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x8e, 0xe3, 0x44,
+	0x10, 0x8e, 0x33, 0xf9, 0x73, 0xe5, 0x77, 0x7a, 0x56, 0x43, 0x08, 0x0b, 0x9a, 0x35, 0x42, 0x04,
+	0x0e, 0xc9, 0x10, 0x56, 0x1c, 0xf6, 0x36, 0xce, 0x44, 0xc4, 0x0c, 0x3b, 0xb3, 0x74, 0xb2, 0x08,
+	0x71, 0xc0, 0x72, 0xec, 0x1e, 0xc7, 0x6c, 0x62, 0x87, 0x76, 0x27, 0x4a, 0xde, 0x82, 0x97, 0xe1,
+	0xc2, 0x2b, 0xf1, 0x0c, 0x48, 0xa8, 0xdb, 0xdd, 0xb6, 0x13, 0x06, 0x89, 0x3d, 0x25, 0x55, 0xd5,
+	0xf5, 0x75, 0xd5, 0x57, 0x5f, 0x97, 0xe1, 0x7c, 0x43, 0x08, 0x1d, 0x92, 0x1d, 0x09, 0x59, 0x3c,
+	0xd8, 0xd0, 0x88, 0x45, 0xa8, 0x22, 0x7e, 0xe2, 0xde, 0x85, 0x1b, 0xad, 0xd7, 0x51, 0x38, 0x4c,
+	0x7e, 0x92, 0x60, 0xaf, 0x27, 0xce, 0xbb, 0x4b, 0x27, 0x08, 0xdd, 0xc8, 0x23, 0xb6, 0xc8, 0x94,
+	0xb1, 0x4b, 0x11, 0x63, 0xd4, 0x09, 0x63, 0xc7, 0x65, 0x81, 0xca, 0x31, 0xde, 0x40, 0x63, 0xac,
+	0x12, 0x30, 0xf1, 0xd1, 0x0b, 0x68, 0x64, 0x00, 0x81, 0xd7, 0xd5, 0xae, 0xb4, 0xbe, 0x8e, 0xeb,
+	0xa9, 0xcf, 0xf2, 0xd0, 0xc7, 0x00, 0x02, 0xd9, 0x0e, 0x9d, 0x35, 0xe9, 0x16, 0xc5, 0x01, 0x5d,
+	0x78, 0xee, 0x9d, 0x35, 0x31, 0xee, 0xa0, 0x3e, 0xdf, 0xcf, 0x98, 0xc3, 0xb6, 0x31, 0x07, 0xbc,
+	0x80, 0x32, 0xdb, 0x67, 0x48, 0x25, 0xb6, 0xb7, 0x3c, 0xf4, 0x39, 0xb4, 0xd7, 0x07, 0x3b, 0x57,
+	0x4d, 0x2c, 0x70, 0x6a, 0xb8, 0xb5, 0x3e, 0xcc, 0x73, 0x5e, 0xe3, 0x2f, 0x0d, 0x6a, 0x56, 0xc8,
+	0x08, 0x25, 0x31, 0x43, 0xd7, 0xea, 0x62, 0x76, 0xd8, 0x10, 0x81, 0xd7, 0x1a, 0x9d, 0x27, 0x7d,
+	0xc4, 0x83, 0x09, 0x8f, 0xcc, 0x0f, 0x1b, 0x22, 0x6b, 0xe1, 0x7f, 0xd1, 0x2d, 0xa0, 0xac, 0x1b,
+	0x4a, 0x7c, 0x3b, 0x08, 0x1f, 0x23, 0x71, 0x55, 0x7d, 0xf4, 0x4c, 0x65, 0xe6, 0xfb, 0x9f, 0x16,
+	0x70, 0xc7, 0xcd, 0xd9, 0x56, 0xf8, 0x18, 0x21, 0x13, 0x10, 0xdb, 0xdb, 0xb1, 0x68, 0x29, 0x43,
+	0x29, 0x09, 0x94, 0x0b, 0x85, 0x92, 0xeb, 0x79, 0x5a, 0xc0, 0x6d, 0x96, 0x99, 0x02, 0xa3, 0x0b,
+	0x55, 0x81, 0x6b, 0xdd, 0x76, 0xcf, 0x04, 0x11, 0xca, 0x34, 0x75, 0xa8, 0xca, 0x43, 0xc6, 0x4b,
+	0xa8, 0x61, 0xe2, 0x07, 0x31, 0x23, 0x14, 0xf5, 0xa1, 0x92, 0x4c, 0xbe, 0xab, 0x5d, 0x9d, 0xf5,
+	0xeb, 0xa3, 0x8e, 0xba, 0x48, 0xd1, 0x81, 0x65, 0xdc, 0x78, 0x0d, 0x3a, 0x26, 0xbf, 0x12, 0xc1,
+	0x18, 0xfa, 0x14, 0x8a, 0x6c, 0xdf, 0xd5, 0x4e, 0x6a, 0xcb, 0x28, 0xc5, 0x45, 0xb6, 0x47, 0x1f,
+	0x81, 0x4e, 0x28, 0x8d, 0xa8, 0xbd, 0x8e, 0x7d, 0x39, 0xc0, 0x9a, 0x70, 0xbc, 0x8e, 0x7d, 0xe3,
+	0x4f, 0x0d, 0x6a, 0xaa, 0x99, 0xa7, 0xa7, 0xf7, 0x02, 0x1a, 0x8b, 0x55, 0xe4, 0xbe, 0xb3, 0xc3,
+	0xed, 0x7a, 0x41, 0xa8, 0x40, 0x28, 0xe1, 0xba, 0xf0, 0xdd, 0x0b, 0x17, 0xfa, 0x10, 0x6a, 0x3c,
+	0x2f, 0xf4, 0xc8, 0x5e, 0xf4, 0xdb, 0xc4, 0x55, 0xb6, 0xb7, 0xb8, 0x89, 0x6e, 0xa0, 0xbd, 0x73,
+	0x56, 0x81, 0xe7, 0xf0, 0x72, 0x6c, 0xce, 0xb3, 0xa0, 0xb2, 0x35, 0xea, 0x66, 0x54, 0xfe, 0x98,
+	0x1e, 0x18, 0xf3, 0x39, 0xb4, 0x76, 0x47, 0xb6, 0x20, 0x93, 0x12, 0x87, 0x45, 0xb4, 0x5b, 0xbe,
+	0xd2, 0xfa, 0x0d, 0xac, 0x4c, 0xe3, 0x1b, 0x80, 0xb7, 0x21, 0x7d, 0x7f, 0x0e, 0xef, 0xa0, 0x3e,
+	0x0b, 0xfc, 0x90, 0x78, 0x42, 0x46, 0xe8, 0x39, 0xe8, 0x71, 0xe0, 0x87, 0x0e, 0xdb, 0xd2, 0x44,
+	0x68, 0x0d, 0x9c, 0x39, 0xd0, 0x27, 0x52, 0x87, 0xe6, 0x81, 0x91, 0x44, 0xb8, 0x0d, 0x9c, 0xf3,
+	0x18, 0x7f, 0x17, 0xa1, 0x9c, 0xe0, 0x0c, 0xa0, 0xa6, 0x8a, 0x91, 0x33, 0x49, 0x4b, 0x50, 0x83,
+	0x9e, 0x16, 0x70, 0x7a, 0x06, 0x7d, 0x06, 0x65, 0xc1, 0xa2, 0x94, 0x68, 0x73, 0x20, 0xdf, 0xb7,
+	0xc9, 0x9d, 0xd3, 0x02, 0x4e, 0xa2, 0x9c, 0xc2, 0x93, 0x57, 0x2e, 0x48, 0xae, 0x8f, 0x2e, 0xff,
+	0xa5, 0x69, 0x51, 0xc7, 0xb4, 0x80, 0x5b, 0xee, 0x91, 0x07, 0x7d, 0x05, 0x3a, 0x55, 0xa2, 0x91,
+	0x52, 0x3e, 0xcf, 0x4a, 0x93, 0x81, 0x69, 0x01, 0x67, 0xa7, 0xd0, 0x4b, 0x80, 0x6d, 0xca, 0xad,
+	0x20, 0xbe, 0x3e, 0x42, 0x2a, 0x27, 0x63, 0x7d, 0x5a, 0xc0, 0xb9, 0x73, 0x68, 0x08, 0x7a, 0xfa,
+	0x78, 0xba, 0xb5, 0x63, 0x0e, 0x94, 0xcc, 0x38, 0x07, 0xea, 0xc1, 0xe4, 0x87, 0x5b, 0x39, 0x1a,
+	0x2e, 0x5f, 0x3c, 0xee, 0xd2, 0x09, 0x43, 0xb2, 0xe2, 0x8a, 0xac, 0x26, 0x8b, 0x47, 0x7a, 0x2c,
+	0xcf, 0xac, 0x4a, 0xd6, 0x8d, 0xdf, 0xe0, 0x99, 0x20, 0xec, 0x98, 0x84, 0x18, 0x5d, 0x42, 0x45,
+	0x2a, 0x56, 0x13, 0x8a, 0x95, 0x16, 0xba, 0x81, 0xce, 0x09, 0x9d, 0x7c, 0xaa, 0x67, 0xff, 0xcd,
+	0x27, 0x6e, 0x1f, 0xb3, 0x19, 0x1b, 0x7f, 0x68, 0xd0, 0xbe, 0x25, 0xab, 0x60, 0x47, 0x28, 0x26,
+	0xf1, 0x26, 0x0a, 0x63, 0xc2, 0xd5, 0x27, 0xdb, 0x4e, 0x56, 0x55, 0x4b, 0x4d, 0x33, 0x6d, 0x5a,
+	0xc6, 0xff, 0xef, 0xd8, 0xad, 0x27, 0xea, 0x4c, 0xe6, 0xfe, 0x5c, 0xd5, 0xf9, 0x54, 0xdf, 0x7c,
+	0x1d, 0x9d, 0xd4, 0x6b, 0x56, 0xa0, 0xc4, 0x17, 0xe4, 0x97, 0x3f, 0x80, 0x9e, 0x2e, 0x4e, 0xd4,
+	0x80, 0x1a, 0x9e, 0x7c, 0x6b, 0xcd, 0xe6, 0x13, 0xdc, 0x29, 0x20, 0x1d, 0xca, 0xe6, 0xf7, 0x0f,
+	0xe3, 0xbb, 0x8e, 0x86, 0x9a, 0xa0, 0x8f, 0xa7, 0x37, 0xd6, 0xfd, 0xf8, 0xe1, 0x76, 0xd2, 0x29,
+	0x72, 0x13, 0x4f, 0xbe, 0x9b, 0x8c, 0xe7, 0xd6, 0xc3, 0x7d, 0xe7, 0x8c, 0x9b, 0xf3, 0x9f, 0xec,
+	0xd9, 0xfc, 0x66, 0xfe, 0x76, 0xd6, 0x29, 0x8d, 0x5e, 0x41, 0x45, 0xf2, 0x7d, 0x0d, 0xa5, 0xf1,
+	0xd2, 0x61, 0x28, 0xdd, 0x43, 0xb9, 0x27, 0xd6, 0x6b, 0x1e, 0x2d, 0x6e, 0xa3, 0xd0, 0xd7, 0xae,
+	0xb5, 0xd1, 0xef, 0x1a, 0x54, 0x25, 0x8d, 0xe8, 0x55, 0xf6, 0xb7, 0xa3, 0x08, 0x99, 0x84, 0x3b,
+	0xb2, 0x8a, 0x36, 0xa4, 0xf7, 0x81, 0xca, 0x3e, 0x21, 0x3d, 0xc1, 0x41, 0x16, 0x5c, 0xca, 0xc0,
+	0xa9, 0x06, 0xde, 0x17, 0xca, 0xfc, 0x05, 0x8c, 0x88, 0xfa, 0x83, 0xe5, 0x61, 0x43, 0xe8, 0x8a,
+	0x78, 0x3e, 0xa1, 0x83, 0x47, 0x67, 0x41, 0x03, 0x57, 0xa5, 0xf1, 0x0f, 0xab, 0xd9, 0x4c, 0xe0,
+	0xdf, 0x38, 0xee, 0x3b, 0xc7, 0x27, 0x3f, 0x7f, 0xe1, 0x07, 0x6c, 0xb9, 0x5d, 0xf0, 0xbb, 0x86,
+	0xb9, 0xcc, 0x61, 0x92, 0x39, 0x4c, 0x32, 0x87, 0x3c, 0x73, 0x91, 0x7c, 0xd1, 0xbf, 0xfe, 0x67,
+	0x00, 0x42, 0xc8, 0x49, 0xa6, 0xed, 0x07, 0x00, 0x00,
 }